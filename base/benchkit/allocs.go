@@ -0,0 +1,20 @@
+package benchkit
+
+import "testing"
+
+// allocRuns 是 AssertAllocs 调用 testing.AllocsPerRun 的运行次数
+const allocRuns = 100
+
+// AssertAllocs 断言 f 每次运行的平均内存分配次数不超过 maxAllocs，用于锁定 API 的零分配保证
+//
+// 以 -race 构建时分配次数不可靠，此时只记录日志，既不做断言也不调用 f；-short 模式下照常检查。
+func AssertAllocs(t testing.TB, maxAllocs float64, f func()) {
+	t.Helper()
+	if RaceEnabled {
+		t.Logf("竞态检测已开启，跳过内存分配断言")
+		return
+	}
+	if allocs := testing.AllocsPerRun(allocRuns, f); allocs > maxAllocs {
+		t.Errorf("每次运行平均分配 %.1f 次，超过上限 %v", allocs, maxAllocs)
+	}
+}
@@ -0,0 +1,43 @@
+package benchkit_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+// recorder 记录失败信息而不使测试失败，用于验证断言能发现问题
+type recorder struct {
+	testing.TB
+	msg string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+func (r *recorder) Logf(string, ...any) {}
+
+// TestAssertAllocs 测试内存分配断言
+func TestAssertAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	benchkit.AssertAllocs(t, 0, func() {
+		buf = append(buf[:0], "no allocation"...)
+	})
+
+	r := &recorder{TB: t}
+	benchkit.AssertAllocs(r, 1, func() {
+		sinkBytes = make([]byte, 32)
+		sinkBytes = make([]byte, 32)
+	})
+	switch {
+	case benchkit.RaceEnabled && r.msg != "":
+		t.Errorf("竞态检测开启时不应断言，得到 %q", r.msg)
+	case !benchkit.RaceEnabled && !strings.Contains(r.msg, "2.0"):
+		t.Errorf("应报告每次分配 2 次，得到 %q", r.msg)
+	}
+}
@@ -0,0 +1,173 @@
+// Package benchkit 提供在 go test 之外也能使用的基准测试工具：
+// 多个实现的对比、统计量计算以及结果报告。
+package benchkit
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Options 控制 Compare 与 Run 的采样方式
+type Options struct {
+	Samples    int           // 每个变体的采样次数，至少为 2 才能计算置信区间
+	SampleTime time.Duration // 每次采样的目标时长
+	Warmup     int           // 正式采样前预热调用的次数
+}
+
+// DefaultOptions 是 Compare 使用的默认采样参数
+var DefaultOptions = Options{Samples: 10, SampleTime: 100 * time.Millisecond}
+
+// Variant 是单个变体的测量结果
+type Variant struct {
+	Name        string
+	NsPerOp     float64 // 各次采样 ns/op 的均值
+	CI          float64 // NsPerOp 的 95% 置信区间半宽
+	AllocsPerOp float64
+	BytesPerOp  float64
+}
+
+// Comparison 是一次对比的结果，Variants 按 NsPerOp 从小到大排列
+type Comparison struct {
+	Name     string
+	Variants []Variant
+}
+
+// Compare 使用 DefaultOptions 依次测量 variants 中的每个函数，返回按速度排序的对比结果
+//
+// 测量包含一次函数调用的开销（约 1~2ns），对比极短的操作时应让每个变体在内部循环多次。
+func Compare(name string, variants map[string]func()) Comparison {
+	return CompareWith(name, DefaultOptions, variants)
+}
+
+// CompareWith 与 Compare 相同，但使用指定的采样参数
+//
+// 各变体的采样轮流进行，而不是测完一个再测下一个，以减小 CPU 频率、后台负载等漂移带来的偏差。
+func CompareWith(name string, opts Options, variants map[string]func()) Comparison {
+	names := make([]string, 0, len(variants))
+	for n := range variants {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+
+	ns := make([][]float64, len(names))
+	allocs := make([]float64, len(names))
+	bytes := make([]float64, len(names))
+	iters := make([]int, len(names))
+	for i, n := range names {
+		warmup(variants[n], opts.Warmup)
+		iters[i] = calibrate(variants[n], opts.SampleTime)
+	}
+	for range max(opts.Samples, 1) {
+		for i, n := range names {
+			s := measure(variants[n], iters[i])
+			ns[i] = append(ns[i], s.nsPerOp)
+			allocs[i] += s.allocsPerOp
+			bytes[i] += s.bytesPerOp
+		}
+	}
+
+	c := Comparison{Name: name, Variants: make([]Variant, len(names))}
+	for i, n := range names {
+		k := float64(len(ns[i]))
+		c.Variants[i] = Variant{
+			Name:        n,
+			NsPerOp:     mean(ns[i]),
+			CI:          ci95(ns[i]),
+			AllocsPerOp: allocs[i] / k,
+			BytesPerOp:  bytes[i] / k,
+		}
+	}
+	slices.SortStableFunc(c.Variants, func(a, b Variant) int {
+		switch {
+		case a.NsPerOp < b.NsPerOp:
+			return -1
+		case a.NsPerOp > b.NsPerOp:
+			return 1
+		}
+		return 0
+	})
+	return c
+}
+
+// sample 是一次采样的结果
+type sample struct {
+	nsPerOp, allocsPerOp, bytesPerOp float64
+}
+
+// calibrate 估算使单次采样达到 target 时长所需的迭代次数
+func calibrate(f func(), target time.Duration) int {
+	n := 1
+	for {
+		start := time.Now()
+		for range n {
+			f()
+		}
+		elapsed := time.Since(start)
+		if elapsed >= target/4 || n >= 1e9 {
+			// 按已测速度外推，并留出 20% 余量
+			per := max(float64(elapsed)/float64(n), 1)
+			return max(int(float64(target)/per*1.2), 1)
+		}
+		n *= 10
+	}
+}
+
+// measure 运行 f n 次并统计耗时与内存分配
+func measure(f func(), n int) sample {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for range n {
+		f()
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return sample{
+		nsPerOp:     float64(elapsed.Nanoseconds()) / float64(n),
+		allocsPerOp: float64(after.Mallocs-before.Mallocs) / float64(n),
+		bytesPerOp:  float64(after.TotalAlloc-before.TotalAlloc) / float64(n),
+	}
+}
+
+// Fastest 返回最快的变体；没有变体时返回零值
+func (c Comparison) Fastest() Variant {
+	if len(c.Variants) == 0 {
+		return Variant{}
+	}
+	return c.Variants[0]
+}
+
+// Significant 报告最快变体与第二快变体的 95% 置信区间是否不重叠，
+// 即最快者是否在统计意义上确实更快
+func (c Comparison) Significant() bool {
+	if len(c.Variants) < 2 {
+		return false
+	}
+	a, b := c.Variants[0], c.Variants[1]
+	return a.NsPerOp+a.CI < b.NsPerOp-b.CI
+}
+
+// String 返回对比表格，delta 为相对最快变体的耗时增幅
+func (c Comparison) String() string {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tns/op\t±95%%\tallocs/op\tB/op\tdelta\n", c.Name)
+	fastest := c.Fastest()
+	for _, v := range c.Variants {
+		delta := "fastest"
+		if v.Name != fastest.Name {
+			delta = fmt.Sprintf("%+.1f%%", (v.NsPerOp/fastest.NsPerOp-1)*100)
+		}
+		fmt.Fprintf(tw, "%s\t%.2f\t%.2f\t%.1f\t%.1f\t%s\n", v.Name, v.NsPerOp, v.CI, v.AllocsPerOp, v.BytesPerOp, delta)
+	}
+	tw.Flush()
+	if len(c.Variants) >= 2 && !c.Significant() {
+		sb.WriteString("注意：最快的两个变体置信区间重叠，差异不显著\n")
+	}
+	return sb.String()
+}
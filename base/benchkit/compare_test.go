@@ -0,0 +1,89 @@
+package benchkit_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+var sinkBytes []byte
+
+// spin 执行 n 次不可被消除的简单运算
+func spin(n int) {
+	x := uint64(1)
+	for i := 0; i < n; i++ {
+		x = x*6364136223846793005 + 1442695040888963407
+	}
+	if x == 0 {
+		panic("unreachable")
+	}
+}
+
+// TestCompare 测试变体排序、内存分配统计与报告
+func TestCompare(t *testing.T) {
+	opts := benchkit.Options{Samples: 5, SampleTime: 2 * time.Millisecond}
+	c := benchkit.CompareWith("spin", opts, map[string]func(){
+		"slow":  func() { spin(2000) },
+		"fast":  func() { spin(10) },
+		"alloc": func() { sinkBytes = make([]byte, 64); spin(500) },
+	})
+
+	if len(c.Variants) != 3 {
+		t.Fatalf("Variants 数量为 %d，预期 3", len(c.Variants))
+	}
+	if f := c.Fastest(); f.Name != "fast" {
+		t.Errorf("Fastest() = %s，预期 fast\n%s", f.Name, c)
+	}
+	if c.Variants[2].Name != "slow" {
+		t.Errorf("最慢的变体为 %s，预期 slow\n%s", c.Variants[2].Name, c)
+	}
+	if !c.Significant() {
+		t.Errorf("fast 与 alloc 的差异应显著\n%s", c)
+	}
+	for _, v := range c.Variants {
+		wantAllocs := 0.0
+		if v.Name == "alloc" {
+			wantAllocs = 1
+		}
+		if v.AllocsPerOp < wantAllocs-0.1 || v.AllocsPerOp > wantAllocs+0.1 {
+			t.Errorf("%s 的 AllocsPerOp = %.2f，预期 %.0f", v.Name, v.AllocsPerOp, wantAllocs)
+		}
+		if v.CI < 0 || v.NsPerOp <= 0 {
+			t.Errorf("%s 的统计量异常: %+v", v.Name, v)
+		}
+	}
+
+	report := c.String()
+	for _, want := range []string{"spin", "ns/op", "fastest", "alloc", "%"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("报告中缺少 %q:\n%s", want, report)
+		}
+	}
+}
+
+// TestCompareEmpty 测试没有变体时的结果
+func TestCompareEmpty(t *testing.T) {
+	c := benchkit.CompareWith("empty", benchkit.Options{Samples: 1, SampleTime: time.Millisecond}, nil)
+	if f := c.Fastest(); f.Name != "" || c.Significant() {
+		t.Errorf("空对比的 Fastest() = %+v", f)
+	}
+}
+
+// ExampleCompare 对比 base/0_const 中 var 与 const 两种写法
+func ExampleCompare() {
+	c := benchkit.Compare("const-vs-var", map[string]func(){
+		"var": func() {
+			str := "Hello world"
+			fmt.Fprint(io.Discard, str)
+		},
+		"const": func() {
+			const str = "Hello world"
+			fmt.Fprint(io.Discard, str)
+		},
+	})
+	fmt.Print(c)
+}
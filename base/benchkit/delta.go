@@ -0,0 +1,93 @@
+package benchkit
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Alpha 是 Delta.String 与 Deltas.String 判断差异是否显著时使用的显著性水平
+const Alpha = 0.05
+
+// Delta 是同一个基准测试在新旧两组结果之间的差异
+type Delta struct {
+	Name       string
+	Old, New   float64 // 新旧结果的 ns/op 中位数
+	Change     float64 // 相对变化 (New-Old)/Old，负数表示变快
+	P          float64 // Mann-Whitney U 检验的双侧 p 值
+	NOld, NNew int     // 新旧结果的样本数
+}
+
+// Significant 报告差异在显著性水平 alpha 下是否显著
+func (d Delta) Significant(alpha float64) bool {
+	return d.P < alpha
+}
+
+// Improved 报告新结果在显著性水平 alpha 下是否显著快于旧结果，便于在测试中断言优化确实有效
+func (d Delta) Improved(alpha float64) bool {
+	return d.Significant(alpha) && d.New < d.Old
+}
+
+// String 返回 benchstat 风格的变化描述，例如 "-12.34% (p=0.008 n=5+5)"；
+// 差异在 Alpha 水平下不显著时以 "~" 代替百分比
+func (d Delta) String() string {
+	change := "~"
+	if d.Significant(Alpha) {
+		change = fmt.Sprintf("%+.2f%%", d.Change*100)
+	}
+	return fmt.Sprintf("%s (p=%.3f n=%d+%d)", change, d.P, d.NOld, d.NNew)
+}
+
+// Deltas 是一组基准测试的差异，按旧结果中的顺序排列
+type Deltas []Delta
+
+// CompareResults 按名称匹配 old 与 new 中的结果，对每对结果的样本执行 Mann-Whitney U 检验
+// 只出现在一侧的结果被忽略；样本取自 Result.Samples，因此应使用 Run 或 ParseBench 得到的结果
+func CompareResults(old, new []Result) Deltas {
+	byName := make(map[string]Result, len(new))
+	for _, r := range new {
+		byName[r.Name] = r
+	}
+	var ds Deltas
+	for _, o := range old {
+		n, ok := byName[o.Name]
+		if !ok {
+			continue
+		}
+		d := Delta{
+			Name: o.Name,
+			Old:  o.Median,
+			New:  n.Median,
+			P:    mannWhitney(o.Samples, n.Samples),
+			NOld: len(o.Samples),
+			NNew: len(n.Samples),
+		}
+		if o.Median != 0 {
+			d.Change = n.Median/o.Median - 1
+		}
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+// Lookup 返回名称为 name 的差异
+func (ds Deltas) Lookup(name string) (Delta, bool) {
+	for _, d := range ds {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Delta{}, false
+}
+
+// String 返回与 benchstat 类似的对比表格
+func (ds Deltas) String() string {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "name\told time/op\tnew time/op\tdelta")
+	for _, d := range ds {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Name, formatNs(d.Old), formatNs(d.New), d)
+	}
+	tw.Flush()
+	return sb.String()
+}
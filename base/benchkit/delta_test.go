@@ -0,0 +1,65 @@
+package benchkit_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+// result 构造一个只含样本与中位数的 Result
+func result(name string, samples ...float64) benchkit.Result {
+	r := benchkit.Result{Name: name, Samples: samples}
+	r.Median = benchkit.Percentile(slices.Sorted(slices.Values(samples)), 0.5)
+	return r
+}
+
+// TestCompareResults 测试新旧结果的匹配与显著性判断
+func TestCompareResults(t *testing.T) {
+	old := []benchkit.Result{
+		result("Fast", 100, 102, 101, 99, 103),
+		result("Same", 50, 52, 51, 49, 53),
+		result("Removed", 1, 2, 3),
+	}
+	new := []benchkit.Result{
+		result("Same", 51, 50, 53, 52, 49.5),
+		result("Fast", 80, 81, 79, 82, 78),
+		result("Added", 1, 2, 3),
+	}
+	ds := benchkit.CompareResults(old, new)
+	if len(ds) != 2 || ds[0].Name != "Fast" || ds[1].Name != "Same" {
+		t.Fatalf("CompareResults = %v", ds)
+	}
+
+	fast, ok := ds.Lookup("Fast")
+	if !ok || !fast.Improved(benchkit.Alpha) || fast.NOld != 5 || fast.NNew != 5 {
+		t.Errorf("Fast = %+v，预期显著变快", fast)
+	}
+	if got := fast.String(); got != "-20.79% (p=0.008 n=5+5)" {
+		t.Errorf("Fast.String() = %q", got)
+	}
+	same, _ := ds.Lookup("Same")
+	if same.Significant(benchkit.Alpha) || !strings.HasPrefix(same.String(), "~ ") {
+		t.Errorf("Same = %v，预期差异不显著", same)
+	}
+	if _, ok := ds.Lookup("Added"); ok {
+		t.Errorf("只在一侧出现的结果不应参与比较")
+	}
+
+	table := ds.String()
+	for _, want := range []string{"old time/op", "Fast", "101ns", "80.00ns", "-20.79%"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("表格中缺少 %q:\n%s", want, table)
+		}
+	}
+}
+
+func ExampleCompareResults() {
+	old := []benchkit.Result{result("Parse", 120, 118, 121, 119, 122)}
+	new := []benchkit.Result{result("Parse", 95, 97, 96, 94, 98)}
+	d, _ := benchkit.CompareResults(old, new).Lookup("Parse")
+	fmt.Println(d.Improved(benchkit.Alpha), d)
+	// Output: true -20.00% (p=0.008 n=5+5)
+}
@@ -0,0 +1,11 @@
+package benchkit
+
+// 供外部测试包使用的内部函数
+var (
+	Mean            = mean
+	Stddev          = stddev
+	CI95            = ci95
+	Percentile      = percentile
+	MannWhitney     = mannWhitney
+	ProfileFileName = profileFileName
+)
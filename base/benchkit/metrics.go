@@ -0,0 +1,52 @@
+package benchkit
+
+import (
+	"runtime"
+	"testing"
+)
+
+// 以下函数封装 b.ReportMetric，使仓库中的基准测试以统一的单位报告自定义指标。
+// 它们应在计时循环结束后调用，此时 b.N 与 b.Elapsed 反映的是本轮完整的测量。
+
+// ReportThroughput 按每次操作处理 bytesPerOp 字节报告 "MB/s"
+//
+// 与 b.SetBytes 的效果相同，但可以在循环结束后调用，适合每次操作处理的字节数只有运行后才知道的情况，
+// 例如压缩输出的大小。
+func ReportThroughput(b *testing.B, bytesPerOp float64) {
+	if s := b.Elapsed().Seconds(); s > 0 {
+		b.ReportMetric(bytesPerOp*float64(b.N)/s/1e6, "MB/s")
+	}
+}
+
+// ReportRatio 以 unit 为单位报告 n/total，total 为 0 时不报告
+func ReportRatio(b *testing.B, n, total int, unit string) {
+	if total > 0 {
+		b.ReportMetric(float64(n)/float64(total), unit)
+	}
+}
+
+// ReportHitRatio 报告缓存命中率 "hit-ratio"，取值范围为 [0, 1]
+func ReportHitRatio(b *testing.B, hits, misses int) {
+	ReportRatio(b, hits, hits+misses, "hit-ratio")
+}
+
+// TrackGC 记录当前的 GC 次数，返回的函数在循环结束后调用，报告每次操作触发的 GC 次数 "gc/op"：
+//
+//	stop := benchkit.TrackGC(b)
+//	for i := 0; i < b.N; i++ {
+//		...
+//	}
+//	stop()
+//
+// 比 allocs/op 更直接地反映分配压力对吞吐的影响；数值通常很小，例如 0.0012 表示约每 800 次操作一次 GC。
+func TrackGC(b *testing.B) func() {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	start := ms.NumGC
+	return func() {
+		runtime.ReadMemStats(&ms)
+		if b.N > 0 {
+			b.ReportMetric(float64(ms.NumGC-start)/float64(b.N), "gc/op")
+		}
+	}
+}
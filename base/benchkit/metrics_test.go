@@ -0,0 +1,42 @@
+package benchkit_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+// TestMetrics 测试各辅助函数报告的指标
+func TestMetrics(t *testing.T) {
+	r := testing.Benchmark(func(b *testing.B) {
+		stop := benchkit.TrackGC(b)
+		hits := 0
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkBytes = make([]byte, 64<<10)
+			if i%4 != 0 {
+				hits++
+			}
+		}
+		stop()
+		benchkit.ReportThroughput(b, 64<<10)
+		benchkit.ReportHitRatio(b, hits, b.N-hits)
+		benchkit.ReportRatio(b, 1, 0, "never")
+	})
+
+	if gc, ok := r.Extra["gc/op"]; !ok || gc <= 0 {
+		t.Errorf("gc/op = %v，预期大于 0", gc)
+	}
+	if mbs := r.Extra["MB/s"]; mbs <= 0 {
+		t.Errorf("MB/s = %v，预期大于 0", mbs)
+	}
+	if hr := r.Extra["hit-ratio"]; hr < 0.74 || hr > 0.76 {
+		t.Errorf("hit-ratio = %v，预期约 0.75", hr)
+	}
+	if _, ok := r.Extra["never"]; ok {
+		t.Errorf("total 为 0 时不应报告指标")
+	}
+	if s := r.String(); !strings.Contains(s, "hit-ratio") {
+		t.Errorf("结果中缺少 hit-ratio: %s", s)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package benchkit
+
+// RaceEnabled 报告当前是否以 -race 构建；竞态检测器会引入额外的内存分配与开销
+const RaceEnabled = false
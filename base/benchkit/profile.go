@@ -0,0 +1,154 @@
+package benchkit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// Profile 是 WithProfiles 采集的性能剖析种类，多个种类可以用 | 组合
+type Profile uint8
+
+const (
+	CPUProfile   Profile = 1 << iota // CPU 剖析
+	HeapProfile                      // 堆内存剖析，结束时先执行一次 GC
+	MutexProfile                     // 互斥锁争用剖析
+	BlockProfile                     // 阻塞剖析
+)
+
+// profileNames 是各剖析种类在文件名中的后缀
+var profileNames = [...]string{"cpu", "heap", "mutex", "block"}
+
+// ProfileOptions 控制 WithProfiles 的行为
+type ProfileOptions struct {
+	Dir      string  // 剖析文件的输出目录，为空时使用 "profiles"，不存在时自动创建
+	Profiles Profile // 要采集的剖析种类，为 0 时采集 CPU 与堆内存
+}
+
+// WithProfiles 为当前基准测试的函数体开启性能剖析，函数体返回时写入剖析文件，
+// 文件名由基准测试名称与剖析种类组成，例如 "BenchmarkFilter_test.cpu.pprof"，可直接交给 go tool pprof，
+// 或用 cmd/effprof 转换为火焰图使用的折叠栈。
+// 它应在计时循环之前调用，并会重置计时器以排除开启剖析的开销：
+//
+//	b.Run("variantB", func(b *testing.B) {
+//		benchkit.WithProfiles(b, benchkit.ProfileOptions{Profiles: benchkit.CPUProfile | benchkit.MutexProfile})
+//		for i := 0; i < b.N; i++ {
+//			...
+//		}
+//	})
+//
+// 基准测试框架会以递增的 b.N 多次执行函数体，文件每次被覆盖，最终保留 b.N 最大的一轮。
+// 堆、锁与阻塞剖析是进程级的累计数据，包含此前其他代码的样本；
+// 同一时刻只能有一个 CPU 剖析，与 go test -cpuprofile 同时使用时跳过 CPU 剖析并记录日志。
+func WithProfiles(b *testing.B, opts ProfileOptions) {
+	b.Helper()
+	if opts.Dir == "" {
+		opts.Dir = "profiles"
+	}
+	if opts.Profiles == 0 {
+		opts.Profiles = CPUProfile | HeapProfile
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		b.Fatalf("benchkit: 创建剖析目录失败: %v", err)
+	}
+	path := func(p Profile) string {
+		return filepath.Join(opts.Dir, profileFileName(b.Name())+"."+profileNames[bitIndex(p)]+".pprof")
+	}
+
+	var cpu *os.File
+	if opts.Profiles&CPUProfile != 0 {
+		f, err := os.Create(path(CPUProfile))
+		if err != nil {
+			b.Fatalf("benchkit: %v", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			b.Logf("benchkit: 跳过 CPU 剖析: %v", err)
+			f.Close()
+			os.Remove(f.Name())
+		} else {
+			cpu = f
+		}
+	}
+	if opts.Profiles&MutexProfile != 0 {
+		prev := runtime.SetMutexProfileFraction(1)
+		b.Cleanup(func() { runtime.SetMutexProfileFraction(prev) })
+	}
+	if opts.Profiles&BlockProfile != 0 {
+		prev := SetBlockProfileRate(1)
+		b.Cleanup(func() { SetBlockProfileRate(prev) })
+	}
+
+	b.Cleanup(func() {
+		if cpu != nil {
+			pprof.StopCPUProfile()
+			if err := cpu.Close(); err != nil {
+				b.Errorf("benchkit: %v", err)
+			}
+		}
+		for _, p := range []Profile{HeapProfile, MutexProfile, BlockProfile} {
+			if opts.Profiles&p == 0 {
+				continue
+			}
+			if p == HeapProfile {
+				runtime.GC()
+			}
+			if err := writeProfile(path(p), profileNames[bitIndex(p)]); err != nil {
+				b.Errorf("benchkit: %v", err)
+			}
+		}
+	})
+	b.ResetTimer()
+}
+
+// blockProfileRate 记录经 SetBlockProfileRate 设置的阻塞剖析采样率，runtime 没有提供读取它的接口
+var blockProfileRate atomic.Int64
+
+// SetBlockProfileRate 调用 runtime.SetBlockProfileRate 设置阻塞剖析采样率，并返回此前经本函数设置的采样率，
+// 用法与 runtime.SetMutexProfileFraction 相同。WithProfiles 结束时据此恢复原来的采样率，
+// 自行开启阻塞剖析的基准测试应通过本函数设置，否则 WithProfiles 结束后采样率会被重置为 0
+func SetBlockProfileRate(rate int) int {
+	prev := blockProfileRate.Swap(int64(rate))
+	runtime.SetBlockProfileRate(rate)
+	return int(prev)
+}
+
+// bitIndex 返回单个剖析种类的位序号
+func bitIndex(p Profile) int {
+	i := 0
+	for p>>(i+1) != 0 {
+		i++
+	}
+	return i
+}
+
+// writeProfile 将名为 name 的运行时剖析写入 path
+func writeProfile(path, name string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := pprof.Lookup(name).WriteTo(f, 0); err != nil {
+		f.Close()
+		return fmt.Errorf("写入 %s 剖析失败: %w", name, err)
+	}
+	return f.Close()
+}
+
+// profileFileName 将基准测试名称转换为可用作文件名的形式，"/" 等字符替换为 "_"
+func profileFileName(name string) string {
+	if name == "" {
+		return "benchmark"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+}
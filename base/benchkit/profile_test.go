@@ -0,0 +1,90 @@
+package benchkit_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+// TestWithProfiles 测试写入所选种类的剖析文件
+// testing.Benchmark 中的基准测试没有名称，文件名使用 "benchmark"
+func TestWithProfiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+	testing.Benchmark(func(b *testing.B) {
+		benchkit.WithProfiles(b, benchkit.ProfileOptions{
+			Dir:      dir,
+			Profiles: benchkit.CPUProfile | benchkit.HeapProfile | benchkit.MutexProfile,
+		})
+		var mu sync.Mutex
+		for i := 0; i < b.N; i++ {
+			mu.Lock()
+			spin(100)
+			mu.Unlock()
+		}
+	})
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		if info, err := e.Info(); err != nil || info.Size() == 0 {
+			t.Errorf("剖析文件 %s 为空", e.Name())
+		}
+		names = append(names, e.Name())
+	}
+	want := []string{"benchmark.cpu.pprof", "benchmark.heap.pprof", "benchmark.mutex.pprof"}
+	if !slices.Equal(names, want) {
+		t.Errorf("剖析文件 = %v，预期 %v", names, want)
+	}
+}
+
+// TestWithProfilesRestoresRates 测试结束后恢复开启剖析之前的锁与阻塞剖析采样率
+func TestWithProfilesRestoresRates(t *testing.T) {
+	prevMutex := runtime.SetMutexProfileFraction(5)
+	prevBlock := benchkit.SetBlockProfileRate(100)
+	t.Cleanup(func() {
+		runtime.SetMutexProfileFraction(prevMutex)
+		benchkit.SetBlockProfileRate(prevBlock)
+	})
+
+	testing.Benchmark(func(b *testing.B) {
+		benchkit.WithProfiles(b, benchkit.ProfileOptions{
+			Dir:      t.TempDir(),
+			Profiles: benchkit.MutexProfile | benchkit.BlockProfile,
+		})
+		for i := 0; i < b.N; i++ {
+			spin(100)
+		}
+	})
+
+	if got := runtime.SetMutexProfileFraction(-1); got != 5 {
+		t.Errorf("锁剖析采样率 = %d，预期恢复为 5", got)
+	}
+	if got := benchkit.SetBlockProfileRate(100); got != 100 {
+		t.Errorf("阻塞剖析采样率 = %d，预期恢复为 100", got)
+	}
+}
+
+// TestProfileFileName 测试基准测试名称到文件名的转换
+func TestProfileFileName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"BenchmarkFilter/test-8", "BenchmarkFilter_test-8"},
+		{"BenchmarkX/key=a b", "BenchmarkX_key_a_b"},
+		{"", "benchmark"},
+	}
+	for _, tc := range testCases {
+		if got := benchkit.ProfileFileName(tc.name); got != tc.expected {
+			t.Errorf("ProfileFileName(%q) = %q，预期 %q", tc.name, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package benchkit
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Env 描述产生一组结果的运行环境，便于日后比较不同机器或版本上的结果
+type Env struct {
+	Host       string    `json:"host"`
+	GOOS       string    `json:"goos"`
+	GOARCH     string    `json:"goarch"`
+	CPU        string    `json:"cpu,omitempty"`
+	GOMAXPROCS int       `json:"gomaxprocs"`
+	GoVersion  string    `json:"go_version"`
+	Time       time.Time `json:"time"`
+}
+
+// CurrentEnv 返回当前进程的运行环境，CPU 型号无法可靠获取时留空
+func CurrentEnv() Env {
+	host, _ := os.Hostname()
+	return Env{
+		Host:       host,
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		GoVersion:  runtime.Version(),
+		Time:       time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// Report 是一组结果及其运行环境
+type Report struct {
+	Env     Env      `json:"env"`
+	Results []Result `json:"results"`
+}
+
+// NewReport 返回以当前运行环境为元数据的 Report
+func NewReport(results ...Result) Report {
+	return Report{Env: CurrentEnv(), Results: results}
+}
+
+// WriteJSON 将 r 以缩进格式的 JSON 写入 w
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// ReadJSON 读取 WriteJSON 写入的 Report
+func ReadJSON(rd io.Reader) (Report, error) {
+	var r Report
+	err := json.NewDecoder(rd).Decode(&r)
+	return r, err
+}
+
+// csvHeader 是 WriteCSV 输出的列
+var csvHeader = []string{
+	"name", "n", "mean_ns", "median_ns", "p99_ns", "stddev_ns", "allocs_per_op", "bytes_per_op",
+	"host", "goos", "goarch", "cpu", "gomaxprocs", "go_version", "time",
+}
+
+// WriteCSV 将 r 写成 CSV，每个结果一行，运行环境作为附加列重复写入每一行，便于合并多次运行的文件
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	f := func(x float64) string { return strconv.FormatFloat(x, 'g', -1, 64) }
+	for _, res := range r.Results {
+		row := []string{
+			res.Name, strconv.Itoa(res.N), f(res.Mean), f(res.Median), f(res.P99), f(res.Stddev),
+			f(res.AllocsPerOp), f(res.BytesPerOp),
+			r.Env.Host, r.Env.GOOS, r.Env.GOARCH, r.Env.CPU, strconv.Itoa(r.Env.GOMAXPROCS), r.Env.GoVersion,
+			r.Env.Time.Format(time.RFC3339),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ErrNoBenchmarks 表示 ParseBench 的输入中没有基准测试结果
+var ErrNoBenchmarks = errors.New("benchkit: 输入中没有基准测试结果")
+
+// ParseBench 解析 go test -bench 的文本输出
+//
+// 同名基准测试的多行结果（-count 大于 1 时）合并为一个 Result，每行的 ns/op 作为一个样本；
+// goos、goarch、cpu 取自输出头部，GOMAXPROCS 取自名称的 "-N" 后缀，其余环境信息取自当前进程。
+func ParseBench(rd io.Reader) (Report, error) {
+	report := Report{Env: CurrentEnv()}
+	index := make(map[string]int) // 名称 → Results 中的下标
+	allocs := make(map[string]float64)
+	bytes := make(map[string]float64)
+
+	s := bufio.NewScanner(rd)
+	for s.Scan() {
+		line := s.Text()
+		if k, v, ok := strings.Cut(line, ": "); ok {
+			switch k {
+			case "goos":
+				report.Env.GOOS = v
+			case "goarch":
+				report.Env.GOARCH = v
+			case "cpu":
+				report.Env.CPU = v
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		name := fields[0]
+		if i := strings.LastIndexByte(name, '-'); i > 0 {
+			if procs, err := strconv.Atoi(name[i+1:]); err == nil {
+				report.Env.GOMAXPROCS = procs
+				name = name[:i]
+			}
+		}
+		n, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+
+		var nsPerOp float64
+		found := false
+		// 其余字段为 "值 单位" 对
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return Report{}, fmt.Errorf("benchkit: 无法解析 %q: %v", line, err)
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				nsPerOp, found = v, true
+			case "allocs/op":
+				allocs[name] += v
+			case "B/op":
+				bytes[name] += v
+			}
+		}
+		if !found {
+			continue
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(report.Results)
+			index[name] = i
+			report.Results = append(report.Results, Result{Name: name})
+		}
+		report.Results[i].N += n
+		report.Results[i].Samples = append(report.Results[i].Samples, nsPerOp)
+	}
+	if err := s.Err(); err != nil {
+		return Report{}, err
+	}
+	if len(report.Results) == 0 {
+		return Report{}, ErrNoBenchmarks
+	}
+
+	for i := range report.Results {
+		r := &report.Results[i]
+		k := float64(len(r.Samples))
+		r.AllocsPerOp = allocs[r.Name] / k
+		r.BytesPerOp = bytes[r.Name] / k
+		r.summarize()
+	}
+	return report, nil
+}
@@ -0,0 +1,116 @@
+package benchkit_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+const benchOutput = `goos: linux
+goarch: amd64
+pkg: github.com/moweilong/efficient-go/base/strtransform
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkAppendApply/AppendApply-8         	 3000000	       326.2 ns/op	       0 B/op	       0 allocs/op
+BenchmarkAppendApply/strings.ToUpper-8     	 1500000	       744.6 ns/op	     192 B/op	       1 allocs/op
+BenchmarkAppendApply/AppendApply-8         	 3000000	       330.8 ns/op	       0 B/op	       0 allocs/op
+BenchmarkAppendApply/strings.ToUpper-8     	 1500000	       750.4 ns/op	     192 B/op	       1 allocs/op
+BenchmarkSlug-8   	  100000	       292.4 ns/op
+PASS
+ok  	github.com/moweilong/efficient-go/base/strtransform	0.383s
+`
+
+// TestParseBench 测试解析 go test -bench 的输出
+func TestParseBench(t *testing.T) {
+	r, err := benchkit.ParseBench(strings.NewReader(benchOutput))
+	if err != nil {
+		t.Fatalf("ParseBench 失败: %v", err)
+	}
+	if r.Env.GOOS != "linux" || r.Env.GOARCH != "amd64" || r.Env.CPU != "Intel(R) Xeon(R) Processor" || r.Env.GOMAXPROCS != 8 {
+		t.Errorf("Env = %+v", r.Env)
+	}
+	if len(r.Results) != 3 {
+		t.Fatalf("解析出 %d 个结果，预期 3", len(r.Results))
+	}
+
+	upper := r.Results[1]
+	if upper.Name != "BenchmarkAppendApply/strings.ToUpper" || upper.N != 3000000 {
+		t.Errorf("Results[1] = %+v", upper)
+	}
+	if !reflect.DeepEqual(upper.Samples, []float64{744.6, 750.4}) || math.Abs(upper.Mean-747.5) > 1e-9 {
+		t.Errorf("Samples = %v，Mean = %v", upper.Samples, upper.Mean)
+	}
+	if upper.AllocsPerOp != 1 || upper.BytesPerOp != 192 {
+		t.Errorf("AllocsPerOp = %v，BytesPerOp = %v", upper.AllocsPerOp, upper.BytesPerOp)
+	}
+	if slug := r.Results[2]; slug.Name != "BenchmarkSlug" || slug.Median != 292.4 {
+		t.Errorf("Results[2] = %+v", slug)
+	}
+
+	if _, err := benchkit.ParseBench(strings.NewReader("PASS\n")); !errors.Is(err, benchkit.ErrNoBenchmarks) {
+		t.Errorf("没有结果时错误为 %v，预期 ErrNoBenchmarks", err)
+	}
+	if _, err := benchkit.ParseBench(strings.NewReader("BenchmarkX-8 10 abc ns/op\n")); err == nil {
+		t.Errorf("非法数值应返回错误")
+	}
+}
+
+// TestReportJSON 测试 JSON 往返
+func TestReportJSON(t *testing.T) {
+	r := benchkit.NewReport(benchkit.RunWith("spin", benchkit.Options{Samples: 3, SampleTime: time.Millisecond}, func() { spin(10) }))
+	if r.Env.GoVersion == "" || r.Env.GOMAXPROCS <= 0 {
+		t.Errorf("Env = %+v", r.Env)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON 失败: %v", err)
+	}
+	for _, key := range []string{`"go_version"`, `"gomaxprocs"`, `"host"`, `"mean_ns"`, `"samples"`} {
+		if !strings.Contains(buf.String(), key) {
+			t.Errorf("JSON 中缺少 %s", key)
+		}
+	}
+	got, err := benchkit.ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSON 失败: %v", err)
+	}
+	if !got.Env.Time.Equal(r.Env.Time) {
+		t.Errorf("Time = %v，预期 %v", got.Env.Time, r.Env.Time)
+	}
+	got.Env.Time = r.Env.Time
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("JSON 往返结果不一致\n得到 %+v\n预期 %+v", got, r)
+	}
+}
+
+// TestReportCSV 测试 CSV 输出
+func TestReportCSV(t *testing.T) {
+	r, err := benchkit.ParseBench(strings.NewReader(benchOutput))
+	if err != nil {
+		t.Fatalf("ParseBench 失败: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV 失败: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("读取 CSV 失败: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("CSV 有 %d 行，预期 4", len(rows))
+	}
+	if rows[0][0] != "name" || rows[0][12] != "gomaxprocs" {
+		t.Errorf("表头 = %v", rows[0])
+	}
+	if rows[2][0] != "BenchmarkAppendApply/strings.ToUpper" || rows[2][2] != "747.5" || rows[2][12] != "8" {
+		t.Errorf("第 2 行 = %v", rows[2])
+	}
+}
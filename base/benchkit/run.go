@@ -0,0 +1,91 @@
+package benchkit
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/units"
+)
+
+// DefaultRunOptions 是 Run 使用的默认采样参数：较多的短采样使中位数与 p99 更有意义
+var DefaultRunOptions = Options{Samples: 100, SampleTime: 10 * time.Millisecond, Warmup: 1000}
+
+// Result 是一次 Run 的测量结果，耗时统计量的单位均为 ns/op
+//
+// 每次采样连续调用函数若干次并记录平均耗时，Median、P99 描述的是各次采样平均值的分布，
+// 而不是单次调用的延迟分布。
+type Result struct {
+	Name        string    `json:"name"`
+	N           int       `json:"n"`       // 正式采样中的总调用次数
+	Samples     []float64 `json:"samples"` // 各次采样的 ns/op，按采样顺序排列
+	Mean        float64   `json:"mean_ns"`
+	Median      float64   `json:"median_ns"`
+	P99         float64   `json:"p99_ns"`
+	Stddev      float64   `json:"stddev_ns"`
+	AllocsPerOp float64   `json:"allocs_per_op"`
+	BytesPerOp  float64   `json:"bytes_per_op"`
+}
+
+// Run 使用 DefaultRunOptions 测量 f，可在 go test 之外使用
+func Run(name string, f func()) Result {
+	return RunWith(name, DefaultRunOptions, f)
+}
+
+// RunWith 与 Run 相同，但使用指定的采样参数：先预热 opts.Warmup 次，
+// 再估算每次采样的调用次数，最后进行 opts.Samples 次采样
+func RunWith(name string, opts Options, f func()) Result {
+	warmup(f, opts.Warmup)
+	n := calibrate(f, opts.SampleTime)
+
+	r := Result{Name: name}
+	var allocs, bytes float64
+	for range max(opts.Samples, 1) {
+		s := measure(f, n)
+		r.Samples = append(r.Samples, s.nsPerOp)
+		allocs += s.allocsPerOp
+		bytes += s.bytesPerOp
+		r.N += n
+	}
+
+	k := float64(len(r.Samples))
+	r.AllocsPerOp = allocs / k
+	r.BytesPerOp = bytes / k
+	r.summarize()
+	return r
+}
+
+// summarize 根据 Samples 计算各统计量
+func (r *Result) summarize() {
+	sorted := slices.Sorted(slices.Values(r.Samples))
+	r.Mean = mean(r.Samples)
+	r.Median = percentile(sorted, 0.5)
+	r.P99 = percentile(sorted, 0.99)
+	r.Stddev = stddev(r.Samples)
+}
+
+// warmup 调用 f n 次，使缓存、分支预测与 GC 状态趋于稳定
+func warmup(f func(), n int) {
+	for range n {
+		f()
+	}
+}
+
+// String 返回单行摘要，如 "strings.ToUpper  n=1200000  mean=153ns ±2%  median=152ns  p99=171ns  1 allocs/op  48 B/op"
+func (r Result) String() string {
+	rel := 0.0
+	if r.Mean > 0 {
+		rel = r.Stddev / r.Mean * 100
+	}
+	return fmt.Sprintf("%s  n=%d  mean=%s ±%.0f%%  median=%s  p99=%s  %.0f allocs/op  %.0f B/op",
+		r.Name, r.N, formatNs(r.Mean), rel, formatNs(r.Median), formatNs(r.P99), r.AllocsPerOp, r.BytesPerOp)
+}
+
+// formatNs 格式化 ns/op，不足 100ns 时保留两位小数以区分极短的操作
+func formatNs(ns float64) string {
+	if ns < 100 {
+		return fmt.Sprintf("%.2fns", ns)
+	}
+	return units.FormatDuration(time.Duration(math.Round(ns)))
+}
@@ -0,0 +1,55 @@
+package benchkit_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+// TestRun 测试统计量的计算与摘要格式
+func TestRun(t *testing.T) {
+	calls := 0
+	opts := benchkit.Options{Samples: 20, SampleTime: time.Millisecond, Warmup: 7}
+	r := benchkit.RunWith("spin", opts, func() { calls++; spin(100) })
+
+	if len(r.Samples) != 20 {
+		t.Fatalf("采样次数为 %d，预期 20", len(r.Samples))
+	}
+	if r.N <= 0 || calls < r.N+7 {
+		t.Errorf("N = %d，实际调用 %d 次，应包含 7 次预热", r.N, calls)
+	}
+	if !(r.Mean > 0 && r.Median > 0 && r.P99 >= r.Median && r.Stddev >= 0) {
+		t.Errorf("统计量异常: %+v", r)
+	}
+	if r.AllocsPerOp > 0.01 {
+		t.Errorf("AllocsPerOp = %.2f，预期 0", r.AllocsPerOp)
+	}
+
+	s := r.String()
+	for _, want := range []string{"spin", "n=", "mean=", "median=", "p99=", "allocs/op"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("摘要中缺少 %q: %s", want, s)
+		}
+	}
+}
+
+// TestRunAllocs 测试内存分配统计
+func TestRunAllocs(t *testing.T) {
+	r := benchkit.RunWith("alloc", benchkit.Options{Samples: 3, SampleTime: time.Millisecond}, func() {
+		sinkBytes = make([]byte, 128)
+	})
+	if r.AllocsPerOp < 0.9 || r.AllocsPerOp > 1.1 || r.BytesPerOp < 128 {
+		t.Errorf("AllocsPerOp = %.2f，BytesPerOp = %.1f，预期 1 次 128 字节", r.AllocsPerOp, r.BytesPerOp)
+	}
+}
+
+// ExampleRun 在 go test 之外测量一段代码
+func ExampleRun() {
+	r := benchkit.Run("strings.Repeat", func() {
+		_ = strings.Repeat("ab", 16)
+	})
+	fmt.Println(r)
+}
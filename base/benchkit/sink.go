@@ -0,0 +1,27 @@
+package benchkit
+
+// 基准测试中被测表达式的结果如果没有被使用，编译器可能把整个计算当作死代码消除，
+// 测得的只是空循环的耗时。把结果写入下面的包级变量或传给 Sink 即可避免这一点：
+//
+//	for i := 0; i < b.N; i++ {
+//		benchkit.SinkU64 = bits.ReverseBytes64(uint64(i))
+//	}
+//
+// 包级变量由其他包导出，编译器无法证明写入结果无人读取；累加（+=）还能防止循环中只保留最后一次计算。
+var (
+	SinkU64    uint64
+	SinkInt    int
+	SinkBool   bool
+	SinkString string
+	SinkBytes  []byte
+)
+
+// Sink 使 v 被视为已使用，适合没有对应包级变量的类型，例如：
+//
+//	benchkit.Sink(m.Lookup(key))
+//
+// Sink 不会被内联，调用方必须把 v 完整计算出来；参数不会逃逸，因此不引入额外的内存分配，
+// 但每次调用有一次函数调用的开销（约 1ns），测量极短的操作时优先使用包级变量。
+//
+//go:noinline
+func Sink[T any](v T) {}
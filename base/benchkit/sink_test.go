@@ -0,0 +1,40 @@
+package benchkit_test
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+type pair struct{ a, b uint64 }
+
+// TestSinkAllocs 测试 Sink 不会因参数逃逸而分配内存
+func TestSinkAllocs(t *testing.T) {
+	s := "efficient go"
+	benchkit.AssertAllocs(t, 0, func() {
+		benchkit.Sink(pair{1, 2})
+		benchkit.Sink(s)
+		benchkit.Sink(&s)
+	})
+}
+
+// BenchmarkSink 对比结果未被使用、写入包级变量与传给 Sink 三种写法的耗时，
+// 第一种的计算可能被编译器整体消除
+func BenchmarkSink(b *testing.B) {
+	b.Run("discard", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = bits.ReverseBytes64(uint64(i))
+		}
+	})
+	b.Run("SinkU64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = bits.ReverseBytes64(uint64(i))
+		}
+	})
+	b.Run("Sink", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.Sink(bits.ReverseBytes64(uint64(i)))
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package benchkit
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// mean 返回 xs 的算术平均值
+func mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// stddev 返回 xs 的样本标准差（分母为 n-1）
+func stddev(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	m := mean(xs)
+	var sum float64
+	for _, x := range xs {
+		sum += (x - m) * (x - m)
+	}
+	return math.Sqrt(sum / float64(len(xs)-1))
+}
+
+// tTable 是自由度 1~30 时双侧 95% 的 t 分布临界值
+var tTable = [...]float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// ci95 返回 xs 均值的 95% 置信区间半宽，样本少于 2 个时返回 0
+func ci95(xs []float64) float64 {
+	n := len(xs)
+	if n < 2 {
+		return 0
+	}
+	t := 1.96
+	if df := n - 1; df <= len(tTable) {
+		t = tTable[df-1]
+	}
+	return t * stddev(xs) / math.Sqrt(float64(n))
+}
+
+// percentile 返回已排序样本的 p 分位数（0 ≤ p ≤ 1），在相邻样本间线性插值
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p * float64(len(sorted)-1)
+	i := int(pos)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float64(i)
+	return sorted[i] + (sorted[i+1]-sorted[i])*frac
+}
+
+// mannWhitney 对两组独立样本执行 Mann-Whitney U 检验，返回双侧 p 值
+//
+// 样本量较小且没有并列值时使用精确分布，否则使用带并列校正与连续性校正的正态近似。
+// 任一组为空时返回 1。
+func mannWhitney(xs, ys []float64) float64 {
+	n1, n2 := len(xs), len(ys)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	// 合并后排序并计算秩，并列值取平均秩
+	type obs struct {
+		v     float64
+		first bool
+	}
+	all := make([]obs, 0, n1+n2)
+	for _, x := range xs {
+		all = append(all, obs{x, true})
+	}
+	for _, y := range ys {
+		all = append(all, obs{y, false})
+	}
+	slices.SortFunc(all, func(a, b obs) int { return cmp.Compare(a.v, b.v) })
+
+	var r1, tieSum float64
+	ties := false
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2 // 第 i+1 到第 j 个位置的平均秩
+		for k := i; k < j; k++ {
+			if all[k].first {
+				r1 += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties = true
+			tieSum += t*t*t - t
+		}
+		i = j
+	}
+	u := r1 - float64(n1*(n1+1))/2
+
+	if !ties && n1+n2 <= 50 {
+		return exactU(n1, n2, u)
+	}
+
+	n := float64(n1 + n2)
+	mu := float64(n1*n2) / 2
+	sigma := math.Sqrt(float64(n1*n2) / 12 * (n + 1 - tieSum/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	z := (math.Abs(u-mu) - 0.5) / sigma
+	return min(math.Erfc(max(z, 0)/math.Sqrt2), 1)
+}
+
+// exactU 返回无并列值时 U 统计量的精确双侧 p 值
+func exactU(n1, n2 int, u float64) float64 {
+	// counts[k] 为 n1 个样本与 n2 个样本的排列中 U = k 的个数，按 n2 逐步递推
+	// 递推式：f(m, n, k) = f(m-1, n, k-n) + f(m, n-1, k)
+	maxU := n1 * n2
+	prev := make([][]float64, n1+1) // prev[m] 为 f(m, n-1, ·)
+	for m := range prev {
+		prev[m] = []float64{1}
+	}
+	for n := 1; n <= n2; n++ {
+		cur := make([][]float64, n1+1)
+		cur[0] = []float64{1}
+		for m := 1; m <= n1; m++ {
+			c := make([]float64, m*n+1)
+			copy(c, prev[m])
+			for k, v := range cur[m-1] {
+				c[k+n] += v
+			}
+			cur[m] = c
+		}
+		prev = cur
+	}
+	counts := prev[n1]
+
+	var total, lower, upper float64
+	k0 := int(math.Round(u))
+	for k := 0; k <= maxU; k++ {
+		total += counts[k]
+		if k <= k0 {
+			lower += counts[k]
+		}
+		if k >= k0 {
+			upper += counts[k]
+		}
+	}
+	return min(2*min(lower, upper)/total, 1)
+}
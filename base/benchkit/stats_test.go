@@ -0,0 +1,65 @@
+package benchkit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+)
+
+// TestStats 测试均值、标准差与置信区间
+func TestStats(t *testing.T) {
+	xs := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	testCases := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"mean", benchkit.Mean(xs), 5},
+		{"stddev", benchkit.Stddev(xs), 2.1381},
+		{"ci95", benchkit.CI95(xs), 2.365 * 2.1381 / math.Sqrt(8)},
+		{"单个样本的 ci95", benchkit.CI95([]float64{3}), 0},
+		{"空样本的 mean", benchkit.Mean(nil), 0},
+		{"中位数", benchkit.Percentile(xs, 0.5), 4.5},
+		{"p99", benchkit.Percentile(xs, 0.99), 8.86},
+		{"最小值", benchkit.Percentile(xs, 0), 2},
+		{"最大值", benchkit.Percentile(xs, 1), 9},
+		{"空样本的分位数", benchkit.Percentile(nil, 0.5), 0},
+	}
+	for _, tc := range testCases {
+		if math.Abs(tc.got-tc.expected) > 1e-3 {
+			t.Errorf("%s = %.4f，预期 %.4f", tc.name, tc.got, tc.expected)
+		}
+	}
+}
+
+// TestMannWhitney 测试 Mann-Whitney U 检验的 p 值
+func TestMannWhitney(t *testing.T) {
+	seq := func(from, n int) []float64 {
+		xs := make([]float64, n)
+		for i := range xs {
+			xs[i] = float64(from + i%10)
+		}
+		return xs
+	}
+	testCases := []struct {
+		name   string
+		xs, ys []float64
+		min    float64
+		max    float64
+	}{
+		{"完全分离的精确检验", seq(1, 5), seq(6, 5), 2.0 / 252, 2.0 / 252},
+		{"交错的精确检验", []float64{1, 3, 5}, []float64{2, 4, 6}, 0.5, 1},
+		{"顺序无关", seq(6, 5), seq(1, 5), 2.0 / 252, 2.0 / 252},
+		{"全部并列", []float64{1, 1, 1}, []float64{1, 1, 1}, 1, 1},
+		{"带并列的正态近似", seq(1, 30), seq(4, 30), 0, 0.01},
+		{"同分布的正态近似", seq(1, 30), seq(1, 30), 0.9, 1},
+		{"空样本", nil, seq(1, 3), 1, 1},
+	}
+	for _, tc := range testCases {
+		p := benchkit.MannWhitney(tc.xs, tc.ys)
+		if p < tc.min-1e-9 || p > tc.max+1e-9 {
+			t.Errorf("%s: p = %.5f，预期在 [%.5f, %.5f] 内", tc.name, p, tc.min, tc.max)
+		}
+	}
+}
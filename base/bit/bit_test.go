@@ -2,12 +2,8 @@ package bit_test
 
 import (
 	"math/rand"
-	"strings"
 	"testing"
 	"time"
-
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 // TestClearLast4LSB 使用 & 运算符清除最后 4 个最低有效位（LSB）为 0
@@ -107,92 +103,6 @@ func TestSetBitsWithOr(t *testing.T) {
 	t.Logf("最终结果：%b（0x%X）", a, a)
 }
 
-// 定义位掩码常量（每个常量对应一个2的幂，确保二进制中只有一位为1）
-const (
-	UPPER = 1 << iota // 1 << 0 = 1（二进制0001）：转换为大写
-	LOWER             // 1 << 1 = 2（二进制0010）：转换为小写
-	CAP               // 1 << 2 = 4（二进制0100）：单词首字母大写
-	REV               // 1 << 3 = 8（二进制1000）：反转字符串
-)
-
-// procstr 根据位掩码配置对字符串执行转换操作
-// 参数：
-//
-//	str: 待转换的字符串
-//	conf: 位掩码配置（通过UPPER|LOWER|CAP|REV组合）
-//
-// 返回：转换后的字符串
-func procstr(str string, conf byte) string {
-	// 反转字符串的内部函数
-	rev := func(s string) string {
-		runes := []rune(s)
-		n := len(runes)
-		for i := 0; i < n/2; i++ {
-			runes[i], runes[n-1-i] = runes[n-1-i], runes[i]
-		}
-		return string(runes)
-	}
-
-	// 根据位掩码配置执行对应操作（用&判断特定位是否被设置）
-	if (conf & UPPER) != 0 {
-		str = strings.ToUpper(str)
-	}
-	if (conf & LOWER) != 0 {
-		str = strings.ToLower(str)
-	}
-	if (conf & REV) != 0 {
-		str = rev(str)
-	}
-	if (conf & CAP) != 0 {
-		str = cases.Title(language.English).String(str)
-	}
-	return str
-}
-
-// TestBitmaskConfig 测试位掩码技术在多配置场景中的应用
-// 验证通过|组合配置项、通过&查询配置项的正确性
-func TestBitmaskConfig(t *testing.T) {
-	// 测试用例：输入字符串和预期结果
-	testCases := []struct {
-		name     string
-		input    string
-		conf     byte
-		expected string
-	}{
-		{
-			name:     "LOWER|REV|CAP组合",
-			input:    "HELLO PEOPLE!",
-			conf:     LOWER | REV | CAP, // 2|8|4=14（二进制1110）
-			expected: "!Elpoep Olleh",
-		},
-		{
-			name:     "UPPER|REV组合",
-			input:    "hello",
-			conf:     UPPER | REV, // 1|8=9（二进制1001）
-			expected: "OLLEH",
-		},
-		{
-			name:     "无配置",
-			input:    "test",
-			conf:     0,
-			expected: "test",
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			result := procstr(tc.input, tc.conf)
-			if result != tc.expected {
-				t.Errorf("配置%s处理失败\n输入: %q\n预期: %q\n实际: %q",
-					tc.name, tc.input, tc.expected, result)
-				return
-			}
-			t.Logf("配置%s处理成功\n输入: %q\n配置值: %d（二进制%04b）\n结果: %q",
-				tc.name, tc.input, tc.conf, tc.conf, result)
-		})
-	}
-}
-
 // TestXORFeatures 测试异或运算的两个核心特性：翻转特定位、判断符号是否相同
 func TestXORFeatures(t *testing.T) {
 	// 测试1：异或运算翻转特定位（前8位，从MSB开始）
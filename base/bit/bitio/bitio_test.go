@@ -0,0 +1,114 @@
+package bitio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitio"
+)
+
+// TestWriteBitsLayout 测试写入的位按 MSB 优先排列
+func TestWriteBitsLayout(t *testing.T) {
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	w.WriteBits(0b101, 3)
+	w.WriteBit(true)
+	w.WriteBits(0xABC, 12)
+	w.WriteBits(0b1, 1)
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush 失败: %v", err)
+	}
+
+	// 101 1 1010 1011 1100 1 + 补齐 0000000
+	expected := []byte{0b1011_1010, 0b1011_1100, 0b1000_0000}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("写入结果 = %08b，预期 %08b", buf.Bytes(), expected)
+	}
+}
+
+// TestRoundTrip 测试随机位宽写入后能原样读回
+func TestRoundTrip(t *testing.T) {
+	type item struct {
+		v uint64
+		n int
+	}
+	r := rand.New(rand.NewSource(1))
+	items := make([]item, 2000)
+	for i := range items {
+		n := r.Intn(65)
+		items[i] = item{r.Uint64() & (1<<uint(n) - 1), n}
+	}
+
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	for _, it := range items {
+		if err := w.WriteBits(it.v, it.n); err != nil {
+			t.Fatalf("WriteBits 失败: %v", err)
+		}
+	}
+	w.Flush()
+
+	rd := bitio.NewReader(&buf)
+	for i, it := range items {
+		v, err := rd.ReadBits(it.n)
+		if err != nil {
+			t.Fatalf("第%d项 ReadBits 失败: %v", i, err)
+		}
+		if v != it.v {
+			t.Fatalf("第%d项 = 0x%X，预期 0x%X（%d位）", i, v, it.v, it.n)
+		}
+	}
+}
+
+// TestUnaryAndAlign 测试一元编码与字节对齐
+func TestUnaryAndAlign(t *testing.T) {
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	w.WriteUnary(3)
+	w.WriteUnary(70)
+	w.WriteBits(0b11, 2)
+	if w.Aligned() {
+		t.Errorf("写入 78 位后不应处于字节边界")
+	}
+	w.Align()
+	if !w.Aligned() {
+		t.Errorf("Align 后应处于字节边界")
+	}
+	w.WriteBits(0xEE, 8)
+	w.Flush()
+
+	rd := bitio.NewReader(bytes.NewReader(buf.Bytes()))
+	if n, _ := rd.ReadUnary(); n != 3 {
+		t.Errorf("ReadUnary = %d，预期 3", n)
+	}
+	if n, _ := rd.ReadUnary(); n != 70 {
+		t.Errorf("ReadUnary = %d，预期 70", n)
+	}
+	rd.ReadBits(2)
+	rd.Align()
+	if v, _ := rd.ReadBits(8); v != 0xEE {
+		t.Errorf("对齐后读取 = 0x%X，预期 0xEE", v)
+	}
+}
+
+// TestReaderErrors 测试数据耗尽与非法位数时的错误
+func TestReaderErrors(t *testing.T) {
+	rd := bitio.NewReader(bytes.NewReader([]byte{0xFF}))
+	if _, err := rd.ReadBits(65); !errors.Is(err, bitio.ErrInvalidWidth) {
+		t.Errorf("ReadBits(65) 错误 = %v，预期 ErrInvalidWidth", err)
+	}
+	if _, err := rd.ReadBits(4); err != nil {
+		t.Fatalf("ReadBits(4) 失败: %v", err)
+	}
+	if _, err := rd.ReadBits(8); err != io.ErrUnexpectedEOF {
+		t.Errorf("读取到一半耗尽的错误 = %v，预期 io.ErrUnexpectedEOF", err)
+	}
+
+	rd = bitio.NewReader(bytes.NewReader(nil))
+	if _, err := rd.ReadBits(1); err != io.EOF {
+		t.Errorf("空输入的错误 = %v，预期 io.EOF", err)
+	}
+}
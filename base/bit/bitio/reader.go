@@ -0,0 +1,106 @@
+// Package bitio 提供按位读写 io.Reader/io.Writer 的 Reader 与 Writer，
+// 作为位级编解码器（Elias、Golomb-Rice 等）的基础。
+//
+// 位序为 MSB 优先：每个字节先读写最高位，多位整数同样先读写最高位。
+package bitio
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// ErrInvalidWidth 表示一次读写的位数不在 [0, 64] 内
+var ErrInvalidWidth = errors.New("bitio: 位数必须在 [0, 64] 内")
+
+// Reader 从底层 io.Reader 中按位读取数据
+type Reader struct {
+	r     io.ByteReader
+	cache uint64 // 低 nbits 位为尚未读取的位
+	nbits uint
+}
+
+// NewReader 创建一个 Reader，r 未实现 io.ByteReader 时使用 bufio.Reader 包装
+func NewReader(r io.Reader) *Reader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Reader{r: br}
+}
+
+// ReadBits 读取 n 位并以无符号整数返回，n 必须在 [0, 64] 内
+// 数据恰好在读取前耗尽时返回 io.EOF，读取到一半时耗尽返回 io.ErrUnexpectedEOF
+func (r *Reader) ReadBits(n int) (uint64, error) {
+	if n < 0 || n > 64 {
+		return 0, ErrInvalidWidth
+	}
+	if n > 32 {
+		// 缓存最多补充到 64 位，分两次读取以避免溢出
+		hi, err := r.ReadBits(n - 32)
+		if err != nil {
+			return 0, err
+		}
+		lo, err := r.ReadBits(32)
+		if err != nil {
+			return 0, noEOF(err)
+		}
+		return hi<<32 | lo, nil
+	}
+
+	for r.nbits < uint(n) {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			if err == io.EOF && r.nbits > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		r.cache = r.cache<<8 | uint64(b)
+		r.nbits += 8
+	}
+	r.nbits -= uint(n)
+	return (r.cache >> r.nbits) & (1<<uint(n) - 1), nil
+}
+
+// ReadBit 读取 1 位
+func (r *Reader) ReadBit() (bool, error) {
+	v, err := r.ReadBits(1)
+	return v == 1, err
+}
+
+// ReadUnary 读取一元编码：返回遇到第一个 1 之前连续 0 的个数，并消耗该 1
+func (r *Reader) ReadUnary() (uint64, error) {
+	var n uint64
+	for {
+		b, err := r.ReadBit()
+		if err != nil {
+			if n > 0 {
+				err = noEOF(err)
+			}
+			return 0, err
+		}
+		if b {
+			return n, nil
+		}
+		n++
+	}
+}
+
+// Align 丢弃当前字节中剩余的位，使下一次读取从字节边界开始
+func (r *Reader) Align() {
+	r.nbits -= r.nbits % 8
+}
+
+// Aligned 判断当前读取位置是否位于字节边界
+func (r *Reader) Aligned() bool {
+	return r.nbits%8 == 0
+}
+
+// noEOF 将读取中途遇到的 io.EOF 转换为 io.ErrUnexpectedEOF
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
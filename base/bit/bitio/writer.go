@@ -0,0 +1,93 @@
+package bitio
+
+import (
+	"bufio"
+	"io"
+)
+
+// Writer 向底层 io.Writer 按位写入数据
+//
+// 写入经过 bufio.Writer 缓冲，结束时必须调用 Flush，
+// 不足一个字节的剩余位会以 0 补齐后写出。
+type Writer struct {
+	w     *bufio.Writer
+	cache uint64 // 低 nbits 位为尚未写出的位
+	nbits uint
+	err   error // 首个写入错误，之后的写入直接返回该错误
+}
+
+// NewWriter 创建一个 Writer
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// WriteBits 写入 v 的低 n 位，n 必须在 [0, 64] 内
+func (w *Writer) WriteBits(v uint64, n int) error {
+	if n < 0 || n > 64 {
+		return ErrInvalidWidth
+	}
+	if n > 32 {
+		if err := w.WriteBits(v>>32, n-32); err != nil {
+			return err
+		}
+		n = 32
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	w.cache = w.cache<<uint(n) | v&(1<<uint(n)-1)
+	w.nbits += uint(n)
+	for w.nbits >= 8 {
+		w.nbits -= 8
+		if err := w.w.WriteByte(byte(w.cache >> w.nbits)); err != nil {
+			w.err = err
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteBit 写入 1 位
+func (w *Writer) WriteBit(b bool) error {
+	var v uint64
+	if b {
+		v = 1
+	}
+	return w.WriteBits(v, 1)
+}
+
+// WriteUnary 写入 n 的一元编码：n 个 0 后跟一个 1
+func (w *Writer) WriteUnary(n uint64) error {
+	for ; n >= 32; n -= 32 {
+		if err := w.WriteBits(0, 32); err != nil {
+			return err
+		}
+	}
+	return w.WriteBits(1, int(n)+1)
+}
+
+// Align 以 0 补齐当前字节，使下一次写入从字节边界开始
+func (w *Writer) Align() error {
+	if pad := (8 - w.nbits%8) % 8; pad > 0 {
+		return w.WriteBits(0, int(pad))
+	}
+	return nil
+}
+
+// Aligned 判断当前写入位置是否位于字节边界
+func (w *Writer) Aligned() bool {
+	return w.nbits%8 == 0
+}
+
+// Flush 补齐当前字节并将缓冲的数据写入底层 io.Writer
+func (w *Writer) Flush() error {
+	if err := w.Align(); err != nil {
+		return err
+	}
+	if err := w.w.Flush(); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Package bitpack 将定宽的 n 位整数紧凑地打包到字节切片中。
+//
+// 例如 1000 个取值不超过 1023 的整数只需 10 位即可表示，
+// 打包后占用 1250 字节，而以 []uint32 存储需要 4000 字节。
+//
+// 打包采用 LSB 优先的小端布局：第 i 个整数占据第 [i*width, (i+1)*width) 位。
+package bitpack
+
+// PackedLen 返回 n 个 width 位整数打包后占用的字节数
+func PackedLen(n, width int) int {
+	return (n*width + 7) / 8
+}
+
+// Pack 将 src 中每个整数的低 width 位依次打包并追加到 dst，返回追加后的切片
+// 高于 width 的位会被丢弃；width 必须在 [1, 32] 内
+func Pack(dst []byte, src []uint32, width int) []byte {
+	checkWidth(width)
+	dst = grow(dst, PackedLen(len(src), width))
+
+	mask := uint64(1)<<uint(width) - 1
+	var acc uint64 // 低 nbits 位为尚未写出的位
+	var nbits uint
+	for _, v := range src {
+		acc |= (uint64(v) & mask) << nbits
+		nbits += uint(width)
+		for nbits >= 8 {
+			dst = append(dst, byte(acc))
+			acc >>= 8
+			nbits -= 8
+		}
+	}
+	if nbits > 0 {
+		dst = append(dst, byte(acc))
+	}
+	return dst
+}
+
+// Unpack 从 src 中解出 n 个 width 位整数并追加到 dst，返回追加后的切片
+// src 长度不足 PackedLen(n, width) 时 panic；width 必须在 [1, 32] 内
+func Unpack(dst []uint32, src []byte, width, n int) []uint32 {
+	checkWidth(width)
+	if len(src) < PackedLen(n, width) {
+		panic("bitpack: src 长度不足")
+	}
+	dst = grow(dst, n)
+
+	mask := uint64(1)<<uint(width) - 1
+	var acc uint64
+	var nbits uint
+	for range n {
+		for nbits < uint(width) {
+			acc |= uint64(src[0]) << nbits
+			src = src[1:]
+			nbits += 8
+		}
+		dst = append(dst, uint32(acc&mask))
+		acc >>= uint(width)
+		nbits -= uint(width)
+	}
+	return dst
+}
+
+// Get 直接读取打包数据中的第 i 个整数，无需解包整个切片
+func Get(src []byte, width, i int) uint32 {
+	checkWidth(width)
+	start := i * width
+	var acc uint64
+	// width <= 32 且起始偏移 < 8，最多跨越 5 个字节
+	for k, b := range src[start/8 : min(len(src), (start+width+7)/8)] {
+		acc |= uint64(b) << (8 * uint(k))
+	}
+	return uint32(acc >> uint(start%8) & (1<<uint(width) - 1))
+}
+
+// Width 返回能容纳 src 中所有整数的最小位宽，src 为空或全为 0 时返回 1
+func Width(src []uint32) int {
+	var or uint32
+	for _, v := range src {
+		or |= v
+	}
+	w := 1
+	for or>>uint(w) != 0 {
+		w++
+	}
+	return w
+}
+
+// checkWidth 检查位宽是否合法
+func checkWidth(width int) {
+	if width < 1 || width > 32 {
+		panic("bitpack: width 必须在 [1, 32] 内")
+	}
+}
+
+// grow 保证 s 至少还能追加 n 个元素而不重新分配
+func grow[T any](s []T, n int) []T {
+	if cap(s)-len(s) < n {
+		t := make([]T, len(s), len(s)+n)
+		copy(t, s)
+		s = t
+	}
+	return s
+}
@@ -0,0 +1,129 @@
+package bitpack_test
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitpack"
+)
+
+// TestPackLayout 测试打包后的字节布局
+func TestPackLayout(t *testing.T) {
+	// 3 个 4 位整数：0x1、0x2、0x3 → 字节 0x21、0x03
+	packed := bitpack.Pack(nil, []uint32{0x1, 0x2, 0x3}, 4)
+	if !slices.Equal(packed, []byte{0x21, 0x03}) {
+		t.Errorf("Pack 结果 = %X，预期 [21 03]", packed)
+	}
+
+	// 高于 width 的位被丢弃
+	packed = bitpack.Pack(nil, []uint32{0xFF}, 4)
+	if !slices.Equal(packed, []byte{0x0F}) {
+		t.Errorf("Pack 截断结果 = %X，预期 [0F]", packed)
+	}
+}
+
+// TestRoundTrip 测试所有位宽下打包与解包可逆
+func TestRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for width := 1; width <= 32; width++ {
+		src := make([]uint32, 1+r.Intn(300))
+		for i := range src {
+			src[i] = uint32(r.Uint64() & (1<<uint(width) - 1))
+		}
+
+		packed := bitpack.Pack([]byte{0xAA}, src, width) // 追加到已有数据之后
+		if len(packed) != 1+bitpack.PackedLen(len(src), width) {
+			t.Fatalf("width=%d: 打包长度 = %d，预期 %d", width, len(packed)-1, bitpack.PackedLen(len(src), width))
+		}
+		got := bitpack.Unpack(nil, packed[1:], width, len(src))
+		if !slices.Equal(got, src) {
+			t.Fatalf("width=%d: 解包结果与原始数据不一致", width)
+		}
+		for i, v := range src {
+			if g := bitpack.Get(packed[1:], width, i); g != v {
+				t.Fatalf("width=%d: Get(%d) = %d，预期 %d", width, i, g, v)
+			}
+		}
+	}
+}
+
+// TestWidth 测试最小位宽计算
+func TestWidth(t *testing.T) {
+	testCases := []struct {
+		src      []uint32
+		expected int
+	}{
+		{nil, 1},
+		{[]uint32{0, 1}, 1},
+		{[]uint32{3, 4}, 3},
+		{[]uint32{1023}, 10},
+		{[]uint32{1 << 31}, 32},
+	}
+	for _, tc := range testCases {
+		if w := bitpack.Width(tc.src); w != tc.expected {
+			t.Errorf("Width(%v) = %d，预期 %d", tc.src, w, tc.expected)
+		}
+	}
+}
+
+// BenchmarkPack 对比 10 位打包与以完整 uint32 存储的吞吐量和内存占用
+func BenchmarkPack(b *testing.B) {
+	const width = 10
+	src := make([]uint32, 4096)
+	for i := range src {
+		src[i] = uint32(i * 7 % 1024)
+	}
+
+	b.Run("uint32", func(b *testing.B) {
+		dst := make([]byte, 0, len(src)*4)
+		b.SetBytes(int64(len(src) * 4))
+		for i := 0; i < b.N; i++ {
+			dst = dst[:0]
+			for _, v := range src {
+				dst = binary.LittleEndian.AppendUint32(dst, v)
+			}
+		}
+		b.ReportMetric(float64(len(dst))/float64(len(src)), "bytes/value")
+	})
+	b.Run("bitpack", func(b *testing.B) {
+		dst := make([]byte, 0, bitpack.PackedLen(len(src), width))
+		b.SetBytes(int64(len(src) * 4))
+		for i := 0; i < b.N; i++ {
+			dst = bitpack.Pack(dst[:0], src, width)
+		}
+		b.ReportMetric(float64(len(dst))/float64(len(src)), "bytes/value")
+	})
+}
+
+// BenchmarkUnpack 对比 10 位解包与读取完整 uint32 的吞吐量
+func BenchmarkUnpack(b *testing.B) {
+	const width = 10
+	src := make([]uint32, 4096)
+	for i := range src {
+		src[i] = uint32(i * 7 % 1024)
+	}
+	raw := make([]byte, 0, len(src)*4)
+	for _, v := range src {
+		raw = binary.LittleEndian.AppendUint32(raw, v)
+	}
+	packed := bitpack.Pack(nil, src, width)
+	dst := make([]uint32, 0, len(src))
+
+	b.Run("uint32", func(b *testing.B) {
+		b.SetBytes(int64(len(src) * 4))
+		for i := 0; i < b.N; i++ {
+			dst = dst[:0]
+			for j := 0; j < len(raw); j += 4 {
+				dst = append(dst, binary.LittleEndian.Uint32(raw[j:]))
+			}
+		}
+	})
+	b.Run("bitpack", func(b *testing.B) {
+		b.SetBytes(int64(len(src) * 4))
+		for i := 0; i < b.N; i++ {
+			dst = bitpack.Unpack(dst[:0], packed, width, len(src))
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package bitpack
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Pack64 与 Pack 相同，整数为 uint64，width 必须在 [1, 64] 内
+// 打包布局与 Pack 一致，位宽不超过 32 时两者的结果相同
+func Pack64(dst []byte, src []uint64, width int) []byte {
+	checkWidth64(width)
+	dst = grow(dst, PackedLen(len(src), width))
+
+	mask := ^uint64(0) >> (64 - uint(width))
+	var acc uint64 // 低 nbits 位为尚未写出的位，nbits < 64
+	var nbits uint
+	for _, v := range src {
+		v &= mask
+		acc |= v << nbits
+		if n := nbits + uint(width); n < 64 {
+			nbits = n
+		} else {
+			// 凑满 64 位整体写出，v 中放不下的高位留给下一轮；nbits 为 0 时 v>>64 为 0
+			dst = binary.LittleEndian.AppendUint64(dst, acc)
+			acc = v >> (64 - nbits)
+			nbits = n - 64
+		}
+	}
+	for ; nbits > 0; nbits -= min(nbits, 8) {
+		dst = append(dst, byte(acc))
+		acc >>= 8
+	}
+	return dst
+}
+
+// Unpack64 与 Unpack 相同，整数为 uint64，width 必须在 [1, 64] 内
+func Unpack64(dst []uint64, src []byte, width, n int) []uint64 {
+	checkWidth64(width)
+	if len(src) < PackedLen(n, width) {
+		panic("bitpack: src 长度不足")
+	}
+	dst = grow(dst, n)
+
+	w := uint(width)
+	mask := ^uint64(0) >> (64 - w)
+	var acc uint64 // 低 nbits 位为已读入但尚未解出的位
+	var nbits uint
+	for range n {
+		if nbits >= w {
+			dst = append(dst, acc&mask)
+			acc >>= w
+			nbits -= w
+			continue
+		}
+		// 补充一个字：剩余数据不足 8 字节时逐字节读入
+		var next uint64
+		var got uint
+		if len(src) >= 8 {
+			next, got = binary.LittleEndian.Uint64(src), 64
+			src = src[8:]
+		} else {
+			for i, b := range src {
+				next |= uint64(b) << (8 * uint(i))
+			}
+			got = 8 * uint(len(src))
+			src = nil
+		}
+		used := w - nbits // 本次从 next 中取走的位数
+		dst = append(dst, (acc|next<<nbits)&mask)
+		acc = next >> used
+		nbits = got - used
+	}
+	return dst
+}
+
+// Width64 返回能容纳 src 中所有整数的最小位宽，src 为空或全为 0 时返回 1
+func Width64(src []uint64) int {
+	var or uint64
+	for _, v := range src {
+		or |= v
+	}
+	return max(1, bits.Len64(or))
+}
+
+// checkWidth64 检查 64 位整数的位宽是否合法
+func checkWidth64(width int) {
+	if width < 1 || width > 64 {
+		panic("bitpack: width 必须在 [1, 64] 内")
+	}
+}
@@ -0,0 +1,107 @@
+package bitpack_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitpack"
+)
+
+// TestPack64RoundTrip 测试所有位宽下 64 位打包与解包可逆
+func TestPack64RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for width := 1; width <= 64; width++ {
+		for _, n := range []int{0, 1, 7, 8, 9, 63, 64, 65, 1 + r.Intn(300)} {
+			src := make([]uint64, n)
+			for i := range src {
+				src[i] = r.Uint64() >> (64 - uint(width))
+			}
+			packed := bitpack.Pack64([]byte{0xAA}, src, width)
+			if len(packed) != 1+bitpack.PackedLen(n, width) {
+				t.Fatalf("width=%d n=%d: 打包长度 = %d，预期 %d", width, n, len(packed)-1, bitpack.PackedLen(n, width))
+			}
+			got := bitpack.Unpack64([]uint64{42}, packed[1:], width, n)
+			if got[0] != 42 || !slices.Equal(got[1:], src) {
+				t.Fatalf("width=%d n=%d: 解包结果与原始数据不一致", width, n)
+			}
+		}
+	}
+}
+
+// TestPack64Layout 测试位宽不超过 32 时与 Pack 的布局一致，高于 width 的位被丢弃
+func TestPack64Layout(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for width := 1; width <= 32; width++ {
+		src32 := make([]uint32, 100)
+		src64 := make([]uint64, len(src32))
+		for i := range src32 {
+			src32[i] = r.Uint32()
+			src64[i] = uint64(src32[i]) | r.Uint64()<<32
+		}
+		if !slices.Equal(bitpack.Pack64(nil, src64, width), bitpack.Pack(nil, src32, width)) {
+			t.Fatalf("width=%d: Pack64 与 Pack 的结果不同", width)
+		}
+	}
+}
+
+// TestWidth64 测试 64 位整数的最小位宽计算
+func TestWidth64(t *testing.T) {
+	testCases := []struct {
+		src      []uint64
+		expected int
+	}{
+		{nil, 1},
+		{[]uint64{0}, 1},
+		{[]uint64{3, 4}, 3},
+		{[]uint64{1 << 40}, 41},
+		{[]uint64{1 << 63}, 64},
+	}
+	for _, tc := range testCases {
+		if w := bitpack.Width64(tc.src); w != tc.expected {
+			t.Errorf("Width64(%v) = %d，预期 %d", tc.src, w, tc.expected)
+		}
+	}
+}
+
+// TestPack64Panics 测试非法位宽与长度不足的输入
+func TestPack64Panics(t *testing.T) {
+	for _, f := range []func(){
+		func() { bitpack.Pack64(nil, []uint64{1}, 0) },
+		func() { bitpack.Pack64(nil, []uint64{1}, 65) },
+		func() { bitpack.Unpack64(nil, []byte{1}, 8, 2) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("预期 panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// BenchmarkPack64 测试 64 位打包与解包的吞吐量
+func BenchmarkPack64(b *testing.B) {
+	const width = 37
+	src := make([]uint64, 4096)
+	for i := range src {
+		src[i] = uint64(i) * 0x9e3779b97f4a7c15 >> (64 - width)
+	}
+	packed := bitpack.Pack64(nil, src, width)
+	b.Run("Pack64", func(b *testing.B) {
+		dst := make([]byte, 0, len(packed)+8)
+		b.SetBytes(int64(len(src) * 8))
+		for i := 0; i < b.N; i++ {
+			dst = bitpack.Pack64(dst[:0], src, width)
+		}
+	})
+	b.Run("Unpack64", func(b *testing.B) {
+		dst := make([]uint64, 0, len(src))
+		b.SetBytes(int64(len(src) * 8))
+		for i := 0; i < b.N; i++ {
+			dst = bitpack.Unpack64(dst[:0], packed, width, len(src))
+		}
+	})
+}
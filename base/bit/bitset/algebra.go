@@ -0,0 +1,112 @@
+package bitset
+
+// 本文件中的集合运算均按 uint64 字并行处理，一次运算 64 位，
+// 而不是逐位调用 Test/Set。
+//
+// 两个集合长度不同时，较短的一方视为高位全部为 0。
+
+// Clone 返回 b 的深拷贝
+func (b *BitSet) Clone() *BitSet {
+	c := &BitSet{
+		words:  make([]uint64, len(b.words)),
+		length: b.length,
+	}
+	copy(c.words, b.words)
+	return c
+}
+
+// UnionWith 原地求并集：b = b ∪ o
+func (b *BitSet) UnionWith(o *BitSet) {
+	b.Grow(o.length)
+	for i, w := range o.words {
+		b.words[i] |= w
+	}
+}
+
+// IntersectWith 原地求交集：b = b ∩ o
+func (b *BitSet) IntersectWith(o *BitSet) {
+	for i := range b.words {
+		if i < len(o.words) {
+			b.words[i] &= o.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+// DifferenceWith 原地求差集：b = b \ o
+func (b *BitSet) DifferenceWith(o *BitSet) {
+	n := min(len(b.words), len(o.words))
+	for i := range n {
+		b.words[i] &^= o.words[i]
+	}
+}
+
+// SymmetricDifferenceWith 原地求对称差：b = b △ o
+func (b *BitSet) SymmetricDifferenceWith(o *BitSet) {
+	b.Grow(o.length)
+	for i, w := range o.words {
+		b.words[i] ^= w
+	}
+}
+
+// Union 返回 b ∪ o，不修改 b 与 o
+func (b *BitSet) Union(o *BitSet) *BitSet {
+	c := b.Clone()
+	c.UnionWith(o)
+	return c
+}
+
+// Intersect 返回 b ∩ o，不修改 b 与 o
+func (b *BitSet) Intersect(o *BitSet) *BitSet {
+	c := b.Clone()
+	c.IntersectWith(o)
+	return c
+}
+
+// Difference 返回 b \ o，不修改 b 与 o
+func (b *BitSet) Difference(o *BitSet) *BitSet {
+	c := b.Clone()
+	c.DifferenceWith(o)
+	return c
+}
+
+// SymmetricDifference 返回 b △ o，不修改 b 与 o
+func (b *BitSet) SymmetricDifference(o *BitSet) *BitSet {
+	c := b.Clone()
+	c.SymmetricDifferenceWith(o)
+	return c
+}
+
+// IsSubsetOf 判断 b 是否为 o 的子集（b 中为 1 的位在 o 中也为 1）
+func (b *BitSet) IsSubsetOf(o *BitSet) bool {
+	for i, w := range b.words {
+		var ow uint64
+		if i < len(o.words) {
+			ow = o.words[i]
+		}
+		if w&^ow != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal 判断 b 与 o 是否包含相同的元素，长度不同但多出的位全为 0 时仍视为相等
+func (b *BitSet) Equal(o *BitSet) bool {
+	short, long := b.words, o.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package bitset_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+)
+
+// fromIndexes 用给定的位序号构造 BitSet
+func fromIndexes(length int, idx ...int) *bitset.BitSet {
+	b := bitset.New(length)
+	for _, i := range idx {
+		b.Set(i)
+	}
+	return b
+}
+
+// TestSetAlgebra 测试并、交、差、对称差运算
+func TestSetAlgebra(t *testing.T) {
+	a := fromIndexes(70, 1, 3, 64, 69)
+	b := fromIndexes(200, 3, 5, 64, 150)
+
+	testCases := []struct {
+		name     string
+		actual   *bitset.BitSet
+		expected *bitset.BitSet
+	}{
+		{"并集", a.Union(b), fromIndexes(0, 1, 3, 5, 64, 69, 150)},
+		{"交集", a.Intersect(b), fromIndexes(0, 3, 64)},
+		{"差集a-b", a.Difference(b), fromIndexes(0, 1, 69)},
+		{"差集b-a", b.Difference(a), fromIndexes(0, 5, 150)},
+		{"对称差", a.SymmetricDifference(b), fromIndexes(0, 1, 5, 69, 150)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.actual.Equal(tc.expected) {
+				t.Errorf("%s结果错误: Count = %d，预期 %d", tc.name, tc.actual.Count(), tc.expected.Count())
+			}
+		})
+	}
+
+	// 复制版本不应修改原集合
+	if a.Count() != 4 || b.Count() != 4 {
+		t.Errorf("复制运算修改了原集合: a.Count = %d，b.Count = %d", a.Count(), b.Count())
+	}
+}
+
+// TestSubsetAndEqual 测试子集与相等判断
+func TestSubsetAndEqual(t *testing.T) {
+	small := fromIndexes(10, 2, 7)
+	big := fromIndexes(300, 2, 7, 250)
+
+	if !small.IsSubsetOf(big) {
+		t.Errorf("small 应为 big 的子集")
+	}
+	if big.IsSubsetOf(small) {
+		t.Errorf("big 不应为 small 的子集")
+	}
+	if !small.Equal(fromIndexes(500, 2, 7)) {
+		t.Errorf("元素相同、长度不同的集合应相等")
+	}
+	if small.Equal(big) {
+		t.Errorf("元素不同的集合不应相等")
+	}
+}
+
+// randomPair 生成两个长度为 n、随机填充的集合
+func randomPair(n int) (*bitset.BitSet, *bitset.BitSet) {
+	r := rand.New(rand.NewSource(1))
+	a, b := bitset.New(n), bitset.New(n)
+	for i := range n {
+		if r.Intn(2) == 0 {
+			a.Set(i)
+		}
+		if r.Intn(2) == 0 {
+			b.Set(i)
+		}
+	}
+	return a, b
+}
+
+// BenchmarkUnion 对比按字并行的并集与逐位循环的并集
+func BenchmarkUnion(b *testing.B) {
+	const n = 1 << 16
+	x, y := randomPair(n)
+
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c := x.Clone()
+			for j := range n {
+				if y.Test(j) {
+					c.Set(j)
+				}
+			}
+		}
+	})
+	b.Run("word", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c := x.Clone()
+			c.UnionWith(y)
+		}
+	})
+}
+
+// BenchmarkIntersect 对比按字并行的交集与逐位循环的交集
+func BenchmarkIntersect(b *testing.B) {
+	const n = 1 << 16
+	x, y := randomPair(n)
+
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c := x.Clone()
+			for j := range n {
+				if !y.Test(j) {
+					c.Clear(j)
+				}
+			}
+		}
+	})
+	b.Run("word", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c := x.Clone()
+			c.IntersectWith(y)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package bitset
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// Atomic 是定长、可被多个 goroutine 并发读写的位集合
+//
+// Set/Clear 使用 atomic.OrUint64/atomic.AndUint64 一次修改一个字，
+// 不需要额外的互斥锁；由于长度固定，Atomic 不支持自动扩容。
+type Atomic struct {
+	words  []uint64
+	length int
+}
+
+// NewAtomic 创建一个长度为 length 位、所有位均为 0 的 Atomic
+func NewAtomic(length int) *Atomic {
+	if length < 0 {
+		panic("bitset: 长度不能为负数")
+	}
+	return &Atomic{
+		words:  make([]uint64, wordsNeeded(length)),
+		length: length,
+	}
+}
+
+// Len 返回位集合的长度（位数）
+func (a *Atomic) Len() int {
+	return a.length
+}
+
+// Set 原子地将第 i 位置为 1
+func (a *Atomic) Set(i int) {
+	a.checkRange(i)
+	atomic.OrUint64(&a.words[i>>log2Word], 1<<(uint(i)&(wordBits-1)))
+}
+
+// Clear 原子地将第 i 位清零
+func (a *Atomic) Clear(i int) {
+	a.checkRange(i)
+	atomic.AndUint64(&a.words[i>>log2Word], ^uint64(1<<(uint(i)&(wordBits-1))))
+}
+
+// Flip 原子地翻转第 i 位，基于 CAS 循环实现
+func (a *Atomic) Flip(i int) {
+	a.checkRange(i)
+	addr := &a.words[i>>log2Word]
+	mask := uint64(1) << (uint(i) & (wordBits - 1))
+	for {
+		old := atomic.LoadUint64(addr)
+		if atomic.CompareAndSwapUint64(addr, old, old^mask) {
+			return
+		}
+	}
+}
+
+// Test 原子地读取第 i 位是否为 1
+func (a *Atomic) Test(i int) bool {
+	a.checkRange(i)
+	return atomic.LoadUint64(&a.words[i>>log2Word])&(1<<(uint(i)&(wordBits-1))) != 0
+}
+
+// TestAndSet 原子地将第 i 位置为 1，并返回该位原来是否为 1
+// 返回 false 表示本次调用将该位从 0 置为 1，多个 goroutine 竞争同一位时只有一个会得到 false
+func (a *Atomic) TestAndSet(i int) bool {
+	a.checkRange(i)
+	mask := uint64(1) << (uint(i) & (wordBits - 1))
+	return atomic.OrUint64(&a.words[i>>log2Word], mask)&mask != 0
+}
+
+// TestAndClear 原子地将第 i 位清零，并返回该位原来是否为 1
+func (a *Atomic) TestAndClear(i int) bool {
+	a.checkRange(i)
+	mask := uint64(1) << (uint(i) & (wordBits - 1))
+	return atomic.AndUint64(&a.words[i>>log2Word], ^mask)&mask != 0
+}
+
+// SetFirstClear 原子地找到一个值为 0 的位并将其置为 1，返回其位序号；所有位均为 1 时返回 (0, false)
+//
+// 每个字内用一次 CAS 认领最低的空闲位，CAS 失败时重新读取该字重试，
+// 配合 Clear 释放，即可作为无锁的槽位分配器：
+//
+//	slot, ok := a.SetFirstClear()
+//	...
+//	a.Clear(slot)
+func (a *Atomic) SetFirstClear() (int, bool) {
+	for x := range a.words {
+		addr := &a.words[x]
+		valid := ^uint64(0) // 该字中位于 Len() 之内的位
+		if r := a.length - x<<log2Word; r < wordBits {
+			valid = 1<<uint(r) - 1
+		}
+		for {
+			old := atomic.LoadUint64(addr)
+			free := ^old & valid
+			if free == 0 {
+				break
+			}
+			low := free & -free
+			if atomic.CompareAndSwapUint64(addr, old, old|low) {
+				return x<<log2Word + bits.TrailingZeros64(low), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// OrWith 原子地将 b 中值为 1 的位合并到 a，每个字使用一次原子或操作；b 比 a 长时 panic
+// 整个合并不是一个原子操作，并发读取者可能看到只合并了一部分字的中间状态
+func (a *Atomic) OrWith(b *BitSet) {
+	if b.Len() > a.length {
+		panic("bitset: 合并的位集合长于 Atomic")
+	}
+	for x, w := range b.Words() {
+		if w != 0 {
+			atomic.OrUint64(&a.words[x], w)
+		}
+	}
+}
+
+// Count 返回值为 1 的位的个数
+// 每个字单独原子读取，并发修改时结果只是近似快照
+func (a *Atomic) Count() int {
+	n := 0
+	for i := range a.words {
+		n += bits.OnesCount64(atomic.LoadUint64(&a.words[i]))
+	}
+	return n
+}
+
+// checkRange 检查位序号是否在 [0, Len()) 内
+func (a *Atomic) checkRange(i int) {
+	if i < 0 || i >= a.length {
+		panic("bitset: 位序号超出范围")
+	}
+}
@@ -0,0 +1,199 @@
+package bitset_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+)
+
+// TestAtomicBasic 测试 Atomic 的单 goroutine 行为
+func TestAtomicBasic(t *testing.T) {
+	a := bitset.NewAtomic(130)
+	a.Set(0)
+	a.Set(129)
+	a.Flip(64)
+	if !a.Test(0) || !a.Test(64) || !a.Test(129) || a.Count() != 3 {
+		t.Fatalf("设置结果错误: Count = %d", a.Count())
+	}
+
+	a.Clear(0)
+	a.Flip(64)
+	if a.Test(0) || a.Test(64) || a.Count() != 1 {
+		t.Errorf("清除结果错误: Count = %d", a.Count())
+	}
+}
+
+// TestAtomicConcurrent 多个 goroutine 交错修改同一批字中的不同位，
+// 使用 go test -race 运行可验证无数据竞争且不会丢失更新
+func TestAtomicConcurrent(t *testing.T) {
+	const (
+		workers = 8
+		n       = 4096
+	)
+	a := bitset.NewAtomic(n)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 第 w 个 goroutine 负责 i%workers == w 的位，相邻位落在同一个字中
+			for i := w; i < n; i += workers {
+				a.Set(i)
+				a.Flip(i)
+				a.Flip(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if a.Count() != n {
+		t.Fatalf("并发 Set 丢失更新: Count = %d，预期 %d", a.Count(), n)
+	}
+
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w; i < n; i += workers {
+				if i%2 == 0 {
+					a.Clear(i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range n {
+		if a.Test(i) != (i%2 == 1) {
+			t.Fatalf("第%d位状态错误", i)
+		}
+	}
+}
+
+// TestAtomicOutOfRange 测试越界访问会触发 panic
+func TestAtomicOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("越界访问应触发 panic")
+		}
+	}()
+	bitset.NewAtomic(64).Set(64)
+}
+
+// TestAtomicTestAndSet 测试 TestAndSet 与 TestAndClear 返回原来的值
+func TestAtomicTestAndSet(t *testing.T) {
+	a := bitset.NewAtomic(70)
+	if a.TestAndSet(65) {
+		t.Errorf("首次 TestAndSet 应返回 false")
+	}
+	if !a.TestAndSet(65) {
+		t.Errorf("再次 TestAndSet 应返回 true")
+	}
+	if !a.TestAndClear(65) || a.Test(65) {
+		t.Errorf("TestAndClear 应返回 true 并清零")
+	}
+	if a.TestAndClear(65) {
+		t.Errorf("再次 TestAndClear 应返回 false")
+	}
+}
+
+// TestAtomicSetFirstClear 测试并发分配槽位时每个槽位只被分配一次，且不会分配 Len() 之外的位
+func TestAtomicSetFirstClear(t *testing.T) {
+	const n = 1000
+	a := bitset.NewAtomic(n)
+	a.Set(0)
+	a.Set(64)
+
+	got := make([][]int, 8)
+	var wg sync.WaitGroup
+	for w := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i, ok := a.SetFirstClear()
+				if !ok {
+					return
+				}
+				got[w] = append(got[w], i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make([]bool, n)
+	total := 0
+	for _, slots := range got {
+		for _, i := range slots {
+			if i >= n || i == 0 || i == 64 || seen[i] {
+				t.Fatalf("槽位 %d 被错误分配", i)
+			}
+			seen[i] = true
+			total++
+		}
+	}
+	if total != n-2 || a.Count() != n {
+		t.Errorf("分配了 %d 个槽位，Count = %d", total, a.Count())
+	}
+
+	a.Clear(777)
+	if i, ok := a.SetFirstClear(); !ok || i != 777 {
+		t.Errorf("SetFirstClear = (%d, %v)，预期复用刚释放的 777", i, ok)
+	}
+}
+
+// TestAtomicOrWith 测试合并普通位集合
+func TestAtomicOrWith(t *testing.T) {
+	a := bitset.NewAtomic(200)
+	a.Set(3)
+	b := bitset.New(130)
+	b.Set(5)
+	b.Set(129)
+	a.OrWith(b)
+	if !a.Test(3) || !a.Test(5) || !a.Test(129) || a.Count() != 3 {
+		t.Errorf("OrWith 后 Count = %d", a.Count())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("合并更长的位集合应 panic")
+		}
+	}()
+	a.OrWith(bitset.New(201))
+}
+
+// BenchmarkSlotAllocator 对比无锁的 SetFirstClear 与互斥锁保护的 BitSet 分配、释放槽位的开销
+func BenchmarkSlotAllocator(b *testing.B) {
+	const slots = 4096
+	b.Run("Atomic", func(b *testing.B) {
+		a := bitset.NewAtomic(slots)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if i, ok := a.SetFirstClear(); ok {
+					a.Clear(i)
+				}
+			}
+		})
+	})
+	b.Run("Mutex", func(b *testing.B) {
+		var mu sync.Mutex
+		bs := bitset.New(slots)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				mu.Lock()
+				i, ok := bs.NextClear(0)
+				if ok {
+					bs.Set(i)
+				}
+				mu.Unlock()
+				if ok {
+					mu.Lock()
+					bs.Clear(i)
+					mu.Unlock()
+				}
+			}
+		})
+	})
+}
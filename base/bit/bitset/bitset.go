@@ -0,0 +1,120 @@
+// Package bitset 提供以 []uint64 为底层存储、可动态增长的位集合。
+package bitset
+
+import "github.com/moweilong/efficient-go/base/bit"
+
+const (
+	wordBits = 64 // 每个字包含的位数
+	log2Word = 6  // log2(wordBits)，用于将位序号换算为字下标
+)
+
+// BitSet 是可动态增长的位集合，零值即为可用的空集合
+//
+// 不变式：超出 Len() 的位始终为 0，Count 等操作可直接按字统计。
+type BitSet struct {
+	words  []uint64
+	length int // 逻辑位长度
+}
+
+// New 创建一个长度为 length 位、所有位均为 0 的 BitSet
+func New(length int) *BitSet {
+	if length < 0 {
+		panic("bitset: 长度不能为负数")
+	}
+	return &BitSet{
+		words:  make([]uint64, wordsNeeded(length)),
+		length: length,
+	}
+}
+
+// wordsNeeded 返回容纳 n 位所需的字数
+func wordsNeeded(n int) int {
+	return (n + wordBits - 1) >> log2Word
+}
+
+// Len 返回位集合的逻辑长度（位数）
+func (b *BitSet) Len() int {
+	return b.length
+}
+
+// Grow 将位集合扩展到至少 n 位，新增的位均为 0；n 不大于 Len() 时不做任何事
+func (b *BitSet) Grow(n int) {
+	if n <= b.length {
+		return
+	}
+	need := wordsNeeded(n)
+	if need > cap(b.words) {
+		// 按两倍扩容，摊还多次 Set 触发的增长开销
+		words := make([]uint64, need, max(need, 2*cap(b.words)))
+		copy(words, b.words)
+		b.words = words
+	} else {
+		// 备用容量可能来自 FromWords 传入的切片，内容未必为 0
+		n := len(b.words)
+		b.words = b.words[:need]
+		clear(b.words[n:])
+	}
+	b.length = n
+}
+
+// Set 将第 i 位置为 1，i 超出长度时自动扩容
+func (b *BitSet) Set(i int) {
+	checkIndex(i)
+	b.Grow(i + 1)
+	b.words[i>>log2Word] |= 1 << (uint(i) & (wordBits - 1))
+}
+
+// Clear 将第 i 位清零，i 超出长度时不做任何事
+func (b *BitSet) Clear(i int) {
+	checkIndex(i)
+	if i >= b.length {
+		return
+	}
+	b.words[i>>log2Word] &^= 1 << (uint(i) & (wordBits - 1))
+}
+
+// Flip 翻转第 i 位，i 超出长度时自动扩容
+func (b *BitSet) Flip(i int) {
+	checkIndex(i)
+	b.Grow(i + 1)
+	b.words[i>>log2Word] ^= 1 << (uint(i) & (wordBits - 1))
+}
+
+// Test 判断第 i 位是否为 1，i 超出长度时返回 false
+func (b *BitSet) Test(i int) bool {
+	checkIndex(i)
+	if i >= b.length {
+		return false
+	}
+	return b.words[i>>log2Word]&(1<<(uint(i)&(wordBits-1))) != 0
+}
+
+// Count 返回值为 1 的位的个数
+func (b *BitSet) Count() int {
+	return bit.PopcountSlice(b.words)
+}
+
+// checkIndex 检查位序号是否合法
+func checkIndex(i int) {
+	if i < 0 {
+		panic("bitset: 位序号不能为负数")
+	}
+}
+
+// Words 返回底层的字切片（第 i 位位于 Words()[i/64] 的第 i%64 位），
+// 供序列化等场景直接访问；修改返回值会影响 b，且超出 Len() 的位必须保持为 0
+func (b *BitSet) Words() []uint64 {
+	return b.words
+}
+
+// FromWords 以 words 为底层存储创建长度为 length 的 BitSet，不复制 words
+// words 的长度必须恰好能容纳 length 位，超出 length 的位会被清零
+func FromWords(words []uint64, length int) *BitSet {
+	if length < 0 || len(words) != wordsNeeded(length) {
+		panic("bitset: words 长度与 length 不匹配")
+	}
+	if r := uint(length) & (wordBits - 1); r != 0 {
+		words[len(words)-1] &= 1<<r - 1
+	}
+	return &BitSet{words: words, length: length}
+}
@@ -0,0 +1,106 @@
+package bitset_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+)
+
+// TestBitSetBasic 测试 Set/Clear/Flip/Test 的基本行为
+func TestBitSetBasic(t *testing.T) {
+	b := bitset.New(100)
+	if b.Len() != 100 {
+		t.Fatalf("Len = %d，预期 100", b.Len())
+	}
+
+	for _, i := range []int{0, 1, 63, 64, 99} {
+		b.Set(i)
+		if !b.Test(i) {
+			t.Errorf("第%d位设置后应为1", i)
+		}
+	}
+	if b.Count() != 5 {
+		t.Errorf("Count = %d，预期 5", b.Count())
+	}
+
+	b.Clear(63)
+	if b.Test(63) {
+		t.Errorf("第63位清除后应为0")
+	}
+
+	b.Flip(63)
+	b.Flip(0)
+	if !b.Test(63) || b.Test(0) {
+		t.Errorf("Flip 结果错误: 第63位=%v，第0位=%v", b.Test(63), b.Test(0))
+	}
+	if b.Count() != 4 {
+		t.Errorf("Count = %d，预期 4", b.Count())
+	}
+}
+
+// TestBitSetGrow 测试超出长度时的自动扩容与 Grow
+func TestBitSetGrow(t *testing.T) {
+	var b bitset.BitSet // 零值可直接使用
+	if b.Test(1000) {
+		t.Errorf("空集合中第1000位应为0")
+	}
+
+	b.Clear(1000) // 超出长度的 Clear 不扩容
+	if b.Len() != 0 {
+		t.Errorf("Clear 不应扩容，Len = %d", b.Len())
+	}
+
+	b.Set(1000)
+	if b.Len() != 1001 || !b.Test(1000) {
+		t.Errorf("Set 自动扩容错误: Len = %d", b.Len())
+	}
+
+	b.Flip(2000)
+	if b.Len() != 2001 || !b.Test(2000) {
+		t.Errorf("Flip 自动扩容错误: Len = %d", b.Len())
+	}
+
+	b.Grow(10)
+	if b.Len() != 2001 {
+		t.Errorf("Grow 不应缩小集合，Len = %d", b.Len())
+	}
+	b.Grow(5000)
+	if b.Len() != 5000 || b.Count() != 2 {
+		t.Errorf("Grow 错误: Len = %d，Count = %d", b.Len(), b.Count())
+	}
+}
+
+// TestBitSetNegativeIndex 测试负数位序号会触发 panic
+func TestBitSetNegativeIndex(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("负数位序号应触发 panic")
+		}
+	}()
+	bitset.New(8).Set(-1)
+}
+
+// TestWords 测试直接访问底层字切片
+func TestWords(t *testing.T) {
+	b := bitset.New(70)
+	b.Set(1)
+	b.Set(65)
+	words := b.Words()
+	if len(words) != 2 || words[0] != 1<<1 || words[1] != 1<<1 {
+		t.Fatalf("Words() = %b", words)
+	}
+
+	c := bitset.FromWords([]uint64{words[0], words[1] | 1<<63}, 70)
+	if !c.Equal(b) || c.Count() != 2 {
+		t.Errorf("FromWords 应清除超出长度的位: Count = %d", c.Count())
+	}
+}
+
+// TestFromWordsGrow 测试扩容复用 FromWords 传入切片的备用容量时新增的位为 0
+func TestFromWordsGrow(t *testing.T) {
+	b := bitset.FromWords([]uint64{0, ^uint64(0)}[:1], 64)
+	b.Grow(128)
+	if b.Count() != 0 || b.Test(100) {
+		t.Errorf("扩容后 Count() = %d, Test(100) = %v，预期新增的位为 0", b.Count(), b.Test(100))
+	}
+}
@@ -0,0 +1,70 @@
+package bitset
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// NextSet 返回不小于 i 的第一个值为 1 的位序号
+// 使用 TrailingZeros64 一次跳过整字的 0，而不是逐位扫描；不存在时返回 (0, false)
+func (b *BitSet) NextSet(i int) (int, bool) {
+	checkIndex(i)
+	if i >= b.length {
+		return 0, false
+	}
+	x := i >> log2Word
+	w := b.words[x] >> (uint(i) & (wordBits - 1)) // 丢弃 i 之前的低位
+	if w != 0 {
+		return i + bits.TrailingZeros64(w), true
+	}
+	for x++; x < len(b.words); x++ {
+		if b.words[x] != 0 {
+			return x<<log2Word + bits.TrailingZeros64(b.words[x]), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear 返回区间 [i, Len()) 内第一个值为 0 的位序号，不存在时返回 (0, false)
+func (b *BitSet) NextClear(i int) (int, bool) {
+	checkIndex(i)
+	if i >= b.length {
+		return 0, false
+	}
+	x := i >> log2Word
+	w := ^b.words[x] >> (uint(i) & (wordBits - 1))
+	if w != 0 {
+		if j := i + bits.TrailingZeros64(w); j < b.length {
+			return j, true
+		}
+		return 0, false
+	}
+	for x++; x < len(b.words); x++ {
+		if b.words[x] != ^uint64(0) {
+			if j := x<<log2Word + bits.TrailingZeros64(^b.words[x]); j < b.length {
+				return j, true
+			}
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+// All 返回按升序遍历所有值为 1 的位序号的迭代器
+//
+//	for i := range b.All() {
+//		...
+//	}
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for x, w := range b.words {
+			for w != 0 {
+				i := x<<log2Word + bits.TrailingZeros64(w)
+				if !yield(i) {
+					return
+				}
+				w &= w - 1 // 清除最低位的 1
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package bitset_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+)
+
+// TestNextSet 测试查找下一个值为 1 的位
+func TestNextSet(t *testing.T) {
+	b := fromIndexes(300, 0, 5, 64, 200)
+
+	testCases := []struct {
+		from     int
+		expected int
+		ok       bool
+	}{
+		{0, 0, true},
+		{1, 5, true},
+		{6, 64, true},
+		{65, 200, true},
+		{200, 200, true},
+		{201, 0, false},
+		{1000, 0, false},
+	}
+	for _, tc := range testCases {
+		i, ok := b.NextSet(tc.from)
+		if i != tc.expected || ok != tc.ok {
+			t.Errorf("NextSet(%d) = (%d, %v)，预期 (%d, %v)", tc.from, i, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+// TestNextClear 测试查找下一个值为 0 的位
+func TestNextClear(t *testing.T) {
+	b := bitset.New(130)
+	for i := range 128 {
+		b.Set(i)
+	}
+
+	testCases := []struct {
+		from     int
+		expected int
+		ok       bool
+	}{
+		{0, 128, true},
+		{100, 128, true},
+		{129, 129, true},
+		{130, 0, false},
+	}
+	for _, tc := range testCases {
+		i, ok := b.NextClear(tc.from)
+		if i != tc.expected || ok != tc.ok {
+			t.Errorf("NextClear(%d) = (%d, %v)，预期 (%d, %v)", tc.from, i, ok, tc.expected, tc.ok)
+		}
+	}
+
+	// 所有位均为 1 时不存在值为 0 的位
+	full := bitset.New(64)
+	for i := range 64 {
+		full.Set(i)
+	}
+	if i, ok := full.NextClear(0); ok {
+		t.Errorf("全1集合 NextClear(0) = %d，预期不存在", i)
+	}
+}
+
+// TestAll 测试迭代器按升序返回所有值为 1 的位，并支持提前退出
+func TestAll(t *testing.T) {
+	expected := []int{1, 63, 64, 127, 500}
+	b := fromIndexes(0, expected...)
+
+	if got := slices.Collect(b.All()); !slices.Equal(got, expected) {
+		t.Errorf("All() = %v，预期 %v", got, expected)
+	}
+
+	var first []int
+	for i := range b.All() {
+		if i > 64 {
+			break
+		}
+		first = append(first, i)
+	}
+	if !slices.Equal(first, expected[:3]) {
+		t.Errorf("提前退出结果 = %v，预期 %v", first, expected[:3])
+	}
+}
+
+// BenchmarkIterate 对比逐位扫描与基于 TrailingZeros 的迭代
+func BenchmarkIterate(b *testing.B) {
+	const n = 1 << 16
+	s := bitset.New(n)
+	for i := 0; i < n; i += 97 { // 稀疏集合
+		s.Set(i)
+	}
+
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for j := range n {
+				if s.Test(j) {
+					sum += j
+				}
+			}
+		}
+	})
+	b.Run("all", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for j := range s.All() {
+				sum += j
+			}
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package bitset_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+)
+
+// TestRankSelect 对随机位集合逐位校验 Rank 与 Select
+func TestRankSelect(t *testing.T) {
+	for _, density := range []float64{0.001, 0.1, 0.5, 0.99} {
+		r := rand.New(rand.NewSource(1))
+		const n = 50000
+		b := bitset.New(n)
+		var positions []int
+		for i := range n {
+			if r.Float64() < density {
+				b.Set(i)
+				positions = append(positions, i)
+			}
+		}
+
+		rs := bitset.NewRankSelect(b)
+		if rs.Ones() != len(positions) || rs.Len() != n {
+			t.Fatalf("密度 %.3f: Ones = %d，预期 %d", density, rs.Ones(), len(positions))
+		}
+
+		rank := 0
+		for i := 0; i <= n; i++ {
+			if got := rs.Rank1(i); got != rank {
+				t.Fatalf("密度 %.3f: Rank1(%d) = %d，预期 %d", density, i, got, rank)
+			}
+			if rs.Rank0(i) != i-rank {
+				t.Fatalf("密度 %.3f: Rank0(%d) 错误", density, i)
+			}
+			if i < n && b.Test(i) {
+				rank++
+			}
+		}
+
+		for k, pos := range positions {
+			if got, ok := rs.Select1(k); !ok || got != pos {
+				t.Fatalf("密度 %.3f: Select1(%d) = (%d, %v)，预期 %d", density, k, got, ok, pos)
+			}
+		}
+		if _, ok := rs.Select1(len(positions)); ok {
+			t.Errorf("密度 %.3f: Select1 越界应返回 false", density)
+		}
+	}
+}
+
+// TestRankSelectEmpty 测试空集合与全 0 集合
+func TestRankSelectEmpty(t *testing.T) {
+	rs := bitset.NewRankSelect(bitset.New(0))
+	if rs.Rank1(0) != 0 {
+		t.Errorf("空集合 Rank1(0) 应为 0")
+	}
+	rs = bitset.NewRankSelect(bitset.New(1000))
+	if rs.Rank1(1000) != 0 {
+		t.Errorf("全 0 集合 Rank1 应为 0")
+	}
+	if _, ok := rs.Select1(0); ok {
+		t.Errorf("全 0 集合 Select1 应返回 false")
+	}
+}
+
+// BenchmarkRankSelect 测试百万位集合上 Rank 与 Select 的耗时
+func BenchmarkRankSelect(b *testing.B) {
+	const n = 1 << 20
+	r := rand.New(rand.NewSource(1))
+	s := bitset.New(n)
+	for i := range n {
+		if r.Intn(2) == 0 {
+			s.Set(i)
+		}
+	}
+	rs := bitset.NewRankSelect(s)
+
+	b.Run("rank", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rs.Rank1(i & (n - 1))
+		}
+	})
+	b.Run("select", func(b *testing.B) {
+		ones := rs.Ones()
+		for i := 0; i < b.N; i++ {
+			rs.Select1(i % ones)
+		}
+	})
+}
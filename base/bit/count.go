@@ -0,0 +1,104 @@
+package bit
+
+import "math/bits"
+
+// OnesCount 返回 x 中值为 1 的位数（popcount）
+func OnesCount[T Unsigned](x T) int {
+	return bits.OnesCount64(uint64(x))
+}
+
+// LeadingZeros 返回 x 在 T 的位宽内的前导零个数，x 为 0 时返回位宽
+func LeadingZeros[T Unsigned](x T) int {
+	return bits.LeadingZeros64(uint64(x)) - (64 - int(Width[T]()))
+}
+
+// TrailingZeros 返回 x 的末尾零个数，x 为 0 时返回 T 的位宽
+func TrailingZeros[T Unsigned](x T) int {
+	if x == 0 {
+		return int(Width[T]())
+	}
+	return bits.TrailingZeros64(uint64(x))
+}
+
+// PopcountSlice 返回 s 中所有字值为 1 的位数之和
+// 按 4 个字一组展开，减少循环开销并让 POPCNT 指令可以流水执行
+func PopcountSlice(s []uint64) int {
+	n0, n1, n2, n3 := 0, 0, 0, 0
+	for len(s) >= 4 {
+		n0 += bits.OnesCount64(s[0])
+		n1 += bits.OnesCount64(s[1])
+		n2 += bits.OnesCount64(s[2])
+		n3 += bits.OnesCount64(s[3])
+		s = s[4:]
+	}
+	for _, w := range s {
+		n0 += bits.OnesCount64(w)
+	}
+	return n0 + n1 + n2 + n3
+}
+
+// HammingDistance 返回 a 与 b 不同的位数，即 popcount(a ^ b)
+func HammingDistance[T Unsigned](a, b T) int {
+	return bits.OnesCount64(uint64(a ^ b))
+}
+
+// HammingDistanceSlice 返回两个等长位向量 a 与 b 不同的位数，长度不同时 panic
+func HammingDistanceSlice(a, b []uint64) int {
+	if len(a) != len(b) {
+		panic("bit: 位向量长度不同")
+	}
+	n0, n1 := 0, 0
+	for len(a) >= 2 {
+		n0 += bits.OnesCount64(a[0] ^ b[0])
+		n1 += bits.OnesCount64(a[1] ^ b[1])
+		a, b = a[2:], b[2:]
+	}
+	if len(a) == 1 {
+		n0 += bits.OnesCount64(a[0] ^ b[0])
+	}
+	return n0 + n1
+}
+
+// SWAR（SIMD Within A Register）常量
+const (
+	m1  = 0x5555555555555555 // 01010101...
+	m2  = 0x3333333333333333 // 00110011...
+	m4  = 0x0f0f0f0f0f0f0f0f // 00001111...
+	h01 = 0x0101010101010101 // 每个字节的最低位为 1
+)
+
+// OnesCountSWAR 是不依赖 POPCNT 指令的纯软件 popcount
+// 原理：先按 2 位、4 位、8 位分组并行求和，最后乘以 h01 把各字节之和累加到最高字节
+func OnesCountSWAR(x uint64) int {
+	x -= (x >> 1) & m1
+	x = x&m2 + (x>>2)&m2
+	x = (x + x>>4) & m4
+	return int(x * h01 >> 56)
+}
+
+// LeadingZerosSWAR 是纯软件实现的 64 位前导零计数
+// 原理：把最高位的 1 向右"涂抹"到所有低位，剩余的 0 的个数即前导零
+func LeadingZerosSWAR(x uint64) int {
+	x |= x >> 1
+	x |= x >> 2
+	x |= x >> 4
+	x |= x >> 8
+	x |= x >> 16
+	x |= x >> 32
+	return 64 - OnesCountSWAR(x)
+}
+
+// TrailingZerosSWAR 是纯软件实现的 64 位末尾零计数
+// 原理：x & -x 只保留最低位的 1，减一后末尾零全部变为 1，再做 popcount
+func TrailingZerosSWAR(x uint64) int {
+	return OnesCountSWAR((x & -x) - 1)
+}
+
+// PopcountSliceSWAR 是 PopcountSlice 的纯软件版本
+func PopcountSliceSWAR(s []uint64) int {
+	n := 0
+	for _, w := range s {
+		n += OnesCountSWAR(w)
+	}
+	return n
+}
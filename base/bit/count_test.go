@@ -0,0 +1,128 @@
+package bit_test
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// TestCountGeneric 测试泛型计数函数在不同位宽下的结果
+func TestCountGeneric(t *testing.T) {
+	testCases := []struct {
+		name     string
+		actual   int
+		expected int
+	}{
+		{"OnesCount[uint8](0xFF)", bit.OnesCount(uint8(0xFF)), 8},
+		{"OnesCount[uint64](0xC4)", bit.OnesCount(uint64(0xC4)), 3},
+		{"LeadingZeros[uint8](1)", bit.LeadingZeros(uint8(1)), 7},
+		{"LeadingZeros[uint16](0)", bit.LeadingZeros(uint16(0)), 16},
+		{"LeadingZeros[uint32](1<<31)", bit.LeadingZeros(uint32(1) << 31), 0},
+		{"TrailingZeros[uint8](0)", bit.TrailingZeros(uint8(0)), 8},
+		{"TrailingZeros[uint32](0x80)", bit.TrailingZeros(uint32(0x80)), 7},
+		{"TrailingZeros[uint64](0)", bit.TrailingZeros(uint64(0)), 64},
+		{"HammingDistance[uint8](0xF0, 0x0F)", bit.HammingDistance(uint8(0xF0), uint8(0x0F)), 8},
+		{"HammingDistance[uint64](5, 5)", bit.HammingDistance(uint64(5), uint64(5)), 0},
+		{"HammingDistance[uint32](1, 2)", bit.HammingDistance(uint32(1), uint32(2)), 2},
+	}
+	for _, tc := range testCases {
+		if tc.actual != tc.expected {
+			t.Errorf("%s = %d，预期 %d", tc.name, tc.actual, tc.expected)
+		}
+	}
+}
+
+// TestHammingDistanceSlice 测试位向量的汉明距离等于逐字汉明距离之和
+func TestHammingDistanceSlice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := range 9 {
+		a, b := make([]uint64, n), make([]uint64, n)
+		want := 0
+		for i := range a {
+			a[i], b[i] = r.Uint64(), r.Uint64()
+			want += bit.HammingDistance(a[i], b[i])
+		}
+		if got := bit.HammingDistanceSlice(a, b); got != want {
+			t.Errorf("n=%d: HammingDistanceSlice = %d，预期 %d", n, got, want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("长度不同时应 panic")
+		}
+	}()
+	bit.HammingDistanceSlice(make([]uint64, 1), nil)
+}
+
+// TestSWAR 测试软件实现与 math/bits 的结果一致
+func TestSWAR(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	inputs := []uint64{0, 1, 1 << 63, ^uint64(0), 0xC4}
+	for range 1000 {
+		inputs = append(inputs, r.Uint64()>>uint(r.Intn(64)))
+	}
+
+	for _, x := range inputs {
+		if bit.OnesCountSWAR(x) != bits.OnesCount64(x) {
+			t.Fatalf("OnesCountSWAR(0x%X) = %d，预期 %d", x, bit.OnesCountSWAR(x), bits.OnesCount64(x))
+		}
+		if bit.LeadingZerosSWAR(x) != bits.LeadingZeros64(x) {
+			t.Fatalf("LeadingZerosSWAR(0x%X) = %d，预期 %d", x, bit.LeadingZerosSWAR(x), bits.LeadingZeros64(x))
+		}
+		if bit.TrailingZerosSWAR(x) != bits.TrailingZeros64(x) {
+			t.Fatalf("TrailingZerosSWAR(0x%X) = %d，预期 %d", x, bit.TrailingZerosSWAR(x), bits.TrailingZeros64(x))
+		}
+	}
+
+	if bit.PopcountSlice(inputs) != bit.PopcountSliceSWAR(inputs) {
+		t.Errorf("PopcountSlice 与 PopcountSliceSWAR 结果不一致")
+	}
+}
+
+// BenchmarkPopcountSlice 对比硬件 POPCNT 与 SWAR 软件实现
+func BenchmarkPopcountSlice(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	s := make([]uint64, 4096)
+	for i := range s {
+		s[i] = r.Uint64()
+	}
+
+	b.Run("hardware", func(b *testing.B) {
+		b.SetBytes(int64(len(s) * 8))
+		for i := 0; i < b.N; i++ {
+			sinkInt += bit.PopcountSlice(s)
+		}
+	})
+	b.Run("swar", func(b *testing.B) {
+		b.SetBytes(int64(len(s) * 8))
+		for i := 0; i < b.N; i++ {
+			sinkInt += bit.PopcountSliceSWAR(s)
+		}
+	})
+}
+
+// BenchmarkZeros 对比前导零/末尾零计数的硬件与软件实现
+func BenchmarkZeros(b *testing.B) {
+	b.Run("leading/hardware", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkInt += bits.LeadingZeros64(uint64(i))
+		}
+	})
+	b.Run("leading/swar", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkInt += bit.LeadingZerosSWAR(uint64(i))
+		}
+	})
+	b.Run("trailing/hardware", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkInt += bits.TrailingZeros64(uint64(i))
+		}
+	})
+	b.Run("trailing/swar", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkInt += bit.TrailingZerosSWAR(uint64(i))
+		}
+	})
+}
@@ -0,0 +1,99 @@
+// Package elias 实现 Elias gamma 与 Elias delta 通用整数编码。
+//
+// 两种编码都是前缀码，无需知道数值上界即可逐个解码，小整数编码得更短，
+// 适合压缩倒排表的间隔、单调序列的差分等以小值为主的数据。
+// 只能编码正整数；需要编码 0 时可先加一。
+package elias
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/moweilong/efficient-go/base/bit/bitio"
+)
+
+var (
+	// ErrZero 表示尝试编码 0
+	ErrZero = errors.New("elias: 只能编码正整数")
+	// ErrCorrupt 表示输入不是合法的编码（长度前缀超过 64 位）
+	ErrCorrupt = errors.New("elias: 编码数据损坏")
+)
+
+// GammaLen 返回 n 的 gamma 编码位数：2*floor(log2 n) + 1
+func GammaLen(n uint64) int {
+	return 2*bits.Len64(n) - 1
+}
+
+// DeltaLen 返回 n 的 delta 编码位数
+func DeltaLen(n uint64) int {
+	l := bits.Len64(n)
+	return GammaLen(uint64(l)) + l - 1
+}
+
+// WriteGamma 写入 n 的 gamma 编码：N 个 0，随后是 n 的 N+1 位二进制（最高位必为 1），
+// 其中 N = floor(log2 n)
+func WriteGamma(w *bitio.Writer, n uint64) error {
+	if n == 0 {
+		return ErrZero
+	}
+	nb := bits.Len64(n) - 1
+	if err := w.WriteBits(0, nb); err != nil {
+		return err
+	}
+	return w.WriteBits(n, nb+1)
+}
+
+// ReadGamma 读取一个 gamma 编码的整数
+func ReadGamma(r *bitio.Reader) (uint64, error) {
+	// 前导 0 的个数即 N，ReadUnary 同时消耗了 n 的最高位 1
+	nb, err := r.ReadUnary()
+	if err != nil {
+		return 0, err
+	}
+	if nb > 63 {
+		return 0, ErrCorrupt
+	}
+	rest, err := r.ReadBits(int(nb))
+	if err != nil {
+		return 0, noEOF(err)
+	}
+	return 1<<nb | rest, nil
+}
+
+// WriteDelta 写入 n 的 delta 编码：先以 gamma 编码写入 n 的位长 L，再写入 n 去掉最高位后的 L-1 位
+// 对较大的整数，delta 编码比 gamma 编码更短
+func WriteDelta(w *bitio.Writer, n uint64) error {
+	if n == 0 {
+		return ErrZero
+	}
+	l := bits.Len64(n)
+	if err := WriteGamma(w, uint64(l)); err != nil {
+		return err
+	}
+	return w.WriteBits(n, l-1)
+}
+
+// ReadDelta 读取一个 delta 编码的整数
+func ReadDelta(r *bitio.Reader) (uint64, error) {
+	l, err := ReadGamma(r)
+	if err != nil {
+		return 0, err
+	}
+	if l > 64 {
+		return 0, ErrCorrupt
+	}
+	rest, err := r.ReadBits(int(l - 1))
+	if err != nil {
+		return 0, noEOF(err)
+	}
+	return 1<<(l-1) | rest, nil
+}
+
+// noEOF 将读取中途遇到的 io.EOF 转换为 io.ErrUnexpectedEOF
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
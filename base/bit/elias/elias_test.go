@@ -0,0 +1,118 @@
+package elias_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitio"
+	"github.com/moweilong/efficient-go/base/bit/elias"
+)
+
+// encode 使用 write 编码 vs 并返回字节数据
+func encode(t testing.TB, write func(*bitio.Writer, uint64) error, vs ...uint64) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	for _, v := range vs {
+		if err := write(w, v); err != nil {
+			t.Fatalf("编码 %d 失败: %v", v, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush 失败: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestGammaLayout 测试 gamma 编码的位布局
+func TestGammaLayout(t *testing.T) {
+	// 1 → 1，2 → 010，5 → 00101，合计 9 位：1010 0010 1 + 补齐
+	got := encode(t, elias.WriteGamma, 1, 2, 5)
+	expected := []byte{0b1010_0010, 0b1000_0000}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("gamma 编码 = %08b，预期 %08b", got, expected)
+	}
+}
+
+// TestLen 测试编码长度计算
+func TestLen(t *testing.T) {
+	testCases := []struct {
+		n            uint64
+		gamma, delta int
+	}{
+		{1, 1, 1},
+		{2, 3, 4},
+		{5, 5, 5},
+		{17, 9, 9},
+		{math.MaxUint64, 127, 76},
+	}
+	for _, tc := range testCases {
+		if l := elias.GammaLen(tc.n); l != tc.gamma {
+			t.Errorf("GammaLen(%d) = %d，预期 %d", tc.n, l, tc.gamma)
+		}
+		if l := elias.DeltaLen(tc.n); l != tc.delta {
+			t.Errorf("DeltaLen(%d) = %d，预期 %d", tc.n, l, tc.delta)
+		}
+	}
+}
+
+// TestErrors 测试编码 0 与输入截断
+func TestErrors(t *testing.T) {
+	w := bitio.NewWriter(io.Discard)
+	if err := elias.WriteGamma(w, 0); !errors.Is(err, elias.ErrZero) {
+		t.Errorf("WriteGamma(0) 错误 = %v，预期 ErrZero", err)
+	}
+	if err := elias.WriteDelta(w, 0); !errors.Is(err, elias.ErrZero) {
+		t.Errorf("WriteDelta(0) 错误 = %v，预期 ErrZero", err)
+	}
+
+	// 00000001 只有长度前缀，缺少 7 位数据
+	r := bitio.NewReader(bytes.NewReader([]byte{0b0000_0001}))
+	if _, err := elias.ReadGamma(r); err != io.ErrUnexpectedEOF {
+		t.Errorf("截断输入错误 = %v，预期 io.ErrUnexpectedEOF", err)
+	}
+
+	// 超过 63 个前导 0 不可能是合法的 64 位整数
+	r = bitio.NewReader(bytes.NewReader(append(make([]byte, 8), 0xFF)))
+	if _, err := elias.ReadGamma(r); !errors.Is(err, elias.ErrCorrupt) {
+		t.Errorf("超长前缀错误 = %v，预期 ErrCorrupt", err)
+	}
+}
+
+// roundTrip 测试编码后能按顺序解码出相同的整数
+func roundTrip(t *testing.T, write func(*bitio.Writer, uint64) error, read func(*bitio.Reader) (uint64, error), vs []uint64) {
+	data := encode(t, write, vs...)
+	r := bitio.NewReader(bytes.NewReader(data))
+	for i, v := range vs {
+		got, err := read(r)
+		if err != nil {
+			t.Fatalf("第%d个整数解码失败: %v", i, err)
+		}
+		if got != v {
+			t.Fatalf("第%d个整数 = %d，预期 %d", i, got, v)
+		}
+	}
+}
+
+// FuzzGamma 对 gamma 编码进行往返模糊测试
+func FuzzGamma(f *testing.F) {
+	f.Add(uint64(1), uint64(2), uint64(math.MaxUint64))
+	f.Add(uint64(1000), uint64(1<<32), uint64(3))
+	f.Fuzz(func(t *testing.T, a, b, c uint64) {
+		vs := []uint64{a | 1, b | 1<<63, max(c, 1)}
+		roundTrip(t, elias.WriteGamma, elias.ReadGamma, vs)
+	})
+}
+
+// FuzzDelta 对 delta 编码进行往返模糊测试
+func FuzzDelta(f *testing.F) {
+	f.Add(uint64(1), uint64(2), uint64(math.MaxUint64))
+	f.Add(uint64(1000), uint64(1<<32), uint64(3))
+	f.Fuzz(func(t *testing.T, a, b, c uint64) {
+		vs := []uint64{a | 1, b | 1<<63, max(c, 1)}
+		roundTrip(t, elias.WriteDelta, elias.ReadDelta, vs)
+	})
+}
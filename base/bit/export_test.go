@@ -0,0 +1,8 @@
+package bit
+
+// 导出内部实现，供 bit_test 包对比硬件与软件路径
+var (
+	HasBMI2            = hasBMI2
+	ExtractBitsGeneric = extractBitsGeneric
+	DepositBitsGeneric = depositBitsGeneric
+)
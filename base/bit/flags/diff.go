@@ -0,0 +1,34 @@
+package flags
+
+import "strings"
+
+// Delta 描述两个位掩码之间的差异
+type Delta struct {
+	Added   []string // 新增的标志名称
+	Removed []string // 移除的标志名称
+}
+
+// Empty 判断两个位掩码是否相同
+func (d Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// String 以 "+WRITE +EXEC -READ" 的形式输出差异，无差异时返回空字符串
+func (d Delta) String() string {
+	var sb strings.Builder
+	for _, name := range d.Added {
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteByte('+')
+		sb.WriteString(name)
+	}
+	for _, name := range d.Removed {
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteByte('-')
+		sb.WriteString(name)
+	}
+	return sb.String()
+}
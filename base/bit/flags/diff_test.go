@@ -0,0 +1,61 @@
+package flags_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/flags"
+)
+
+// TestExplain 测试列出掩码中的标志名称
+func TestExplain(t *testing.T) {
+	r := newRegistry()
+	testCases := []struct {
+		mask     uint8
+		expected []string
+	}{
+		{UPPER | REV, []string{"UPPER", "REV"}},
+		{0, []string{}},
+		{CAP | 0x80, []string{"CAP", "0x80"}},
+	}
+	for _, tc := range testCases {
+		if names := r.Explain(tc.mask); !slices.Equal(names, tc.expected) {
+			t.Errorf("Explain(%08b) = %v，预期 %v", tc.mask, names, tc.expected)
+		}
+	}
+
+	// 196 = 11000100：第 3、7、8 位（从 1 开始计数），未注册名称时以数值输出
+	if names := flags.Explain(196); !slices.Equal(names, []string{"0x4", "0x40", "0x80"}) {
+		t.Errorf("flags.Explain(196) = %v", names)
+	}
+}
+
+// TestDiff 测试比较两个掩码的差异
+func TestDiff(t *testing.T) {
+	r := newRegistry()
+	testCases := []struct {
+		name     string
+		old, new uint8
+		added    []string
+		removed  []string
+		str      string
+	}{
+		{"新增与移除", UPPER | REV, LOWER | REV | CAP, []string{"LOWER", "CAP"}, []string{"UPPER"}, "+LOWER +CAP -UPPER"},
+		{"无变化", REV, REV, []string{}, []string{}, ""},
+		{"全部移除", UPPER | LOWER, 0, []string{}, []string{"UPPER", "LOWER"}, "-UPPER -LOWER"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := r.Diff(tc.old, tc.new)
+			if !slices.Equal(d.Added, tc.added) || !slices.Equal(d.Removed, tc.removed) {
+				t.Errorf("Diff = %+v，预期新增 %v、移除 %v", d, tc.added, tc.removed)
+			}
+			if d.String() != tc.str {
+				t.Errorf("Delta.String() = %q，预期 %q", d.String(), tc.str)
+			}
+			if d.Empty() != (tc.old == tc.new) {
+				t.Errorf("Delta.Empty() = %v", d.Empty())
+			}
+		})
+	}
+}
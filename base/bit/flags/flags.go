@@ -0,0 +1,74 @@
+// Package flags 提供通用的位掩码标志类型 Flags，
+// 用于替代以 byte/uint 等裸整数传递的组合配置（如 UPPER|LOWER|CAP|REV）。
+package flags
+
+import "github.com/moweilong/efficient-go/base/bit"
+
+// Flags 是以无符号整数 T 存储的标志集合，零值表示未设置任何标志
+type Flags[T bit.Unsigned] struct {
+	bits T
+}
+
+// New 返回设置了 fs 中所有标志的 Flags
+func New[T bit.Unsigned](fs ...T) Flags[T] {
+	var f Flags[T]
+	for _, x := range fs {
+		f.bits |= x
+	}
+	return f
+}
+
+// Bits 返回底层的位掩码
+func (f Flags[T]) Bits() T {
+	return f.bits
+}
+
+// Set 设置 mask 中的所有标志（f |= mask）
+func (f *Flags[T]) Set(mask T) {
+	f.bits |= mask
+}
+
+// Clear 清除 mask 中的所有标志（f &^= mask）
+func (f *Flags[T]) Clear(mask T) {
+	f.bits &^= mask
+}
+
+// Toggle 翻转 mask 中的所有标志（f ^= mask）
+func (f *Flags[T]) Toggle(mask T) {
+	f.bits ^= mask
+}
+
+// Has 判断 mask 中的标志是否全部被设置，mask 可以是单个标志或多个标志的组合
+func (f Flags[T]) Has(mask T) bool {
+	return f.bits&mask == mask
+}
+
+// Any 判断 fs 中是否至少有一个标志被设置；不传参数时判断是否设置了任意标志
+func (f Flags[T]) Any(fs ...T) bool {
+	if len(fs) == 0 {
+		return f.bits != 0
+	}
+	for _, x := range fs {
+		if f.bits&x != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// All 判断 fs 中的标志是否全部被设置；不传参数时返回 true
+func (f Flags[T]) All(fs ...T) bool {
+	for _, x := range fs {
+		if !f.Has(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// String 返回以 "|" 连接的标志字符串；未设置任何标志时为 "0"
+// 类型 T 在 RegistryFor[T]() 中注册了名称的位输出名称（如 "LOWER|REV"），其余位以十六进制输出（如 "0x2|0x8"），
+// 与 Registry.Format 及 MarshalText 的格式相同
+func (f Flags[T]) String() string {
+	return f.registry().Format(f.bits)
+}
@@ -0,0 +1,78 @@
+package flags_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/flags"
+)
+
+// 与 bit_test.go 中相同的字符串转换配置
+const (
+	UPPER uint8 = 1 << iota
+	LOWER
+	CAP
+	REV
+)
+
+// TestFlagsSetClearToggle 测试标志的设置、清除与翻转
+func TestFlagsSetClearToggle(t *testing.T) {
+	var f flags.Flags[uint8]
+	if f.Any() {
+		t.Errorf("零值不应设置任何标志")
+	}
+
+	f.Set(LOWER | REV)
+	f.Set(CAP)
+	if f.Bits() != LOWER|REV|CAP {
+		t.Errorf("Set 结果 = %04b，预期 %04b", f.Bits(), LOWER|REV|CAP)
+	}
+
+	f.Clear(REV)
+	f.Toggle(UPPER | CAP)
+	if f.Bits() != UPPER|LOWER {
+		t.Errorf("Clear/Toggle 结果 = %04b，预期 %04b", f.Bits(), UPPER|LOWER)
+	}
+}
+
+// TestFlagsQuery 测试 Has/Any/All 的语义
+func TestFlagsQuery(t *testing.T) {
+	f := flags.New(LOWER, REV)
+
+	testCases := []struct {
+		name     string
+		actual   bool
+		expected bool
+	}{
+		{"Has单个标志", f.Has(LOWER), true},
+		{"Has组合标志全部设置", f.Has(LOWER | REV), true},
+		{"Has组合标志部分设置", f.Has(LOWER | CAP), false},
+		{"Any命中一个", f.Any(UPPER, REV), true},
+		{"Any全部未命中", f.Any(UPPER, CAP), false},
+		{"Any无参数", f.Any(), true},
+		{"All全部设置", f.All(LOWER, REV), true},
+		{"All部分设置", f.All(LOWER, CAP), false},
+		{"All无参数", f.All(), true},
+	}
+	for _, tc := range testCases {
+		if tc.actual != tc.expected {
+			t.Errorf("%s: 结果 %v，预期 %v", tc.name, tc.actual, tc.expected)
+		}
+	}
+}
+
+// TestFlagsString 测试无名称时的字符串格式
+func TestFlagsString(t *testing.T) {
+	testCases := []struct {
+		f        flags.Flags[uint16]
+		expected string
+	}{
+		{flags.New[uint16](), "0"},
+		{flags.New[uint16](2, 4, 8), "0x2|0x4|0x8"},
+		{flags.New[uint16](1 << 15), "0x8000"},
+	}
+	for _, tc := range testCases {
+		if s := tc.f.String(); s != tc.expected {
+			t.Errorf("String() = %q，预期 %q", s, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package flags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Style 表示 Flags 序列化为 JSON 时的格式
+type Style int
+
+const (
+	// StylePipe 序列化为以 "|" 连接的字符串，例如 "UPPER|REV"
+	StylePipe Style = iota
+	// StyleArray 序列化为名称数组，例如 ["UPPER","REV"]
+	StyleArray
+)
+
+// registry 返回 T 对应的注册表
+func (f Flags[T]) registry() *Registry[T] {
+	return RegistryFor[T]()
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出 "UPPER|REV" 形式的字符串
+func (f Flags[T]) MarshalText() ([]byte, error) {
+	return []byte(f.registry().Format(f.bits)), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 "UPPER|REV" 形式的字符串
+func (f *Flags[T]) UnmarshalText(text []byte) error {
+	v, err := f.registry().Parse(string(text))
+	if err != nil {
+		return err
+	}
+	f.bits = v
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler，格式由 RegistryFor[T]().SetStyle 配置
+func (f Flags[T]) MarshalJSON() ([]byte, error) {
+	r := f.registry()
+	if r.Style() != StyleArray {
+		return json.Marshal(r.Format(f.bits))
+	}
+	names := []string{}
+	if f.bits != 0 {
+		names = strings.Split(r.Format(f.bits), "|")
+	}
+	return json.Marshal(names)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，无论配置为何种格式，字符串与名称数组均可解析
+func (f *Flags[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return fmt.Errorf("flags: 空的 JSON 数据")
+	}
+	switch data[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return f.UnmarshalText([]byte(s))
+	case '[':
+		var names []string
+		if err := json.Unmarshal(data, &names); err != nil {
+			return err
+		}
+		return f.UnmarshalText([]byte(strings.Join(names, "|")))
+	default:
+		return fmt.Errorf("flags: 无法将 %s 解析为标志", data)
+	}
+}
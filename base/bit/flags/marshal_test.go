@@ -0,0 +1,87 @@
+package flags_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/flags"
+)
+
+// textOption 以默认的字符串格式序列化
+type textOption uint8
+
+// arrayOption 以名称数组格式序列化
+type arrayOption uint16
+
+func init() {
+	tr := flags.RegistryFor[textOption]()
+	tr.MustRegister("UPPER", 1)
+	tr.MustRegister("LOWER", 2)
+	tr.MustRegister("REV", 8)
+
+	ar := flags.RegistryFor[arrayOption]()
+	ar.MustRegister("READ", 1)
+	ar.MustRegister("WRITE", 2)
+	ar.MustRegister("EXEC", 4)
+	ar.SetStyle(flags.StyleArray)
+}
+
+// TestMarshalJSONPipe 测试字符串格式的 JSON 序列化与反序列化
+func TestMarshalJSONPipe(t *testing.T) {
+	type config struct {
+		Opts flags.Flags[textOption] `json:"opts"`
+	}
+	data, err := json.Marshal(config{Opts: flags.New[textOption](8, 1)})
+	if err != nil || string(data) != `{"opts":"UPPER|REV"}` {
+		t.Fatalf("json.Marshal = (%s, %v)", data, err)
+	}
+
+	var c config
+	if err := json.Unmarshal([]byte(`{"opts":"LOWER|REV"}`), &c); err != nil || c.Opts.Bits() != 2|8 {
+		t.Errorf("从字符串反序列化 = (%v, %v)", c.Opts, err)
+	}
+	if err := json.Unmarshal([]byte(`{"opts":["UPPER"]}`), &c); err != nil || c.Opts.Bits() != 1 {
+		t.Errorf("从数组反序列化 = (%v, %v)", c.Opts, err)
+	}
+	if err := json.Unmarshal([]byte(`{"opts":"BOLD"}`), &c); err == nil {
+		t.Errorf("未知名称应返回错误")
+	}
+	if err := json.Unmarshal([]byte(`{"opts":3}`), &c); err == nil {
+		t.Errorf("数值应返回错误")
+	}
+}
+
+// TestMarshalJSONArray 测试数组格式的 JSON 序列化与反序列化
+func TestMarshalJSONArray(t *testing.T) {
+	testCases := []struct {
+		f        flags.Flags[arrayOption]
+		expected string
+	}{
+		{flags.New[arrayOption](1, 4), `["READ","EXEC"]`},
+		{flags.New[arrayOption](), `[]`},
+	}
+	for _, tc := range testCases {
+		data, err := json.Marshal(tc.f)
+		if err != nil || string(data) != tc.expected {
+			t.Errorf("json.Marshal = (%s, %v)，预期 %s", data, err, tc.expected)
+		}
+		var back flags.Flags[arrayOption]
+		if err := json.Unmarshal(data, &back); err != nil || back != tc.f {
+			t.Errorf("往返结果 = (%v, %v)，预期 %v", back, err, tc.f)
+		}
+	}
+}
+
+// TestMarshalText 测试文本序列化以及 String 使用注册的名称
+func TestMarshalText(t *testing.T) {
+	f := flags.New[textOption](2, 8)
+	text, _ := f.MarshalText()
+	if string(text) != "LOWER|REV" || f.String() != "LOWER|REV" {
+		t.Errorf("MarshalText = %q，String = %q，预期 %q", text, f.String(), "LOWER|REV")
+	}
+
+	var g flags.Flags[textOption]
+	if err := g.UnmarshalText([]byte("UPPER")); err != nil || g.Bits() != 1 {
+		t.Errorf("UnmarshalText = (%v, %v)", g, err)
+	}
+}
@@ -0,0 +1,202 @@
+package flags
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// Registry 维护标志名称与位掩码之间的映射，使位掩码配置可以用 "UPPER|REV" 这样的字符串表示，
+// 便于命令行参数与配置文件使用
+//
+// 同一个 Registry 可以被多个 goroutine 并发使用。
+type Registry[T bit.Unsigned] struct {
+	mu      sync.RWMutex
+	byName  map[string]T
+	byBit   [64]string // byBit[i] 为第 i 位单独对应的名称
+	ordered []string   // 按注册顺序排列的名称
+	style   Style      // Flags 序列化时使用的格式
+}
+
+// NewRegistry 创建一个空的 Registry
+func NewRegistry[T bit.Unsigned]() *Registry[T] {
+	return &Registry[T]{byName: make(map[string]T)}
+}
+
+// Register 注册名称 name 对应的位掩码 v
+// v 可以是单个标志，也可以是多个标志的组合（如 ALL）；组合名称只用于 Parse，Format 始终按单个标志输出
+func (r *Registry[T]) Register(name string, v T) error {
+	if name == "" || strings.ContainsAny(name, "| \t") {
+		return fmt.Errorf("flags: 非法的标志名 %q", name)
+	}
+	if v == 0 {
+		return fmt.Errorf("flags: 标志 %q 的值不能为 0", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byName[name]; ok {
+		return fmt.Errorf("flags: 标志名 %q 重复注册", name)
+	}
+	if bit.IsPowerOfTwo(v) {
+		i := bits.TrailingZeros64(uint64(v))
+		if r.byBit[i] != "" {
+			return fmt.Errorf("flags: 标志 %q 与 %q 的值相同", name, r.byBit[i])
+		}
+		r.byBit[i] = name
+	}
+	r.byName[name] = v
+	r.ordered = append(r.ordered, name)
+	return nil
+}
+
+// MustRegister 与 Register 相同，但注册失败时 panic，适合在 init 或包级变量中使用
+func (r *Registry[T]) MustRegister(name string, v T) T {
+	if err := r.Register(name, v); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Names 按注册顺序返回所有名称
+func (r *Registry[T]) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.ordered...)
+}
+
+// Len 返回已注册的名称个数
+func (r *Registry[T]) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.ordered)
+}
+
+// Lookup 返回名称 name 对应的位掩码
+func (r *Registry[T]) Lookup(name string) (T, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	v, ok := r.byName[name]
+	return v, ok
+}
+
+// Parse 解析以 "|" 分隔的标志名称并返回组合后的位掩码，例如 "UPPER|REV"
+// 每一项也可以是数值字面量（如 "0x10"）；空字符串与 "0" 解析为 0
+func (r *Registry[T]) Parse(s string) (T, error) {
+	var mask T
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	for part := range strings.SplitSeq(s, "|") {
+		part = strings.TrimSpace(part)
+		if v, ok := r.Lookup(part); ok {
+			mask |= v
+			continue
+		}
+		n, err := strconv.ParseUint(part, 0, int(bit.Width[T]()))
+		if err != nil {
+			return 0, fmt.Errorf("flags: 未知的标志 %q", part)
+		}
+		mask |= T(n)
+	}
+	return mask, nil
+}
+
+// Format 返回 mask 的规范字符串：按位从低到高输出各标志的名称并以 "|" 连接，
+// 未注册名称的位以十六进制数值输出；mask 为 0 时返回 "0"
+func (r *Registry[T]) Format(mask T) string {
+	if mask == 0 {
+		return "0"
+	}
+	return strings.Join(r.Explain(mask), "|")
+}
+
+// Explain 按位从低到高返回 mask 中各标志的名称，未注册名称的位以十六进制数值表示
+// 用于在日志中输出可读的标志列表，而不是 196 这样的裸整数
+func (r *Registry[T]) Explain(mask T) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, bits.OnesCount64(uint64(mask)))
+	for v := uint64(mask); v != 0; v &= v - 1 {
+		if name := r.byBit[bits.TrailingZeros64(v)]; name != "" {
+			names = append(names, name)
+		} else {
+			names = append(names, "0x"+strconv.FormatUint(v&-v, 16))
+		}
+	}
+	return names
+}
+
+// Diff 比较 old 与 new 两个位掩码，返回新增与移除的标志
+func (r *Registry[T]) Diff(old, new T) Delta {
+	return Delta{
+		Added:   r.Explain(new &^ old),
+		Removed: r.Explain(old &^ new),
+	}
+}
+
+// SetStyle 设置 Flags[T] 序列化时使用的格式
+func (r *Registry[T]) SetStyle(s Style) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.style = s
+}
+
+// Style 返回 Flags[T] 序列化时使用的格式
+func (r *Registry[T]) Style() Style {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.style
+}
+
+// registries 保存每个标志类型对应的注册表，键为 reflect.Type
+var registries sync.Map
+
+// RegistryFor 返回类型 T 专属的注册表，首次调用时创建
+// Flags[T] 的 String 与序列化方法使用该注册表中的名称和格式，例如：
+//
+//	type Option uint8
+//	flags.RegistryFor[Option]().MustRegister("UPPER", 1)
+func RegistryFor[T bit.Unsigned]() *Registry[T] {
+	key := reflect.TypeFor[T]()
+	if r, ok := registries.Load(key); ok {
+		return r.(*Registry[T])
+	}
+	r, _ := registries.LoadOrStore(key, NewRegistry[T]())
+	return r.(*Registry[T])
+}
+
+// defaultRegistry 是包级函数 Register/Parse/Format 使用的全局注册表，即 uint64 类型的注册表
+var defaultRegistry = RegistryFor[uint64]()
+
+// Register 在全局注册表中注册名称 name 对应的位掩码 v
+func Register(name string, v uint64) error {
+	return defaultRegistry.Register(name, v)
+}
+
+// Parse 使用全局注册表解析 "UPPER|REV" 形式的字符串
+func Parse(s string) (uint64, error) {
+	return defaultRegistry.Parse(s)
+}
+
+// Format 使用全局注册表将位掩码格式化为 "UPPER|REV" 形式的字符串
+func Format(mask uint64) string {
+	return defaultRegistry.Format(mask)
+}
+
+// Explain 使用全局注册表返回 mask 中各标志的名称
+func Explain(mask uint64) []string {
+	return defaultRegistry.Explain(mask)
+}
+
+// Diff 使用全局注册表比较 old 与 new 两个位掩码
+func Diff(old, new uint64) Delta {
+	return defaultRegistry.Diff(old, new)
+}
@@ -0,0 +1,108 @@
+package flags_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/flags"
+)
+
+// newRegistry 返回注册了 UPPER/LOWER/CAP/REV 的注册表
+func newRegistry() *flags.Registry[uint8] {
+	r := flags.NewRegistry[uint8]()
+	r.MustRegister("UPPER", UPPER)
+	r.MustRegister("LOWER", LOWER)
+	r.MustRegister("CAP", CAP)
+	r.MustRegister("REV", REV)
+	r.MustRegister("ALL", UPPER|LOWER|CAP|REV)
+	return r
+}
+
+// TestRegistryParse 测试字符串解析
+func TestRegistryParse(t *testing.T) {
+	r := newRegistry()
+	testCases := []struct {
+		input    string
+		expected uint8
+	}{
+		{"UPPER|REV", UPPER | REV},
+		{" LOWER | CAP ", LOWER | CAP},
+		{"ALL", UPPER | LOWER | CAP | REV},
+		{"REV|0x10", REV | 0x10},
+		{"", 0},
+		{"0", 0},
+	}
+	for _, tc := range testCases {
+		v, err := r.Parse(tc.input)
+		if err != nil || v != tc.expected {
+			t.Errorf("Parse(%q) = (%04b, %v)，预期 %04b", tc.input, v, err, tc.expected)
+		}
+	}
+
+	for _, bad := range []string{"UPPER|UNKNOWN", "UPPER||REV", "0x100"} {
+		if _, err := r.Parse(bad); err == nil {
+			t.Errorf("Parse(%q) 应返回错误", bad)
+		}
+	}
+}
+
+// TestRegistryFormat 测试规范字符串格式化及与 Parse 的往返
+func TestRegistryFormat(t *testing.T) {
+	r := newRegistry()
+	testCases := []struct {
+		mask     uint8
+		expected string
+	}{
+		{REV | UPPER, "UPPER|REV"},
+		{LOWER | CAP | REV, "LOWER|CAP|REV"},
+		{0, "0"},
+		{UPPER | 0x40, "UPPER|0x40"},
+	}
+	for _, tc := range testCases {
+		s := r.Format(tc.mask)
+		if s != tc.expected {
+			t.Errorf("Format(%08b) = %q，预期 %q", tc.mask, s, tc.expected)
+		}
+		if v, err := r.Parse(s); err != nil || v != tc.mask {
+			t.Errorf("Parse(Format(%08b)) = (%08b, %v)", tc.mask, v, err)
+		}
+	}
+
+	if names := r.Names(); !slices.Equal(names, []string{"UPPER", "LOWER", "CAP", "REV", "ALL"}) {
+		t.Errorf("Names() = %v", names)
+	}
+}
+
+// TestRegistryRegisterErrors 测试非法注册
+func TestRegistryRegisterErrors(t *testing.T) {
+	r := newRegistry()
+	testCases := []struct {
+		name  string
+		value uint8
+	}{
+		{"UPPER", 0x10}, // 名称重复
+		{"UP2", UPPER},  // 单个标志的值重复
+		{"ZERO", 0},     // 值为 0
+		{"A|B", 0x20},   // 名称包含分隔符
+		{"", 0x20},      // 名称为空
+	}
+	for _, tc := range testCases {
+		if err := r.Register(tc.name, tc.value); err == nil {
+			t.Errorf("Register(%q, %d) 应返回错误", tc.name, tc.value)
+		}
+	}
+}
+
+// TestDefaultRegistry 测试包级的全局注册表
+func TestDefaultRegistry(t *testing.T) {
+	flags.Register("TEST_READ", 1<<40)
+	flags.Register("TEST_WRITE", 1<<41)
+
+	v, err := flags.Parse("TEST_WRITE|TEST_READ")
+	if err != nil || v != 1<<40|1<<41 {
+		t.Errorf("Parse = (%d, %v)", v, err)
+	}
+	if s := flags.Format(v); s != "TEST_READ|TEST_WRITE" {
+		t.Errorf("Format = %q，预期 %q", s, "TEST_READ|TEST_WRITE")
+	}
+}
@@ -0,0 +1,89 @@
+// Package golomb 实现 Golomb-Rice 编码（除数为 2^k 的 Golomb 编码）。
+//
+// 对服从几何分布的非负整数，选择合适的 k 时 Rice 编码接近最优，
+// 常用于无损音频、图像残差以及倒排表间隔的压缩。
+package golomb
+
+import (
+	"bytes"
+	"io"
+	"math"
+
+	"github.com/moweilong/efficient-go/base/bit/bitio"
+)
+
+// Write 写入 n 的 Rice 编码：商 n>>k 以一元编码写入，余数写入低 k 位
+// 商的一元编码长度与 n>>k 成正比，k 过小时大数值会产生很长的编码
+func Write(w *bitio.Writer, n uint64, k int) error {
+	if err := w.WriteUnary(n >> uint(k)); err != nil {
+		return err
+	}
+	return w.WriteBits(n, k)
+}
+
+// Read 读取一个以参数 k 编码的整数
+func Read(r *bitio.Reader, k int) (uint64, error) {
+	q, err := r.ReadUnary()
+	if err != nil {
+		return 0, err
+	}
+	rem, err := r.ReadBits(k)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	return q<<uint(k) | rem, nil
+}
+
+// Len 返回 n 以参数 k 编码后的位数
+func Len(n uint64, k int) int {
+	return int(n>>uint(k)) + 1 + k
+}
+
+// EstimateK 根据样本均值估计最优参数 k
+// 对均值为 μ 的几何分布，最优 k 约为 ceil(log2(μ·ln2))
+func EstimateK(vs []uint64) int {
+	if len(vs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range vs {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(vs))
+	if mean*math.Ln2 <= 1 {
+		return 0
+	}
+	return min(int(math.Ceil(math.Log2(mean*math.Ln2))), 63)
+}
+
+// Encode 以参数 k 编码 vs 中的所有整数，k < 0 时使用 EstimateK 自动选择，
+// 返回编码数据及实际使用的 k
+func Encode(vs []uint64, k int) ([]byte, int) {
+	if k < 0 {
+		k = EstimateK(vs)
+	}
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	for _, v := range vs {
+		Write(w, v, k) // 写入 bytes.Buffer 不会出错
+	}
+	w.Flush()
+	return buf.Bytes(), k
+}
+
+// Decode 从 data 中以参数 k 解码 n 个整数
+func Decode(data []byte, k, n int) ([]uint64, error) {
+	r := bitio.NewReader(bytes.NewReader(data))
+	vs := make([]uint64, 0, n)
+	for range n {
+		v, err := Read(r, k)
+		if err != nil {
+			return vs, err
+		}
+		vs = append(vs, v)
+	}
+	return vs, nil
+}
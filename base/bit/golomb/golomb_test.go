@@ -0,0 +1,112 @@
+package golomb_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit/bitio"
+	"github.com/moweilong/efficient-go/base/bit/golomb"
+	"github.com/moweilong/efficient-go/base/varint"
+)
+
+// geometric 生成 n 个均值约为 mean 的几何分布样本
+func geometric(n int, mean float64, seed int64) []uint64 {
+	r := rand.New(rand.NewSource(seed))
+	vs := make([]uint64, n)
+	for i := range vs {
+		vs[i] = uint64(r.ExpFloat64() * mean)
+	}
+	return vs
+}
+
+// TestLayout 测试 Rice 编码的位布局
+func TestLayout(t *testing.T) {
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	golomb.Write(w, 9, 2) // 商 2 → 001，余数 1 → 01
+	golomb.Write(w, 3, 2) // 商 0 → 1，余数 3 → 11
+	w.Flush()
+
+	expected := []byte{0b0010_1111}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("编码结果 = %08b，预期 %08b", buf.Bytes(), expected)
+	}
+	if golomb.Len(9, 2) != 5 || golomb.Len(3, 2) != 3 {
+		t.Errorf("Len 计算错误: Len(9,2)=%d，Len(3,2)=%d", golomb.Len(9, 2), golomb.Len(3, 2))
+	}
+}
+
+// TestRoundTrip 测试不同参数下的往返编解码
+func TestRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		vs   []uint64
+		k    int
+	}{
+		{"k=0纯一元编码", geometric(200, 2, 1), 0},
+		{"k=1", geometric(2000, 4, 1), 1},
+		{"k=5", append(geometric(2000, 50, 1), 0, 1<<20), 5},
+		{"k=32", append(geometric(2000, 50, 1), 1<<40), 32},
+		{"自动估计k", geometric(2000, 300, 1), -1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, k := golomb.Encode(tc.vs, tc.k)
+			got, err := golomb.Decode(data, k, len(tc.vs))
+			if err != nil || !slices.Equal(got, tc.vs) {
+				t.Fatalf("往返失败: k=%d, err=%v", k, err)
+			}
+		})
+	}
+}
+
+// TestEstimateK 测试参数估计会选出编码最短的 k（允许相差 1）
+func TestEstimateK(t *testing.T) {
+	for _, mean := range []float64{0.5, 4, 50, 1000, 1e6} {
+		vs := geometric(5000, mean, 2)
+		k := golomb.EstimateK(vs)
+
+		best, bestBits := 0, -1
+		for c := range 40 {
+			total := 0
+			for _, v := range vs {
+				total += golomb.Len(v, c)
+			}
+			if bestBits < 0 || total < bestBits {
+				best, bestBits = c, total
+			}
+		}
+		if k < best-1 || k > best+1 {
+			t.Errorf("均值 %.1f: EstimateK = %d，最优 k = %d", mean, k, best)
+		}
+		t.Logf("均值 %.1f: EstimateK = %d，最优 k = %d", mean, k, best)
+	}
+	if golomb.EstimateK(nil) != 0 {
+		t.Errorf("空样本应返回 0")
+	}
+}
+
+// BenchmarkCompression 对比几何分布数据上 Rice 编码与 varint 的压缩率和速度
+func BenchmarkCompression(b *testing.B) {
+	for _, mean := range []float64{8, 100, 5000} {
+		vs := geometric(10000, mean, 3)
+
+		b.Run(fmt.Sprintf("varint/mean=%g", mean), func(b *testing.B) {
+			var buf []byte
+			for i := 0; i < b.N; i++ {
+				buf = varint.AppendUvarints(buf[:0], vs)
+			}
+			b.ReportMetric(float64(len(buf)*8)/float64(len(vs)), "bits/value")
+		})
+		b.Run(fmt.Sprintf("rice/mean=%g", mean), func(b *testing.B) {
+			var data []byte
+			for i := 0; i < b.N; i++ {
+				data, _ = golomb.Encode(vs, -1)
+			}
+			b.ReportMetric(float64(len(data)*8)/float64(len(vs)), "bits/value")
+		})
+	}
+}
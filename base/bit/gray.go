@@ -0,0 +1,41 @@
+package bit
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// ToGray 将二进制数 n 转换为格雷码：相邻的两个数转换后只有一位不同
+func ToGray[T Unsigned](n T) T {
+	return n ^ n>>1
+}
+
+// FromGray 将格雷码 g 还原为二进制数
+// 原理：第 i 位二进制等于格雷码第 i 位及其以上所有位的异或，用倍增移位在 log(宽度) 步内完成
+func FromGray[T Unsigned](g T) T {
+	for s := uint(1); s < Width[T](); s <<= 1 {
+		g ^= g >> s
+	}
+	return g
+}
+
+// GrayCodes 返回按顺序遍历全部 width 位格雷码的迭代器，共 2^width 个
+// 迭代器产出 (序号, 格雷码)，每一步只翻转一位：第 i 步翻转第 TrailingZeros(i) 位，无需重新计算完整的编码
+// width 必须在 [0, 63] 内
+func GrayCodes(width int) iter.Seq2[uint64, uint64] {
+	if width < 0 || width > 63 {
+		panic("bit: width 必须在 [0, 63] 内")
+	}
+	return func(yield func(uint64, uint64) bool) {
+		var g uint64
+		n := uint64(1) << width
+		for i := uint64(0); i < n; i++ {
+			if i > 0 {
+				g ^= 1 << bits.TrailingZeros64(i)
+			}
+			if !yield(i, g) {
+				return
+			}
+		}
+	}
+}
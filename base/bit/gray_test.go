@@ -0,0 +1,59 @@
+package bit_test
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// TestGray 测试格雷码编码与解码
+func TestGray(t *testing.T) {
+	expected := []uint8{0b000, 0b001, 0b011, 0b010, 0b110, 0b111, 0b101, 0b100}
+	for i, e := range expected {
+		if g := bit.ToGray(uint8(i)); g != e {
+			t.Errorf("ToGray(%d) = %03b，预期 %03b", i, g, e)
+		}
+		if n := bit.FromGray(e); n != uint8(i) {
+			t.Errorf("FromGray(%03b) = %d，预期 %d", e, n, i)
+		}
+	}
+
+	for _, n := range []uint64{0, 1, 12345, 1 << 63, ^uint64(0)} {
+		if bit.FromGray(bit.ToGray(n)) != n {
+			t.Errorf("uint64 格雷码往返转换失败: %d", n)
+		}
+	}
+	for n := range uint32(1 << 16) {
+		if bit.FromGray(bit.ToGray(n)) != n {
+			t.Fatalf("uint32 格雷码往返转换失败: %d", n)
+		}
+	}
+}
+
+// TestGrayCodes 测试迭代器：与 ToGray 一致，且相邻编码只相差一位
+func TestGrayCodes(t *testing.T) {
+	const width = 10
+	count := 0
+	var prev uint64
+	for i, g := range bit.GrayCodes(width) {
+		if g != bit.ToGray(i) {
+			t.Fatalf("第%d个格雷码 = %b，预期 %b", i, g, bit.ToGray(i))
+		}
+		if i > 0 && bits.OnesCount64(g^prev) != 1 {
+			t.Fatalf("第%d个格雷码与前一个相差 %d 位", i, bits.OnesCount64(g^prev))
+		}
+		prev = g
+		count++
+	}
+	if count != 1<<width {
+		t.Errorf("格雷码个数 = %d，预期 %d", count, 1<<width)
+	}
+
+	// 提前退出
+	for i := range bit.GrayCodes(width) {
+		if i == 3 {
+			break
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package bit
+
+import "math/bits"
+
+// Mask128 是 128 位的定长位掩码，以两个 uint64 组成的数组存储
+// 所有方法都使用值接收者并返回新值，变量可以完全分配在栈上，不产生堆内存分配
+type Mask128 [2]uint64
+
+// Set 返回将第 i 位置为 1 后的掩码，i 必须小于 128
+func (m Mask128) Set(i uint) Mask128 {
+	m[i>>6] |= 1 << (i & 63)
+	return m
+}
+
+// Clear 返回将第 i 位清零后的掩码
+func (m Mask128) Clear(i uint) Mask128 {
+	m[i>>6] &^= 1 << (i & 63)
+	return m
+}
+
+// Toggle 返回将第 i 位翻转后的掩码
+func (m Mask128) Toggle(i uint) Mask128 {
+	m[i>>6] ^= 1 << (i & 63)
+	return m
+}
+
+// Test 判断第 i 位是否为 1
+func (m Mask128) Test(i uint) bool {
+	return m[i>>6]&(1<<(i&63)) != 0
+}
+
+// And 返回 m & o
+func (m Mask128) And(o Mask128) Mask128 {
+	return Mask128{m[0] & o[0], m[1] & o[1]}
+}
+
+// Or 返回 m | o
+func (m Mask128) Or(o Mask128) Mask128 {
+	return Mask128{m[0] | o[0], m[1] | o[1]}
+}
+
+// Xor 返回 m ^ o
+func (m Mask128) Xor(o Mask128) Mask128 {
+	return Mask128{m[0] ^ o[0], m[1] ^ o[1]}
+}
+
+// AndNot 返回 m &^ o
+func (m Mask128) AndNot(o Mask128) Mask128 {
+	return Mask128{m[0] &^ o[0], m[1] &^ o[1]}
+}
+
+// Not 返回按位取反后的掩码
+func (m Mask128) Not() Mask128 {
+	return Mask128{^m[0], ^m[1]}
+}
+
+// Count 返回值为 1 的位数
+func (m Mask128) Count() int {
+	return bits.OnesCount64(m[0]) + bits.OnesCount64(m[1])
+}
+
+// IsZero 判断是否所有位均为 0
+func (m Mask128) IsZero() bool {
+	return m[0]|m[1] == 0
+}
+
+// Mask256 是 256 位的定长位掩码，以四个 uint64 组成的数组存储，语义与 Mask128 相同
+type Mask256 [4]uint64
+
+// Set 返回将第 i 位置为 1 后的掩码，i 必须小于 256
+func (m Mask256) Set(i uint) Mask256 {
+	m[i>>6] |= 1 << (i & 63)
+	return m
+}
+
+// Clear 返回将第 i 位清零后的掩码
+func (m Mask256) Clear(i uint) Mask256 {
+	m[i>>6] &^= 1 << (i & 63)
+	return m
+}
+
+// Toggle 返回将第 i 位翻转后的掩码
+func (m Mask256) Toggle(i uint) Mask256 {
+	m[i>>6] ^= 1 << (i & 63)
+	return m
+}
+
+// Test 判断第 i 位是否为 1
+func (m Mask256) Test(i uint) bool {
+	return m[i>>6]&(1<<(i&63)) != 0
+}
+
+// And 返回 m & o
+func (m Mask256) And(o Mask256) Mask256 {
+	return Mask256{m[0] & o[0], m[1] & o[1], m[2] & o[2], m[3] & o[3]}
+}
+
+// Or 返回 m | o
+func (m Mask256) Or(o Mask256) Mask256 {
+	return Mask256{m[0] | o[0], m[1] | o[1], m[2] | o[2], m[3] | o[3]}
+}
+
+// Xor 返回 m ^ o
+func (m Mask256) Xor(o Mask256) Mask256 {
+	return Mask256{m[0] ^ o[0], m[1] ^ o[1], m[2] ^ o[2], m[3] ^ o[3]}
+}
+
+// AndNot 返回 m &^ o
+func (m Mask256) AndNot(o Mask256) Mask256 {
+	return Mask256{m[0] &^ o[0], m[1] &^ o[1], m[2] &^ o[2], m[3] &^ o[3]}
+}
+
+// Not 返回按位取反后的掩码
+func (m Mask256) Not() Mask256 {
+	return Mask256{^m[0], ^m[1], ^m[2], ^m[3]}
+}
+
+// Count 返回值为 1 的位数
+func (m Mask256) Count() int {
+	return bits.OnesCount64(m[0]) + bits.OnesCount64(m[1]) +
+		bits.OnesCount64(m[2]) + bits.OnesCount64(m[3])
+}
+
+// IsZero 判断是否所有位均为 0
+func (m Mask256) IsZero() bool {
+	return m[0]|m[1]|m[2]|m[3] == 0
+}
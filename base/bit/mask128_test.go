@@ -0,0 +1,80 @@
+package bit_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// TestMask128 测试 128 位掩码的单个位与集合运算
+func TestMask128(t *testing.T) {
+	var m bit.Mask128
+	m = m.Set(0).Set(64).Set(127)
+	if !m.Test(0) || !m.Test(64) || !m.Test(127) || m.Test(63) || m.Count() != 3 {
+		t.Fatalf("Set 结果错误: %v", m)
+	}
+	if m.Clear(64).Test(64) || !m.Toggle(1).Test(1) {
+		t.Errorf("Clear/Toggle 结果错误")
+	}
+	if !m.Test(64) {
+		t.Errorf("值语义下 Clear 不应修改原掩码")
+	}
+
+	o := bit.Mask128{}.Set(64).Set(100)
+	testCases := []struct {
+		name     string
+		actual   bit.Mask128
+		expected bit.Mask128
+	}{
+		{"And", m.And(o), bit.Mask128{}.Set(64)},
+		{"Or", m.Or(o), bit.Mask128{}.Set(0).Set(64).Set(100).Set(127)},
+		{"Xor", m.Xor(o), bit.Mask128{}.Set(0).Set(100).Set(127)},
+		{"AndNot", m.AndNot(o), bit.Mask128{}.Set(0).Set(127)},
+	}
+	for _, tc := range testCases {
+		if tc.actual != tc.expected {
+			t.Errorf("%s = %v，预期 %v", tc.name, tc.actual, tc.expected)
+		}
+	}
+	if m.Not().Count() != 125 || !m.And(m.Not()).IsZero() {
+		t.Errorf("Not 结果错误")
+	}
+}
+
+// TestMask256 测试 256 位掩码的单个位与集合运算
+func TestMask256(t *testing.T) {
+	var m bit.Mask256
+	for _, i := range []uint{0, 63, 128, 255} {
+		m = m.Set(i)
+	}
+	if m.Count() != 4 || !m.Test(255) || m.Test(254) {
+		t.Fatalf("Set 结果错误: %v", m)
+	}
+
+	o := bit.Mask256{}.Set(128).Set(200)
+	if m.And(o) != (bit.Mask256{}.Set(128)) {
+		t.Errorf("And 结果错误")
+	}
+	if m.Or(o).Count() != 5 || m.Xor(o).Count() != 4 || m.AndNot(o).Count() != 3 {
+		t.Errorf("Or/Xor/AndNot 结果错误")
+	}
+	if m.Not().Count() != 252 || !(bit.Mask256{}).IsZero() {
+		t.Errorf("Not/IsZero 结果错误")
+	}
+	if m.Clear(255).Test(255) || !m.Toggle(1).Test(1) {
+		t.Errorf("Clear/Toggle 结果错误")
+	}
+}
+
+// TestMaskNoAlloc 测试定长掩码的运算不产生堆内存分配
+func TestMaskNoAlloc(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		var a bit.Mask256
+		a = a.Set(3).Set(200)
+		b := bit.Mask256{}.Set(200)
+		sinkInt += a.And(b).Or(a).Xor(b.Not()).Count()
+	})
+	if allocs != 0 {
+		t.Errorf("分配次数 = %v，预期 0", allocs)
+	}
+}
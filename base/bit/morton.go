@@ -0,0 +1,150 @@
+package bit
+
+// Morton 码（Z 序曲线）将多维坐标的各位交错排列成一个整数，
+// 使空间上相邻的点在一维编码上也大致相邻，常用于空间索引与四叉树/八叉树。
+//
+// 本文件提供两种实现：
+//   - 魔数展开法：通过 log(位宽) 次 "移位-或-掩码" 把相邻位逐步拉开
+//   - 查表法：每次处理一个字节（三维解码为 9 位，每个坐标 3 位），查预先计算好的展开表
+
+// spread2 将 x 的 32 位展开到 64 位中的偶数位（第 i 位移动到第 2i 位）
+func spread2(x uint32) uint64 {
+	v := uint64(x)
+	v = (v | v<<16) & 0x0000FFFF0000FFFF
+	v = (v | v<<8) & 0x00FF00FF00FF00FF
+	v = (v | v<<4) & 0x0F0F0F0F0F0F0F0F
+	v = (v | v<<2) & 0x3333333333333333
+	v = (v | v<<1) & 0x5555555555555555
+	return v
+}
+
+// compact2 是 spread2 的逆操作：收集 v 的偶数位并紧凑排列
+func compact2(v uint64) uint32 {
+	v &= 0x5555555555555555
+	v = (v | v>>1) & 0x3333333333333333
+	v = (v | v>>2) & 0x0F0F0F0F0F0F0F0F
+	v = (v | v>>4) & 0x00FF00FF00FF00FF
+	v = (v | v>>8) & 0x0000FFFF0000FFFF
+	v = (v | v>>16) & 0x00000000FFFFFFFF
+	return uint32(v)
+}
+
+// Interleave2 使用魔数展开法计算二维 Morton 码：x 占偶数位，y 占奇数位
+func Interleave2(x, y uint32) uint64 {
+	return spread2(x) | spread2(y)<<1
+}
+
+// Deinterleave2 使用魔数展开法将二维 Morton 码还原为坐标
+func Deinterleave2(z uint64) (x, y uint32) {
+	return compact2(z), compact2(z >> 1)
+}
+
+// spread3 将 x 的低 21 位展开到每三位中的第一位（第 i 位移动到第 3i 位）
+func spread3(x uint32) uint64 {
+	v := uint64(x) & 0x1FFFFF
+	v = (v | v<<32) & 0x001F00000000FFFF
+	v = (v | v<<16) & 0x001F0000FF0000FF
+	v = (v | v<<8) & 0x100F00F00F00F00F
+	v = (v | v<<4) & 0x10C30C30C30C30C3
+	v = (v | v<<2) & 0x1249249249249249
+	return v
+}
+
+// compact3 是 spread3 的逆操作
+func compact3(v uint64) uint32 {
+	v &= 0x1249249249249249
+	v = (v ^ v>>2) & 0x10C30C30C30C30C3
+	v = (v ^ v>>4) & 0x100F00F00F00F00F
+	v = (v ^ v>>8) & 0x001F0000FF0000FF
+	v = (v ^ v>>16) & 0x001F00000000FFFF
+	v = (v ^ v>>32) & 0x00000000001FFFFF
+	return uint32(v)
+}
+
+// Interleave3 使用魔数展开法计算三维 Morton 码，每个坐标只使用低 21 位
+func Interleave3(x, y, z uint32) uint64 {
+	return spread3(x) | spread3(y)<<1 | spread3(z)<<2
+}
+
+// Deinterleave3 使用魔数展开法将三维 Morton 码还原为坐标
+func Deinterleave3(m uint64) (x, y, z uint32) {
+	return compact3(m), compact3(m >> 1), compact3(m >> 2)
+}
+
+var (
+	// spread2Table[b] 为字节 b 展开到偶数位后的 16 位值
+	spread2Table = func() (t [256]uint16) {
+		for i := range t {
+			t[i] = uint16(spread2(uint32(i)))
+		}
+		return t
+	}()
+
+	// compact2Table[b] 的低 4 位为 b 的偶数位，高 4 位为 b 的奇数位
+	compact2Table = func() (t [256]uint8) {
+		for i := range t {
+			t[i] = uint8(compact2(uint64(i))) | uint8(compact2(uint64(i)>>1))<<4
+		}
+		return t
+	}()
+
+	// spread3Table[b] 为字节 b 展开到每三位中第一位后的 24 位值
+	spread3Table = func() (t [256]uint32) {
+		for i := range t {
+			t[i] = uint32(spread3(uint32(i)))
+		}
+		return t
+	}()
+
+	// compact3Table[v] 的第 0～2、3～5、6～8 位分别为 9 位值 v 中属于 x、y、z 的 3 位
+	compact3Table = func() (t [512]uint16) {
+		for i := range t {
+			t[i] = uint16(compact3(uint64(i))) | uint16(compact3(uint64(i)>>1))<<3 | uint16(compact3(uint64(i)>>2))<<6
+		}
+		return t
+	}()
+)
+
+// Interleave2LUT 使用查表法计算二维 Morton 码，结果与 Interleave2 相同
+func Interleave2LUT(x, y uint32) uint64 {
+	var z uint64
+	for i := range 4 {
+		s := uint(8 * i)
+		z |= (uint64(spread2Table[uint8(x>>s)]) | uint64(spread2Table[uint8(y>>s)])<<1) << (2 * s)
+	}
+	return z
+}
+
+// Deinterleave2LUT 使用查表法还原二维 Morton 码，结果与 Deinterleave2 相同
+func Deinterleave2LUT(z uint64) (x, y uint32) {
+	for i := range 8 {
+		c := compact2Table[uint8(z>>(8*i))]
+		x |= uint32(c&0x0F) << (4 * i)
+		y |= uint32(c>>4) << (4 * i)
+	}
+	return x, y
+}
+
+// Interleave3LUT 使用查表法计算三维 Morton 码，结果与 Interleave3 相同
+func Interleave3LUT(x, y, z uint32) uint64 {
+	var m uint64
+	for i := range 3 {
+		s := uint(8 * i)
+		m |= (uint64(spread3Table[uint8(x>>s)]) |
+			uint64(spread3Table[uint8(y>>s)])<<1 |
+			uint64(spread3Table[uint8(z>>s)])<<2) << (3 * s)
+	}
+	return m & (1<<63 - 1) // 每个坐标只保留低 21 位，共 63 位
+}
+
+// Deinterleave3LUT 使用查表法还原三维 Morton 码，结果与 Deinterleave3 相同
+// 9 位恰好包含每个坐标的 3 位，63 位的编码分 7 次查表
+func Deinterleave3LUT(m uint64) (x, y, z uint32) {
+	for i := range 7 {
+		c := compact3Table[m>>(9*i)&0x1FF]
+		x |= uint32(c&7) << (3 * i)
+		y |= uint32(c>>3&7) << (3 * i)
+		z |= uint32(c>>6) << (3 * i)
+	}
+	return x, y, z
+}
@@ -0,0 +1,148 @@
+package bit_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// interleaveNaive 逐位交错，作为对照实现
+func interleaveNaive(coords []uint32, bitsPer int) uint64 {
+	var z uint64
+	for i := range bitsPer {
+		for d, c := range coords {
+			z |= uint64(c>>uint(i)&1) << uint(i*len(coords)+d)
+		}
+	}
+	return z
+}
+
+// TestMorton2 测试二维 Morton 码两种实现的正确性与可逆性
+func TestMorton2(t *testing.T) {
+	if z := bit.Interleave2(0b11, 0b00); z != 0b0101 {
+		t.Errorf("Interleave2(3, 0) = %b，预期 101", z)
+	}
+	if z := bit.Interleave2(0, 1); z != 0b10 {
+		t.Errorf("Interleave2(0, 1) = %b，预期 10", z)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for range 1000 {
+		x, y := r.Uint32(), r.Uint32()
+		expected := interleaveNaive([]uint32{x, y}, 32)
+		if z := bit.Interleave2(x, y); z != expected {
+			t.Fatalf("Interleave2(%d, %d) = 0x%X，预期 0x%X", x, y, z, expected)
+		}
+		if z := bit.Interleave2LUT(x, y); z != expected {
+			t.Fatalf("Interleave2LUT(%d, %d) = 0x%X，预期 0x%X", x, y, z, expected)
+		}
+		if dx, dy := bit.Deinterleave2(expected); dx != x || dy != y {
+			t.Fatalf("Deinterleave2 = (%d, %d)，预期 (%d, %d)", dx, dy, x, y)
+		}
+		if dx, dy := bit.Deinterleave2LUT(expected); dx != x || dy != y {
+			t.Fatalf("Deinterleave2LUT = (%d, %d)，预期 (%d, %d)", dx, dy, x, y)
+		}
+	}
+}
+
+// TestMorton3 测试三维 Morton 码两种实现的正确性与可逆性
+func TestMorton3(t *testing.T) {
+	const max21 = 1<<21 - 1
+	r := rand.New(rand.NewSource(1))
+	for range 1000 {
+		x, y, z := r.Uint32()&max21, r.Uint32()&max21, r.Uint32()&max21
+		expected := interleaveNaive([]uint32{x, y, z}, 21)
+		if m := bit.Interleave3(x, y, z); m != expected {
+			t.Fatalf("Interleave3(%d, %d, %d) = 0x%X，预期 0x%X", x, y, z, m, expected)
+		}
+		if m := bit.Interleave3LUT(x, y, z); m != expected {
+			t.Fatalf("Interleave3LUT(%d, %d, %d) = 0x%X，预期 0x%X", x, y, z, m, expected)
+		}
+		if dx, dy, dz := bit.Deinterleave3(expected); dx != x || dy != y || dz != z {
+			t.Fatalf("Deinterleave3 = (%d, %d, %d)，预期 (%d, %d, %d)", dx, dy, dz, x, y, z)
+		}
+		if dx, dy, dz := bit.Deinterleave3LUT(expected); dx != x || dy != y || dz != z {
+			t.Fatalf("Deinterleave3LUT = (%d, %d, %d)，预期 (%d, %d, %d)", dx, dy, dz, x, y, z)
+		}
+	}
+
+	// 超过 21 位的部分被忽略
+	if bit.Interleave3(1<<21|1, 0, 0) != 1 {
+		t.Errorf("Interleave3 应忽略第21位以上的位")
+	}
+	// 解码忽略第 63 位
+	for _, m := range []uint64{1<<64 - 1, 1 << 63} {
+		x, y, z := bit.Deinterleave3(m)
+		if lx, ly, lz := bit.Deinterleave3LUT(m); lx != x || ly != y || lz != z {
+			t.Errorf("Deinterleave3LUT(0x%X) = (%d, %d, %d)，预期 (%d, %d, %d)", m, lx, ly, lz, x, y, z)
+		}
+	}
+}
+
+// BenchmarkInterleave2 对比魔数展开法、查表法与 PDEP 指令
+func BenchmarkInterleave2(b *testing.B) {
+	b.Run("magic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = bit.Interleave2(uint32(i), uint32(i>>3))
+		}
+	})
+	b.Run("lut", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = bit.Interleave2LUT(uint32(i), uint32(i>>3))
+		}
+	})
+	b.Run("pdep", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = bit.DepositBits(uint64(uint32(i)), 0x5555555555555555) |
+				bit.DepositBits(uint64(uint32(i>>3)), 0xAAAAAAAAAAAAAAAA)
+		}
+	})
+}
+
+// BenchmarkDeinterleave2 对比魔数展开法与查表法的解码
+func BenchmarkDeinterleave2(b *testing.B) {
+	b.Run("magic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x, y := bit.Deinterleave2(uint64(i) * 0x9E3779B97F4A7C15)
+			sink32 = x ^ y
+		}
+	})
+	b.Run("lut", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x, y := bit.Deinterleave2LUT(uint64(i) * 0x9E3779B97F4A7C15)
+			sink32 = x ^ y
+		}
+	})
+}
+
+// BenchmarkInterleave3 对比三维编码的魔数展开法与查表法
+func BenchmarkInterleave3(b *testing.B) {
+	b.Run("magic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = bit.Interleave3(uint32(i), uint32(i>>3), uint32(i>>5))
+		}
+	})
+	b.Run("lut", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = bit.Interleave3LUT(uint32(i), uint32(i>>3), uint32(i>>5))
+		}
+	})
+}
+
+// BenchmarkDeinterleave3 对比三维解码的魔数展开法与查表法
+func BenchmarkDeinterleave3(b *testing.B) {
+	b.Run("magic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x, y, z := bit.Deinterleave3(uint64(i) * 0x9E3779B97F4A7C15)
+			sink32 = x ^ y ^ z
+		}
+	})
+	b.Run("lut", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x, y, z := bit.Deinterleave3LUT(uint64(i) * 0x9E3779B97F4A7C15)
+			sink32 = x ^ y ^ z
+		}
+	})
+}
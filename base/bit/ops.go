@@ -0,0 +1,60 @@
+// Package bit 提供常用的位操作函数，将 bit_test.go 中演示的技巧封装为可复用的 API。
+//
+// 所有位序号均从 0 开始，从最低有效位（LSB）开始计数。
+// 函数均为泛型实现，同一套代码适用于 uint8/uint16/uint32/uint64/uint/uintptr。
+package bit
+
+import "unsafe"
+
+// Unsigned 约束所有无符号整数类型（与 golang.org/x/exp/constraints.Unsigned 等价）
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Width 返回类型 T 的位宽，例如 Width[uint16]() = 16
+func Width[T Unsigned]() uint {
+	var zero T
+	return uint(unsafe.Sizeof(zero)) * 8 // 实例化后被编译器折叠为常量，依赖位宽的 switch 会被消除
+}
+
+// Set 将 x 的第 n 位置为 1（x | 1<<n）
+func Set[T Unsigned](x T, n uint) T {
+	return x | 1<<n
+}
+
+// Clear 将 x 的第 n 位清零（x &^ 1<<n）
+func Clear[T Unsigned](x T, n uint) T {
+	return x &^ (1 << n)
+}
+
+// Toggle 翻转 x 的第 n 位（x ^ 1<<n）
+func Toggle[T Unsigned](x T, n uint) T {
+	return x ^ 1<<n
+}
+
+// Test 判断 x 的第 n 位是否为 1
+func Test[T Unsigned](x T, n uint) bool {
+	return x&(1<<n) != 0
+}
+
+// IsSet 等价于 Test，保留以兼容早期的调用方式
+func IsSet[T Unsigned](x T, n uint) bool {
+	return Test(x, n)
+}
+
+// ClearLSBs 清除 x 最低的 n 位，n 不小于 T 的位宽时结果为 0
+func ClearLSBs[T Unsigned](x T, n uint) T {
+	return x &^ (1<<n - 1)
+}
+
+// Mask 返回区间 [from, to) 内的位全为 1 的掩码
+// 例如 Mask[uint8](4, 8) = 0xF0；from >= to 时返回 0
+func Mask[T Unsigned](from, to uint) T {
+	return (T(1)<<to - 1) &^ (T(1)<<from - 1)
+}
+
+// Extract 取出 x 在区间 [from, to) 内的位域并右移到最低位
+// 例如 Extract(uint16(0xABCD), 4, 12) = 0xBC
+func Extract[T Unsigned](x T, from, to uint) T {
+	return (x & Mask[T](from, to)) >> from
+}
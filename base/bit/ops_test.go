@@ -0,0 +1,144 @@
+package bit_test
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// TestSetClearToggle 测试单个位的设置、清除与翻转
+func TestSetClearToggle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		op       func(uint64, uint) uint64
+		x        uint64
+		n        uint
+		expected uint64
+	}{
+		{"Set第2位", bit.Set[uint64], 0x08, 2, 0x0C},
+		{"Set已设置的位", bit.Set[uint64], 0x0C, 2, 0x0C},
+		{"Set第63位", bit.Set[uint64], 0, 63, 1 << 63},
+		{"Clear第2位", bit.Clear[uint64], 0x0D, 2, 0x09},
+		{"Clear未设置的位", bit.Clear[uint64], 0x09, 2, 0x09},
+		{"Toggle置1", bit.Toggle[uint64], 0x00, 0, 0x01},
+		{"Toggle置0", bit.Toggle[uint64], 0x01, 0, 0x00},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.op(tc.x, tc.n)
+			if result != tc.expected {
+				t.Errorf("结果错误\n原始值: %08b, 位: %d\n实际结果: %08b\n预期结果: %08b",
+					tc.x, tc.n, result, tc.expected)
+			}
+		})
+	}
+}
+
+// TestIsSet 测试判断特定位是否被设置
+func TestIsSet(t *testing.T) {
+	var x uint64 = 0xC4 // 二进制：11000100（第2、6、7位为1）
+	for n := uint(0); n < 8; n++ {
+		expected := n == 2 || n == 6 || n == 7
+		if bit.IsSet(x, n) != expected {
+			t.Errorf("第%d位判断错误: 预期 %v", n, expected)
+		}
+	}
+}
+
+// TestClearLSBs 测试清除最低的 n 位
+func TestClearLSBs(t *testing.T) {
+	testCases := []struct {
+		x        uint64
+		n        uint
+		expected uint64
+	}{
+		{0xAC, 4, 0xA0},
+		{0xAC, 0, 0xAC},
+		{0xFFFF, 8, 0xFF00},
+		{^uint64(0), 64, 0},
+	}
+	for _, tc := range testCases {
+		if result := bit.ClearLSBs(tc.x, tc.n); result != tc.expected {
+			t.Errorf("ClearLSBs(0x%X, %d) = 0x%X，预期 0x%X", tc.x, tc.n, result, tc.expected)
+		}
+	}
+}
+
+// TestMask 测试区间掩码的构造
+func TestMask(t *testing.T) {
+	testCases := []struct {
+		from, to uint
+		expected uint64
+	}{
+		{4, 8, 0xF0},
+		{0, 4, 0x0F},
+		{0, 64, ^uint64(0)},
+		{60, 64, 0xF << 60},
+		{3, 3, 0},
+		{5, 2, 0},
+	}
+	for _, tc := range testCases {
+		if result := bit.Mask[uint64](tc.from, tc.to); result != tc.expected {
+			t.Errorf("Mask(%d, %d) = 0x%X，预期 0x%X", tc.from, tc.to, result, tc.expected)
+		}
+	}
+}
+
+// checkWidth 对单一位宽 T 运行全部通用位操作的表驱动测试
+func checkWidth[T bit.Unsigned](t *testing.T, width uint) {
+	t.Helper()
+	if w := bit.Width[T](); w != width {
+		t.Fatalf("Width = %d，预期 %d", w, width)
+	}
+
+	top := width - 1
+	testCases := []struct {
+		name     string
+		actual   T
+		expected T
+	}{
+		{"Set最低位", bit.Set(T(0), 0), 1},
+		{"Set最高位", bit.Set(T(0), top), T(1) << top},
+		{"Clear最高位", bit.Clear(^T(0), top), ^T(0) >> 1},
+		{"Toggle最高位", bit.Toggle(T(0), top), T(1) << top},
+		{"Toggle两次还原", bit.Toggle(bit.Toggle(T(0xA5), 3), 3), 0xA5},
+		{"ClearLSBs低4位", bit.ClearLSBs(T(0xAC), 4), 0xA0},
+		{"ClearLSBs全部位", bit.ClearLSBs(^T(0), width), 0},
+		{"Mask全部位", bit.Mask[T](0, width), ^T(0)},
+		{"Mask高4位", bit.Mask[T](width-4, width), T(0xF) << (width - 4)},
+		{"Extract中间位域", bit.Extract(T(0xAC), 2, 6), 0xB},
+		{"Extract最高位", bit.Extract(T(1)<<top, top, width), 1},
+	}
+	for _, tc := range testCases {
+		if tc.actual != tc.expected {
+			t.Errorf("%s错误\n实际结果: %0*b\n预期结果: %0*b",
+				tc.name, width, uint64(tc.actual), width, uint64(tc.expected))
+		}
+	}
+
+	if !bit.Test(T(1)<<top, top) || bit.Test(T(1)<<top, 0) {
+		t.Errorf("Test 判断第%d位错误", top)
+	}
+}
+
+// TestGenericWidths 测试泛型位操作在所有无符号整数位宽上的行为一致
+func TestGenericWidths(t *testing.T) {
+	t.Run("uint8", func(t *testing.T) { checkWidth[uint8](t, 8) })
+	t.Run("uint16", func(t *testing.T) { checkWidth[uint16](t, 16) })
+	t.Run("uint32", func(t *testing.T) { checkWidth[uint32](t, 32) })
+	t.Run("uint64", func(t *testing.T) { checkWidth[uint64](t, 64) })
+	t.Run("uint", func(t *testing.T) { checkWidth[uint](t, bits.UintSize) })
+	t.Run("uintptr", func(t *testing.T) { checkWidth[uintptr](t, bits.UintSize) })
+}
+
+// TestExtract 测试位域提取
+func TestExtract(t *testing.T) {
+	if result := bit.Extract(uint16(0xABCD), 4, 12); result != 0xBC {
+		t.Errorf("Extract(0xABCD, 4, 12) = 0x%X，预期 0xBC", result)
+	}
+	if result := bit.Extract(uint32(0xDEADBEEF), 16, 32); result != 0xDEAD {
+		t.Errorf("Extract(0xDEADBEEF, 16, 32) = 0x%X，预期 0xDEAD", result)
+	}
+}
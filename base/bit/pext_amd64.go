@@ -0,0 +1,28 @@
+//go:build amd64 && !purego
+
+package bit
+
+// hasBMI2 表示 CPU 是否支持 BMI2 指令集（CPUID.(EAX=7,ECX=0):EBX 第 8 位）
+var hasBMI2 = detectBMI2()
+
+func detectBMI2() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+	_, ebx, _, _ := cpuid(7, 0)
+	return ebx&(1<<8) != 0
+}
+
+// cpuid 执行 CPUID 指令，在 pext_amd64.s 中实现
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// pext 使用 PEXTQ 指令，在 pext_amd64.s 中实现
+//
+//go:noescape
+func pext(x, mask uint64) uint64
+
+// pdep 使用 PDEPQ 指令，在 pext_amd64.s 中实现
+//
+//go:noescape
+func pdep(x, mask uint64) uint64
@@ -0,0 +1,30 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func pext(x, mask uint64) uint64
+TEXT ·pext(SB), NOSPLIT, $0-24
+	MOVQ x+0(FP), AX
+	MOVQ mask+8(FP), BX
+	PEXTQ BX, AX, AX
+	MOVQ AX, ret+16(FP)
+	RET
+
+// func pdep(x, mask uint64) uint64
+TEXT ·pdep(SB), NOSPLIT, $0-24
+	MOVQ x+0(FP), AX
+	MOVQ mask+8(FP), BX
+	PDEPQ BX, AX, AX
+	MOVQ AX, ret+16(FP)
+	RET
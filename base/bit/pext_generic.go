@@ -0,0 +1,10 @@
+//go:build !amd64 || purego
+
+package bit
+
+// hasBMI2 在非 amd64 平台上恒为 false，始终使用纯 Go 实现
+const hasBMI2 = false
+
+func pext(x, mask uint64) uint64 { return extractBitsGeneric(x, mask) }
+
+func pdep(x, mask uint64) uint64 { return depositBitsGeneric(x, mask) }
@@ -0,0 +1,69 @@
+package bit_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// TestExtractDepositBits 测试并行位提取与存放
+func TestExtractDepositBits(t *testing.T) {
+	testCases := []struct {
+		name           string
+		x, mask        uint64
+		extract, depos uint64
+	}{
+		{"提取示例", 0b1011_0110, 0b1100_1010, 0b1001, 0b0100_1000},
+		{"存放示例", 0b0000_1001, 0b1100_1010, 0b0000_0010, 0b1000_0010},
+		{"空掩码", 0xFFFF, 0, 0, 0},
+		{"全掩码", 0xDEADBEEF, ^uint64(0), 0xDEADBEEF, 0xDEADBEEF},
+		{"连续位域", 0xABCD, 0x0FF0, 0xBC, 0x0CD0},
+		{"最高位", 1 << 63, 1 << 63, 1, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if r := bit.ExtractBits(tc.x, tc.mask); r != tc.extract {
+				t.Errorf("ExtractBits(0x%X, 0x%X) = 0x%X，预期 0x%X", tc.x, tc.mask, r, tc.extract)
+			}
+			if r := bit.DepositBits(tc.x, tc.mask); r != tc.depos {
+				t.Errorf("DepositBits(0x%X, 0x%X) = 0x%X，预期 0x%X", tc.x, tc.mask, r, tc.depos)
+			}
+		})
+	}
+}
+
+// TestExtractDepositRoundTrip 测试硬件与软件路径一致，且 Deposit(Extract(x)) 还原 mask 内的位
+func TestExtractDepositRoundTrip(t *testing.T) {
+	t.Logf("BMI2 硬件路径: %v", bit.HasBMI2)
+	r := rand.New(rand.NewSource(1))
+	for range 10000 {
+		x, mask := r.Uint64(), r.Uint64()&r.Uint64()
+		e := bit.ExtractBits(x, mask)
+		if e != bit.ExtractBitsGeneric(x, mask) {
+			t.Fatalf("ExtractBits(0x%X, 0x%X) 硬件与软件结果不一致", x, mask)
+		}
+		if d := bit.DepositBits(x, mask); d != bit.DepositBitsGeneric(x, mask) {
+			t.Fatalf("DepositBits(0x%X, 0x%X) 硬件与软件结果不一致", x, mask)
+		}
+		if bit.DepositBits(e, mask) != x&mask {
+			t.Fatalf("DepositBits(ExtractBits(x)) 未能还原 x&mask: x=0x%X, mask=0x%X", x, mask)
+		}
+	}
+}
+
+// BenchmarkExtractBits 对比 PEXT 指令与纯 Go 实现
+func BenchmarkExtractBits(b *testing.B) {
+	const mask = 0x00FF_F0F0_0F0F_FF00 // 32 位为 1
+	b.Run("dispatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = bit.ExtractBits(uint64(i), mask)
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = bit.ExtractBitsGeneric(uint64(i), mask)
+		}
+	})
+}
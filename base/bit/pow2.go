@@ -0,0 +1,41 @@
+package bit
+
+import "math/bits"
+
+// IsPowerOfTwo 判断 x 是否为 2 的幂（0 不是 2 的幂）
+// 原理：2 的幂只有一位为 1，x & (x-1) 会清除最低位的 1
+func IsPowerOfTwo[T Unsigned](x T) bool {
+	return x != 0 && x&(x-1) == 0
+}
+
+// NextPowerOfTwo 返回不小于 x 的最小 2 的幂，NextPowerOfTwo(0) = 1
+// 基于前导零计数：结果为 1 << bits.Len(x-1)；超出 T 的表示范围时返回 0
+func NextPowerOfTwo[T Unsigned](x T) T {
+	if x <= 1 {
+		return 1
+	}
+	return T(1) << bits.Len64(uint64(x-1))
+}
+
+// PrevPowerOfTwo 返回不大于 x 的最大 2 的幂，PrevPowerOfTwo(0) = 0
+func PrevPowerOfTwo[T Unsigned](x T) T {
+	if x == 0 {
+		return 0
+	}
+	return T(1) << (bits.Len64(uint64(x)) - 1)
+}
+
+// RoundUpTo 将 x 向上取整为 multiple 的倍数，multiple 为 0 时 panic
+// multiple 为 2 的幂时使用掩码运算，避免除法
+func RoundUpTo[T Unsigned](x, multiple T) T {
+	if multiple == 0 {
+		panic("bit: multiple 不能为 0")
+	}
+	if IsPowerOfTwo(multiple) {
+		return (x + multiple - 1) &^ (multiple - 1)
+	}
+	if r := x % multiple; r != 0 {
+		return x + multiple - r
+	}
+	return x
+}
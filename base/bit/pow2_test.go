@@ -0,0 +1,71 @@
+package bit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// TestIsPowerOfTwo 测试 2 的幂判断
+func TestIsPowerOfTwo(t *testing.T) {
+	for x := uint32(0); x <= 1<<12; x++ {
+		expected := x != 0 && x == 1<<uint(math.Log2(float64(x)))
+		if bit.IsPowerOfTwo(x) != expected {
+			t.Fatalf("IsPowerOfTwo(%d) 预期 %v", x, expected)
+		}
+	}
+	if !bit.IsPowerOfTwo(uint64(1) << 63) {
+		t.Errorf("1<<63 应为 2 的幂")
+	}
+}
+
+// TestNextPrevPowerOfTwo 测试相邻 2 的幂的计算
+func TestNextPrevPowerOfTwo(t *testing.T) {
+	testCases := []struct {
+		x, next, prev uint64
+	}{
+		{0, 1, 0},
+		{1, 1, 1},
+		{2, 2, 2},
+		{3, 4, 2},
+		{5, 8, 4},
+		{1000, 1024, 512},
+		{1024, 1024, 1024},
+		{1 << 63, 1 << 63, 1 << 63},
+		{1<<63 + 1, 0, 1 << 63}, // 溢出
+	}
+	for _, tc := range testCases {
+		if r := bit.NextPowerOfTwo(tc.x); r != tc.next {
+			t.Errorf("NextPowerOfTwo(%d) = %d，预期 %d", tc.x, r, tc.next)
+		}
+		if r := bit.PrevPowerOfTwo(tc.x); r != tc.prev {
+			t.Errorf("PrevPowerOfTwo(%d) = %d，预期 %d", tc.x, r, tc.prev)
+		}
+	}
+
+	if r := bit.NextPowerOfTwo(uint8(129)); r != 0 {
+		t.Errorf("NextPowerOfTwo[uint8](129) = %d，预期溢出为 0", r)
+	}
+}
+
+// TestRoundUpTo 测试向上取整到倍数
+func TestRoundUpTo(t *testing.T) {
+	testCases := []struct {
+		x, multiple, expected uint
+	}{
+		{0, 8, 0},
+		{1, 8, 8},
+		{8, 8, 8},
+		{9, 8, 16},
+		{4095, 4096, 4096},
+		{10, 3, 12},
+		{12, 3, 12},
+		{7, 1, 7},
+	}
+	for _, tc := range testCases {
+		if r := bit.RoundUpTo(tc.x, tc.multiple); r != tc.expected {
+			t.Errorf("RoundUpTo(%d, %d) = %d，预期 %d", tc.x, tc.multiple, r, tc.expected)
+		}
+	}
+}
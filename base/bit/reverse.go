@@ -0,0 +1,18 @@
+package bit
+
+import "math/bits"
+
+// Reverse 反转 x 低 width 位的顺序，高于 width 的位被丢弃
+// 例如 Reverse(0b0011, 4) = 0b1100；常用于 FFT 的位反转下标和按 LSB 优先传输的协议字段
+//
+// 原理：先用 bits.Reverse64 反转全部 64 位，此时原来的低 width 位位于最高位，再右移 64-width 位即可。
+// width 必须在 [0, 64] 内。
+func Reverse(x uint64, width int) uint64 {
+	if width < 0 || width > 64 {
+		panic("bit: width 必须在 [0, 64] 内")
+	}
+	if width == 0 {
+		return 0
+	}
+	return bits.Reverse64(x) >> (64 - width)
+}
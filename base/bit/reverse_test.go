@@ -0,0 +1,63 @@
+package bit_test
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// reverseNaive 逐位反转低 width 位，作为对照实现
+func reverseNaive(x uint64, width int) uint64 {
+	var r uint64
+	for i := range width {
+		r = r<<1 | (x>>uint(i))&1
+	}
+	return r
+}
+
+// TestReverse 测试任意位宽的位反转
+func TestReverse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		x        uint64
+		width    int
+		expected uint64
+	}{
+		{"4位", 0b0011, 4, 0b1100},
+		{"12位字段", 0xABC, 12, 0x3D5},
+		{"高位被丢弃", 0xF1, 4, 0b1000},
+		{"宽度为0", 0xFF, 0, 0},
+		{"宽度为1", 0b11, 1, 1},
+		{"64位与math/bits一致", 0x1, 64, 1 << 63},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if r := bit.Reverse(tc.x, tc.width); r != tc.expected {
+				t.Errorf("Reverse(0x%X, %d) = 0x%X，预期 0x%X", tc.x, tc.width, r, tc.expected)
+			}
+		})
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for range 1000 {
+		x, width := r.Uint64(), r.Intn(65)
+		if bit.Reverse(x, width) != reverseNaive(x, width) {
+			t.Fatalf("Reverse(0x%X, %d) 与逐位实现不一致", x, width)
+		}
+	}
+	if bit.Reverse(0xDEADBEEF, 64) != bits.Reverse64(0xDEADBEEF) {
+		t.Errorf("64位反转应与 bits.Reverse64 一致")
+	}
+}
+
+// TestReverseFFTIndex 测试 FFT 位反转置换：对 8 点 FFT 的下标两两互换
+func TestReverseFFTIndex(t *testing.T) {
+	expected := []uint64{0, 4, 2, 6, 1, 5, 3, 7}
+	for i, e := range expected {
+		if r := bit.Reverse(uint64(i), 3); r != e {
+			t.Errorf("Reverse(%d, 3) = %d，预期 %d", i, r, e)
+		}
+	}
+}
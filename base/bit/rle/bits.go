@@ -0,0 +1,168 @@
+package rle
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"slices"
+
+	"github.com/moweilong/efficient-go/base/bit/bitio"
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+	"github.com/moweilong/efficient-go/base/bit/elias"
+)
+
+// 位游程的格式：gamma(n+1)，1 位存储方式，随后是
+//   - 原样存储：n 位，按字依次写入，每个字 64 位（最后一个字为 n%64 位）
+//   - 游程存储：首位的值（1 位），随后依次是各个游程的长度，以 gamma 编码，所有游程的长度之和为 n
+const (
+	modeRaw  = 0
+	modeRuns = 1
+)
+
+// WriteBitRuns 把 words 中的前 n 位（第 i 位位于 words[i/64] 的第 i%64 位，与 bitset 的布局一致）
+// 以游程编码写入 w；游程编码不能明显缩短时改为原样存储
+func WriteBitRuns(w *bitio.Writer, words []uint64, n int) error {
+	if n < 0 || n > len(words)*64 {
+		panic("rle: n 超出 words 的范围")
+	}
+	if err := elias.WriteGamma(w, uint64(n)+1); err != nil {
+		return err
+	}
+
+	// 先计算游程编码的长度，决定存储方式
+	size := 1
+	for i := 0; i < n; {
+		j := runEnd(words, i, n)
+		size += elias.GammaLen(uint64(j - i))
+		i = j
+	}
+	// 原样存储的解码快得多，游程编码至少节省 1/8 时才采用
+	if size > n-n/8 {
+		if err := w.WriteBits(modeRaw, 1); err != nil {
+			return err
+		}
+		for i := 0; i < n; i += 64 {
+			if err := w.WriteBits(words[i/64], min(64, n-i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := w.WriteBits(modeRuns, 1); err != nil {
+		return err
+	}
+	if err := w.WriteBits(words[0]&1, 1); err != nil {
+		return err
+	}
+	for i := 0; i < n; {
+		j := runEnd(words, i, n)
+		if err := elias.WriteGamma(w, uint64(j-i)); err != nil {
+			return err
+		}
+		i = j
+	}
+	return nil
+}
+
+// runEnd 返回从第 i 位开始、与第 i 位相同的连续位的结束位置（不超过 n）
+func runEnd(words []uint64, i, n int) int {
+	k := i / 64
+	// 与第 i 位相同的位异或后为 0，第一个为 1 的位即游程的结束位置
+	var flip uint64
+	if words[k]>>(i%64)&1 == 1 {
+		flip = ^uint64(0)
+	}
+	diff := (words[k] ^ flip) & (^uint64(0) << (i % 64))
+	for diff == 0 {
+		k++
+		if k >= len(words) || k*64 >= n {
+			return n
+		}
+		diff = words[k] ^ flip
+	}
+	return min(n, k*64+bits.TrailingZeros64(diff))
+}
+
+// ReadBitRuns 读取 WriteBitRuns 写入的位序列，把各个字追加到 dst，返回追加后的切片与位数
+// maxBits 限制解码的位数，防止损坏或恶意的输入使解码分配过多内存，超出时返回 ErrTooLarge
+func ReadBitRuns(r *bitio.Reader, dst []uint64, maxBits int) ([]uint64, int, error) {
+	g, err := elias.ReadGamma(r)
+	if err != nil {
+		return dst, 0, wrap(err)
+	}
+	n := g - 1
+	if n > uint64(maxBits) {
+		return dst, 0, fmt.Errorf("%w：%d 位，上限 %d", ErrTooLarge, n, maxBits)
+	}
+	start, m := len(dst), int((n+63)/64)
+	dst = slices.Grow(dst, m)[:start+m]
+	words := dst[start:]
+	clear(words)
+
+	mode, err := r.ReadBits(1)
+	if err != nil {
+		return dst[:start], 0, wrap(err)
+	}
+	if mode == modeRaw {
+		for i := uint64(0); i < n; i += 64 {
+			v, err := r.ReadBits(int(min(64, n-i)))
+			if err != nil {
+				return dst[:start], 0, wrap(err)
+			}
+			words[i/64] = v
+		}
+		return dst, int(n), nil
+	}
+
+	one, err := r.ReadBit()
+	if err != nil {
+		return dst[:start], 0, wrap(err)
+	}
+	for i := uint64(0); i < n; one = !one {
+		l, err := elias.ReadGamma(r)
+		if err != nil {
+			return dst[:start], 0, wrap(err)
+		}
+		if l > n-i {
+			return dst[:start], 0, fmt.Errorf("%w：游程长度之和超出位数", ErrCorrupt)
+		}
+		if one {
+			setRange(words, int(i), int(i+l))
+		}
+		i += l
+	}
+	return dst, int(n), nil
+}
+
+// setRange 把 [i, j) 内的位置为 1
+func setRange(words []uint64, i, j int) {
+	for i < j {
+		k, lo := i/64, i%64
+		hi := min(64, lo+j-i)
+		words[k] |= ^uint64(0) >> (64 - (hi - lo)) << lo
+		i += hi - lo
+	}
+}
+
+// wrap 把截断的输入与 gamma 编码错误统一为 ErrCorrupt
+func wrap(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF || err == elias.ErrCorrupt {
+		return fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	return err
+}
+
+// WriteBitSet 以游程编码写入 b 的全部位
+func WriteBitSet(w *bitio.Writer, b *bitset.BitSet) error {
+	return WriteBitRuns(w, b.Words(), b.Len())
+}
+
+// ReadBitSet 读取 WriteBitSet 写入的位集合，maxBits 的含义与 ReadBitRuns 相同
+func ReadBitSet(r *bitio.Reader, maxBits int) (*bitset.BitSet, error) {
+	words, n, err := ReadBitRuns(r, nil, maxBits)
+	if err != nil {
+		return nil, err
+	}
+	return bitset.FromWords(words, n), nil
+}
@@ -0,0 +1,173 @@
+package rle_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/bit/bitio"
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+	"github.com/moweilong/efficient-go/base/bit/rle"
+)
+
+// encodeBits 以位游程编码 words 的前 n 位
+func encodeBits(t testing.TB, words []uint64, n int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	if err := rle.WriteBitRuns(w, words, n); err != nil {
+		t.Fatalf("WriteBitRuns 失败: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// pattern 返回按 f 生成的 n 位
+func pattern(n int, f func(i int) bool) []uint64 {
+	words := make([]uint64, (n+63)/64)
+	for i := range n {
+		if f(i) {
+			words[i/64] |= 1 << (i % 64)
+		}
+	}
+	return words
+}
+
+// bitInputs 返回覆盖各种游程分布的输入，包括交替出现的病态输入
+func bitInputs() map[string][]uint64 {
+	r := rand.New(rand.NewSource(1))
+	const n = 10000
+	return map[string][]uint64{
+		"全 0":     pattern(n, func(int) bool { return false }),
+		"全 1":     pattern(n, func(int) bool { return true }),
+		"交替":      pattern(n, func(i int) bool { return i%2 == 1 }),
+		"两位交替":    pattern(n, func(i int) bool { return i/2%2 == 1 }),
+		"稀疏":      pattern(n, func(int) bool { return r.Intn(500) == 0 }),
+		"随机":      pattern(n, func(int) bool { return r.Intn(2) == 0 }),
+		"长游程":     pattern(n, func(i int) bool { return i/1000%2 == 0 }),
+		"跨字边界的游程": pattern(n, func(i int) bool { return i >= 60 && i < 200 }),
+	}
+}
+
+// TestBitRunsRoundTrip 测试各种输入与长度下编解码可逆
+func TestBitRunsRoundTrip(t *testing.T) {
+	for name, words := range bitInputs() {
+		for _, n := range []int{0, 1, 63, 64, 65, 1000, len(words) * 64} {
+			data := encodeBits(t, words, n)
+			got, m, err := rle.ReadBitRuns(bitio.NewReader(bytes.NewReader(data)), []uint64{42}, 1<<20)
+			if err != nil {
+				t.Fatalf("%s n=%d: ReadBitRuns 失败: %v", name, n, err)
+			}
+			want := pattern(n, func(i int) bool { return words[i/64]>>(i%64)&1 == 1 })
+			if m != n || got[0] != 42 || !slices.Equal(got[1:], want) {
+				t.Fatalf("%s n=%d: 解码结果与原始数据不一致", name, n)
+			}
+		}
+	}
+}
+
+// TestBitRunsSize 测试压缩效果：长游程显著变短，病态输入最多比原始位多出很少的头部
+func TestBitRunsSize(t *testing.T) {
+	const n = 10000
+	raw := n / 8
+	for name, words := range bitInputs() {
+		size := len(encodeBits(t, words, n))
+		switch name {
+		case "全 0", "全 1", "长游程", "跨字边界的游程":
+			if size > 32 {
+				t.Errorf("%s: 编码长度 = %d 字节，预期显著小于原始的 %d 字节", name, size, raw)
+			}
+		case "稀疏":
+			if size > raw/4 {
+				t.Errorf("%s: 编码长度 = %d 字节，预期小于 %d 字节", name, size, raw/4)
+			}
+		default:
+			if size > raw+8 {
+				t.Errorf("%s: 编码长度 = %d 字节，超过原始的 %d 字节加头部", name, size, raw)
+			}
+		}
+	}
+}
+
+// TestBitSet 测试 BitSet 的编解码
+func TestBitSet(t *testing.T) {
+	b := bitset.New(1000)
+	for _, i := range []int{0, 5, 6, 7, 500, 999} {
+		b.Set(i)
+	}
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	if err := rle.WriteBitSet(w, b); err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Flush()
+	got, err := rle.ReadBitSet(bitio.NewReader(&buf), 1000)
+	if err != nil {
+		t.Fatalf("ReadBitSet 失败: %v", err)
+	}
+	if got.Len() != b.Len() || !slices.Equal(got.Words(), b.Words()) {
+		t.Errorf("解码结果与原始位集合不一致")
+	}
+}
+
+// TestBitRunsErrors 测试截断、长度上限与非法的游程
+func TestBitRunsErrors(t *testing.T) {
+	for name, words := range bitInputs() {
+		data := encodeBits(t, words, 10000)
+		for _, n := range []int{0, 1, len(data) / 2, len(data) - 1} {
+			if _, _, err := rle.ReadBitRuns(bitio.NewReader(bytes.NewReader(data[:n])), nil, 1<<20); !errors.Is(err, rle.ErrCorrupt) {
+				t.Errorf("%s 截断为 %d 字节: err = %v，预期 ErrCorrupt", name, n, err)
+			}
+		}
+		if _, _, err := rle.ReadBitRuns(bitio.NewReader(bytes.NewReader(data)), nil, 9999); !errors.Is(err, rle.ErrTooLarge) {
+			t.Errorf("%s: 超出上限 err = %v，预期 ErrTooLarge", name, err)
+		}
+	}
+
+	// 声明 3 位，游程长度为 4
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	_ = w.WriteBits(0b00100, 5) // gamma(4)：n = 3
+	_ = w.WriteBits(0b11, 2)    // 游程存储，首位为 1
+	_ = w.WriteBits(0b00100, 5) // gamma(4)
+	_ = w.Flush()
+	if _, _, err := rle.ReadBitRuns(bitio.NewReader(&buf), nil, 100); !errors.Is(err, rle.ErrCorrupt) {
+		t.Errorf("游程长度之和超出位数: err = %v，预期 ErrCorrupt", err)
+	}
+}
+
+// BenchmarkBitRuns 测试不同分布下的编码速度与压缩效果，bits/bit 为编码后每个原始位平均占用的位数
+func BenchmarkBitRuns(b *testing.B) {
+	for _, name := range []string{"稀疏", "长游程", "交替", "随机"} {
+		words := bitInputs()[name]
+		n := len(words) * 64
+		b.Run(fmt.Sprintf("encode/%s", name), func(b *testing.B) {
+			var buf bytes.Buffer
+			w := bitio.NewWriter(&buf)
+			b.SetBytes(int64(n / 8))
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				_ = rle.WriteBitRuns(w, words, n)
+				_ = w.Flush()
+			}
+			b.ReportMetric(float64(buf.Len()*8)/float64(n), "bits/bit")
+		})
+		data := encodeBits(b, words, n)
+		b.Run(fmt.Sprintf("decode/%s", name), func(b *testing.B) {
+			src := bytes.NewReader(data)
+			dst := make([]uint64, 0, len(words))
+			b.SetBytes(int64(n / 8))
+			for i := 0; i < b.N; i++ {
+				src.Reset(data)
+				dst, _, _ = rle.ReadBitRuns(bitio.NewReader(src), dst[:0], n)
+			}
+			benchkit.SinkInt = len(dst)
+		})
+	}
+}
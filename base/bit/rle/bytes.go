@@ -0,0 +1,75 @@
+package rle
+
+import "fmt"
+
+// 字节游程的格式为一系列以控制字节开头的片段：
+//   - 控制字节 c < 128：随后是 c+1 个原样存储的字节
+//   - 控制字节 c >= 128：随后是 1 个字节，重复 c-128+minRun 次
+const (
+	maxLiteral = 128
+	minRun     = 3 // 长度为 2 的游程编码后不会变短，夹在原样字节中时还会打断原样片段
+	maxRun     = 127 + minRun
+)
+
+// MaxByteRunsLen 返回长度为 n 的输入经 AppendByteRuns 编码后的最大长度
+func MaxByteRunsLen(n int) int {
+	return n + (n+maxLiteral-1)/maxLiteral
+}
+
+// AppendByteRuns 把 src 以字节游程编码追加到 dst
+// 连续 3 个及以上相同的字节编码为 2 字节的游程，其余字节每 128 个一组原样存储，额外占用 1 字节
+func AppendByteRuns(dst, src []byte) []byte {
+	for len(src) > 0 {
+		if r := runLen(src); r >= minRun {
+			dst = append(dst, byte(128+r-minRun), src[0])
+			src = src[r:]
+			continue
+		}
+		// 原样片段延续到下一个足够长的游程开始或达到 128 字节
+		n := 1
+		for n < len(src) && n < maxLiteral && !(n+2 < len(src) && src[n] == src[n+1] && src[n] == src[n+2]) {
+			n++
+		}
+		dst = append(dst, byte(n-1))
+		dst = append(dst, src[:n]...)
+		src = src[n:]
+	}
+	return dst
+}
+
+// runLen 返回 src 开头相同字节的个数，不超过 maxRun
+func runLen(src []byte) int {
+	n := 1
+	for n < len(src) && n < maxRun && src[n] == src[0] {
+		n++
+	}
+	return n
+}
+
+// DecodeByteRuns 解码 AppendByteRuns 的结果并追加到 dst
+// 输入在片段中间结束时返回原来的 dst 与 ErrCorrupt
+func DecodeByteRuns(dst, src []byte) ([]byte, error) {
+	start := len(dst)
+	for i := 0; i < len(src); {
+		c := int(src[i])
+		i++
+		if c < 128 {
+			n := c + 1
+			if len(src)-i < n {
+				return dst[:start], fmt.Errorf("%w：原样片段不完整（位置 %d）", ErrCorrupt, i-1)
+			}
+			dst = append(dst, src[i:i+n]...)
+			i += n
+			continue
+		}
+		if i == len(src) {
+			return dst[:start], fmt.Errorf("%w：游程片段不完整（位置 %d）", ErrCorrupt, i-1)
+		}
+		b := src[i]
+		i++
+		for range c - 128 + minRun {
+			dst = append(dst, b)
+		}
+	}
+	return dst, nil
+}
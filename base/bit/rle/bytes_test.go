@@ -0,0 +1,127 @@
+package rle_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/bit/rle"
+)
+
+// byteInputs 返回覆盖各种游程分布的输入，包括没有任何重复的病态输入
+func byteInputs() map[string][]byte {
+	r := rand.New(rand.NewSource(1))
+	random := make([]byte, 10000)
+	r.Read(random)
+	var mixed []byte
+	for len(mixed) < 10000 {
+		if r.Intn(2) == 0 {
+			mixed = append(mixed, bytes.Repeat([]byte{byte(r.Intn(4))}, 1+r.Intn(300))...)
+		} else {
+			mixed = append(mixed, random[:r.Intn(50)]...)
+		}
+	}
+	return map[string][]byte{
+		"空":    nil,
+		"单字节":  {7},
+		"两个相同": {7, 7},
+		"三个相同": {7, 7, 7},
+		"全 0":  make([]byte, 10000),
+		"交替":   bytes.Repeat([]byte{0, 1}, 5000),
+		"成对重复": bytes.Repeat([]byte{0, 0, 1, 1}, 2500),
+		"随机":   random,
+		"混合":   mixed,
+	}
+}
+
+// TestByteRunsRoundTrip 测试各种输入编解码可逆，且编码长度不超过 MaxByteRunsLen
+func TestByteRunsRoundTrip(t *testing.T) {
+	for name, src := range byteInputs() {
+		enc := rle.AppendByteRuns([]byte("x"), src)
+		if len(enc)-1 > rle.MaxByteRunsLen(len(src)) {
+			t.Errorf("%s: 编码长度 = %d，超过 MaxByteRunsLen = %d", name, len(enc)-1, rle.MaxByteRunsLen(len(src)))
+		}
+		got, err := rle.DecodeByteRuns([]byte("y"), enc[1:])
+		if err != nil {
+			t.Fatalf("%s: DecodeByteRuns 失败: %v", name, err)
+		}
+		if got[0] != 'y' || !bytes.Equal(got[1:], src) {
+			t.Fatalf("%s: 解码结果与原始数据不一致", name)
+		}
+	}
+}
+
+// TestByteRunsSize 测试压缩效果与病态输入的膨胀上限
+func TestByteRunsSize(t *testing.T) {
+	inputs := byteInputs()
+	if n := len(rle.AppendByteRuns(nil, inputs["全 0"])); n > 10000/130*2+2 {
+		t.Errorf("全 0: 编码长度 = %d，预期每 130 字节占 2 字节", n)
+	}
+	for _, name := range []string{"交替", "成对重复", "随机"} {
+		src := inputs[name]
+		if n := len(rle.AppendByteRuns(nil, src)); n != rle.MaxByteRunsLen(len(src)) {
+			t.Errorf("%s: 编码长度 = %d，预期全部原样存储为 %d", name, n, rle.MaxByteRunsLen(len(src)))
+		}
+	}
+}
+
+// TestByteRunsCorrupt 测试截断的输入
+func TestByteRunsCorrupt(t *testing.T) {
+	for _, data := range [][]byte{{5, 'a', 'b'}, {200}, {0}} {
+		got, err := rle.DecodeByteRuns([]byte("keep"), data)
+		if !errors.Is(err, rle.ErrCorrupt) || string(got) != "keep" {
+			t.Errorf("DecodeByteRuns(%v) = %q, %v，预期保持 dst 不变并返回 ErrCorrupt", data, got, err)
+		}
+	}
+}
+
+// FuzzByteRuns 测试任意输入编解码可逆，任意输入解码不 panic
+func FuzzByteRuns(f *testing.F) {
+	for _, src := range byteInputs() {
+		f.Add(src)
+	}
+	f.Fuzz(func(t *testing.T, src []byte) {
+		_, _ = rle.DecodeByteRuns(nil, src)
+		got, err := rle.DecodeByteRuns(nil, rle.AppendByteRuns(nil, src))
+		if err != nil || !bytes.Equal(got, src) {
+			t.Fatalf("往返失败: %v", err)
+		}
+	})
+}
+
+// TestByteRunsAllocs 测试复用缓冲区时编解码不分配内存
+func TestByteRunsAllocs(t *testing.T) {
+	src := byteInputs()["混合"]
+	enc := make([]byte, 0, rle.MaxByteRunsLen(len(src)))
+	dec := make([]byte, 0, len(src))
+	benchkit.AssertAllocs(t, 0, func() {
+		enc = rle.AppendByteRuns(enc[:0], src)
+		dec, _ = rle.DecodeByteRuns(dec[:0], enc)
+	})
+}
+
+// BenchmarkByteRuns 测试不同分布下的编解码速度与压缩效果，bytes/byte 为编码后每个原始字节平均占用的字节数
+func BenchmarkByteRuns(b *testing.B) {
+	for _, name := range []string{"全 0", "混合", "随机"} {
+		src := byteInputs()[name]
+		b.Run(fmt.Sprintf("encode/%s", name), func(b *testing.B) {
+			dst := make([]byte, 0, rle.MaxByteRunsLen(len(src)))
+			b.SetBytes(int64(len(src)))
+			for i := 0; i < b.N; i++ {
+				dst = rle.AppendByteRuns(dst[:0], src)
+			}
+			b.ReportMetric(float64(len(dst))/float64(len(src)), "bytes/byte")
+		})
+		enc := rle.AppendByteRuns(nil, src)
+		dec := make([]byte, 0, len(src))
+		b.Run(fmt.Sprintf("decode/%s", name), func(b *testing.B) {
+			b.SetBytes(int64(len(src)))
+			for i := 0; i < b.N; i++ {
+				dec, _ = rle.DecodeByteRuns(dec[:0], enc)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+// Package rle 实现游程编码（run-length encoding），把连续相同的位或字节压缩为“值 + 重复次数”。
+//
+// 位游程（WriteBitRuns/ReadBitRuns）基于 bitio 与 Elias gamma 编码，适合序列化大段连续为 0 或为 1 的位图；
+// 字节游程（AppendByteRuns/DecodeByteRuns）采用 PackBits 风格的格式，适合含有大量重复字节的数据。
+//
+// 游程编码在最坏情况下（如 0 与 1 交替出现）会使数据膨胀，两种编码都对此做了限制：
+// 位游程在编码不能明显缩短时改为原样存储，最多多出几十位的头部；字节游程最多每 128 字节多出 1 字节。
+package rle
+
+import "errors"
+
+var (
+	// ErrCorrupt 表示输入不是合法的编码结果
+	ErrCorrupt = errors.New("rle: 数据损坏")
+	// ErrTooLarge 表示编码中声明的长度超出调用方给出的上限
+	ErrTooLarge = errors.New("rle: 长度超出上限")
+)
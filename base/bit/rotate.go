@@ -0,0 +1,70 @@
+package bit
+
+import "math/bits"
+
+// RotateLeft 将 x 循环左移 k 位，k 为负数时循环右移
+// 对 8/16/32/64 位宽直接转发给 math/bits，由编译器内联为单条 ROL 指令
+func RotateLeft[T Unsigned](x T, k int) T {
+	switch Width[T]() {
+	case 8:
+		return T(bits.RotateLeft8(uint8(x), k))
+	case 16:
+		return T(bits.RotateLeft16(uint16(x), k))
+	case 32:
+		return T(bits.RotateLeft32(uint32(x), k))
+	default:
+		return T(bits.RotateLeft64(uint64(x), k))
+	}
+}
+
+// RotateRight 将 x 循环右移 k 位，k 为负数时循环左移
+func RotateRight[T Unsigned](x T, k int) T {
+	return RotateLeft(x, -k)
+}
+
+// RotateLeftN 将 x 低 width 位组成的位域循环左移 k 位，高于 width 的位被清零
+// 用于 math/bits 无法直接处理的任意位宽，例如 12 位的字段；要求 0 < width <= 64
+func RotateLeftN(x uint64, k int, width uint) uint64 {
+	if width == 0 || width > 64 {
+		panic("bit: width 必须在 (0, 64] 内")
+	}
+	mask := Mask[uint64](0, width)
+	x &= mask
+	s := uint(k % int(width))
+	if k < 0 {
+		s = uint(int(width) + k%int(width))
+	}
+	if s == 0 || s == width {
+		return x
+	}
+	return (x<<s | x>>(width-s)) & mask
+}
+
+// Bswap16 反转 16 位整数的字节序
+func Bswap16(x uint16) uint16 {
+	return bits.ReverseBytes16(x)
+}
+
+// Bswap32 反转 32 位整数的字节序
+func Bswap32(x uint32) uint32 {
+	return bits.ReverseBytes32(x)
+}
+
+// Bswap64 反转 64 位整数的字节序
+func Bswap64(x uint64) uint64 {
+	return bits.ReverseBytes64(x)
+}
+
+// Bswap 反转任意无符号整数的字节序，8 位整数原样返回
+func Bswap[T Unsigned](x T) T {
+	switch Width[T]() {
+	case 8:
+		return x
+	case 16:
+		return T(bits.ReverseBytes16(uint16(x)))
+	case 32:
+		return T(bits.ReverseBytes32(uint32(x)))
+	default:
+		return T(bits.ReverseBytes64(uint64(x)))
+	}
+}
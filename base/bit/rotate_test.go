@@ -0,0 +1,126 @@
+package bit_test
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// TestRotate 测试各位宽下的循环移位
+func TestRotate(t *testing.T) {
+	if r := bit.RotateLeft(uint8(0x81), 1); r != 0x03 {
+		t.Errorf("RotateLeft(uint8(0x81), 1) = 0x%X，预期 0x03", r)
+	}
+	if r := bit.RotateRight(uint16(0x0001), 1); r != 0x8000 {
+		t.Errorf("RotateRight(uint16(1), 1) = 0x%X，预期 0x8000", r)
+	}
+	if r := bit.RotateLeft(uint32(0x12345678), 8); r != 0x34567812 {
+		t.Errorf("RotateLeft(uint32, 8) = 0x%X，预期 0x34567812", r)
+	}
+	if r := bit.RotateLeft(uint64(1)<<63, -63); r != 1 {
+		t.Errorf("RotateLeft(1<<63, -63) = 0x%X，预期 1", r)
+	}
+	for k := -70; k <= 70; k++ {
+		x := uint64(0xDEADBEEFCAFEBABE)
+		if bit.RotateLeft(x, k) != bits.RotateLeft64(x, k) {
+			t.Fatalf("RotateLeft 与 math/bits 在 k=%d 时结果不一致", k)
+		}
+		if bit.RotateRight(bit.RotateLeft(x, k), k) != x {
+			t.Fatalf("RotateLeft/RotateRight 在 k=%d 时不可逆", k)
+		}
+	}
+}
+
+// TestRotateLeftN 测试任意位宽的循环移位
+func TestRotateLeftN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		x        uint64
+		k        int
+		width    uint
+		expected uint64
+	}{
+		{"12位左移4位", 0xABC, 4, 12, 0xBCA},
+		{"12位右移4位", 0xABC, -4, 12, 0xCAB},
+		{"12位移动整周", 0xABC, 12, 12, 0xABC},
+		{"3位左移1位", 0b101, 1, 3, 0b011},
+		{"高位被忽略", 0xF00F, 0, 4, 0xF},
+		{"64位与math/bits一致", 0x8000000000000001, 1, 64, 0x3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if r := bit.RotateLeftN(tc.x, tc.k, tc.width); r != tc.expected {
+				t.Errorf("RotateLeftN(0x%X, %d, %d) = 0x%X，预期 0x%X", tc.x, tc.k, tc.width, r, tc.expected)
+			}
+		})
+	}
+}
+
+// TestBswap 测试字节序反转
+func TestBswap(t *testing.T) {
+	if r := bit.Bswap16(0x1234); r != 0x3412 {
+		t.Errorf("Bswap16 = 0x%X，预期 0x3412", r)
+	}
+	if r := bit.Bswap32(0x12345678); r != 0x78563412 {
+		t.Errorf("Bswap32 = 0x%X，预期 0x78563412", r)
+	}
+	if r := bit.Bswap64(0x0102030405060708); r != 0x0807060504030201 {
+		t.Errorf("Bswap64 = 0x%X，预期 0x0807060504030201", r)
+	}
+	if r := bit.Bswap(uint32(0x12345678)); r != 0x78563412 {
+		t.Errorf("Bswap[uint32] = 0x%X，预期 0x78563412", r)
+	}
+	if r := bit.Bswap(uint8(0xAB)); r != 0xAB {
+		t.Errorf("Bswap[uint8] = 0x%X，预期 0xAB", r)
+	}
+}
+
+var sink32 uint32 // 防止编译器消除被测代码
+
+// BenchmarkRotateLeft 对比泛型封装与 math/bits，两者应编译为相同的指令
+func BenchmarkRotateLeft(b *testing.B) {
+	x := uint32(0x12345678)
+	b.Run("math/bits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink32 = bits.RotateLeft32(x, i)
+		}
+	})
+	b.Run("bit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink32 = bit.RotateLeft(x, i)
+		}
+	})
+	b.Run("shift", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			k := uint(i) & 31
+			sink32 = x<<k | x>>(32-k)
+		}
+	})
+}
+
+// BenchmarkBswap32 对比字节序反转的封装与手写移位实现
+func BenchmarkBswap32(b *testing.B) {
+	x := uint32(0x12345678)
+	b.Run("math/bits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink32 = bits.ReverseBytes32(x + uint32(i))
+		}
+	})
+	b.Run("bit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink32 = bit.Bswap32(x + uint32(i))
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink32 = bit.Bswap(x + uint32(i))
+		}
+	})
+	b.Run("manual", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v := x + uint32(i)
+			sink32 = v>>24 | v>>8&0xFF00 | v<<8&0xFF0000 | v<<24
+		}
+	})
+}
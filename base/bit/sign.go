@@ -0,0 +1,40 @@
+package bit
+
+import "unsafe"
+
+// Signed 约束所有有符号整数类型
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// signMask 返回 x 的符号掩码：x < 0 时为 -1（全 1），否则为 0
+// 利用有符号整数右移为算术移位、高位补符号位的特性
+func signMask[T Signed](x T) T {
+	return x >> (unsafe.Sizeof(x)*8 - 1)
+}
+
+// SameSign 判断 a 与 b 的符号是否相同（0 视为正数）
+// 原理：符号位相同时异或结果的符号位为 0，即 (a ^ b) >= 0
+func SameSign[T Signed](a, b T) bool {
+	return a^b >= 0
+}
+
+// Abs 无分支地求 x 的绝对值
+// 原理：m 为符号掩码，x >= 0 时 (x ^ 0) - 0 = x，x < 0 时 (x ^ -1) + 1 = -x
+// 与 math.Abs 的整数写法一样，最小负数的绝对值会溢出为其本身
+func Abs[T Signed](x T) T {
+	m := signMask(x)
+	return (x ^ m) - m
+}
+
+// Sign 无分支地返回 x 的符号：x < 0 为 -1，x == 0 为 0，x > 0 为 1
+func Sign[T Signed](x T) T {
+	return signMask(x) | signMask(-x)&1
+}
+
+// CopySign 返回绝对值等于 |x|、符号与 y 相同的值（y == 0 视为正数）
+// 原理：x 与 y 符号不同时 m 为 -1，对 x 取反加一即取相反数
+func CopySign[T Signed](x, y T) T {
+	m := signMask(x ^ y)
+	return (x ^ m) - m
+}
@@ -0,0 +1,133 @@
+package bit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// TestSameSign 测试符号是否相同的判断（与 TestXORFeatures 中的用例一致）
+func TestSameSign(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     int
+		expected bool
+	}{
+		{"均为正数", 12, 25, true},
+		{"均为负数", -12, -25, true},
+		{"一正一负", -12, 25, false},
+		{"一负一正", 12, -25, false},
+		{"包含零（零视为正数）", 0, -5, false},
+		{"零与正数", 0, 5, true},
+		{"极值", math.MinInt, math.MaxInt, false},
+	}
+	for _, tc := range testCases {
+		if actual := bit.SameSign(tc.a, tc.b); actual != tc.expected {
+			t.Errorf("%s: SameSign(%d, %d) = %v，预期 %v", tc.name, tc.a, tc.b, actual, tc.expected)
+		}
+	}
+}
+
+// TestAbsSignCopySign 测试无分支的绝对值、符号与符号复制
+func TestAbsSignCopySign(t *testing.T) {
+	testCases := []struct {
+		x, y                  int
+		abs, sign, copySignXY int
+	}{
+		{5, 3, 5, 1, 5},
+		{5, -3, 5, 1, -5},
+		{-5, 3, 5, -1, 5},
+		{-5, -3, 5, -1, -5},
+		{0, -3, 0, 0, 0},
+		{7, 0, 7, 1, 7},
+		{math.MaxInt, -1, math.MaxInt, 1, -math.MaxInt},
+		{math.MinInt, 1, math.MinInt, -1, math.MinInt}, // 溢出行为与二进制补码一致
+	}
+	for _, tc := range testCases {
+		if r := bit.Abs(tc.x); r != tc.abs {
+			t.Errorf("Abs(%d) = %d，预期 %d", tc.x, r, tc.abs)
+		}
+		if r := bit.Sign(tc.x); r != tc.sign {
+			t.Errorf("Sign(%d) = %d，预期 %d", tc.x, r, tc.sign)
+		}
+		if r := bit.CopySign(tc.x, tc.y); r != tc.copySignXY {
+			t.Errorf("CopySign(%d, %d) = %d，预期 %d", tc.x, tc.y, r, tc.copySignXY)
+		}
+	}
+
+	// 窄位宽同样适用
+	for x := math.MinInt8 + 1; x <= math.MaxInt8; x++ {
+		v := int8(x)
+		expected := v
+		if v < 0 {
+			expected = -v
+		}
+		if bit.Abs(v) != expected {
+			t.Fatalf("Abs[int8](%d) = %d，预期 %d", v, bit.Abs(v), expected)
+		}
+	}
+}
+
+var sinkInt int // 防止编译器消除被测代码
+
+// absBranch 使用分支实现的绝对值，作为对照
+func absBranch(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// signBranch 使用分支实现的符号函数，作为对照
+func signBranch(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// benchInputs 返回正负交替、分支预测不友好的输入
+func benchInputs() []int {
+	in := make([]int, 1024)
+	seed := uint32(1)
+	for i := range in {
+		seed = seed*1664525 + 1013904223
+		in[i] = int(int32(seed))
+	}
+	return in
+}
+
+// BenchmarkAbs 对比无分支与分支版本的绝对值
+func BenchmarkAbs(b *testing.B) {
+	in := benchInputs()
+	b.Run("branch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkInt += absBranch(in[i&1023])
+		}
+	})
+	b.Run("branchless", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkInt += bit.Abs(in[i&1023])
+		}
+	})
+}
+
+// BenchmarkSign 对比无分支与分支版本的符号函数
+func BenchmarkSign(b *testing.B) {
+	in := benchInputs()
+	b.Run("branch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkInt += signBranch(in[i&1023])
+		}
+	})
+	b.Run("branchless", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkInt += bit.Sign(in[i&1023])
+		}
+	})
+}
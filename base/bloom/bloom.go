@@ -0,0 +1,158 @@
+// Package bloom 实现基于 bitset.BitSet 的布隆过滤器。
+//
+// 布隆过滤器以极小的空间判断元素是否"可能存在"：Test 返回 false 时元素一定不存在，
+// 返回 true 时元素以一定的误判率（false positive rate）存在。
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+)
+
+// Filter 是布隆过滤器，不支持并发写入
+type Filter struct {
+	bits *bitset.BitSet
+	m    uint64 // 位数
+	k    uint64 // 哈希函数个数
+}
+
+// OptimalParams 根据预期元素个数 n 和误判率 p 计算最优的位数 m 与哈希函数个数 k
+//
+//	m = -n·ln(p) / (ln2)²
+//	k = (m/n)·ln2
+func OptimalParams(n uint64, p float64) (m, k uint64) {
+	if n == 0 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		panic("bloom: 误判率必须在 (0, 1) 内")
+	}
+	m = uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k = uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	return max(m, 1), max(k, 1)
+}
+
+// New 创建一个可容纳约 n 个元素、误判率约为 p 的布隆过滤器
+func New(n uint64, p float64) *Filter {
+	return NewWithParams(OptimalParams(n, p))
+}
+
+// NewWithParams 以指定的位数 m 和哈希函数个数 k 创建布隆过滤器
+func NewWithParams(m, k uint64) *Filter {
+	if m == 0 || k == 0 {
+		panic("bloom: m 与 k 必须大于 0")
+	}
+	return &Filter{bits: bitset.New(int(m)), m: m, k: k}
+}
+
+// M 返回位数
+func (f *Filter) M() uint64 {
+	return f.m
+}
+
+// K 返回哈希函数个数
+func (f *Filter) K() uint64 {
+	return f.k
+}
+
+// Add 添加元素
+func (f *Filter) Add(data []byte) {
+	f.add(hashes(data))
+}
+
+// AddString 添加字符串元素，不产生内存分配
+func (f *Filter) AddString(s string) {
+	f.add(hashString(s))
+}
+
+// Test 判断元素是否可能存在
+func (f *Filter) Test(data []byte) bool {
+	return f.test(hashes(data))
+}
+
+// TestString 判断字符串元素是否可能存在
+func (f *Filter) TestString(s string) bool {
+	return f.test(hashString(s))
+}
+
+// add 以双重哈希计算 k 个位置并置 1
+func (f *Filter) add(h1, h2 uint64) {
+	for i := range f.k {
+		f.bits.Set(int((h1 + i*h2) % f.m))
+	}
+}
+
+// test 判断双重哈希计算出的 k 个位置是否全部为 1
+func (f *Filter) test(h1, h2 uint64) bool {
+	for i := range f.k {
+		if !f.bits.Test(int((h1 + i*h2) % f.m)) {
+			return false
+		}
+	}
+	return true
+}
+
+// EstimateCount 根据值为 1 的位数估计已添加的不同元素个数
+//
+//	n ≈ -(m/k)·ln(1 - X/m)，X 为值为 1 的位数
+func (f *Filter) EstimateCount() uint64 {
+	x := float64(f.bits.Count())
+	m, k := float64(f.m), float64(f.k)
+	if x >= m {
+		return math.MaxUint64
+	}
+	return uint64(math.Round(-m / k * math.Log(1-x/m)))
+}
+
+// FillRatio 返回值为 1 的位所占的比例
+func (f *Filter) FillRatio() float64 {
+	return float64(f.bits.Count()) / float64(f.m)
+}
+
+// Reset 清空过滤器
+func (f *Filter) Reset() {
+	clear(f.bits.Words())
+}
+
+// 序列化格式：魔数 "BLM1"、m、k（均为小端 uint64），随后是位数组的各个字
+var magic = [4]byte{'B', 'L', 'M', '1'}
+
+// ErrInvalidData 表示反序列化的数据不是合法的布隆过滤器
+var ErrInvalidData = errors.New("bloom: 非法的序列化数据")
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	words := f.bits.Words()
+	buf := make([]byte, 0, 4+16+8*len(words))
+	buf = append(buf, magic[:]...)
+	buf = binary.LittleEndian.AppendUint64(buf, f.m)
+	buf = binary.LittleEndian.AppendUint64(buf, f.k)
+	for _, w := range words {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < 20 || [4]byte(data[:4]) != magic {
+		return ErrInvalidData
+	}
+	m := binary.LittleEndian.Uint64(data[4:])
+	k := binary.LittleEndian.Uint64(data[12:])
+	data = data[20:]
+	if m == 0 || k == 0 || m > math.MaxInt || uint64(len(data)) != (m+63)/64*8 {
+		return ErrInvalidData
+	}
+
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	f.bits = bitset.FromWords(words, int(m))
+	f.m, f.k = m, k
+	return nil
+}
@@ -0,0 +1,133 @@
+package bloom_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/bloom"
+)
+
+// TestOptimalParams 测试参数计算与经典取值一致：1% 误判率约需 9.6 位/元素、7 个哈希函数
+func TestOptimalParams(t *testing.T) {
+	m, k := bloom.OptimalParams(1000, 0.01)
+	if m != 9586 || k != 7 {
+		t.Errorf("OptimalParams(1000, 0.01) = (%d, %d)，预期 (9586, 7)", m, k)
+	}
+}
+
+// TestNoFalseNegatives 测试已添加的元素一定能被查到
+func TestNoFalseNegatives(t *testing.T) {
+	f := bloom.New(10000, 0.01)
+	for i := range 10000 {
+		f.AddString("key-" + strconv.Itoa(i))
+	}
+	for i := range 10000 {
+		if !f.Test([]byte("key-" + strconv.Itoa(i))) {
+			t.Fatalf("已添加的元素 key-%d 未被查到", i)
+		}
+	}
+}
+
+// TestFalsePositiveRate 测试实际误判率接近配置值
+func TestFalsePositiveRate(t *testing.T) {
+	const n = 10000
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		f := bloom.New(n, p)
+		for i := range n {
+			f.AddString("in-" + strconv.Itoa(i))
+		}
+		fp := 0
+		const trials = 100000
+		for i := range trials {
+			if f.TestString("out-" + strconv.Itoa(i)) {
+				fp++
+			}
+		}
+		rate := float64(fp) / trials
+		t.Logf("配置误判率 %.3f，实际误判率 %.4f", p, rate)
+		if rate > p*1.5 {
+			t.Errorf("误判率 %.4f 超出配置值 %.3f 过多", rate, p)
+		}
+	}
+}
+
+// TestEstimateCount 测试元素个数估计
+func TestEstimateCount(t *testing.T) {
+	f := bloom.New(10000, 0.01)
+	for i := range 5000 {
+		f.AddString(strconv.Itoa(i))
+		f.AddString(strconv.Itoa(i)) // 重复添加不影响估计
+	}
+	n := f.EstimateCount()
+	if n < 4800 || n > 5200 {
+		t.Errorf("EstimateCount = %d，预期约 5000", n)
+	}
+
+	f.Reset()
+	if f.EstimateCount() != 0 || f.TestString("1") {
+		t.Errorf("Reset 后过滤器应为空")
+	}
+}
+
+// TestMarshalBinary 测试序列化与反序列化
+func TestMarshalBinary(t *testing.T) {
+	f := bloom.New(1000, 0.01)
+	for i := range 1000 {
+		f.AddString(strconv.Itoa(i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary 失败: %v", err)
+	}
+
+	var g bloom.Filter
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary 失败: %v", err)
+	}
+	if g.M() != f.M() || g.K() != f.K() {
+		t.Errorf("参数不一致: (%d, %d) != (%d, %d)", g.M(), g.K(), f.M(), f.K())
+	}
+	for i := range 1000 {
+		if !g.TestString(strconv.Itoa(i)) {
+			t.Fatalf("反序列化后元素 %d 丢失", i)
+		}
+	}
+
+	for _, bad := range [][]byte{nil, data[:10], data[:len(data)-1], append([]byte("XXXX"), data[4:]...)} {
+		if err := g.UnmarshalBinary(bad); !errors.Is(err, bloom.ErrInvalidData) {
+			t.Errorf("非法数据的错误 = %v，预期 ErrInvalidData", err)
+		}
+	}
+}
+
+// BenchmarkFilter 测试添加与查询的性能
+func BenchmarkFilter(b *testing.B) {
+	f := bloom.New(1<<20, 0.01)
+	keys := make([]string, 1024)
+	absent := make([]string, 1024)
+	for i := range keys {
+		keys[i] = "user:" + strconv.Itoa(i)
+		absent[i] = "absent:" + strconv.Itoa(i)
+	}
+	b.Run("add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.AddString(keys[i&1023])
+		}
+	})
+	b.Run("test", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.TestString(keys[i&1023])
+		}
+	})
+	b.Run("test-absent", func(b *testing.B) {
+		fp := 0
+		for i := 0; i < b.N; i++ {
+			if f.TestString(absent[i&1023]) {
+				fp++
+			}
+		}
+		benchkit.ReportRatio(b, fp, b.N, "fp-ratio")
+	})
+}
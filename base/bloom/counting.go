@@ -0,0 +1,89 @@
+package bloom
+
+// maxCount 是 4 位计数器的上限，达到上限后不再增减，避免溢出导致漏判
+const maxCount = 15
+
+// CountingFilter 是支持删除的计数布隆过滤器
+// 每个位置使用 4 位计数器代替单个位，空间是普通 Filter 的 4 倍
+type CountingFilter struct {
+	counters []byte // 每个字节存放两个 4 位计数器
+	m        uint64
+	k        uint64
+}
+
+// NewCounting 创建一个可容纳约 n 个元素、误判率约为 p 的计数布隆过滤器
+func NewCounting(n uint64, p float64) *CountingFilter {
+	m, k := OptimalParams(n, p)
+	return &CountingFilter{counters: make([]byte, (m+1)/2), m: m, k: k}
+}
+
+// get 返回第 i 个计数器的值
+func (f *CountingFilter) get(i uint64) byte {
+	return f.counters[i>>1] >> (4 * (i & 1)) & 0x0F
+}
+
+// add 将第 i 个计数器加上 delta（+1 或 -1），达到上限的计数器保持不变
+func (f *CountingFilter) add(i uint64, delta int) {
+	c := f.get(i)
+	if c == maxCount || (c == 0 && delta < 0) {
+		return
+	}
+	shift := 4 * (i & 1)
+	f.counters[i>>1] = f.counters[i>>1]&^(0x0F<<shift) | byte(int(c)+delta)<<shift
+}
+
+// Add 添加元素
+func (f *CountingFilter) Add(data []byte) {
+	h1, h2 := hashes(data)
+	f.update(h1, h2, 1)
+}
+
+// AddString 添加字符串元素
+func (f *CountingFilter) AddString(s string) {
+	h1, h2 := hashString(s)
+	f.update(h1, h2, 1)
+}
+
+// Remove 删除元素，元素一定不存在时返回 false 且不做任何修改
+// 删除从未添加过的元素（恰好被误判为存在）会导致其他元素被漏判，调用方应只删除确实添加过的元素
+func (f *CountingFilter) Remove(data []byte) bool {
+	return f.remove(hashes(data))
+}
+
+// RemoveString 删除字符串元素
+func (f *CountingFilter) RemoveString(s string) bool {
+	return f.remove(hashString(s))
+}
+
+// Test 判断元素是否可能存在
+func (f *CountingFilter) Test(data []byte) bool {
+	return f.test(hashes(data))
+}
+
+// TestString 判断字符串元素是否可能存在
+func (f *CountingFilter) TestString(s string) bool {
+	return f.test(hashString(s))
+}
+
+func (f *CountingFilter) update(h1, h2 uint64, delta int) {
+	for i := range f.k {
+		f.add((h1+i*h2)%f.m, delta)
+	}
+}
+
+func (f *CountingFilter) remove(h1, h2 uint64) bool {
+	if !f.test(h1, h2) {
+		return false
+	}
+	f.update(h1, h2, -1)
+	return true
+}
+
+func (f *CountingFilter) test(h1, h2 uint64) bool {
+	for i := range f.k {
+		if f.get((h1+i*h2)%f.m) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package bloom_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bloom"
+)
+
+// TestCountingFilter 测试计数布隆过滤器的添加、查询与删除
+func TestCountingFilter(t *testing.T) {
+	f := bloom.NewCounting(1000, 0.01)
+	for i := range 1000 {
+		f.AddString(strconv.Itoa(i))
+	}
+	for i := range 1000 {
+		if !f.Test([]byte(strconv.Itoa(i))) {
+			t.Fatalf("已添加的元素 %d 未被查到", i)
+		}
+	}
+
+	// 删除偶数后偶数大多查不到，奇数仍然全部存在
+	for i := 0; i < 1000; i += 2 {
+		if !f.RemoveString(strconv.Itoa(i)) {
+			t.Fatalf("删除已添加的元素 %d 失败", i)
+		}
+	}
+	remaining := 0
+	for i := range 1000 {
+		present := f.TestString(strconv.Itoa(i))
+		if i%2 == 1 && !present {
+			t.Fatalf("删除其他元素后，元素 %d 被漏判", i)
+		}
+		if i%2 == 0 && present {
+			remaining++
+		}
+	}
+	if remaining > 20 {
+		t.Errorf("删除后仍有 %d 个元素被判为存在，误判过多", remaining)
+	}
+
+	if f.Remove([]byte("never-added")) {
+		t.Errorf("删除不存在的元素应返回 false")
+	}
+}
+
+// TestCountingSaturation 测试计数器饱和后不会因删除产生漏判
+func TestCountingSaturation(t *testing.T) {
+	f := bloom.NewCounting(10, 0.01)
+	for range 20 {
+		f.AddString("hot")
+	}
+	for range 20 {
+		f.RemoveString("hot")
+	}
+	if !f.TestString("hot") {
+		t.Errorf("饱和的计数器不应被减到 0")
+	}
+}
@@ -0,0 +1,105 @@
+package bloom_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/bloom"
+)
+
+// TestCuckoo 测试布谷鸟过滤器的添加、查询与删除
+func TestCuckoo(t *testing.T) {
+	const n = 10000
+	c := bloom.NewCuckoo(n)
+	for i := range n {
+		if !c.AddString(strconv.Itoa(i)) {
+			t.Fatalf("添加第 %d 个元素失败，装载率 %.2f", i, c.LoadFactor())
+		}
+	}
+	if c.Count() != n {
+		t.Errorf("Count = %d，预期 %d", c.Count(), n)
+	}
+	for i := range n {
+		if !c.Test([]byte(strconv.Itoa(i))) {
+			t.Fatalf("已添加的元素 %d 未被查到", i)
+		}
+	}
+
+	fp := 0
+	for i := range 100000 {
+		if c.TestString("out-" + strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	t.Logf("装载率 %.2f，误判率 %.5f", c.LoadFactor(), float64(fp)/100000)
+	if fp > 100 {
+		t.Errorf("误判 %d 次，超出预期", fp)
+	}
+
+	for i := 0; i < n; i += 2 {
+		if !c.RemoveString(strconv.Itoa(i)) {
+			t.Fatalf("删除元素 %d 失败", i)
+		}
+	}
+	for i := 1; i < n; i += 2 {
+		if !c.TestString(strconv.Itoa(i)) {
+			t.Fatalf("删除其他元素后，元素 %d 被漏判", i)
+		}
+	}
+	if c.Count() != n/2 {
+		t.Errorf("删除后 Count = %d，预期 %d", c.Count(), n/2)
+	}
+}
+
+// TestCuckooFull 测试过滤器装满后插入失败
+func TestCuckooFull(t *testing.T) {
+	c := bloom.NewCuckoo(64)
+	added := 0
+	for i := range 1000 {
+		if !c.AddString(strconv.Itoa(i)) {
+			break
+		}
+		added++
+	}
+	if added < 50 || added >= 1000 {
+		t.Errorf("容量 64 的过滤器添加了 %d 个元素", added)
+	}
+}
+
+// BenchmarkFilters 对比三种过滤器在 1% 左右误判率下的速度与空间
+func BenchmarkFilters(b *testing.B) {
+	const n = 1 << 16
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "key:" + strconv.Itoa(i)
+	}
+
+	b.Run("bloom", func(b *testing.B) {
+		f := bloom.New(n, 0.01)
+		for i := 0; i < b.N; i++ {
+			f.AddString(keys[i%n])
+			f.TestString(keys[(i+7)%n])
+		}
+		b.ReportMetric(float64(f.M())/n, "bits/key")
+	})
+	b.Run("counting", func(b *testing.B) {
+		f := bloom.NewCounting(n, 0.01)
+		m, _ := bloom.OptimalParams(n, 0.01)
+		for i := 0; i < b.N; i++ {
+			f.AddString(keys[i%n])
+			f.TestString(keys[(i+7)%n])
+		}
+		b.ReportMetric(float64(m*4)/n, "bits/key")
+	})
+	b.Run("cuckoo", func(b *testing.B) {
+		f := bloom.NewCuckoo(n)
+		for i := 0; i < b.N; i++ {
+			if i%n == 0 {
+				f = bloom.NewCuckoo(n) // 避免重复插入同一元素导致装满
+			}
+			f.AddString(keys[i%n])
+			f.TestString(keys[(i+7)%n])
+		}
+		b.ReportMetric(16/0.95, "bits/key") // 16 位指纹，按 95% 的可用装载率估算
+	})
+}
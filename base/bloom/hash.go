@@ -0,0 +1,38 @@
+package bloom
+
+// FNV-1a 64 位参数
+const (
+	offset64 = 14695981039346656037
+	prime64  = 1099511628211
+)
+
+// hashes 计算 data 的两个 64 位哈希值，供双重哈希生成 k 个探测位置：g_i = h1 + i*h2
+// 使用 FNV-1a 而不是 hash/maphash，保证哈希值跨进程稳定，序列化后的过滤器可以在其他进程中加载
+func hashes(data []byte) (h1, h2 uint64) {
+	h := uint64(offset64)
+	for _, c := range data {
+		h ^= uint64(c)
+		h *= prime64
+	}
+	return h, mix(h) | 1 // h2 为奇数，保证探测序列不会退化为同一位置
+}
+
+// hashString 与 hashes 相同，但直接处理字符串，避免 []byte(s) 转换的内存分配
+func hashString(s string) (h1, h2 uint64) {
+	h := uint64(offset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	return h, mix(h) | 1
+}
+
+// mix 是 splitmix64 的终结函数，将 h 的各位充分混合得到第二个独立的哈希值
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xBF58476D1CE4E5B9
+	h ^= h >> 27
+	h *= 0x94D049BB133111EB
+	h ^= h >> 31
+	return h
+}
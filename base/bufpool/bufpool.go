@@ -0,0 +1,87 @@
+// Package bufpool 提供按 2 的幂分级的 []byte 缓冲池。
+//
+// 所有大小的缓冲区共用一个 sync.Pool 时，偶尔出现的大缓冲区会被归还并长期滞留，
+// 之后每次 Get 取到的都可能是它，使内存占用远超实际需要。
+// bufpool 按容量把缓冲区放入对应的级别，Get(n) 只从能容纳 n 字节的最小级别中取，超过上限的缓冲区不会被保留。
+package bufpool
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// Pool 是按 2 的幂分级的缓冲池，零值不可用，应使用 New 创建
+//
+// 同一个 Pool 可以被多个 goroutine 并发使用。
+type Pool struct {
+	minShift, maxShift int
+	classes            []sync.Pool // classes[i] 中的缓冲区容量不小于 1<<(minShift+i)
+	holders            sync.Pool   // 空闲的 *[]byte，避免每次 Put 把切片装箱为 any 产生分配
+}
+
+// New 创建最小级别为 minSize、最大级别为 maxSize 字节的缓冲池，两者均向上取整为 2 的幂
+// minSize 不为正数或大于 maxSize 时 panic
+func New(minSize, maxSize int) *Pool {
+	if minSize <= 0 || minSize > maxSize {
+		panic("bufpool: 非法的级别范围")
+	}
+	p := &Pool{
+		minShift: bits.Len(bit.NextPowerOfTwo(uint(minSize)) - 1),
+		maxShift: bits.Len(bit.NextPowerOfTwo(uint(maxSize)) - 1),
+	}
+	p.classes = make([]sync.Pool, p.maxShift-p.minShift+1)
+	return p
+}
+
+// MaxSize 返回最大级别的大小，更大的缓冲区不经过缓冲池
+func (p *Pool) MaxSize() int {
+	return 1 << p.maxShift
+}
+
+// Get 返回长度为 n 的缓冲区，容量为不小于 n 的级别大小，内容未清零
+// n 超过 MaxSize 时直接分配
+func (p *Pool) Get(n int) []byte {
+	if n > p.MaxSize() {
+		return make([]byte, n)
+	}
+	size := max(bit.NextPowerOfTwo(uint(n)), 1<<p.minShift)
+	i := bits.Len(size) - 1 - p.minShift
+	if h, ok := p.classes[i].Get().(*[]byte); ok {
+		b := (*h)[:n]
+		*h = nil
+		p.holders.Put(h)
+		return b
+	}
+	return make([]byte, n, size)
+}
+
+// Put 将 b 归还到容量所对应的级别，归还后调用方不应再使用 b
+// 容量小于最小级别或大于最大级别的缓冲区被丢弃；容量不是 2 的幂时放入不超过容量的最大级别
+func (p *Pool) Put(b []byte) {
+	c := cap(b)
+	if c < 1<<p.minShift || c > p.MaxSize() {
+		return
+	}
+	i := bits.Len(uint(c)) - 1 - p.minShift
+	h, _ := p.holders.Get().(*[]byte)
+	if h == nil {
+		h = new([]byte)
+	}
+	*h = b[:0]
+	p.classes[i].Put(h)
+}
+
+// defaultPool 是包级函数使用的缓冲池，级别从 64B 到 1MiB
+var defaultPool = New(64, 1<<20)
+
+// Get 从默认缓冲池取出长度为 n 的缓冲区
+func Get(n int) []byte {
+	return defaultPool.Get(n)
+}
+
+// Put 将 b 归还默认缓冲池
+func Put(b []byte) {
+	defaultPool.Put(b)
+}
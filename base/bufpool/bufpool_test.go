@@ -0,0 +1,108 @@
+package bufpool_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/bufpool"
+)
+
+// TestGetCap 测试 Get 返回的长度与容量
+func TestGetCap(t *testing.T) {
+	p := bufpool.New(64, 4096)
+	testCases := []struct {
+		n       int
+		wantCap int
+	}{
+		{0, 64},
+		{1, 64},
+		{64, 64},
+		{65, 128},
+		{1000, 1024},
+		{4096, 4096},
+		{4097, 4097}, // 超过最大级别，直接分配
+	}
+	for _, tc := range testCases {
+		b := p.Get(tc.n)
+		if len(b) != tc.n || cap(b) != tc.wantCap {
+			t.Errorf("Get(%d) 的长度与容量为 %d、%d，预期 %d、%d", tc.n, len(b), cap(b), tc.n, tc.wantCap)
+		}
+	}
+	if p.MaxSize() != 4096 {
+		t.Errorf("MaxSize() = %d", p.MaxSize())
+	}
+	if got := bufpool.New(100, 3000).MaxSize(); got != 4096 {
+		t.Errorf("级别未向上取整为 2 的幂: MaxSize() = %d", got)
+	}
+}
+
+// TestPutClass 测试缓冲区归还到正确的级别，超出范围的缓冲区被丢弃
+func TestPutClass(t *testing.T) {
+	// 竞态检测器会随机丢弃 sync.Pool 中的对象，此时无法断言复用
+	if benchkit.RaceEnabled {
+		t.Skip("竞态检测下 sync.Pool 不保证复用")
+	}
+	p := bufpool.New(64, 4096)
+
+	b := p.Get(100)
+	p.Put(b)
+	if got := p.Get(120); &got[0] != &b[0] {
+		t.Errorf("未复用同一级别的缓冲区")
+	}
+
+	// 容量 200 不是 2 的幂，归入 128 级别，可以满足 Get(100)
+	odd := make([]byte, 0, 200)
+	p.Put(odd)
+	if got := p.Get(200); cap(got) != 256 {
+		t.Errorf("Get(200) 的容量为 %d，不应取到 128 级别的缓冲区", cap(got))
+	}
+	if got := p.Get(100); cap(got) != 200 {
+		t.Errorf("Get(100) 的容量为 %d，预期复用容量 200 的缓冲区", cap(got))
+	}
+
+	// 超过最大级别的缓冲区不被保留
+	p.Put(make([]byte, 8192))
+	if got := p.Get(4096); cap(got) != 4096 {
+		t.Errorf("Get(4096) 的容量为 %d，超大缓冲区不应被保留", cap(got))
+	}
+}
+
+// TestAllocs 测试稳定状态下 Get 与 Put 不分配内存
+func TestAllocs(t *testing.T) {
+	p := bufpool.New(64, 4096)
+	p.Put(p.Get(512))
+	benchkit.AssertAllocs(t, 0, func() {
+		b := p.Get(512)
+		b[0] = 1
+		p.Put(b)
+	})
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("minSize 大于 maxSize 时应 panic")
+		}
+	}()
+	bufpool.New(4096, 64)
+}
+
+// BenchmarkBufpool 对比直接分配与从缓冲池取出不同大小的缓冲区
+func BenchmarkBufpool(b *testing.B) {
+	sizes := []int{100, 3000, 700, 60000, 20}
+	b.Run("make", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := make([]byte, sizes[i%len(sizes)])
+			benchkit.SinkBytes = buf
+		}
+	})
+	b.Run("bufpool", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := bufpool.Get(sizes[i%len(sizes)])
+			benchkit.SinkBytes = buf
+			bufpool.Put(buf)
+		}
+	})
+}
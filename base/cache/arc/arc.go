@@ -0,0 +1,182 @@
+// Package arc 实现自适应替换缓存（Adaptive Replacement Cache，Megiddo 与 Modha，2003）。
+//
+// ARC 把缓存的条目分为两个 LRU 链表：T1 存放只被访问过一次的条目（近期性），
+// T2 存放至少被访问过两次的条目（频率）。此外为两者各维护一个只记录键的幽灵链表 B1、B2，
+// 保存最近从 T1、T2 淘汰的键。未命中的键若出现在 B1 中，说明 T1 太小，就把 T1 的目标大小 p 调大；
+// 出现在 B2 中则调小。这样 ARC 无需调参即可在偏近期与偏频率的负载之间自动平衡，
+// 且一次扫描只会冲刷 T1，不会挤出 T2 中的热点数据。
+package arc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/internal/list"
+)
+
+var _ cache.Cache[int, int] = (*Cache[int, int])(nil)
+
+// segment 标识条目所在的链表
+type segment uint8
+
+const (
+	t1 segment = iota
+	t2
+	b1
+	b2
+)
+
+// entry 是链表元素中保存的条目，位于幽灵链表时 val 为零值
+type entry[K comparable, V any] struct {
+	key K
+	val V
+	seg segment
+}
+
+// Cache 是 ARC 缓存，零值不可用，应使用 New 创建
+type Cache[K comparable, V any] struct {
+	mu    sync.Mutex
+	c     int // 容量
+	p     int // T1 的目标大小
+	lists [4]list.List[entry[K, V]]
+	items map[K]*list.Element[entry[K, V]]
+
+	loads cache.LoadGroup[K, V] // GetOrLoad 的并发合并与负缓存
+}
+
+// New 创建容量为 capacity 的 ARC 缓存，capacity 不为正数时 panic
+// 幽灵链表最多额外记录 capacity 个键
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("arc: 容量必须大于 0")
+	}
+	return &Cache[K, V]{c: capacity, items: make(map[K]*list.Element[entry[K, V]], 2*capacity)}
+}
+
+// Get 返回键 k 对应的值，命中的条目移入 T2
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[k]
+	if !ok || e.Value.seg >= b1 {
+		var zero V
+		return zero, false
+	}
+	c.move(e, t2)
+	return e.Value.val, true
+}
+
+// GetOrLoad 返回键 k 对应的值，未命中时调用 loader 加载并写入缓存
+// 对同一个键并发的未命中只调用一次 loader，见 cache.LoadGroup
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, k K, loader cache.Loader[K, V]) (V, error) {
+	return c.loads.GetOrLoad(ctx, c, k, loader)
+}
+
+// WithNegativeTTL 开启负缓存：GetOrLoad 中 loader 返回 cache.ErrNotFound 的键在 ttl 内直接返回 cache.ErrNotFound，
+// 不再调用 loader；最多记录与容量相同个数的键。应在使用缓存前调用
+func (c *Cache[K, V]) WithNegativeTTL(ttl time.Duration) *Cache[K, V] {
+	c.loads.SetNegative(cache.NewNegative[K](ttl, c.c))
+	return c
+}
+
+// Set 设置键 k 对应的值，命中幽灵链表时调整 T1 的目标大小
+func (c *Cache[K, V]) Set(k K, v V) {
+	c.loads.Forget(k)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[k]
+	if ok {
+		switch e.Value.seg {
+		case b1:
+			// T1 的条目淘汰得太早，增大 T1 的目标大小
+			c.p = min(c.c, c.p+max(c.len(b2)/c.len(b1), 1))
+			c.replace(false)
+		case b2:
+			c.p = max(0, c.p-max(c.len(b1)/c.len(b2), 1))
+			c.replace(true)
+		}
+		e.Value.val = v
+		c.move(e, t2)
+		return
+	}
+
+	if l1 := c.len(t1) + c.len(b1); l1 >= c.c {
+		if c.len(t1) < c.c {
+			c.drop(c.lists[b1].Back())
+			c.replace(false)
+		} else {
+			c.drop(c.lists[t1].Back())
+		}
+	} else if total := l1 + c.len(t2) + c.len(b2); total >= c.c {
+		if total >= 2*c.c {
+			c.drop(c.lists[b2].Back())
+		}
+		c.replace(false)
+	}
+	c.items[k] = c.lists[t1].PushFront(entry[K, V]{key: k, val: v, seg: t1})
+}
+
+// Delete 删除键 k，同时清除其在幽灵链表中的记录
+func (c *Cache[K, V]) Delete(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[k]; ok {
+		c.drop(e)
+	}
+}
+
+// Len 返回缓存中的条目数，不含幽灵链表中的键
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.len(t1) + c.len(t2)
+}
+
+// Cap 返回容量
+func (c *Cache[K, V]) Cap() int {
+	return c.c
+}
+
+// P 返回 T1 的当前目标大小，用于观察 ARC 在近期性与频率之间的取舍
+func (c *Cache[K, V]) P() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.p
+}
+
+func (c *Cache[K, V]) len(seg segment) int {
+	return c.lists[seg].Len()
+}
+
+// move 将元素 e 移到链表 seg 的表头，移入幽灵链表时清除值
+func (c *Cache[K, V]) move(e *list.Element[entry[K, V]], seg segment) {
+	c.lists[e.Value.seg].Remove(e)
+	e.Value.seg = seg
+	if seg >= b1 {
+		var zero V
+		e.Value.val = zero
+	}
+	c.lists[seg].PushElementFront(e)
+}
+
+// drop 彻底移除元素 e
+func (c *Cache[K, V]) drop(e *list.Element[entry[K, V]]) {
+	c.lists[e.Value.seg].Remove(e)
+	delete(c.items, e.Value.key)
+}
+
+// replace 在缓存已满时从 T1 或 T2 淘汰一个条目到对应的幽灵链表
+// T1 超过目标大小时淘汰 T1，否则淘汰 T2；inB2 表示当前未命中的键位于 B2，此时 T1 恰好等于目标大小也淘汰 T1
+func (c *Cache[K, V]) replace(inB2 bool) {
+	n1 := c.len(t1)
+	if n1+c.len(t2) < c.c {
+		return // 删除过条目，仍有空位
+	}
+	if n1 > 0 && (n1 > c.p || (inB2 && n1 == c.p) || c.len(t2) == 0) {
+		c.move(c.lists[t1].Back(), b1)
+	} else {
+		c.move(c.lists[t2].Back(), b2)
+	}
+}
@@ -0,0 +1,139 @@
+package arc_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/arc"
+	"github.com/moweilong/efficient-go/base/cache/internal/trace"
+	"github.com/moweilong/efficient-go/base/cache/lru"
+)
+
+// TestCache 测试基本读写、更新与删除
+func TestCache(t *testing.T) {
+	c := arc.New[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v", v, ok)
+	}
+	c.Set("c", 3) // 淘汰只访问过一次的 b
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("b 应被淘汰")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("被访问过两次的 a 应保留，Get(a) = %d, %v", v, ok)
+	}
+	c.Set("a", 10)
+	if v, _ := c.Get("a"); v != 10 {
+		t.Errorf("更新后 Get(a) = %d", v)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok || c.Len() != 1 {
+		t.Errorf("Delete 后 Len() = %d", c.Len())
+	}
+	if c.Cap() != 2 {
+		t.Errorf("Cap() = %d", c.Cap())
+	}
+}
+
+// TestAdapt 测试命中幽灵链表时 T1 目标大小的调整方向
+func TestAdapt(t *testing.T) {
+	c := arc.New[int, int](4)
+	for k := range 4 {
+		c.Set(k, k)
+	}
+	c.Get(3)    // T2 = {3}，T1 = {2, 1, 0}
+	c.Set(4, 4) // 0 从 T1 淘汰到 B1
+	c.Set(0, 0) // 命中 B1，增大 p
+	if p := c.P(); p != 1 {
+		t.Fatalf("命中 B1 后 P() = %d，预期 1", p)
+	}
+	c.Set(5, 5)
+	c.Set(6, 6) // T1 = {6, 5}，B1 = {4, 2}
+	c.Set(2, 2) // 命中 B1，p 增大到 2，T1 不再超过目标，改为从 T2 淘汰 3 到 B2
+	if p := c.P(); p != 2 {
+		t.Fatalf("再次命中 B1 后 P() = %d，预期 2", p)
+	}
+	c.Set(3, 3) // 命中 B2，减小 p
+	if p := c.P(); p != 1 {
+		t.Errorf("命中 B2 后 P() = %d，预期 1", p)
+	}
+}
+
+// TestCapacity 测试缓存条目数不超过容量，且读到的值正确
+func TestCapacity(t *testing.T) {
+	for _, capacity := range []int{1, 2, 10, 250} {
+		c := arc.New[uint64, uint64](capacity)
+		keys := trace.Zipf(int64(capacity), 1.1, 10*uint64(capacity)+10, 20*capacity+100)
+		for i, k := range keys {
+			if v, ok := c.Get(k); ok && v != k {
+				t.Fatalf("cap=%d: Get(%d) = %d", capacity, k, v)
+			}
+			c.Set(k, k)
+			if i%7 == 0 {
+				c.Delete(keys[i/2])
+			}
+			if n := c.Len(); n > capacity {
+				t.Fatalf("cap=%d: Len() = %d 超过容量", capacity, n)
+			}
+		}
+	}
+}
+
+// workloads 是 ARC 优于 LRU 的典型访问序列
+var workloads = []struct {
+	name     string
+	capacity int
+	keys     []uint64
+}{
+	// 频率偏斜的负载：热点键反复出现，LRU 只看最近一次访问，会被偶发的冷键挤掉
+	{"zipf-1.01", 1000, trace.Zipf(1, 1.01, 100000, 200000)},
+	// 热点中穿插扫描：扫描只冲刷 T1，T2 中的热点保持命中
+	{"zipf+scan", 1000, trace.Interleave(trace.Zipf(3, 1.1, 100000, 100000), 1000, trace.Loop(1_000_000, 100000, 100000), 1000)},
+}
+
+// TestHitRatio 在各负载上对比 ARC 与 LRU 的命中率
+func TestHitRatio(t *testing.T) {
+	for _, w := range workloads {
+		t.Run(w.name, func(t *testing.T) {
+			got := trace.HitRatio(arc.New[uint64, uint64](w.capacity), w.keys)
+			base := trace.HitRatio(lru.New[uint64, uint64](w.capacity), w.keys)
+			t.Logf("ARC %.4f，LRU %.4f", got, base)
+			if got <= base {
+				t.Errorf("ARC 命中率 %.4f 不高于 LRU 的 %.4f", got, base)
+			}
+		})
+	}
+}
+
+// BenchmarkHitRatio 报告各负载下 ARC 与 LRU 的命中率与每次访问的开销
+func BenchmarkHitRatio(b *testing.B) {
+	impls := []struct {
+		name string
+		new  func(int) cache.Cache[uint64, uint64]
+	}{
+		{"LRU", func(n int) cache.Cache[uint64, uint64] { return lru.New[uint64, uint64](n) }},
+		{"ARC", func(n int) cache.Cache[uint64, uint64] { return arc.New[uint64, uint64](n) }},
+	}
+	for _, w := range workloads {
+		for _, impl := range impls {
+			b.Run(fmt.Sprintf("%s/%s", w.name, impl.name), func(b *testing.B) {
+				c := impl.new(w.capacity)
+				hits, misses := 0, 0
+				for i := 0; i < b.N; i++ {
+					k := w.keys[i%len(w.keys)]
+					if _, ok := c.Get(k); ok {
+						hits++
+					} else {
+						misses++
+						c.Set(k, k)
+					}
+				}
+				benchkit.ReportHitRatio(b, hits, misses)
+			})
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Package cache 定义各缓存实现共用的接口，具体的淘汰算法位于子包中：
+//
+//   - lru：最近最少使用，作为其他算法的对照基准
+//   - tinylfu：以 count-min sketch 估计访问频率、决定是否准入的 W-TinyLFU
+//   - arc：在近期性与频率之间自适应平衡的 ARC
+//   - clock：以 bitset.BitSet 保存访问位的 CLOCK（二次机会），内存占用低于 LRU
+//   - tiered：由进程内缓存与 Redis 等远程缓存组成的二级缓存
+//   - sharded：把任意实现按键划分到多个分片，降低锁争用
+//
+// 所有实现都可以被多个 goroutine 并发使用，并提供 GetOrLoad：对同一个键并发的未命中只调用一次加载函数，
+// 避免热点键失效时大量请求同时穿透到数据源；开启负缓存（WithNegativeTTL）后，
+// 数据源中不存在的键在一段时间内也不会反复查询。
+package cache
+
+import "errors"
+
+// ErrNotFound 表示键在缓存及其数据源中都不存在
+var ErrNotFound = errors.New("cache: 键不存在")
+
+// Cache 是容量有限的键值缓存，容量满时按各实现的策略淘汰条目
+type Cache[K comparable, V any] interface {
+	// Get 返回键 k 对应的值，并记录一次访问
+	Get(k K) (V, bool)
+	// Set 设置键 k 对应的值，必要时淘汰其他条目；实现可能拒绝准入新键
+	Set(k K, v V)
+	// Delete 删除键 k
+	Delete(k K)
+	// Len 返回当前条目数
+	Len() int
+}
@@ -0,0 +1,164 @@
+// Package clock 实现 CLOCK（二次机会）淘汰的缓存。
+//
+// 条目存放在固定大小的数组中，每个槽位对应 bitset.BitSet 中的一个访问位。命中只需置位，
+// 不像 LRU 那样移动链表节点；淘汰时指针（hand）沿数组环形前进，遇到访问位为 1 的槽位清零后跳过，
+// 给它第二次机会，遇到为 0 的槽位则淘汰。访问位按 64 位一组扫描，整字为 1 时一次跳过 64 个槽位。
+//
+// CLOCK 的命中率接近 LRU，但每个条目只额外占用 1 位而不是两个指针，且数组布局对 GC 更友好。
+package clock
+
+import (
+	"context"
+	"math/bits"
+	"sync"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+	"github.com/moweilong/efficient-go/base/cache"
+)
+
+var _ cache.Cache[int, int] = (*Cache[int, int])(nil)
+
+// Cache 是 CLOCK 缓存，零值不可用，应使用 New 创建
+type Cache[K comparable, V any] struct {
+	mu     sync.Mutex
+	keys   []K
+	vals   []V
+	index  map[K]int      // 键所在的槽位
+	ref    *bitset.BitSet // 访问位
+	hand   int            // 下一个检查的槽位
+	free   []int          // Delete 留下的空槽位
+	filled int            // [filled, cap) 为从未使用过的槽位
+
+	loads cache.LoadGroup[K, V] // GetOrLoad 的并发合并与负缓存
+}
+
+// New 创建容量为 capacity 的 CLOCK 缓存，capacity 不为正数时 panic
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("clock: 容量必须大于 0")
+	}
+	return &Cache[K, V]{
+		keys:  make([]K, capacity),
+		vals:  make([]V, capacity),
+		index: make(map[K]int, capacity),
+		ref:   bitset.New(capacity),
+	}
+}
+
+// Get 返回键 k 对应的值，并置位其访问位
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i, ok := c.index[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.ref.Set(i)
+	return c.vals[i], true
+}
+
+// GetOrLoad 返回键 k 对应的值，未命中时调用 loader 加载并写入缓存
+// 对同一个键并发的未命中只调用一次 loader，见 cache.LoadGroup
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, k K, loader cache.Loader[K, V]) (V, error) {
+	return c.loads.GetOrLoad(ctx, c, k, loader)
+}
+
+// WithNegativeTTL 开启负缓存：GetOrLoad 中 loader 返回 cache.ErrNotFound 的键在 ttl 内直接返回 cache.ErrNotFound，
+// 不再调用 loader；最多记录与容量相同个数的键。应在使用缓存前调用
+func (c *Cache[K, V]) WithNegativeTTL(ttl time.Duration) *Cache[K, V] {
+	c.loads.SetNegative(cache.NewNegative[K](ttl, len(c.keys)))
+	return c
+}
+
+// Set 设置键 k 对应的值，缓存已满时淘汰一个条目
+// 新条目的访问位为 0，在被访问之前是下一轮扫描中最先被淘汰的，只访问一次的键不会挤出热点数据
+func (c *Cache[K, V]) Set(k K, v V) {
+	c.loads.Forget(k)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i, ok := c.index[k]; ok {
+		c.vals[i] = v
+		c.ref.Set(i)
+		return
+	}
+	i := c.slot()
+	c.keys[i], c.vals[i] = k, v
+	c.index[k] = i
+}
+
+// Delete 删除键 k
+func (c *Cache[K, V]) Delete(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i, ok := c.index[k]
+	if !ok {
+		return
+	}
+	delete(c.index, k)
+	c.clearSlot(i)
+	c.free = append(c.free, i)
+}
+
+// Len 返回当前条目数
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.index)
+}
+
+// Cap 返回容量
+func (c *Cache[K, V]) Cap() int {
+	return len(c.keys)
+}
+
+// slot 返回一个可用的空槽位，没有时淘汰一个条目
+func (c *Cache[K, V]) slot() int {
+	if n := len(c.free); n > 0 {
+		i := c.free[n-1]
+		c.free = c.free[:n-1]
+		return i
+	}
+	if c.filled < len(c.keys) {
+		c.filled++
+		return c.filled - 1
+	}
+	i := c.sweep()
+	delete(c.index, c.keys[i])
+	c.clearSlot(i)
+	return i
+}
+
+// clearSlot 清除槽位 i 的内容，使其不再引用键和值
+func (c *Cache[K, V]) clearSlot(i int) {
+	var (
+		zk K
+		zv V
+	)
+	c.keys[i], c.vals[i] = zk, zv
+	c.ref.Clear(i)
+}
+
+// sweep 从 hand 开始找到第一个访问位为 0 的槽位并返回，途经的访问位全部清零
+// 最坏情况下扫描一整圈，此时所有访问位都已被清零，第二圈必然找到
+func (c *Cache[K, V]) sweep() int {
+	words := c.ref.Words()
+	n := len(c.keys)
+	for {
+		w, off := c.hand>>6, uint(c.hand&63)
+		if zeros := ^words[w] >> off; zeros != 0 {
+			tz := uint(bits.TrailingZeros64(zeros))
+			if i := c.hand + int(tz); i < n {
+				words[w] &^= (1<<tz - 1) << off
+				c.hand = (i + 1) % n
+				return i
+			}
+		}
+		// 本字中 hand 之后的槽位都被访问过（或超出容量），清零后跳到下一个字
+		words[w] &= 1<<off - 1
+		if c.hand = (w + 1) << 6; c.hand >= n {
+			c.hand = 0
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package clock_test
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/clock"
+	"github.com/moweilong/efficient-go/base/cache/internal/trace"
+	"github.com/moweilong/efficient-go/base/cache/lru"
+	"github.com/moweilong/efficient-go/base/memwatch"
+)
+
+// TestCache 测试基本读写以及二次机会的淘汰顺序
+func TestCache(t *testing.T) {
+	c := clock.New[string, int](3)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Get("a")    // a 获得第二次机会
+	c.Set("d", 4) // 淘汰访问位为 0 的 b
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("b 应被淘汰")
+	}
+	for k, want := range map[string]int{"a": 1, "c": 3, "d": 4} {
+		if v, ok := c.Get(k); !ok || v != want {
+			t.Errorf("Get(%q) = %d, %v，预期 %d", k, v, ok, want)
+		}
+	}
+
+	c.Delete("c")
+	c.Set("e", 5) // 复用 c 留下的空槽位，不淘汰
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d，预期 3", c.Len())
+	}
+	for _, k := range []string{"a", "d", "e"} {
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("%q 不应被淘汰", k)
+		}
+	}
+	if v, err := c.GetOrLoad(context.Background(), "f", func(context.Context, string) (int, error) { return 6, nil }); v != 6 || err != nil {
+		t.Errorf("GetOrLoad(f) = %d, %v", v, err)
+	}
+	if c.Cap() != 3 || c.Len() != 3 {
+		t.Errorf("Cap() = %d，Len() = %d", c.Cap(), c.Len())
+	}
+}
+
+// naive 是逐位扫描的 CLOCK 参考实现，用于校验按字扫描的结果
+type naive struct {
+	keys  []int
+	ref   []bool
+	index map[int]int
+	hand  int
+	free  []int
+}
+
+func (n *naive) get(k int) bool {
+	i, ok := n.index[k]
+	if ok {
+		n.ref[i] = true
+	}
+	return ok
+}
+
+func (n *naive) set(k int) {
+	if i, ok := n.index[k]; ok {
+		n.ref[i] = true
+		return
+	}
+	var i int
+	switch {
+	case len(n.free) > 0:
+		i, n.free = n.free[len(n.free)-1], n.free[:len(n.free)-1]
+	case len(n.index) < len(n.keys):
+		i = len(n.index)
+	default:
+		for n.ref[n.hand] {
+			n.ref[n.hand] = false
+			n.hand = (n.hand + 1) % len(n.keys)
+		}
+		i = n.hand
+		n.hand = (n.hand + 1) % len(n.keys)
+		delete(n.index, n.keys[i])
+	}
+	n.keys[i], n.ref[i] = k, false
+	n.index[k] = i
+}
+
+func (n *naive) del(k int) {
+	if i, ok := n.index[k]; ok {
+		delete(n.index, k)
+		n.ref[i] = false
+		n.free = append(n.free, i)
+	}
+}
+
+// TestSweep 在随机操作序列上与逐位扫描的参考实现对比，覆盖容量不是 64 的倍数的情况
+func TestSweep(t *testing.T) {
+	for _, capacity := range []int{1, 7, 64, 130} {
+		r := rand.New(rand.NewSource(int64(capacity)))
+		c := clock.New[int, int](capacity)
+		ref := &naive{keys: make([]int, capacity), ref: make([]bool, capacity), index: make(map[int]int)}
+		for range 20000 {
+			k := r.Intn(3 * capacity)
+			switch op := r.Intn(10); {
+			case op < 5:
+				_, got := c.Get(k)
+				if want := ref.get(k); got != want {
+					t.Fatalf("cap=%d: Get(%d) = %v，参考实现为 %v", capacity, k, got, want)
+				}
+			case op < 9:
+				c.Set(k, k)
+				ref.set(k)
+			default:
+				c.Delete(k)
+				ref.del(k)
+			}
+		}
+		if c.Len() != len(ref.index) {
+			t.Errorf("cap=%d: Len() = %d，参考实现为 %d", capacity, c.Len(), len(ref.index))
+		}
+	}
+}
+
+// TestHitRatio 测试 CLOCK 的命中率与 LRU 相近
+func TestHitRatio(t *testing.T) {
+	keys := trace.Zipf(1, 1.1, 100000, 200000)
+	got := trace.HitRatio(clock.New[uint64, uint64](1000), keys)
+	base := trace.HitRatio(lru.New[uint64, uint64](1000), keys)
+	t.Logf("CLOCK %.4f，LRU %.4f", got, base)
+	if got < base-0.02 {
+		t.Errorf("CLOCK 命中率 %.4f 明显低于 LRU 的 %.4f", got, base)
+	}
+}
+
+// retained 返回 build 构建的缓存在 GC 后仍占用的堆内存
+func retained(build func() cache.Cache[int, int]) int64 {
+	runtime.GC()
+	before := memwatch.Take()
+	c := build()
+	runtime.GC()
+	growth := memwatch.Take().Sub(before).HeapGrowth
+	runtime.KeepAlive(c)
+	return growth
+}
+
+// fill 返回填满 n 个条目的缓存构造函数
+func fill(newCache func(int) cache.Cache[int, int], n int) func() cache.Cache[int, int] {
+	return func() cache.Cache[int, int] {
+		c := newCache(n)
+		for k := range n {
+			c.Set(k, k)
+		}
+		return c
+	}
+}
+
+var (
+	newClock = func(n int) cache.Cache[int, int] { return clock.New[int, int](n) }
+	newLRU   = func(n int) cache.Cache[int, int] { return lru.New[int, int](n) }
+)
+
+// TestMemory 测试 CLOCK 每个条目占用的内存少于 LRU
+func TestMemory(t *testing.T) {
+	if benchkit.RaceEnabled {
+		t.Skip("竞态检测会改变内存占用")
+	}
+	const n = 100000
+	c, l := retained(fill(newClock, n)), retained(fill(newLRU, n))
+	t.Logf("CLOCK %.1f B/entry，LRU %.1f B/entry", float64(c)/n, float64(l)/n)
+	if c >= l {
+		t.Errorf("CLOCK 占用 %d 字节，不少于 LRU 的 %d 字节", c, l)
+	}
+}
+
+// BenchmarkMemory 报告 CLOCK 与 LRU 每个条目占用的内存（B/entry）以及填满缓存的开销
+func BenchmarkMemory(b *testing.B) {
+	const n = 100000
+	for _, impl := range []struct {
+		name string
+		new  func(int) cache.Cache[int, int]
+	}{
+		{"CLOCK", newClock},
+		{"LRU", newLRU},
+	} {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var growth int64
+			for i := 0; i < b.N; i++ {
+				growth = retained(fill(impl.new, n))
+			}
+			b.ReportMetric(float64(growth)/n, "B/entry")
+		})
+	}
+}
+
+// BenchmarkGet 对比命中路径的开销：CLOCK 只置位，LRU 需要移动链表节点
+func BenchmarkGet(b *testing.B) {
+	const n = 1 << 14
+	for _, impl := range []struct {
+		name string
+		new  func(int) cache.Cache[int, int]
+	}{
+		{"CLOCK", newClock},
+		{"LRU", newLRU},
+	} {
+		b.Run(impl.name, func(b *testing.B) {
+			c := fill(impl.new, n)()
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkInt, benchkit.SinkBool = c.Get(i & (n - 1))
+			}
+		})
+	}
+}
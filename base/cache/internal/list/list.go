@@ -0,0 +1,111 @@
+// Package list 是泛型的双向循环链表，供各缓存实现维护访问顺序。
+//
+// 与 container/list 相比，元素的值不经过 any 装箱，且元素可以在链表之间移动而不重新分配。
+package list
+
+// Element 是链表中的元素
+type Element[T any] struct {
+	next, prev *Element[T]
+	list       *List[T]
+	Value      T
+}
+
+// Next 返回下一个元素，没有时返回 nil
+func (e *Element[T]) Next() *Element[T] {
+	if n := e.next; e.list != nil && n != &e.list.root {
+		return n
+	}
+	return nil
+}
+
+// Prev 返回上一个元素，没有时返回 nil
+func (e *Element[T]) Prev() *Element[T] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// List 是双向循环链表，零值为空链表
+type List[T any] struct {
+	root Element[T] // 哨兵，root.next 为表头，root.prev 为表尾
+	len  int
+}
+
+// lazyInit 初始化零值链表的哨兵
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+// Len 返回元素个数
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Front 返回表头元素，链表为空时返回 nil
+func (l *List[T]) Front() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back 返回表尾元素，链表为空时返回 nil
+func (l *List[T]) Back() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PushFront 在表头插入值为 v 的新元素
+func (l *List[T]) PushFront(v T) *Element[T] {
+	e := &Element[T]{Value: v}
+	l.PushElementFront(e)
+	return e
+}
+
+// PushElementFront 将不属于任何链表的元素 e 插入表头，用于在链表之间移动元素
+func (l *List[T]) PushElementFront(e *Element[T]) {
+	if e.list != nil {
+		panic("list: 元素已属于某个链表")
+	}
+	l.lazyInit()
+	l.insertAfter(e, &l.root)
+}
+
+// MoveToFront 将 l 中的元素 e 移到表头
+func (l *List[T]) MoveToFront(e *Element[T]) {
+	if e.list != l || l.root.next == e {
+		return
+	}
+	l.unlink(e)
+	l.insertAfter(e, &l.root)
+}
+
+// Remove 从 l 中移除元素 e，之后 e 可以插入其他链表
+func (l *List[T]) Remove(e *Element[T]) {
+	if e.list != l {
+		return
+	}
+	l.unlink(e)
+	e.next, e.prev, e.list = nil, nil, nil
+}
+
+func (l *List[T]) insertAfter(e, at *Element[T]) {
+	e.prev = at
+	e.next = at.next
+	at.next.prev = e
+	at.next = e
+	e.list = l
+	l.len++
+}
+
+func (l *List[T]) unlink(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	l.len--
+}
@@ -0,0 +1,68 @@
+package list_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/cache/internal/list"
+)
+
+// values 从表头到表尾返回 l 中的值，同时从表尾反向遍历校验链接
+func values(t *testing.T, l *list.List[int]) []int {
+	t.Helper()
+	var fwd, back []int
+	for e := l.Front(); e != nil; e = e.Next() {
+		fwd = append(fwd, e.Value)
+	}
+	for e := l.Back(); e != nil; e = e.Prev() {
+		back = append(back, e.Value)
+	}
+	slices.Reverse(back)
+	if !slices.Equal(fwd, back) || len(fwd) != l.Len() {
+		t.Fatalf("链表不一致：正向 %v，反向 %v，Len %d", fwd, back, l.Len())
+	}
+	return fwd
+}
+
+// TestList 测试插入、移动、移除以及元素在链表之间移动
+func TestList(t *testing.T) {
+	var a, b list.List[int]
+	if a.Front() != nil || a.Back() != nil || a.Len() != 0 {
+		t.Fatalf("零值链表应为空")
+	}
+	e1 := a.PushFront(1)
+	e2 := a.PushFront(2)
+	e3 := a.PushFront(3)
+	if got := values(t, &a); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("PushFront 后 = %v", got)
+	}
+	a.MoveToFront(e1)
+	if got := values(t, &a); !slices.Equal(got, []int{1, 3, 2}) {
+		t.Errorf("MoveToFront 后 = %v", got)
+	}
+	a.MoveToFront(e1)
+	b.MoveToFront(e2) // 不属于 b 时忽略
+	if got := values(t, &a); !slices.Equal(got, []int{1, 3, 2}) {
+		t.Errorf("重复 MoveToFront 后 = %v", got)
+	}
+
+	a.Remove(e3)
+	b.PushElementFront(e3)
+	if got := values(t, &a); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("移出后 a = %v", got)
+	}
+	if got := values(t, &b); !slices.Equal(got, []int{3}) {
+		t.Errorf("移入后 b = %v", got)
+	}
+	a.Remove(e3) // 不属于 a 时忽略
+	if a.Len() != 2 {
+		t.Errorf("Len() = %d", a.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("插入已属于链表的元素应 panic")
+		}
+	}()
+	a.PushElementFront(e1)
+}
@@ -0,0 +1,53 @@
+// Package trace 生成缓存模拟使用的访问序列，并统计缓存在序列上的命中率。
+package trace
+
+import (
+	"math/rand"
+
+	"github.com/moweilong/efficient-go/base/cache"
+)
+
+// Zipf 返回 n 次访问组成的序列，键取自 [0, keys)，第 i 个键被访问的概率正比于 1/(i+1)^s，s 必须大于 1
+// 真实系统中少数热点键占据大部分访问，Zipf 分布是模拟这种负载的常用模型
+func Zipf(seed int64, s float64, keys uint64, n int) []uint64 {
+	z := rand.NewZipf(rand.New(rand.NewSource(seed)), s, 1, keys-1)
+	out := make([]uint64, n)
+	for i := range out {
+		out[i] = z.Uint64()
+	}
+	return out
+}
+
+// Loop 返回反复顺序访问 [start, start+size) 直到凑满 n 次的序列，模拟扫描与循环访问
+func Loop(start, size uint64, n int) []uint64 {
+	out := make([]uint64, n)
+	for i := range out {
+		out[i] = start + uint64(i)%size
+	}
+	return out
+}
+
+// Interleave 每从 a 取 runA 个再从 b 取 runB 个，交替拼接直到两个序列都取完
+func Interleave(a []uint64, runA int, b []uint64, runB int) []uint64 {
+	out := make([]uint64, 0, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		k := min(runA, len(a))
+		out, a = append(out, a[:k]...), a[k:]
+		k = min(runB, len(b))
+		out, b = append(out, b[:k]...), b[k:]
+	}
+	return out
+}
+
+// HitRatio 按序列访问缓存，未命中时写入，返回命中次数占访问次数的比例
+func HitRatio(c cache.Cache[uint64, uint64], keys []uint64) float64 {
+	hits := 0
+	for _, k := range keys {
+		if _, ok := c.Get(k); ok {
+			hits++
+		} else {
+			c.Set(k, k)
+		}
+	}
+	return float64(hits) / float64(len(keys))
+}
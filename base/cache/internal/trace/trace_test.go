@@ -0,0 +1,46 @@
+package trace_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/cache/internal/trace"
+	"github.com/moweilong/efficient-go/base/cache/lru"
+)
+
+// TestZipf 测试 Zipf 序列的范围与偏斜
+func TestZipf(t *testing.T) {
+	keys := trace.Zipf(1, 1.2, 1000, 10000)
+	counts := make([]int, 1000)
+	for _, k := range keys {
+		if k >= 1000 {
+			t.Fatalf("键 %d 超出范围", k)
+		}
+		counts[k]++
+	}
+	if counts[0] <= counts[10] || counts[10] <= counts[500] {
+		t.Errorf("访问次数应随键递减：%d, %d, %d", counts[0], counts[10], counts[500])
+	}
+	if !slices.Equal(keys, trace.Zipf(1, 1.2, 1000, 10000)) {
+		t.Errorf("相同种子应生成相同序列")
+	}
+}
+
+// TestLoopInterleave 测试循环与交替拼接
+func TestLoopInterleave(t *testing.T) {
+	if got := trace.Loop(10, 3, 7); !slices.Equal(got, []uint64{10, 11, 12, 10, 11, 12, 10}) {
+		t.Errorf("Loop() = %v", got)
+	}
+	got := trace.Interleave([]uint64{1, 2, 3}, 2, []uint64{7, 8, 9}, 1)
+	if want := []uint64{1, 2, 7, 3, 8, 9}; !slices.Equal(got, want) {
+		t.Errorf("Interleave() = %v，预期 %v", got, want)
+	}
+}
+
+// TestHitRatio 测试命中率统计
+func TestHitRatio(t *testing.T) {
+	// 第一轮全部未命中，之后全部命中
+	if got := trace.HitRatio(lru.New[uint64, uint64](4), trace.Loop(0, 4, 40)); got != 0.9 {
+		t.Errorf("HitRatio() = %v，预期 0.9", got)
+	}
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"github.com/moweilong/efficient-go/base/singleflight"
+)
+
+// Loader 在缓存未命中时从数据源加载键 k 对应的值，键不存在时应返回包装了 ErrNotFound 的错误
+type Loader[K comparable, V any] func(ctx context.Context, k K) (V, error)
+
+// LoadGroup 是各缓存实现 GetOrLoad 的共用部分：合并对同一个键并发的未命中，并可选地记录不存在的键
+// 零值即可使用，此时不做负缓存。
+type LoadGroup[K comparable, V any] struct {
+	flight   singleflight.Group[K, V]
+	negative *Negative[K]
+}
+
+// SetNegative 设置负缓存，loader 返回 ErrNotFound 的键会被记录，有效期内 GetOrLoad 直接返回 ErrNotFound
+// 应在开始使用前调用，n 为 nil 表示关闭负缓存
+func (g *LoadGroup[K, V]) SetNegative(n *Negative[K]) {
+	g.negative = n
+}
+
+// Forget 删除键 k 的负缓存记录，缓存实现在写入键时调用
+func (g *LoadGroup[K, V]) Forget(k K) {
+	if g.negative != nil {
+		g.negative.Forget(k)
+	}
+}
+
+// GetOrLoad 返回 c 中键 k 对应的值，未命中时调用 loader 加载并写入 c，加载失败时不写入
+// 对同一个键并发的未命中只调用一次 loader，其余调用者等待并共享结果，
+// 此时 loader 收到的是真正执行加载的调用者的 ctx。
+func (g *LoadGroup[K, V]) GetOrLoad(ctx context.Context, c Cache[K, V], k K, loader Loader[K, V]) (V, error) {
+	if v, ok := c.Get(k); ok {
+		return v, nil
+	}
+	if g.negative != nil && g.negative.Has(k) {
+		var zero V
+		return zero, ErrNotFound
+	}
+	v, err, _ := g.flight.Do(k, func() (V, error) {
+		// 上一轮加载可能刚刚结束并写入了缓存
+		if v, ok := c.Get(k); ok {
+			return v, nil
+		}
+		v, err := loader(ctx, k)
+		switch {
+		case err == nil:
+			c.Set(k, v)
+		case g.negative != nil && errors.Is(err, ErrNotFound):
+			g.negative.Add(k)
+		}
+		return v, err
+	})
+	return v, err
+}
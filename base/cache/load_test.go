@@ -0,0 +1,190 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/arc"
+	"github.com/moweilong/efficient-go/base/cache/clock"
+	"github.com/moweilong/efficient-go/base/cache/lru"
+	"github.com/moweilong/efficient-go/base/cache/sharded"
+	"github.com/moweilong/efficient-go/base/cache/tinylfu"
+)
+
+// loadingCache 是提供 GetOrLoad 的缓存实现
+type loadingCache interface {
+	cache.Cache[int, int]
+	GetOrLoad(ctx context.Context, k int, loader cache.Loader[int, int]) (int, error)
+}
+
+var impls = []struct {
+	name     string
+	new      func(capacity int) loadingCache
+	negative func(capacity int, ttl time.Duration) loadingCache // 开启负缓存
+}{
+	{
+		"lru",
+		func(n int) loadingCache { return lru.New[int, int](n) },
+		func(n int, ttl time.Duration) loadingCache { return lru.New[int, int](n).WithNegativeTTL(ttl) },
+	},
+	{
+		"arc",
+		func(n int) loadingCache { return arc.New[int, int](n) },
+		func(n int, ttl time.Duration) loadingCache { return arc.New[int, int](n).WithNegativeTTL(ttl) },
+	},
+	{
+		"tinylfu",
+		func(n int) loadingCache { return tinylfu.New[int, int](n) },
+		func(n int, ttl time.Duration) loadingCache { return tinylfu.New[int, int](n).WithNegativeTTL(ttl) },
+	},
+	{
+		"clock",
+		func(n int) loadingCache { return clock.New[int, int](n) },
+		func(n int, ttl time.Duration) loadingCache { return clock.New[int, int](n).WithNegativeTTL(ttl) },
+	},
+	{
+		"sharded",
+		func(n int) loadingCache { return sharded.Wrap(newLRUShard(n/4), 4) },
+		func(n int, ttl time.Duration) loadingCache {
+			return sharded.Wrap(newLRUShard(n/4), 4).WithNegativeTTL(ttl, n)
+		},
+	},
+}
+
+func newLRUShard(n int) func() cache.Cache[int, int] {
+	return func() cache.Cache[int, int] { return lru.New[int, int](max(n, 1)) }
+}
+
+// TestGetOrLoadConcurrent 测试大量 goroutine 并发读取少量未命中的键时每个键只加载一次
+func TestGetOrLoadConcurrent(t *testing.T) {
+	const keys, goroutines = 16, 1000
+	for _, impl := range impls {
+		t.Run(impl.name, func(t *testing.T) {
+			c := impl.new(1000)
+			var loads [keys]atomic.Int32
+			start := make(chan struct{})
+			loader := func(_ context.Context, k int) (int, error) {
+				loads[k].Add(1)
+				time.Sleep(5 * time.Millisecond) // 加载越慢，并发的未命中越多
+				return k * 10, nil
+			}
+
+			var wg sync.WaitGroup
+			for g := range goroutines {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					k := g % keys
+					if v, err := c.GetOrLoad(context.Background(), k, loader); v != k*10 || err != nil {
+						t.Errorf("GetOrLoad(%d) = %d, %v", k, v, err)
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+			for k := range keys {
+				if n := loads[k].Load(); n != 1 {
+					t.Errorf("键 %d 加载了 %d 次，预期 1", k, n)
+				}
+			}
+		})
+	}
+}
+
+// TestGetOrLoadError 测试加载失败时不写入缓存，下一次调用重新加载
+func TestGetOrLoadError(t *testing.T) {
+	errBoom := errors.New("boom")
+	for _, impl := range impls {
+		t.Run(impl.name, func(t *testing.T) {
+			c := impl.new(10)
+			calls := 0
+			loader := func(context.Context, int) (int, error) {
+				calls++
+				if calls == 1 {
+					return 0, errBoom
+				}
+				return 7, nil
+			}
+			if _, err := c.GetOrLoad(context.Background(), 1, loader); !errors.Is(err, errBoom) {
+				t.Errorf("GetOrLoad 错误 = %v", err)
+			}
+			if _, ok := c.Get(1); ok {
+				t.Errorf("加载失败后不应写入缓存")
+			}
+			if v, err := c.GetOrLoad(context.Background(), 1, loader); v != 7 || err != nil {
+				t.Errorf("GetOrLoad = %d, %v", v, err)
+			}
+			if v, err := c.GetOrLoad(context.Background(), 1, loader); v != 7 || err != nil || calls != 2 {
+				t.Errorf("命中时不应再次加载：GetOrLoad = %d, %v，加载 %d 次", v, err, calls)
+			}
+		})
+	}
+}
+
+// TestNegativeTTL 测试开启负缓存后不存在的键在有效期内不再调用 loader，写入后立即可见
+func TestNegativeTTL(t *testing.T) {
+	ctx := context.Background()
+	for _, impl := range impls {
+		t.Run(impl.name, func(t *testing.T) {
+			var calls atomic.Int32
+			missing := func(context.Context, int) (int, error) {
+				calls.Add(1)
+				return 0, fmt.Errorf("查询用户: %w", cache.ErrNotFound)
+			}
+
+			// 未开启时每次都调用 loader
+			plain := impl.new(10)
+			for range 3 {
+				plain.GetOrLoad(ctx, 1, missing)
+			}
+			if n := calls.Load(); n != 3 {
+				t.Errorf("未开启负缓存时调用了 %d 次，预期 3", n)
+			}
+
+			calls.Store(0)
+			c := impl.negative(10, 30*time.Millisecond)
+			for range 5 {
+				if _, err := c.GetOrLoad(ctx, 1, missing); !errors.Is(err, cache.ErrNotFound) {
+					t.Fatalf("GetOrLoad 错误 = %v", err)
+				}
+			}
+			if n := calls.Load(); n != 1 {
+				t.Errorf("有效期内调用了 %d 次，预期 1", n)
+			}
+
+			// 其他错误不记录
+			errBoom := errors.New("boom")
+			for range 2 {
+				c.GetOrLoad(ctx, 2, func(context.Context, int) (int, error) {
+					calls.Add(1)
+					return 0, errBoom
+				})
+			}
+			if n := calls.Load(); n != 3 {
+				t.Errorf("非 ErrNotFound 错误不应被记录，共调用 %d 次", n)
+			}
+
+			// 过期后重新调用 loader
+			time.Sleep(40 * time.Millisecond)
+			c.GetOrLoad(ctx, 1, missing)
+			if n := calls.Load(); n != 4 {
+				t.Errorf("过期后应重新调用 loader，共调用 %d 次", n)
+			}
+
+			// 写入后立即可见，删除后不会被当作不存在
+			c.Set(1, 100)
+			c.Delete(1)
+			v, err := c.GetOrLoad(ctx, 1, func(context.Context, int) (int, error) { return 7, nil })
+			if v != 7 || err != nil {
+				t.Errorf("写入后 GetOrLoad = %d, %v，预期重新加载", v, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Package lru 实现最近最少使用（LRU）淘汰的缓存。
+//
+// LRU 只根据最近一次访问的时间淘汰，实现简单、对突发的热点反应迅速，
+// 但一次大范围扫描就能把热数据全部挤出，其他算法通常以它为对照基准。
+package lru
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/internal/list"
+)
+
+var _ cache.Cache[int, int] = (*Cache[int, int])(nil)
+
+// entry 是链表元素中保存的条目
+type entry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+// Cache 是 LRU 缓存，零值不可用，应使用 New 创建
+type Cache[K comparable, V any] struct {
+	mu    sync.Mutex
+	cap   int
+	ll    list.List[entry[K, V]] // 表头为最近访问的条目
+	items map[K]*list.Element[entry[K, V]]
+
+	loads cache.LoadGroup[K, V] // GetOrLoad 的并发合并与负缓存
+}
+
+// New 创建容量为 capacity 的 LRU 缓存，capacity 不为正数时 panic
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("lru: 容量必须大于 0")
+	}
+	return &Cache[K, V]{cap: capacity, items: make(map[K]*list.Element[entry[K, V]], capacity)}
+}
+
+// Get 返回键 k 对应的值，并将其标记为最近访问
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.ll.MoveToFront(e)
+	return e.Value.val, true
+}
+
+// GetOrLoad 返回键 k 对应的值，未命中时调用 loader 加载并写入缓存
+// 对同一个键并发的未命中只调用一次 loader，见 cache.LoadGroup
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, k K, loader cache.Loader[K, V]) (V, error) {
+	return c.loads.GetOrLoad(ctx, c, k, loader)
+}
+
+// WithNegativeTTL 开启负缓存：GetOrLoad 中 loader 返回 cache.ErrNotFound 的键在 ttl 内直接返回 cache.ErrNotFound，
+// 不再调用 loader；最多记录与容量相同个数的键。应在使用缓存前调用
+func (c *Cache[K, V]) WithNegativeTTL(ttl time.Duration) *Cache[K, V] {
+	c.loads.SetNegative(cache.NewNegative[K](ttl, c.cap))
+	return c
+}
+
+// Set 设置键 k 对应的值，缓存已满时淘汰最久未访问的条目
+func (c *Cache[K, V]) Set(k K, v V) {
+	c.loads.Forget(k)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[k]; ok {
+		e.Value.val = v
+		c.ll.MoveToFront(e)
+		return
+	}
+	if c.ll.Len() < c.cap {
+		c.items[k] = c.ll.PushFront(entry[K, V]{k, v})
+		return
+	}
+	// 复用被淘汰条目的元素，稳定状态下 Set 不分配链表元素
+	e := c.ll.Back()
+	delete(c.items, e.Value.key)
+	e.Value = entry[K, V]{k, v}
+	c.ll.MoveToFront(e)
+	c.items[k] = e
+}
+
+// Delete 删除键 k
+func (c *Cache[K, V]) Delete(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[k]; ok {
+		c.ll.Remove(e)
+		delete(c.items, k)
+	}
+}
+
+// Len 返回当前条目数
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Cap 返回容量
+func (c *Cache[K, V]) Cap() int {
+	return c.cap
+}
@@ -0,0 +1,72 @@
+package lru_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/cache/lru"
+)
+
+// TestCache 测试读写与按最近访问顺序淘汰
+func TestCache(t *testing.T) {
+	c := lru.New[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // b 成为最久未访问
+	c.Set("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("b 应被淘汰")
+	}
+	for k, want := range map[string]int{"a": 1, "c": 3} {
+		if v, ok := c.Get(k); !ok || v != want {
+			t.Errorf("Get(%q) = %d, %v，预期 %d", k, v, ok, want)
+		}
+	}
+
+	c.Set("a", 10) // 更新已有键不淘汰
+	if v, _ := c.Get("a"); v != 10 || c.Len() != 2 {
+		t.Errorf("更新后 Get(a) = %d，Len() = %d", v, c.Len())
+	}
+	c.Delete("a")
+	c.Delete("missing")
+	if _, ok := c.Get("a"); ok || c.Len() != 1 {
+		t.Errorf("Delete 后 Len() = %d", c.Len())
+	}
+	if c.Cap() != 2 {
+		t.Errorf("Cap() = %d", c.Cap())
+	}
+}
+
+// TestSetAllocs 测试缓存已满时替换条目不分配内存
+func TestSetAllocs(t *testing.T) {
+	c := lru.New[int, int](64)
+	for i := range 64 {
+		c.Set(i, i)
+	}
+	i := 64
+	benchkit.AssertAllocs(t, 0, func() {
+		c.Set(i, i)
+		i++
+	})
+}
+
+// BenchmarkCache 测量命中与淘汰路径的开销
+func BenchmarkCache(b *testing.B) {
+	const n = 1024
+	b.Run("get-hit", func(b *testing.B) {
+		c := lru.New[int, int](n)
+		for i := range n {
+			c.Set(i, i)
+		}
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkInt, benchkit.SinkBool = c.Get(i & (n - 1))
+		}
+	})
+	b.Run("set-evict", func(b *testing.B) {
+		b.ReportAllocs()
+		c := lru.New[int, int](n)
+		for i := 0; i < b.N; i++ {
+			c.Set(i, i)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Negative 记录已知不存在的键（负缓存），在 ttl 内再次查询这些键时不必访问数据源
+//
+// 不存在的键通常来自拼写错误、已删除的数据或恶意构造的请求，它们永远不会写入缓存，
+// 若不记录，每次查询都会穿透到数据源。ttl 应明显短于正常条目的有效期，使新写入的数据能尽快可见。
+// 记录的键数超过上限时先清除过期的键，仍然超过时随机丢弃一个。
+type Negative[K comparable] struct {
+	mu  sync.Mutex
+	ttl time.Duration
+	max int
+	m   map[K]time.Time // 键到过期时间
+}
+
+// NewNegative 创建有效期为 ttl、最多记录 max 个键的负缓存，ttl 或 max 不为正数时 panic
+func NewNegative[K comparable](ttl time.Duration, max int) *Negative[K] {
+	if ttl <= 0 || max <= 0 {
+		panic("cache: 负缓存的有效期与容量必须大于 0")
+	}
+	return &Negative[K]{ttl: ttl, max: max, m: make(map[K]time.Time)}
+}
+
+// Add 记录键 k 不存在
+func (n *Negative[K]) Add(k K) {
+	now := time.Now()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.m[k]; !ok && len(n.m) >= n.max {
+		for k, exp := range n.m {
+			if !now.Before(exp) {
+				delete(n.m, k)
+			}
+		}
+		for k := range n.m {
+			if len(n.m) < n.max {
+				break
+			}
+			delete(n.m, k)
+		}
+	}
+	n.m[k] = now.Add(n.ttl)
+}
+
+// Has 返回键 k 是否被记录为不存在且尚未过期
+func (n *Negative[K]) Has(k K) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	exp, ok := n.m[k]
+	if ok && !time.Now().Before(exp) {
+		delete(n.m, k)
+		return false
+	}
+	return ok
+}
+
+// Forget 删除键 k 的记录，键被写入时调用
+func (n *Negative[K]) Forget(k K) {
+	n.mu.Lock()
+	delete(n.m, k)
+	n.mu.Unlock()
+}
+
+// Len 返回记录的键数，含已过期但尚未清除的键
+func (n *Negative[K]) Len() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.m)
+}
@@ -0,0 +1,50 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/cache"
+)
+
+// TestNegative 测试记录、过期、删除与容量上限
+func TestNegative(t *testing.T) {
+	n := cache.NewNegative[string](20*time.Millisecond, 3)
+	n.Add("a")
+	if !n.Has("a") || n.Has("b") {
+		t.Errorf("Has(a) = %v，Has(b) = %v", n.Has("a"), n.Has("b"))
+	}
+	n.Forget("a")
+	if n.Has("a") {
+		t.Errorf("Forget 后 Has(a) 应为 false")
+	}
+
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		n.Add(k)
+	}
+	if got := n.Len(); got != 3 {
+		t.Errorf("超过上限后 Len() = %d，预期 3", got)
+	}
+	if !n.Has("e") {
+		t.Errorf("最后记录的键应保留")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	n.Add("f") // 已满时先清除过期的键
+	if got := n.Len(); got != 1 {
+		t.Errorf("清除过期键后 Len() = %d，预期 1", got)
+	}
+	if n.Has("e") || !n.Has("f") {
+		t.Errorf("过期后 Has(e) 应为 false，Has(f) 应为 true")
+	}
+}
+
+// TestNewNegativePanics 测试非法参数时 panic
+func TestNewNegativePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ttl 为 0 时应 panic")
+		}
+	}()
+	cache.NewNegative[int](0, 1)
+}
@@ -0,0 +1,93 @@
+// Package sharded 把任意缓存实现按键的哈希划分到多个分片，每个分片是独立的缓存实例，
+// 拥有各自的锁，从而降低多个 goroutine 并发访问同一个缓存时的锁争用。
+//
+// 分片后淘汰在各分片内独立进行，每个分片的容量应为总容量除以分片数；
+// 键分布均匀时整体行为与单个缓存接近，但热点键集中的分片会更早淘汰。
+package sharded
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/bit"
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/hashx"
+)
+
+var _ cache.Cache[int, int] = (*Cache[int, int])(nil)
+
+// Cache 是分片缓存，零值不可用，应使用 Wrap 创建
+type Cache[K comparable, V any] struct {
+	seed   hashx.Seeded
+	mask   uint64
+	shards []cache.Cache[K, V]
+
+	loads cache.LoadGroup[K, V] // GetOrLoad 的并发合并与负缓存
+}
+
+// Wrap 用 newShard 创建至少 n 个分片组成的缓存，分片数向上取整为 2 的幂；
+// n 不为正数时使用 4 倍的 GOMAXPROCS
+//
+//	c := sharded.Wrap(func() cache.Cache[string, []byte] {
+//		return lru.New[string, []byte](capacity / 16)
+//	}, 16)
+func Wrap[K comparable, V any](newShard func() cache.Cache[K, V], n int) *Cache[K, V] {
+	if n <= 0 {
+		n = 4 * runtime.GOMAXPROCS(0)
+	}
+	size := bit.NextPowerOfTwo(uint64(n))
+	c := &Cache[K, V]{seed: hashx.NewSeeded(), mask: size - 1, shards: make([]cache.Cache[K, V], size)}
+	for i := range c.shards {
+		c.shards[i] = newShard()
+	}
+	return c
+}
+
+// shardFor 返回键 k 所在的分片
+func (c *Cache[K, V]) shardFor(k K) cache.Cache[K, V] {
+	return c.shards[hashx.Comparable(c.seed, k)&c.mask]
+}
+
+// Get 返回键 k 对应的值
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	return c.shardFor(k).Get(k)
+}
+
+// GetOrLoad 返回键 k 对应的值，未命中时调用 loader 加载并写入缓存
+// 对同一个键并发的未命中只调用一次 loader，见 cache.LoadGroup
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, k K, loader cache.Loader[K, V]) (V, error) {
+	return c.loads.GetOrLoad(ctx, c, k, loader)
+}
+
+// WithNegativeTTL 开启负缓存：GetOrLoad 中 loader 返回 cache.ErrNotFound 的键在 ttl 内直接返回 cache.ErrNotFound，
+// 不再调用 loader；最多记录 max 个键。应在使用缓存前调用
+func (c *Cache[K, V]) WithNegativeTTL(ttl time.Duration, max int) *Cache[K, V] {
+	c.loads.SetNegative(cache.NewNegative[K](ttl, max))
+	return c
+}
+
+// Set 设置键 k 对应的值
+func (c *Cache[K, V]) Set(k K, v V) {
+	c.loads.Forget(k)
+	c.shardFor(k).Set(k, v)
+}
+
+// Delete 删除键 k
+func (c *Cache[K, V]) Delete(k K) {
+	c.shardFor(k).Delete(k)
+}
+
+// Len 返回各分片条目数之和，各分片分别加锁读取
+func (c *Cache[K, V]) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.Len()
+	}
+	return n
+}
+
+// Shards 返回分片数
+func (c *Cache[K, V]) Shards() int {
+	return len(c.shards)
+}
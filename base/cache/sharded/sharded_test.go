@@ -0,0 +1,112 @@
+package sharded_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/lru"
+	"github.com/moweilong/efficient-go/base/cache/sharded"
+	"github.com/moweilong/efficient-go/base/cache/tinylfu"
+)
+
+func newLRU(capacity int) func() cache.Cache[int, int] {
+	return func() cache.Cache[int, int] { return lru.New[int, int](capacity) }
+}
+
+// TestWrap 测试分片数取整以及读写删除被路由到同一个分片
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		n, want int
+	}{
+		{1, 1},
+		{3, 4},
+		{16, 16},
+	}
+	for _, tt := range tests {
+		if got := sharded.Wrap(newLRU(4), tt.n).Shards(); got != tt.want {
+			t.Errorf("Wrap(_, %d).Shards() = %d，预期 %d", tt.n, got, tt.want)
+		}
+	}
+	if got := sharded.Wrap(newLRU(4), 0).Shards(); got < 4 {
+		t.Errorf("Wrap(_, 0).Shards() = %d", got)
+	}
+
+	c := sharded.Wrap(newLRU(100), 8)
+	for k := range 200 {
+		c.Set(k, k*2)
+	}
+	if n := c.Len(); n != 200 {
+		t.Fatalf("Len() = %d，预期 200", n)
+	}
+	for k := range 200 {
+		if v, ok := c.Get(k); !ok || v != k*2 {
+			t.Fatalf("Get(%d) = %d, %v", k, v, ok)
+		}
+	}
+	c.Delete(7)
+	if _, ok := c.Get(7); ok || c.Len() != 199 {
+		t.Errorf("Delete 后 Len() = %d", c.Len())
+	}
+	if v, err := c.GetOrLoad(context.Background(), 7, func(_ context.Context, k int) (int, error) { return -k, nil }); v != -7 || err != nil {
+		t.Errorf("GetOrLoad(7) = %d, %v", v, err)
+	}
+}
+
+// run 用 goroutines 个 goroutine 共同执行 b.N 次读写，读写比为 9:1
+func run(b *testing.B, c cache.Cache[int, int], goroutines int) {
+	const keys = 1 << 14
+	for k := range keys {
+		c.Set(k, k)
+	}
+	b.ResetTimer()
+	var wg sync.WaitGroup
+	per := b.N/goroutines + 1
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			x := uint32(g*7919 + 1)
+			for i := range per {
+				x ^= x << 13
+				x ^= x >> 17
+				x ^= x << 5
+				k := int(x & (keys - 1))
+				if i%10 == 0 {
+					c.Set(k, k)
+				} else {
+					c.Get(k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// BenchmarkContention 对比单个缓存与分片缓存在 1、8、64 个 goroutine 并发访问时的开销
+// 单核环境下没有真正的并行，差异主要来自锁竞争引起的 goroutine 切换；核心越多分片的优势越明显
+func BenchmarkContention(b *testing.B) {
+	const capacity = 1 << 15
+	impls := []struct {
+		name string
+		new  func() cache.Cache[int, int]
+	}{
+		{"lru", newLRU(capacity)},
+		{"sharded-lru", func() cache.Cache[int, int] { return sharded.Wrap(newLRU(capacity/16), 16) }},
+		{"tinylfu", func() cache.Cache[int, int] { return tinylfu.New[int, int](capacity) }},
+		{"sharded-tinylfu", func() cache.Cache[int, int] {
+			return sharded.Wrap(func() cache.Cache[int, int] { return tinylfu.New[int, int](capacity / 16) }, 16)
+		}},
+	}
+	for _, goroutines := range []int{1, 8, 64} {
+		for _, impl := range impls {
+			b.Run(fmt.Sprintf("goroutines=%d/%s", goroutines, impl.name), func(b *testing.B) {
+				run(b, impl.new(), goroutines)
+				benchkit.SinkInt = goroutines
+			})
+		}
+	}
+}
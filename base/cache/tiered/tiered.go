@@ -0,0 +1,276 @@
+// Package tiered 提供二级缓存：进程内的 L1 缓存在前，Redis 等远程缓存作为 L2 在后。
+//
+// 读取依次查找 L1、L2 与数据源（read-through），并把结果回填到前面的层级；
+// 对同一个键的并发未命中通过 singleflight 合并为一次 L2 读取与一次加载，避免缓存击穿。
+// 写入支持两种模式：写穿（write-through）同步写入 L2；写回（write-back）先写 L1 并记为脏数据，
+// 由后台定期批量写入 L2，同一个键的多次写入只落盘最后一次。
+package tiered
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/singleflight"
+)
+
+// L2 是二级缓存，通常是对 Redis、Memcached 等客户端的封装，必须可以并发使用
+type L2[K comparable, V any] interface {
+	// Get 返回键 k 对应的值，键不存在时 ok 为 false 且 err 为 nil
+	Get(ctx context.Context, k K) (v V, ok bool, err error)
+	// Set 设置键 k 对应的值
+	Set(ctx context.Context, k K, v V) error
+	// Delete 删除键 k，键不存在时不返回错误
+	Delete(ctx context.Context, k K) error
+}
+
+// Mode 是写入模式
+type Mode uint8
+
+const (
+	WriteThrough Mode = iota // Set 同步写入 L2，成功后再写入 L1
+	WriteBack                // Set 只写入 L1，由后台定期写入 L2
+)
+
+// Options 是二级缓存的配置
+type Options[K comparable, V any] struct {
+	Mode Mode
+	// FlushInterval 是写回模式下后台写入 L2 的间隔，不为正数时为 1 秒
+	FlushInterval time.Duration
+	// Loader 在 L1 与 L2 都未命中时从数据源加载，为 nil 时 Get 返回 cache.ErrNotFound
+	Loader cache.Loader[K, V]
+	// NegativeTTL 为正数时开启负缓存：L2 与 Loader 都找不到的键在此期间直接返回 cache.ErrNotFound，
+	// 不再访问 L2 与 Loader；NegativeSize 为最多记录的键数，不为正数时为 1024
+	NegativeTTL  time.Duration
+	NegativeSize int
+	// OnError 接收不返回给调用方的错误：后台写回失败与加载后回填 L2 失败，可以为 nil
+	OnError func(error)
+}
+
+// Cache 是二级缓存，零值不可用，应使用 New 创建
+type Cache[K comparable, V any] struct {
+	l1      cache.Cache[K, V]
+	l2      L2[K, V]
+	opts    Options[K, V]
+	flight  singleflight.Group[K, V]
+	missing *cache.Negative[K] // 负缓存，未开启时为 nil
+
+	mu       sync.Mutex
+	dirty    map[K]V    // 写回模式下尚未写入 L2 的条目
+	flushing map[K]V    // 正在由 Flush 写入 L2 的条目，Delete 从中删除以通知 Flush 放弃该条目
+	flushMu  sync.Mutex // 串行化 Flush，同一时刻只有一批条目在写入
+
+	stop      chan struct{}
+	stopOnce  sync.Once
+	flusherWG sync.WaitGroup
+
+	l1Hits, l2Hits, loads atomic.Uint64
+}
+
+// New 创建由 l1 与 l2 组成的二级缓存；写回模式下会启动后台写入的 goroutine，使用完毕后应调用 Close
+func New[K comparable, V any](l1 cache.Cache[K, V], l2 L2[K, V], opts Options[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{l1: l1, l2: l2, opts: opts, dirty: make(map[K]V), flushing: make(map[K]V), stop: make(chan struct{})}
+	if opts.NegativeTTL > 0 {
+		size := opts.NegativeSize
+		if size <= 0 {
+			size = 1024
+		}
+		c.missing = cache.NewNegative[K](opts.NegativeTTL, size)
+	}
+	if opts.Mode == WriteBack {
+		interval := opts.FlushInterval
+		if interval <= 0 {
+			interval = time.Second
+		}
+		c.flusherWG.Add(1)
+		go c.flusher(interval)
+	}
+	return c
+}
+
+// Get 依次从 L1、L2 与 Options.Loader 读取键 k 对应的值，并回填到前面的层级
+// 对同一个键并发的未命中只有一个调用真正访问 L2 与 Loader，其余调用共享其结果，
+// 此时 L2 与 Loader 收到的是该调用的 ctx。
+func (c *Cache[K, V]) Get(ctx context.Context, k K) (V, error) {
+	return c.GetOrLoad(ctx, k, c.opts.Loader)
+}
+
+// GetOrLoad 与 Get 相同，但两级缓存都未命中时使用 loader 而不是 Options.Loader 加载
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, k K, loader cache.Loader[K, V]) (V, error) {
+	if v, ok := c.l1.Get(k); ok {
+		c.l1Hits.Add(1)
+		return v, nil
+	}
+	c.mu.Lock()
+	v, ok := c.dirty[k] // 脏条目可能已被 L1 淘汰
+	if !ok {
+		v, ok = c.flushing[k] // 正在写入的条目在 L2 中可能还是旧值
+	}
+	c.mu.Unlock()
+	if ok {
+		c.l1Hits.Add(1)
+		return v, nil
+	}
+	if c.missing != nil && c.missing.Has(k) {
+		var zero V
+		return zero, cache.ErrNotFound
+	}
+	v, err, _ := c.flight.Do(k, func() (V, error) {
+		v, err := c.fetch(ctx, k, loader)
+		if c.missing != nil && errors.Is(err, cache.ErrNotFound) {
+			c.missing.Add(k)
+		}
+		return v, err
+	})
+	return v, err
+}
+
+// fetch 从 L2 或 loader 读取并回填
+func (c *Cache[K, V]) fetch(ctx context.Context, k K, loader cache.Loader[K, V]) (V, error) {
+	v, ok, err := c.l2.Get(ctx, k)
+	if err != nil {
+		return v, err
+	}
+	if ok {
+		c.l2Hits.Add(1)
+		c.l1.Set(k, v)
+		return v, nil
+	}
+	if loader == nil {
+		var zero V
+		return zero, cache.ErrNotFound
+	}
+	v, err = loader(ctx, k)
+	if err != nil {
+		return v, err
+	}
+	c.loads.Add(1)
+	if err := c.l2.Set(ctx, k, v); err != nil {
+		c.report(err)
+	}
+	c.l1.Set(k, v)
+	return v, nil
+}
+
+// Set 设置键 k 对应的值；写穿模式下 L2 写入失败时返回错误且不修改 L1
+func (c *Cache[K, V]) Set(ctx context.Context, k K, v V) error {
+	if c.missing != nil {
+		c.missing.Forget(k)
+	}
+	if c.opts.Mode == WriteBack {
+		c.mu.Lock()
+		c.dirty[k] = v
+		c.mu.Unlock()
+		c.l1.Set(k, v)
+		return nil
+	}
+	if err := c.l2.Set(ctx, k, v); err != nil {
+		return err
+	}
+	c.l1.Set(k, v)
+	return nil
+}
+
+// Delete 从两级缓存中删除键 k，写回模式下同时丢弃尚未写入与正在写入的值
+func (c *Cache[K, V]) Delete(ctx context.Context, k K) error {
+	c.mu.Lock()
+	delete(c.dirty, k)
+	delete(c.flushing, k)
+	c.mu.Unlock()
+	c.l1.Delete(k)
+	c.flight.Forget(k)
+	return c.l2.Delete(ctx, k)
+}
+
+// Flush 将写回模式下的脏条目写入 L2，返回所有失败的错误；失败的条目保留，下次写入时重试
+//
+// 写入期间被 Delete 的条目不再保留；如果它的写入晚于 Delete 对 L2 的删除而成功，
+// Flush 会再次从 L2 删除它，避免已删除的键重新出现。
+func (c *Cache[K, V]) Flush(ctx context.Context) error {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+	c.mu.Lock()
+	batch := c.dirty
+	c.dirty = make(map[K]V)
+	for k, v := range batch {
+		c.flushing[k] = v
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	for k, v := range batch {
+		err := c.l2.Set(ctx, k, v)
+		c.mu.Lock()
+		_, live := c.flushing[k]
+		delete(c.flushing, k)
+		_, newer := c.dirty[k]
+		if err != nil && live && !newer { // 写入期间有更新的值时以新值为准
+			c.dirty[k] = v
+		}
+		c.mu.Unlock()
+		switch {
+		case err != nil:
+			errs = append(errs, err)
+		case !live && !newer:
+			if err := c.l2.Delete(ctx, k); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close 停止后台写入并执行最后一次 Flush；可以重复调用
+func (c *Cache[K, V]) Close(ctx context.Context) error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	c.flusherWG.Wait()
+	return c.Flush(ctx)
+}
+
+// flusher 定期执行 Flush
+func (c *Cache[K, V]) flusher(interval time.Duration) {
+	defer c.flusherWG.Done()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-t.C:
+			if err := c.Flush(context.Background()); err != nil {
+				c.report(err)
+			}
+		}
+	}
+}
+
+// report 将错误交给 OnError
+func (c *Cache[K, V]) report(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+// Stats 是二级缓存的访问统计
+type Stats struct {
+	L1Hits uint64 // L1 命中次数，含写回模式下命中尚未写入的脏条目
+	L2Hits uint64 // L2 命中次数
+	Loads  uint64 // 调用 Loader 成功的次数
+	Dirty  int    // 写回模式下尚未写入 L2 的条目数
+}
+
+// Stats 返回访问统计
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	dirty := len(c.dirty)
+	for k := range c.flushing {
+		if _, ok := c.dirty[k]; !ok {
+			dirty++
+		}
+	}
+	c.mu.Unlock()
+	return Stats{L1Hits: c.l1Hits.Load(), L2Hits: c.l2Hits.Load(), Loads: c.loads.Load(), Dirty: dirty}
+}
@@ -0,0 +1,370 @@
+package tiered_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/lru"
+	"github.com/moweilong/efficient-go/base/cache/tiered"
+)
+
+var errDown = errors.New("l2 不可用")
+
+// memL2 是测试用的 L2，记录调用次数并可模拟故障
+type memL2 struct {
+	mu         sync.Mutex
+	m          map[string]int
+	gets, sets int
+	fail       bool
+	onSet      func(k string) // Set 写入前调用，不持有锁，用于模拟慢速写入
+}
+
+func newMemL2() *memL2 {
+	return &memL2{m: make(map[string]int)}
+}
+
+func (l *memL2) Get(_ context.Context, k string) (int, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.gets++
+	if l.fail {
+		return 0, false, errDown
+	}
+	v, ok := l.m[k]
+	return v, ok, nil
+}
+
+func (l *memL2) Set(_ context.Context, k string, v int) error {
+	l.mu.Lock()
+	hook := l.onSet
+	l.mu.Unlock()
+	if hook != nil {
+		hook(k)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.fail {
+		return errDown
+	}
+	l.sets++
+	l.m[k] = v
+	return nil
+}
+
+func (l *memL2) Delete(_ context.Context, k string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.m, k)
+	return nil
+}
+
+func (l *memL2) lookup(k string) (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	v, ok := l.m[k]
+	return v, ok
+}
+
+func (l *memL2) setFail(fail bool) {
+	l.mu.Lock()
+	l.fail = fail
+	l.mu.Unlock()
+}
+
+// TestReadThrough 测试依次读取 L1、L2 与 Loader 并回填
+func TestReadThrough(t *testing.T) {
+	ctx := context.Background()
+	l2 := newMemL2()
+	var loads atomic.Int32
+	opts := tiered.Options[string, int]{
+		Loader: func(_ context.Context, k string) (int, error) {
+			loads.Add(1)
+			return len(k), nil
+		},
+	}
+	c := tiered.New(lru.New[string, int](8), l2, opts)
+	for range 3 {
+		if v, err := c.Get(ctx, "abc"); v != 3 || err != nil {
+			t.Fatalf("Get(abc) = %d, %v", v, err)
+		}
+	}
+	if s := c.Stats(); loads.Load() != 1 || s.Loads != 1 || s.L1Hits != 2 || s.L2Hits != 0 {
+		t.Errorf("加载 %d 次，Stats() = %+v", loads.Load(), s)
+	}
+	if v, ok := l2.lookup("abc"); !ok || v != 3 {
+		t.Errorf("加载结果未回填 L2")
+	}
+
+	// 新的 L1 从 L2 读取
+	c2 := tiered.New(lru.New[string, int](8), l2, opts)
+	if v, err := c2.Get(ctx, "abc"); v != 3 || err != nil || c2.Stats().L2Hits != 1 || loads.Load() != 1 {
+		t.Errorf("Get(abc) = %d, %v，Stats() = %+v", v, err, c2.Stats())
+	}
+
+	// 没有 Loader 时返回 ErrNotFound，L2 故障时返回其错误
+	c3 := tiered.New(lru.New[string, int](8), l2, tiered.Options[string, int]{})
+	if _, err := c3.Get(ctx, "missing"); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("Get(missing) 错误 = %v，预期 ErrNotFound", err)
+	}
+	l2.setFail(true)
+	if _, err := c3.Get(ctx, "x"); !errors.Is(err, errDown) {
+		t.Errorf("L2 故障时 Get 错误 = %v", err)
+	}
+}
+
+// TestWriteThrough 测试写穿模式同步写入 L2，失败时不修改 L1
+func TestWriteThrough(t *testing.T) {
+	ctx := context.Background()
+	l2 := newMemL2()
+	c := tiered.New(lru.New[string, int](8), l2, tiered.Options[string, int]{})
+	if err := c.Set(ctx, "a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := l2.lookup("a"); !ok || v != 1 {
+		t.Errorf("Set 后 L2 中没有 a")
+	}
+
+	l2.setFail(true)
+	if err := c.Set(ctx, "a", 2); !errors.Is(err, errDown) {
+		t.Errorf("Set 错误 = %v", err)
+	}
+	if v, _ := c.Get(ctx, "a"); v != 1 {
+		t.Errorf("L2 写入失败后 Get(a) = %d，预期仍为 1", v)
+	}
+	l2.setFail(false)
+
+	if err := c.Delete(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, "a"); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("Delete 后 Get 错误 = %v", err)
+	}
+}
+
+// TestWriteBack 测试写回模式合并写入、失败重试与 Close 时的最后一次写入
+func TestWriteBack(t *testing.T) {
+	ctx := context.Background()
+	l2 := newMemL2()
+	var reported atomic.Int32
+	c := tiered.New(lru.New[string, int](1), l2, tiered.Options[string, int]{
+		Mode:          tiered.WriteBack,
+		FlushInterval: time.Hour,
+		OnError:       func(error) { reported.Add(1) },
+	})
+	for v := range 3 {
+		c.Set(ctx, "a", v)
+	}
+	c.Set(ctx, "b", 10) // L1 容量为 1，a 被淘汰但仍可从脏数据读取
+	if v, err := c.Get(ctx, "a"); v != 2 || err != nil {
+		t.Errorf("Get(a) = %d, %v，预期 2", v, err)
+	}
+	if _, ok := l2.lookup("a"); ok || c.Stats().Dirty != 2 {
+		t.Errorf("Flush 前不应写入 L2，Stats() = %+v", c.Stats())
+	}
+
+	l2.setFail(true)
+	if err := c.Flush(ctx); !errors.Is(err, errDown) {
+		t.Errorf("Flush 错误 = %v", err)
+	}
+	if d := c.Stats().Dirty; d != 2 {
+		t.Errorf("失败后脏条目数 = %d，预期保留 2", d)
+	}
+	l2.setFail(false)
+
+	c.Delete(ctx, "b")
+	if err := c.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.Close(ctx)
+	if v, ok := l2.lookup("a"); !ok || v != 2 || l2.sets != 1 {
+		t.Errorf("Close 后 L2 中 a = %d, %v，写入 %d 次，预期只写入最后的值 2", v, ok, l2.sets)
+	}
+	if _, ok := l2.lookup("b"); ok {
+		t.Errorf("已删除的脏条目不应写入")
+	}
+	if reported.Load() != 0 {
+		t.Errorf("手动 Flush 的错误不应交给 OnError")
+	}
+}
+
+// TestDeleteDuringFlush 测试 Flush 写入期间被删除的键不会重新出现，
+// 无论这次写入失败还是晚于删除成功
+func TestDeleteDuringFlush(t *testing.T) {
+	ctx := context.Background()
+	for _, fail := range []bool{true, false} {
+		l2 := newMemL2()
+		l2.m["k"] = 0 // L2 中的旧值
+		c := tiered.New(lru.New[string, int](16), l2, tiered.Options[string, int]{Mode: tiered.WriteBack, FlushInterval: time.Hour})
+
+		entered, release := make(chan struct{}), make(chan struct{})
+		var once sync.Once
+		l2.onSet = func(string) {
+			once.Do(func() {
+				close(entered)
+				<-release
+				l2.setFail(fail)
+			})
+		}
+		c.Set(ctx, "k", 1)
+		flushed := make(chan error)
+		go func() { flushed <- c.Flush(ctx) }()
+		<-entered
+
+		c.Delete(ctx, "k")
+		if _, err := c.Get(ctx, "k"); !errors.Is(err, cache.ErrNotFound) {
+			t.Errorf("fail=%v: 写入期间删除后 Get 错误 = %v，预期 ErrNotFound", fail, err)
+		}
+		close(release)
+		if err := <-flushed; (err != nil) != fail {
+			t.Errorf("fail=%v: Flush() = %v", fail, err)
+		}
+		l2.setFail(false)
+
+		if v, err := c.Get(ctx, "k"); !errors.Is(err, cache.ErrNotFound) {
+			t.Errorf("fail=%v: 删除的键重新出现: Get = %d, %v", fail, v, err)
+		}
+		if _, ok := l2.lookup("k"); ok {
+			t.Errorf("fail=%v: 删除的键仍在 L2 中", fail)
+		}
+		if s := c.Stats(); s.Dirty != 0 {
+			t.Errorf("fail=%v: Dirty = %d，预期 0", fail, s.Dirty)
+		}
+		c.Close(ctx)
+	}
+}
+
+// TestReadDuringFlush 测试 Flush 写入期间 L1 未命中时读到正在写入的值而不是 L2 中的旧值
+func TestReadDuringFlush(t *testing.T) {
+	ctx := context.Background()
+	l2 := newMemL2()
+	l2.m["k"] = 0
+	c := tiered.New(lru.New[string, int](1), l2, tiered.Options[string, int]{Mode: tiered.WriteBack, FlushInterval: time.Hour})
+	defer c.Close(ctx)
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	l2.onSet = func(string) {
+		once.Do(func() {
+			close(entered)
+			<-release
+		})
+	}
+	c.Set(ctx, "k", 1)
+	flushed := make(chan error)
+	go func() { flushed <- c.Flush(ctx) }()
+	<-entered
+	c.Set(ctx, "other", 2) // L1 容量为 1，淘汰 k
+	c.Delete(ctx, "other")
+	if v, err := c.Get(ctx, "k"); v != 1 || err != nil {
+		t.Errorf("写入期间 Get = %d, %v，预期 1", v, err)
+	}
+	close(release)
+	if err := <-flushed; err != nil {
+		t.Errorf("Flush() = %v", err)
+	}
+}
+
+// TestBackgroundFlush 测试后台定期写入
+func TestBackgroundFlush(t *testing.T) {
+	l2 := newMemL2()
+	c := tiered.New(lru.New[string, int](8), l2, tiered.Options[string, int]{
+		Mode:          tiered.WriteBack,
+		FlushInterval: time.Millisecond,
+	})
+	defer c.Close(context.Background())
+	c.Set(context.Background(), "a", 1)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := l2.lookup("a"); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("后台未写入 L2")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestStampede 测试大量并发未命中只加载一次
+func TestStampede(t *testing.T) {
+	var loads atomic.Int32
+	release := make(chan struct{})
+	c := tiered.New(lru.New[string, int](8), newMemL2(), tiered.Options[string, int]{
+		Loader: func(context.Context, string) (int, error) {
+			loads.Add(1)
+			<-release
+			return 42, nil
+		},
+	})
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := c.Get(context.Background(), "hot"); v != 42 || err != nil {
+				t.Errorf("Get(hot) = %d, %v", v, err)
+			}
+		}()
+	}
+	for loads.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := loads.Load(); n != 1 {
+		t.Errorf("加载了 %d 次，预期 1", n)
+	}
+}
+
+// TestGetOrLoad 测试 GetOrLoad 使用传入的 loader，且同样回填两级缓存
+func TestGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	l2 := newMemL2()
+	c := tiered.New(lru.New[string, int](8), l2, tiered.Options[string, int]{})
+	loader := func(_ context.Context, k string) (int, error) { return len(k) * 2, nil }
+	if v, err := c.GetOrLoad(ctx, "ab", loader); v != 4 || err != nil {
+		t.Fatalf("GetOrLoad(ab) = %d, %v", v, err)
+	}
+	if v, ok := l2.lookup("ab"); !ok || v != 4 {
+		t.Errorf("加载结果未回填 L2")
+	}
+	if v, err := c.Get(ctx, "ab"); v != 4 || err != nil || c.Stats().L1Hits != 1 {
+		t.Errorf("Get(ab) = %d, %v，Stats() = %+v", v, err, c.Stats())
+	}
+}
+
+// TestNegativeTTL 测试两级缓存都找不到的键在有效期内不再访问 L2 与 Loader
+func TestNegativeTTL(t *testing.T) {
+	ctx := context.Background()
+	l2 := newMemL2()
+	var loads atomic.Int32
+	c := tiered.New(lru.New[string, int](8), l2, tiered.Options[string, int]{
+		Loader: func(context.Context, string) (int, error) {
+			loads.Add(1)
+			return 0, cache.ErrNotFound
+		},
+		NegativeTTL: time.Hour,
+	})
+	for range 5 {
+		if _, err := c.Get(ctx, "ghost"); !errors.Is(err, cache.ErrNotFound) {
+			t.Fatalf("Get 错误 = %v", err)
+		}
+	}
+	if n := loads.Load(); n != 1 || l2.gets != 1 {
+		t.Errorf("Loader 调用 %d 次，L2 读取 %d 次，预期各 1 次", n, l2.gets)
+	}
+	if err := c.Set(ctx, "ghost", 1); err != nil {
+		t.Fatal(err)
+	}
+	c.Delete(ctx, "ghost")
+	l2.Set(ctx, "ghost", 2)
+	if v, err := c.Get(ctx, "ghost"); v != 2 || err != nil {
+		t.Errorf("写入后 Get = %d, %v，负缓存记录应已清除", v, err)
+	}
+}
@@ -0,0 +1,83 @@
+package tinylfu
+
+import (
+	"math/bits"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// sketchDepth 是 count-min sketch 的行数，估计值取各行计数器的最小值
+const sketchDepth = 4
+
+// Sketch 是 4 位计数器的 count-min sketch，用于以极小的空间估计键的访问频率
+//
+// 每个 uint64 存放 16 个计数器，计数上限为 15。累计记录的次数达到采样窗口后所有计数器减半，
+// 使频率随时间衰减，曾经的热点不会永久占据缓存。估计值只会偏大不会偏小。
+type Sketch struct {
+	table     []uint64
+	mask      uint64 // 计数器个数减 1
+	additions int
+	window    int // 采样窗口，达到后执行一次衰减
+}
+
+// NewSketch 创建适合约 n 个不同键的 Sketch，计数器个数为 n 向上取整为 2 的幂后的 4 倍，
+// 采样窗口为 n 的 10 倍
+func NewSketch(n int) *Sketch {
+	n = max(n, 4)
+	counters := bit.NextPowerOfTwo(uint64(n)) * 4
+	return &Sketch{
+		table:  make([]uint64, counters/16),
+		mask:   counters - 1,
+		window: 10 * n,
+	}
+}
+
+// index 返回哈希值 h 在第 i 行的计数器下标，各行由双重哈希得到
+func (s *Sketch) index(h uint64, i int) uint64 {
+	h2 := bits.RotateLeft64(h*0x9e3779b97f4a7c15, 31) | 1
+	return (h + uint64(i)*h2) & s.mask
+}
+
+// get 返回下标为 j 的计数器
+func (s *Sketch) get(j uint64) uint64 {
+	return s.table[j>>4] >> ((j & 15) * 4) & 0xf
+}
+
+// Add 记录一次哈希值为 h 的访问
+// 采用保守更新：只递增等于当前最小值的计数器，减少哈希冲突造成的高估
+func (s *Sketch) Add(h uint64) {
+	var idx [sketchDepth]uint64
+	minimum := uint64(0xf)
+	for i := range idx {
+		idx[i] = s.index(h, i)
+		minimum = min(minimum, s.get(idx[i]))
+	}
+	if minimum == 0xf {
+		return
+	}
+	for _, j := range idx {
+		if s.get(j) == minimum {
+			s.table[j>>4] += 1 << ((j & 15) * 4)
+		}
+	}
+	if s.additions++; s.additions >= s.window {
+		s.Reset()
+	}
+}
+
+// Estimate 返回哈希值为 h 的键的估计访问次数
+func (s *Sketch) Estimate(h uint64) uint8 {
+	minimum := uint64(0xf)
+	for i := range sketchDepth {
+		minimum = min(minimum, s.get(s.index(h, i)))
+	}
+	return uint8(minimum)
+}
+
+// Reset 将所有计数器减半，相当于让历史访问的权重衰减一半
+func (s *Sketch) Reset() {
+	for i, w := range s.table {
+		s.table[i] = w >> 1 & 0x7777777777777777
+	}
+	s.additions /= 2
+}
@@ -0,0 +1,68 @@
+package tinylfu_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/cache/tinylfu"
+)
+
+// TestSketch 测试估计值不小于真实次数（上限 15）且误差有限
+func TestSketch(t *testing.T) {
+	s := tinylfu.NewSketch(1000)
+	// 键 i 访问 i%16 次，共约 7500 次，小于采样窗口，不会触发衰减
+	for i := range uint64(1000) {
+		for range i % 16 {
+			s.Add(mix(i))
+		}
+	}
+	over := 0
+	for i := range uint64(1000) {
+		got, want := s.Estimate(mix(i)), uint8(min(i%16, 15))
+		if got < want {
+			t.Fatalf("Estimate(%d) = %d，小于真实次数 %d", i, got, want)
+		}
+		if got > want {
+			over++
+		}
+	}
+	if over > 100 {
+		t.Errorf("%d 个键的估计值偏大，冲突过多", over)
+	}
+}
+
+// TestSketchReset 测试计数达到上限后饱和，Reset 后减半
+func TestSketchReset(t *testing.T) {
+	s := tinylfu.NewSketch(1000)
+	h := mix(42)
+	for range 20 {
+		s.Add(h)
+	}
+	if got := s.Estimate(h); got != 15 {
+		t.Errorf("饱和后 Estimate = %d，预期 15", got)
+	}
+	s.Reset()
+	if got := s.Estimate(h); got != 7 {
+		t.Errorf("Reset 后 Estimate = %d，预期 7", got)
+	}
+
+	// 采样窗口（10 倍容量）内的记录会自动触发衰减
+	small := tinylfu.NewSketch(4)
+	for range 15 {
+		small.Add(h)
+	}
+	for i := range uint64(40) {
+		small.Add(mix(1000 + i))
+	}
+	if got := small.Estimate(h); got >= 15 {
+		t.Errorf("超过采样窗口后 Estimate = %d，应已衰减", got)
+	}
+}
+
+// mix 是测试用的 64 位混合函数（splitmix64 的终结步骤）
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
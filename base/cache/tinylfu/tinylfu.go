@@ -0,0 +1,192 @@
+// Package tinylfu 实现 W-TinyLFU 缓存：以 count-min sketch 估计访问频率，决定新条目能否替换已有条目。
+//
+// 缓存分为两部分：
+//
+//   - 窗口区：约占容量的 1%，是普通的 LRU，新条目先进入这里，使突发的新热点能立即被缓存
+//   - 主区：分段 LRU（SLRU），由试用段与保护段组成，试用段中再次被访问的条目晋升到保护段
+//
+// 条目被挤出窗口区时成为候选者，只有其估计频率高于主区试用段末尾的条目时才会替换它，否则被丢弃。
+// 因此只访问一次的键（如扫描）无法挤出热点数据，在偏斜的负载下命中率明显高于 LRU。
+package tinylfu
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/internal/list"
+	"github.com/moweilong/efficient-go/base/hashx"
+)
+
+var _ cache.Cache[int, int] = (*Cache[int, int])(nil)
+
+// segment 标识条目所在的区域
+type segment uint8
+
+const (
+	window segment = iota
+	probation
+	protected
+)
+
+// entry 是链表元素中保存的条目
+type entry[K comparable, V any] struct {
+	key  K
+	val  V
+	hash uint64
+	seg  segment
+}
+
+// Cache 是 W-TinyLFU 缓存，零值不可用，应使用 New 创建
+type Cache[K comparable, V any] struct {
+	mu     sync.Mutex
+	seed   hashx.Seeded
+	sketch *Sketch
+	items  map[K]*list.Element[entry[K, V]]
+
+	window, probation, protected list.List[entry[K, V]]
+
+	windowCap, mainCap, protectedCap int
+
+	loads cache.LoadGroup[K, V] // GetOrLoad 的并发合并与负缓存
+}
+
+// New 创建容量为 capacity 的缓存，capacity 不为正数时 panic
+// 窗口区占 1%（至少 1 个条目），主区的 80% 为保护段
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("tinylfu: 容量必须大于 0")
+	}
+	windowCap := max(1, capacity/100)
+	mainCap := capacity - windowCap
+	return &Cache[K, V]{
+		seed:         hashx.NewSeeded(),
+		sketch:       NewSketch(capacity),
+		items:        make(map[K]*list.Element[entry[K, V]], capacity),
+		windowCap:    windowCap,
+		mainCap:      mainCap,
+		protectedCap: mainCap * 8 / 10,
+	}
+}
+
+// Get 返回键 k 对应的值；无论是否命中都会计入访问频率
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	h := hashx.Comparable(c.seed, k)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sketch.Add(h)
+	e, ok := c.items[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.touch(e)
+	return e.Value.val, true
+}
+
+// GetOrLoad 返回键 k 对应的值，未命中时调用 loader 加载并写入缓存
+// 对同一个键并发的未命中只调用一次 loader，见 cache.LoadGroup
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, k K, loader cache.Loader[K, V]) (V, error) {
+	return c.loads.GetOrLoad(ctx, c, k, loader)
+}
+
+// WithNegativeTTL 开启负缓存：GetOrLoad 中 loader 返回 cache.ErrNotFound 的键在 ttl 内直接返回 cache.ErrNotFound，
+// 不再调用 loader；最多记录与容量相同个数的键。应在使用缓存前调用
+func (c *Cache[K, V]) WithNegativeTTL(ttl time.Duration) *Cache[K, V] {
+	c.loads.SetNegative(cache.NewNegative[K](ttl, c.Cap()))
+	return c
+}
+
+// Set 设置键 k 对应的值
+// 新键先进入窗口区，由此挤出的候选者可能因频率不足而被丢弃，因此 Set 之后不保证能 Get 到
+func (c *Cache[K, V]) Set(k K, v V) {
+	c.loads.Forget(k)
+	h := hashx.Comparable(c.seed, k)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sketch.Add(h)
+	if e, ok := c.items[k]; ok {
+		e.Value.val = v
+		c.touch(e)
+		return
+	}
+	c.items[k] = c.window.PushFront(entry[K, V]{key: k, val: v, hash: h, seg: window})
+	if c.window.Len() > c.windowCap {
+		cand := c.window.Back()
+		c.window.Remove(cand)
+		c.admit(cand)
+	}
+}
+
+// Delete 删除键 k
+func (c *Cache[K, V]) Delete(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[k]; ok {
+		c.listOf(e.Value.seg).Remove(e)
+		delete(c.items, k)
+	}
+}
+
+// Len 返回当前条目数
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Cap 返回容量
+func (c *Cache[K, V]) Cap() int {
+	return c.windowCap + c.mainCap
+}
+
+// listOf 返回区域 seg 对应的链表
+func (c *Cache[K, V]) listOf(seg segment) *list.List[entry[K, V]] {
+	switch seg {
+	case window:
+		return &c.window
+	case probation:
+		return &c.probation
+	default:
+		return &c.protected
+	}
+}
+
+// touch 记录一次命中：试用段的条目晋升到保护段，其余条目移到所在链表的表头
+func (c *Cache[K, V]) touch(e *list.Element[entry[K, V]]) {
+	if e.Value.seg != probation {
+		c.listOf(e.Value.seg).MoveToFront(e)
+		return
+	}
+	c.probation.Remove(e)
+	e.Value.seg = protected
+	c.protected.PushElementFront(e)
+	if c.protected.Len() > c.protectedCap {
+		// 保护段溢出时末尾的条目降回试用段，再获得一次被访问的机会
+		d := c.protected.Back()
+		c.protected.Remove(d)
+		d.Value.seg = probation
+		c.probation.PushElementFront(d)
+	}
+}
+
+// admit 决定被挤出窗口区的候选者 cand 能否进入主区
+func (c *Cache[K, V]) admit(cand *list.Element[entry[K, V]]) {
+	cand.Value.seg = probation
+	if c.probation.Len()+c.protected.Len() < c.mainCap {
+		c.probation.PushElementFront(cand)
+		return
+	}
+	victim := c.probation.Back()
+	if victim == nil {
+		victim = c.protected.Back()
+	}
+	if victim == nil || c.sketch.Estimate(cand.Value.hash) <= c.sketch.Estimate(victim.Value.hash) {
+		delete(c.items, cand.Value.key)
+		return
+	}
+	c.listOf(victim.Value.seg).Remove(victim)
+	delete(c.items, victim.Value.key)
+	c.probation.PushElementFront(cand)
+}
@@ -0,0 +1,121 @@
+package tinylfu_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/cache"
+	"github.com/moweilong/efficient-go/base/cache/internal/trace"
+	"github.com/moweilong/efficient-go/base/cache/lru"
+	"github.com/moweilong/efficient-go/base/cache/tinylfu"
+)
+
+// TestCache 测试基本读写、更新与删除
+func TestCache(t *testing.T) {
+	c := tinylfu.New[string, int](100)
+	c.Set("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v", v, ok)
+	}
+	c.Set("a", 2)
+	if v, _ := c.Get("a"); v != 2 {
+		t.Errorf("更新后 Get(a) = %d", v)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok || c.Len() != 0 {
+		t.Errorf("Delete 后仍能读取，Len() = %d", c.Len())
+	}
+	if c.Cap() != 100 {
+		t.Errorf("Cap() = %d", c.Cap())
+	}
+}
+
+// TestCapacity 测试条目数不超过容量，且命中条目在各区域之间移动后仍可读取
+func TestCapacity(t *testing.T) {
+	for _, capacity := range []int{1, 2, 10, 250} {
+		c := tinylfu.New[uint64, uint64](capacity)
+		for _, k := range trace.Zipf(int64(capacity), 1.1, 10*uint64(capacity)+10, 20*capacity+100) {
+			if v, ok := c.Get(k); ok && v != k {
+				t.Fatalf("cap=%d: Get(%d) = %d", capacity, k, v)
+			}
+			c.Set(k, k)
+			if n := c.Len(); n > capacity {
+				t.Fatalf("cap=%d: Len() = %d 超过容量", capacity, n)
+			}
+		}
+	}
+}
+
+// TestScanResistance 测试一次性的扫描不会挤出频繁访问的热点键
+func TestScanResistance(t *testing.T) {
+	c := tinylfu.New[uint64, uint64](100)
+	hot := trace.Loop(0, 50, 1000)
+	trace.HitRatio(c, hot)
+	trace.HitRatio(c, trace.Loop(1000, 10000, 10000)) // 扫描 10000 个只访问一次的键
+	if got := trace.HitRatio(c, trace.Loop(0, 50, 50)); got < 0.9 {
+		t.Errorf("扫描后热点键命中率为 %.2f，预期不低于 0.9", got)
+	}
+
+	l := lru.New[uint64, uint64](100)
+	trace.HitRatio(l, hot)
+	trace.HitRatio(l, trace.Loop(1000, 10000, 10000))
+	if got := trace.HitRatio(l, trace.Loop(0, 50, 50)); got != 0 {
+		t.Errorf("LRU 扫描后热点键命中率为 %.2f，预期为 0", got)
+	}
+}
+
+// workloads 是命中率模拟使用的访问序列
+var workloads = []struct {
+	name     string
+	capacity int
+	keys     []uint64
+}{
+	{"zipf-1.01", 1000, trace.Zipf(1, 1.01, 100000, 200000)},
+	{"zipf-1.2", 1000, trace.Zipf(2, 1.2, 100000, 200000)},
+	// Zipf 热点中穿插大范围扫描，模拟在线请求与后台批处理混合的负载
+	{"zipf+scan", 1000, trace.Interleave(trace.Zipf(3, 1.1, 100000, 100000), 1000, trace.Loop(1_000_000, 100000, 100000), 1000)},
+}
+
+// TestHitRatio 在 Zipf 负载上对比 TinyLFU 与 LRU 的命中率
+func TestHitRatio(t *testing.T) {
+	for _, w := range workloads {
+		t.Run(w.name, func(t *testing.T) {
+			got := trace.HitRatio(tinylfu.New[uint64, uint64](w.capacity), w.keys)
+			base := trace.HitRatio(lru.New[uint64, uint64](w.capacity), w.keys)
+			t.Logf("TinyLFU %.4f，LRU %.4f", got, base)
+			if got <= base {
+				t.Errorf("TinyLFU 命中率 %.4f 不高于 LRU 的 %.4f", got, base)
+			}
+		})
+	}
+}
+
+// BenchmarkHitRatio 报告各负载下 TinyLFU 与 LRU 的命中率与每次访问的开销
+func BenchmarkHitRatio(b *testing.B) {
+	impls := []struct {
+		name string
+		new  func(int) cache.Cache[uint64, uint64]
+	}{
+		{"LRU", func(n int) cache.Cache[uint64, uint64] { return lru.New[uint64, uint64](n) }},
+		{"TinyLFU", func(n int) cache.Cache[uint64, uint64] { return tinylfu.New[uint64, uint64](n) }},
+	}
+	for _, w := range workloads {
+		for _, impl := range impls {
+			b.Run(fmt.Sprintf("%s/%s", w.name, impl.name), func(b *testing.B) {
+				c := impl.new(w.capacity)
+				hits, misses := 0, 0
+				for i := 0; i < b.N; i++ {
+					k := w.keys[i%len(w.keys)]
+					if _, ok := c.Get(k); ok {
+						hits++
+					} else {
+						misses++
+						c.Set(k, k)
+					}
+				}
+				benchkit.ReportHitRatio(b, hits, misses)
+			})
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package compresspool 复用 compress/flate 与 compress/gzip 的压缩器与解压器，以 Append 形式压缩与解压整块数据。
+//
+// flate 压缩器内部有约 1MiB 的哈希表与窗口，gzip.NewWriter/flate.NewWriter 每次调用都重新分配并初始化它们，
+// 对小消息而言这部分开销远超压缩本身；解压器也有约 40KiB 的状态。
+// Pool 按压缩级别把压缩器放入各自的 sync.Pool，通过 Reset 复用，稳定状态下压缩与解压都不分配内存。
+package compresspool
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+var (
+	// ErrLevel 表示压缩级别不在 [flate.HuffmanOnly, flate.BestCompression] 内
+	ErrLevel = errors.New("compresspool: 非法的压缩级别")
+	// ErrTooLarge 表示解压结果超出调用方给出的上限
+	ErrTooLarge = errors.New("compresspool: 解压结果超出上限")
+)
+
+// Format 是压缩数据的格式
+type Format uint8
+
+const (
+	FormatFlate Format = iota // RFC 1951 原始 DEFLATE 数据
+	FormatGzip                // RFC 1952 gzip 格式，带有头部与 CRC-32 校验
+)
+
+// 压缩级别的范围，与 compress/flate 一致；DefaultCompression 为 -1
+const (
+	minLevel = flate.HuffmanOnly
+	maxLevel = flate.BestCompression
+)
+
+// compressor 是 flate.Writer 与 gzip.Writer 共同的方法
+type compressor interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// sliceWriter 把写入的数据追加到 b
+type sliceWriter struct {
+	b []byte
+}
+
+func (w *sliceWriter) Write(p []byte) (int, error) {
+	w.b = append(w.b, p...)
+	return len(p), nil
+}
+
+// writer 是池中的压缩器及其输出
+type writer struct {
+	c   compressor
+	out sliceWriter
+}
+
+// reader 是池中的解压器及其输入
+type reader struct {
+	r   io.Reader
+	src bytes.Reader
+}
+
+// Pool 按压缩级别缓存某种格式的压缩器与解压器，零值不可用，应使用 New 创建
+//
+// 同一个 Pool 可以被多个 goroutine 并发使用。
+type Pool struct {
+	format  Format
+	writers [maxLevel - minLevel + 1]sync.Pool // writers[level-minLevel] 中为 *writer
+	readers sync.Pool                          // *reader
+}
+
+// New 创建格式为 f 的 Pool
+func New(f Format) *Pool {
+	if f != FormatFlate && f != FormatGzip {
+		panic("compresspool: 未知的格式")
+	}
+	return &Pool{format: f}
+}
+
+// Flate 与 Gzip 是两种格式的默认 Pool
+var (
+	Flate = New(FormatFlate)
+	Gzip  = New(FormatGzip)
+)
+
+// Compress 以 level 压缩 src 并把结果追加到 dst，level 的取值与 compress/flate 相同
+func (p *Pool) Compress(dst, src []byte, level int) ([]byte, error) {
+	if level < minLevel || level > maxLevel {
+		return dst, fmt.Errorf("%w %d", ErrLevel, level)
+	}
+	pool := &p.writers[level-minLevel]
+	w, _ := pool.Get().(*writer)
+	if w == nil {
+		w = &writer{}
+		var err error
+		if w.c, err = p.newCompressor(&w.out, level); err != nil {
+			return dst, err
+		}
+	}
+	w.out.b = dst
+	w.c.Reset(&w.out)
+	_, err := w.c.Write(src)
+	if err == nil {
+		err = w.c.Close()
+	}
+	dst, w.out.b = w.out.b, nil
+	pool.Put(w)
+	return dst, err
+}
+
+// newCompressor 创建写入 out 的压缩器
+func (p *Pool) newCompressor(out io.Writer, level int) (compressor, error) {
+	if p.format == FormatGzip {
+		return gzip.NewWriterLevel(out, level)
+	}
+	return flate.NewWriter(out, level)
+}
+
+// Decompress 解压 src 并把结果追加到 dst，解压结果超过 maxSize 字节时返回 ErrTooLarge，maxSize 不为正数时不限制
+// 出错时返回原来的 dst 与错误；限制解压结果的大小可以防御很小的输入解压出巨量数据的压缩炸弹
+func (p *Pool) Decompress(dst, src []byte, maxSize int) ([]byte, error) {
+	r, _ := p.readers.Get().(*reader)
+	if r == nil {
+		r = &reader{}
+	}
+	r.src.Reset(src)
+	if err := p.resetDecompressor(r); err != nil {
+		p.readers.Put(r)
+		if err == io.EOF { // gzip 头部之前就已结束
+			err = io.ErrUnexpectedEOF
+		}
+		return dst, err
+	}
+
+	start := len(dst)
+	var err error
+	for {
+		if len(dst) == cap(dst) {
+			dst = append(dst, 0)[:len(dst)]
+		}
+		var n int
+		n, err = r.r.Read(dst[len(dst):cap(dst)])
+		dst = dst[:len(dst)+n]
+		if maxSize > 0 && len(dst)-start > maxSize {
+			err = fmt.Errorf("%w（%d 字节）", ErrTooLarge, maxSize)
+			break
+		}
+		if err != nil {
+			break
+		}
+	}
+	r.src.Reset(nil)
+	p.readers.Put(r)
+	if err != io.EOF {
+		return dst[:start], err
+	}
+	return dst, nil
+}
+
+// resetDecompressor 使解压器从 r.src 读取，首次使用时创建解压器
+func (p *Pool) resetDecompressor(r *reader) error {
+	switch {
+	case r.r == nil && p.format == FormatGzip:
+		// gzip.NewReader 立即读取头部，头部非法时不保留解压器
+		zr, err := gzip.NewReader(&r.src)
+		if err != nil {
+			return err
+		}
+		r.r = zr
+	case r.r == nil:
+		r.r = flate.NewReader(&r.src)
+	case p.format == FormatGzip:
+		return r.r.(*gzip.Reader).Reset(&r.src)
+	default:
+		return r.r.(flate.Resetter).Reset(&r.src, nil)
+	}
+	return nil
+}
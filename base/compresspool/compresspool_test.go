@@ -0,0 +1,218 @@
+package compresspool_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/compresspool"
+)
+
+// payload 返回约 n 字节、具有一定重复度的类 JSON 文本
+func payload(n int) []byte {
+	var b bytes.Buffer
+	for i := 0; b.Len() < n; i++ {
+		fmt.Fprintf(&b, `{"id":%d,"name":"user-%d","active":%t},`, i, i*7, i%3 == 0)
+	}
+	return b.Bytes()[:n]
+}
+
+var formats = map[string]*compresspool.Pool{"flate": compresspool.Flate, "gzip": compresspool.Gzip}
+
+// stdDecompress 用标准库解压
+func stdDecompress(t *testing.T, name string, data []byte) []byte {
+	t.Helper()
+	var r io.Reader
+	if name == "gzip" {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("gzip.NewReader 失败: %v", err)
+		}
+		r = zr
+	} else {
+		r = flate.NewReader(bytes.NewReader(data))
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("标准库解压失败: %v", err)
+	}
+	return out
+}
+
+// TestRoundTrip 测试所有级别下压缩结果能被标准库与 Decompress 还原
+func TestRoundTrip(t *testing.T) {
+	for name, p := range formats {
+		for level := flate.HuffmanOnly; level <= flate.BestCompression; level++ {
+			for _, src := range [][]byte{nil, []byte("a"), payload(100 << 10)} {
+				// 同一个级别压缩两次，第二次使用池中复用的压缩器
+				for range 2 {
+					enc, err := p.Compress([]byte("x"), src, level)
+					if err != nil {
+						t.Fatalf("%s level=%d: Compress 失败: %v", name, level, err)
+					}
+					if enc[0] != 'x' {
+						t.Fatalf("%s level=%d: dst 原有的内容被覆盖", name, level)
+					}
+					if got := stdDecompress(t, name, enc[1:]); !bytes.Equal(got, src) {
+						t.Fatalf("%s level=%d: 标准库解压结果与原始数据不一致", name, level)
+					}
+					got, err := p.Decompress([]byte("y"), enc[1:], 0)
+					if err != nil {
+						t.Fatalf("%s level=%d: Decompress 失败: %v", name, level, err)
+					}
+					if got[0] != 'y' || !bytes.Equal(got[1:], src) {
+						t.Fatalf("%s level=%d: 解压结果与原始数据不一致", name, level)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestDecompressStd 测试解压标准库压缩的数据
+func TestDecompressStd(t *testing.T) {
+	src := payload(10000)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(src[:5000])
+	zw.Close()
+	zw = gzip.NewWriter(&buf) // 多个 gzip 成员首尾相接
+	zw.Write(src[5000:])
+	zw.Close()
+	got, err := compresspool.Gzip.Decompress(nil, buf.Bytes(), 0)
+	if err != nil || !bytes.Equal(got, src) {
+		t.Errorf("解压多成员 gzip 数据失败: %v", err)
+	}
+}
+
+// TestErrors 测试非法级别、损坏的数据与解压上限
+func TestErrors(t *testing.T) {
+	for name, p := range formats {
+		for _, level := range []int{-3, 10} {
+			if dst, err := p.Compress([]byte("keep"), []byte("a"), level); !errors.Is(err, compresspool.ErrLevel) || string(dst) != "keep" {
+				t.Errorf("%s level=%d: Compress = %q, %v，预期 ErrLevel", name, level, dst, err)
+			}
+		}
+
+		enc, _ := p.Compress(nil, payload(10000), flate.DefaultCompression)
+		for _, data := range [][]byte{nil, enc[:len(enc)/2], []byte(strings.Repeat("\xff", 100))} {
+			dst, err := p.Decompress([]byte("keep"), data, 0)
+			if err == nil || string(dst) != "keep" {
+				t.Errorf("%s: 解压 %d 字节的损坏数据 = %d 字节, %v，预期保持 dst 不变并返回错误", name, len(data), len(dst), err)
+			}
+		}
+
+		if _, err := p.Decompress(nil, enc, 9999); !errors.Is(err, compresspool.ErrTooLarge) {
+			t.Errorf("%s: 超出上限 err = %v，预期 ErrTooLarge", name, err)
+		}
+		if got, err := p.Decompress(nil, enc, 10000); err != nil || len(got) != 10000 {
+			t.Errorf("%s: 恰好等于上限时解压失败: %v", name, err)
+		}
+
+		// 出错后池中的解压器仍然可用
+		if got, err := p.Decompress(nil, enc, 0); err != nil || !bytes.Equal(got, payload(10000)) {
+			t.Errorf("%s: 出错后再次解压失败: %v", name, err)
+		}
+	}
+}
+
+// TestConcurrent 测试多个 goroutine 并发使用同一个 Pool
+func TestConcurrent(t *testing.T) {
+	src := payload(20000)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var enc, dec []byte
+			for i := range 20 {
+				var err error
+				enc, err = compresspool.Gzip.Compress(enc[:0], src, (g+i)%10)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				dec, err = compresspool.Gzip.Decompress(dec[:0], enc, 0)
+				if err != nil || !bytes.Equal(dec, src) {
+					t.Errorf("并发往返失败: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestAllocs 测试稳定状态下压缩与解压基本不分配内存
+// 池中的对象可能在 GC 时被回收，重新创建时会有少量分配，因此允许平均不到 1 次
+func TestAllocs(t *testing.T) {
+	src := payload(4096)
+	enc := make([]byte, 0, 8192)
+	dec := make([]byte, 0, 8192)
+	for name, p := range formats {
+		t.Run(name, func(t *testing.T) {
+			benchkit.AssertAllocs(t, 0.9, func() {
+				enc, _ = p.Compress(enc[:0], src, flate.BestSpeed)
+				dec, _ = p.Decompress(dec[:0], enc, 0)
+			})
+		})
+	}
+}
+
+// BenchmarkCompress 对比复用的压缩器与每次调用 gzip.NewWriterLevel 的开销
+func BenchmarkCompress(b *testing.B) {
+	for _, size := range []int{1 << 10, 64 << 10} {
+		src := payload(size)
+		b.Run(fmt.Sprintf("size=%d/pool", size), func(b *testing.B) {
+			dst := make([]byte, 0, size)
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dst, _ = compresspool.Gzip.Compress(dst[:0], src, flate.DefaultCompression)
+			}
+			benchkit.SinkBytes = dst
+		})
+		b.Run(fmt.Sprintf("size=%d/NewWriter", size), func(b *testing.B) {
+			var buf bytes.Buffer
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				zw, _ := gzip.NewWriterLevel(&buf, flate.DefaultCompression)
+				zw.Write(src)
+				zw.Close()
+			}
+			benchkit.SinkBytes = buf.Bytes()
+		})
+	}
+}
+
+// BenchmarkDecompress 对比复用的解压器与每次调用 gzip.NewReader 的开销
+func BenchmarkDecompress(b *testing.B) {
+	src := payload(1 << 10)
+	enc, _ := compresspool.Gzip.Compress(nil, src, flate.DefaultCompression)
+	b.Run("pool", func(b *testing.B) {
+		dst := make([]byte, 0, len(src))
+		b.SetBytes(int64(len(src)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dst, _ = compresspool.Gzip.Decompress(dst[:0], enc, 0)
+		}
+		benchkit.SinkBytes = dst
+	})
+	b.Run("NewReader", func(b *testing.B) {
+		b.SetBytes(int64(len(src)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			zr, _ := gzip.NewReader(bytes.NewReader(enc))
+			benchkit.SinkBytes, _ = io.ReadAll(zr)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+// Package counter 提供高并发下仍能扩展的计数器。
+//
+// 所有 goroutine 对同一个 atomic.Int64 执行 Add 时，该变量所在的缓存行在核心之间反复转移，
+// 核心越多吞吐越低。Striped 把增量分散到多个独立缓存行上的分片，读取时再求和，
+// 适合写多读少的热点指标，例如请求数与字节数统计。
+package counter
+
+import (
+	"math/rand/v2"
+	"runtime"
+
+	"github.com/moweilong/efficient-go/base/bit"
+	"github.com/moweilong/efficient-go/base/pad"
+)
+
+// Striped 是分片计数器，零值不可用，应使用 NewStriped 创建
+//
+// Load 不是快照：与 Add 并发时返回的值可能不包含正在进行的增量，但每个已完成的 Add 都会被计入。
+type Striped struct {
+	mask  uint32
+	cells []pad.PaddedUint64
+}
+
+// NewStriped 创建至少有 shards 个分片的计数器，分片数向上取整为 2 的幂；
+// shards 不为正数时使用 GOMAXPROCS 的 2 倍
+func NewStriped(shards int) *Striped {
+	if shards <= 0 {
+		shards = 2 * runtime.GOMAXPROCS(0)
+	}
+	n := bit.NextPowerOfTwo(uint64(shards))
+	return &Striped{mask: uint32(n - 1), cells: make([]pad.PaddedUint64, n)}
+}
+
+// Shards 返回分片数
+func (c *Striped) Shards() int {
+	return len(c.cells)
+}
+
+// Add 将计数器加上 delta，delta 可以为负数
+// 分片由 math/rand/v2 的每线程随机数选取，不需要共享状态，并发的 goroutine 大概率落在不同分片上
+func (c *Striped) Add(delta int64) {
+	c.cells[rand.Uint32()&c.mask].Add(uint64(delta))
+}
+
+// Inc 将计数器加 1
+func (c *Striped) Inc() {
+	c.Add(1)
+}
+
+// Load 返回所有分片之和，开销与分片数成正比
+func (c *Striped) Load() int64 {
+	var sum uint64
+	for i := range c.cells {
+		sum += c.cells[i].Load()
+	}
+	return int64(sum)
+}
+
+// Reset 将计数器清零并返回清零前的值，与 Add 并发时不会丢失增量
+func (c *Striped) Reset() int64 {
+	var sum uint64
+	for i := range c.cells {
+		sum += c.cells[i].Swap(0)
+	}
+	return int64(sum)
+}
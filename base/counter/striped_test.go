@@ -0,0 +1,103 @@
+package counter_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/counter"
+)
+
+// TestNewStriped 测试分片数的取整
+func TestNewStriped(t *testing.T) {
+	tests := []struct {
+		shards, want int
+	}{
+		{1, 1},
+		{3, 4},
+		{8, 8},
+		{9, 16},
+	}
+	for _, tt := range tests {
+		if got := counter.NewStriped(tt.shards).Shards(); got != tt.want {
+			t.Errorf("NewStriped(%d).Shards() = %d，预期 %d", tt.shards, got, tt.want)
+		}
+	}
+	if got := counter.NewStriped(0).Shards(); got < 2 {
+		t.Errorf("NewStriped(0).Shards() = %d", got)
+	}
+}
+
+// TestStriped 测试并发增减后的总和与 Reset
+func TestStriped(t *testing.T) {
+	c := counter.NewStriped(8)
+	const goroutines, n = 8, 10000
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range n {
+				c.Inc()
+				if g%2 == 0 {
+					c.Add(-1)
+					c.Add(2)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	want := int64(goroutines*n + goroutines/2*n)
+	if got := c.Load(); got != want {
+		t.Errorf("Load() = %d，预期 %d", got, want)
+	}
+	if got := c.Reset(); got != want {
+		t.Errorf("Reset() = %d，预期 %d", got, want)
+	}
+	if got := c.Load(); got != 0 {
+		t.Errorf("Reset 后 Load() = %d", got)
+	}
+	c.Add(-5)
+	if got := c.Load(); got != -5 {
+		t.Errorf("Load() = %d，预期 -5", got)
+	}
+}
+
+// TestStripedAllocs 测试 Add 与 Load 不分配内存
+func TestStripedAllocs(t *testing.T) {
+	c := counter.NewStriped(4)
+	benchkit.AssertAllocs(t, 0, func() {
+		c.Inc()
+		benchkit.SinkInt = int(c.Load())
+	})
+}
+
+// BenchmarkContention 对比高并发递增时单个原子变量与分片计数器的开销
+// 单核环境下两者差别不大，核心越多 Striped 的优势越明显
+func BenchmarkContention(b *testing.B) {
+	b.Run("atomic.Int64", func(b *testing.B) {
+		var c atomic.Int64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				c.Add(1)
+			}
+		})
+		benchkit.SinkInt = int(c.Load())
+	})
+	b.Run("Striped", func(b *testing.B) {
+		c := counter.NewStriped(0)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				c.Inc()
+			}
+		})
+		benchkit.SinkInt = int(c.Load())
+	})
+	b.Run("Striped/Load", func(b *testing.B) {
+		c := counter.NewStriped(0)
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkInt = int(c.Load())
+		}
+	})
+}
@@ -0,0 +1,23 @@
+//go:build amd64 && !purego
+
+package crcx
+
+// hasSSE42 表示 CPU 是否支持 SSE4.2 指令集（CPUID.(EAX=1):ECX 第 20 位），CRC32 指令属于该指令集
+var hasSSE42 = detectSSE42()
+
+func detectSSE42() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 1 {
+		return false
+	}
+	_, _, ecx, _ := cpuid(1, 0)
+	return ecx&(1<<20) != 0
+}
+
+// cpuid 执行 CPUID 指令，在 crc_amd64.s 中实现
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// castagnoliSSE42 用 CRC32 指令更新未取反的 CRC-32C 状态，在 crc_amd64.s 中实现
+//
+//go:noescape
+func castagnoliSSE42(crc uint32, p []byte) uint32
@@ -0,0 +1,44 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func castagnoliSSE42(crc uint32, p []byte) uint32
+TEXT ·castagnoliSSE42(SB), NOSPLIT, $0-36
+	MOVL crc+0(FP), AX
+	MOVQ p_base+8(FP), SI
+	MOVQ p_len+16(FP), CX
+
+	// 每次处理 8 个字节
+	CMPQ CX, $8
+	JB   tail
+loop8:
+	CRC32Q (SI), AX
+	ADDQ   $8, SI
+	SUBQ   $8, CX
+	CMPQ   CX, $8
+	JAE    loop8
+
+	// 剩余不足 8 个字节时逐字节处理
+tail:
+	TESTQ CX, CX
+	JZ    done
+loop1:
+	CRC32B (SI), AX
+	INCQ   SI
+	DECQ   CX
+	JNZ    loop1
+
+done:
+	MOVL AX, ret+32(FP)
+	RET
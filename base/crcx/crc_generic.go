@@ -0,0 +1,8 @@
+//go:build !amd64 || purego
+
+package crcx
+
+// hasSSE42 在非 amd64 平台上恒为 false，始终使用软件实现
+const hasSSE42 = false
+
+func castagnoliSSE42(crc uint32, p []byte) uint32 { return update32(crc, castagnoliTable, p) }
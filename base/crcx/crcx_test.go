@@ -0,0 +1,128 @@
+package crcx_test
+
+import (
+	"fmt"
+	"hash/crc32"
+	"hash/crc64"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/crcx"
+)
+
+var (
+	castagnoli = crc32.MakeTable(crc32.Castagnoli)
+	iso        = crc64.MakeTable(crc64.ISO)
+)
+
+// TestKnownValues 测试标准校验值（"123456789" 的校验和）
+func TestKnownValues(t *testing.T) {
+	p := []byte("123456789")
+	if got := crcx.Castagnoli(p); got != 0xe3069283 {
+		t.Errorf("Castagnoli = 0x%08x，预期 0xe3069283", got)
+	}
+	if got := crcx.ISO(p); got != 0xb90956c775a41001 {
+		t.Errorf("ISO = 0x%016x，预期 0xb90956c775a41001", got)
+	}
+	if crcx.Castagnoli(nil) != 0 || crcx.ISO(nil) != 0 {
+		t.Errorf("空输入的校验和应为 0")
+	}
+}
+
+// TestAgainstStdlib 测试各种长度与对齐下与标准库结果一致，且硬件与软件路径一致
+func TestAgainstStdlib(t *testing.T) {
+	t.Logf("SSE4.2 硬件路径: %v", crcx.HasSSE42)
+	r := rand.New(rand.NewSource(1))
+	buf := make([]byte, 4096+8)
+	r.Read(buf)
+	for n := 0; n <= 4096; n = n*5/4 + 1 {
+		for off := range 8 {
+			p := buf[off : off+n]
+			if got, want := crcx.Castagnoli(p), crc32.Checksum(p, castagnoli); got != want {
+				t.Fatalf("Castagnoli(len=%d, off=%d) = 0x%08x，预期 0x%08x", n, off, got, want)
+			}
+			if got, want := crcx.UpdateCastagnoliGeneric(0, p), crc32.Checksum(p, castagnoli); got != want {
+				t.Fatalf("软件路径 Castagnoli(len=%d, off=%d) = 0x%08x，预期 0x%08x", n, off, got, want)
+			}
+			if got, want := crcx.ISO(p), crc64.Checksum(p, iso); got != want {
+				t.Fatalf("ISO(len=%d, off=%d) = 0x%016x，预期 0x%016x", n, off, got, want)
+			}
+		}
+	}
+}
+
+// TestDigest 测试分段写入与一次计算的结果一致
+func TestDigest(t *testing.T) {
+	p := make([]byte, 1000)
+	rand.New(rand.NewSource(2)).Read(p)
+	d32, d64 := crcx.NewCastagnoli(), crcx.NewISO()
+	for rest := p; len(rest) > 0; {
+		n := min(len(rest), 1+len(rest)%37)
+		d32.Write(rest[:n])
+		d64.Write(rest[:n])
+		rest = rest[n:]
+	}
+	if got, want := d32.Sum32(), crcx.Castagnoli(p); got != want {
+		t.Errorf("Digest32.Sum32 = 0x%08x，预期 0x%08x", got, want)
+	}
+	if got, want := d64.Sum64(), crcx.ISO(p); got != want {
+		t.Errorf("Digest64.Sum64 = 0x%016x，预期 0x%016x", got, want)
+	}
+
+	std := crc32.New(castagnoli)
+	std.Write(p)
+	if got, want := fmt.Sprintf("%x", d32.Sum([]byte{1})), fmt.Sprintf("%x", std.Sum([]byte{1})); got != want {
+		t.Errorf("Digest32.Sum = %s，预期 %s", got, want)
+	}
+
+	d32.Reset()
+	d64.Reset()
+	if d32.Sum32() != 0 || d64.Sum64() != 0 {
+		t.Errorf("Reset 后校验和应为 0")
+	}
+}
+
+// BenchmarkCastagnoli 对比硬件路径、软件路径与 hash/crc32
+func BenchmarkCastagnoli(b *testing.B) {
+	for _, n := range []int{15, 64, 255, 1024, 64 << 10} {
+		p := make([]byte, n)
+		b.Run(fmt.Sprintf("n=%d/dispatch", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkU64 = uint64(crcx.Castagnoli(p))
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/generic", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkU64 = uint64(crcx.UpdateCastagnoliGeneric(0, p))
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkU64 = uint64(crc32.Checksum(p, castagnoli))
+			}
+		})
+	}
+}
+
+// BenchmarkISO 对比 slicing-by-8 与 hash/crc64
+func BenchmarkISO(b *testing.B) {
+	for _, n := range []int{15, 64, 1024, 64 << 10} {
+		p := make([]byte, n)
+		b.Run(fmt.Sprintf("n=%d/crcx", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkU64 = crcx.ISO(p)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkU64 = crc64.Checksum(p, iso)
+			}
+		})
+	}
+}
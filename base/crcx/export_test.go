@@ -0,0 +1,7 @@
+package crcx
+
+// 导出内部实现，供 crcx_test 包对比硬件与软件路径
+var (
+	HasSSE42                = hasSSE42
+	UpdateCastagnoliGeneric = updateCastagnoliGeneric
+)
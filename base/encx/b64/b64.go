@@ -0,0 +1,215 @@
+// Package b64 实现 base64 编码与解码（RFC 4648 的标准与 URL 安全字母表，带填充与不带填充），
+// 以 Append 形式写入调用方的缓冲区，复用缓冲区时不产生内存分配，
+// 避免 encoding/base64 的 EncodeToString/DecodeString 每次分配结果字符串或切片。
+//
+// 主循环每次处理 6 个字节与 8 个字符：编码用一次 8 字节的大端读取取出 48 位，依次查表得到 8 个字符；
+// 解码查表得到 8 个 6 位数值，合并校验后拼成 48 位一次写出，比每次处理 3 个字节的循环少一半的边界检查与分支。
+//
+// 解码结果与 encoding/base64 相同，区别是不跳过输入中的换行符（\r、\n），遇到时作为非法字符报错。
+package b64
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrCorrupt 表示待解码的输入含有非法字符、填充错误或长度不完整
+var ErrCorrupt = errors.New("b64: 输入数据损坏")
+
+const (
+	stdAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	urlAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	padChar     = '='
+	invalidChar = 0xff // dec 表中非字母表字符的值
+)
+
+// Encoding 是一种 base64 编码方式，由字母表与是否填充决定
+type Encoding struct {
+	enc [64]byte
+	dec [256]byte
+	pad bool
+}
+
+var (
+	// Std 是标准字母表、带填充的编码（RFC 4648 第 4 节）
+	Std = newEncoding(stdAlphabet, true)
+	// URL 是 URL 与文件名安全字母表、带填充的编码（RFC 4648 第 5 节）
+	URL = newEncoding(urlAlphabet, true)
+	// RawStd 是标准字母表、不带填充的编码
+	RawStd = newEncoding(stdAlphabet, false)
+	// RawURL 是 URL 安全字母表、不带填充的编码，常用于 JWT 等放在 URL 中的令牌
+	RawURL = newEncoding(urlAlphabet, false)
+)
+
+func newEncoding(alphabet string, pad bool) *Encoding {
+	e := &Encoding{pad: pad}
+	copy(e.enc[:], alphabet)
+	for i := range e.dec {
+		e.dec[i] = invalidChar
+	}
+	for i := range len(alphabet) {
+		e.dec[alphabet[i]] = byte(i)
+	}
+	return e
+}
+
+// EncodedLen 返回 n 个字节编码后的长度
+func (e *Encoding) EncodedLen(n int) int {
+	if e.pad {
+		return (n + 2) / 3 * 4
+	}
+	return (n*8 + 5) / 6
+}
+
+// DecodedLen 返回长度为 n 的输入解码后的最大字节数
+func (e *Encoding) DecodedLen(n int) int {
+	if e.pad {
+		return n / 4 * 3
+	}
+	return n * 6 / 8
+}
+
+// AppendEncode 把 src 编码后追加到 dst
+func (e *Encoding) AppendEncode(dst, src []byte) []byte {
+	n := len(dst)
+	size := e.EncodedLen(len(src))
+	dst = slices.Grow(dst, size)[:n+size]
+	out := dst[n:]
+
+	i, j := e.encodeBlocks(out, src)
+	src, out = src[i:], out[j:]
+	for len(src) >= 3 {
+		v := uint(src[0])<<16 | uint(src[1])<<8 | uint(src[2])
+		out[0], out[1], out[2], out[3] = e.enc[v>>18&63], e.enc[v>>12&63], e.enc[v>>6&63], e.enc[v&63]
+		src, out = src[3:], out[4:]
+	}
+	switch len(src) {
+	case 1:
+		v := uint(src[0]) << 16
+		out[0], out[1] = e.enc[v>>18&63], e.enc[v>>12&63]
+		if e.pad {
+			out[2], out[3] = padChar, padChar
+		}
+	case 2:
+		v := uint(src[0])<<16 | uint(src[1])<<8
+		out[0], out[1], out[2] = e.enc[v>>18&63], e.enc[v>>12&63], e.enc[v>>6&63]
+		if e.pad {
+			out[3] = padChar
+		}
+	}
+	return dst
+}
+
+// encodeBlocks 每次把 src 中的 6 个字节编码为 8 个字符写入 out，返回消耗的字节数与写出的字符数
+// 每次读取 8 个字节而只使用前 6 个，因此在剩余不足 8 个字节时停止，由调用方处理尾部
+func (e *Encoding) encodeBlocks(out, src []byte) (int, int) {
+	i, j := 0, 0
+	for len(src)-i >= 8 && len(out)-j >= 8 {
+		x := binary.BigEndian.Uint64(src[i:])
+		o := out[j : j+8]
+		o[0], o[1], o[2], o[3] = e.enc[x>>58], e.enc[x>>52&63], e.enc[x>>46&63], e.enc[x>>40&63]
+		o[4], o[5], o[6], o[7] = e.enc[x>>34&63], e.enc[x>>28&63], e.enc[x>>22&63], e.enc[x>>16&63]
+		i, j = i+6, j+8
+	}
+	return i, j
+}
+
+// AppendDecode 把 src 解码后追加到 dst
+// 出错时返回原来的 dst 与包装了出错位置的 ErrCorrupt
+func (e *Encoding) AppendDecode(dst, src []byte) ([]byte, error) {
+	if e.pad && len(src)%4 != 0 {
+		return dst, corrupt(len(src) / 4 * 4)
+	}
+	n := len(dst)
+	// 多预留 2 个字节，使主循环可以每次写出 8 个字节而只保留前 6 个
+	size := e.DecodedLen(len(src))
+	dst = slices.Grow(dst, size+2)[:n+size+2]
+	out := dst[n:]
+
+	i, j := e.decodeBlocks(out, src)
+	for ; len(src)-i >= 4; i, j = i+4, j+3 {
+		a, b, c, d := e.dec[src[i]], e.dec[src[i+1]], e.dec[src[i+2]], e.dec[src[i+3]]
+		if (a|b|c|d)&0xc0 != 0 {
+			break // 非法字符或最后一组中的填充，交给下面处理
+		}
+		v := uint(a)<<18 | uint(b)<<12 | uint(c)<<6 | uint(d)
+		out[j], out[j+1], out[j+2] = byte(v>>16), byte(v>>8), byte(v)
+	}
+
+	// 最后一组：带填充时恰为 4 个字符，末尾可能有 1～2 个填充；不带填充时剩余 2～3 个字符
+	rest := src[i:]
+	if e.pad && len(rest) == 4 {
+		switch {
+		case rest[3] != padChar:
+			return dst[:n], e.invalid(src, i)
+		case rest[2] == padChar:
+			rest = rest[:2]
+		default:
+			rest = rest[:3]
+		}
+	}
+	switch len(rest) {
+	case 0:
+	case 2, 3:
+		a, b := e.dec[rest[0]], e.dec[rest[1]]
+		if (a|b)&0xc0 != 0 {
+			return dst[:n], e.invalid(src, i)
+		}
+		v := uint(a)<<18 | uint(b)<<12
+		out[j] = byte(v >> 16)
+		j++
+		if len(rest) == 3 {
+			c := e.dec[rest[2]]
+			if c&0xc0 != 0 {
+				return dst[:n], e.invalid(src, i)
+			}
+			v |= uint(c) << 6
+			out[j] = byte(v >> 8)
+			j++
+		}
+	default:
+		return dst[:n], e.invalid(src, i)
+	}
+	return dst[:n+j], nil
+}
+
+// decodeBlocks 每次把 src 中的 8 个字符解码为 6 个字节写入 out，返回消耗的字符数与写出的字节数
+// 每次写出 8 个字节而只保留前 6 个，遇到非法字符或填充、或剩余不足时停止，由调用方处理尾部并定位错误；
+// 带填充时保留最后 4 个字符不处理，使填充只会出现在调用方的最后一组中
+func (e *Encoding) decodeBlocks(out, src []byte) (int, int) {
+	limit := len(src)
+	if e.pad {
+		limit -= 4
+	}
+	i, j := 0, 0
+	for limit-i >= 8 && len(out)-j >= 8 {
+		s := src[i : i+8]
+		a0, a1, a2, a3 := e.dec[s[0]], e.dec[s[1]], e.dec[s[2]], e.dec[s[3]]
+		a4, a5, a6, a7 := e.dec[s[4]], e.dec[s[5]], e.dec[s[6]], e.dec[s[7]]
+		if (a0|a1|a2|a3|a4|a5|a6|a7)&0xc0 != 0 {
+			break
+		}
+		x := uint64(a0)<<58 | uint64(a1)<<52 | uint64(a2)<<46 | uint64(a3)<<40 |
+			uint64(a4)<<34 | uint64(a5)<<28 | uint64(a6)<<22 | uint64(a7)<<16
+		binary.BigEndian.PutUint64(out[j:], x)
+		i, j = i+8, j+6
+	}
+	return i, j
+}
+
+// invalid 返回 src 中从 from 开始第一个非法字符的错误
+// 最后一组中位置正确的填充不算非法字符，找不到非法字符时说明填充位置错误或长度不完整，报告 from
+func (e *Encoding) invalid(src []byte, from int) error {
+	for i := from; i < len(src); i++ {
+		if e.dec[src[i]] == invalidChar && !(e.pad && src[i] == padChar && i >= len(src)-2) {
+			return corrupt(i)
+		}
+	}
+	return corrupt(from)
+}
+
+func corrupt(pos int) error {
+	return fmt.Errorf("%w（位置 %d）", ErrCorrupt, pos)
+}
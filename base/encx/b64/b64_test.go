@@ -0,0 +1,201 @@
+package b64_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/encx/b64"
+)
+
+var encodings = []struct {
+	name string
+	enc  *b64.Encoding
+	std  *base64.Encoding
+}{
+	{"Std", b64.Std, base64.StdEncoding},
+	{"URL", b64.URL, base64.URLEncoding},
+	{"RawStd", b64.RawStd, base64.RawStdEncoding},
+	{"RawURL", b64.RawURL, base64.RawURLEncoding},
+}
+
+// TestAgainstStdlib 测试各种长度下编码与解码的结果与 encoding/base64 一致
+func TestAgainstStdlib(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, tt := range encodings {
+		for n := range 100 {
+			src := make([]byte, n)
+			r.Read(src)
+			enc := tt.enc.AppendEncode([]byte("prefix"), src)
+			if got, want := string(enc[6:]), tt.std.EncodeToString(src); got != want {
+				t.Fatalf("%s: AppendEncode(len=%d) = %q，预期 %q", tt.name, n, got, want)
+			}
+			if len(enc)-6 != tt.enc.EncodedLen(n) {
+				t.Fatalf("%s: EncodedLen(%d) = %d，实际 %d", tt.name, n, tt.enc.EncodedLen(n), len(enc)-6)
+			}
+			dec, err := tt.enc.AppendDecode([]byte("prefix"), enc[6:])
+			if err != nil {
+				t.Fatalf("%s: AppendDecode(%q) 失败: %v", tt.name, enc[6:], err)
+			}
+			if string(dec[:6]) != "prefix" || !bytes.Equal(dec[6:], src) {
+				t.Fatalf("%s: AppendDecode(%q) = %x，预期 %x", tt.name, enc[6:], dec[6:], src)
+			}
+		}
+	}
+}
+
+// TestDecodeCorrupt 测试非法输入
+func TestDecodeCorrupt(t *testing.T) {
+	testCases := []struct {
+		name string
+		enc  *b64.Encoding
+		src  string
+		pos  int
+	}{
+		{"长度不是 4 的倍数", b64.Std, "QUJD" + "QQ", 4},
+		{"非法字符", b64.Std, "QUJDREVG" + "R0!J", 10},
+		{"主循环中的非法字符", b64.Std, "QUJD*EVGR0hJ" + "SktM", 4},
+		{"填充过多", b64.Std, "Q===", 1},
+		{"填充后还有数据", b64.Std, "QQ==QUJD", 2},
+		{"填充位置错误", b64.Std, "QQ=A", 0},
+		{"URL 字母表中的 +", b64.URL, "ab+d", 2},
+		{"标准字母表中的 -", b64.Std, "ab-d", 2},
+		{"不带填充时出现填充", b64.RawStd, "QQ==", 2},
+		{"不带填充时剩余 1 个字符", b64.RawStd, "QUJDR", 4},
+		{"换行符", b64.Std, "QUJD\nREV", 4},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst, err := tc.enc.AppendDecode([]byte("x"), []byte(tc.src))
+			if !errors.Is(err, b64.ErrCorrupt) {
+				t.Fatalf("err = %v，预期 ErrCorrupt", err)
+			}
+			if want := fmt.Sprintf("位置 %d", tc.pos); !bytes.Contains([]byte(err.Error()), []byte(want)) {
+				t.Errorf("错误信息 %q 未包含 %q", err, want)
+			}
+			if string(dst) != "x" {
+				t.Errorf("出错时 dst = %q，预期保持原样", dst)
+			}
+		})
+	}
+}
+
+// TestAllocs 测试复用缓冲区时不分配内存
+func TestAllocs(t *testing.T) {
+	src := bytes.Repeat([]byte{0xab, 0xcd, 0xef}, 100)
+	enc := b64.Std.AppendEncode(nil, src)
+	buf := make([]byte, 0, 512)
+	benchkit.AssertAllocs(t, 0, func() {
+		buf = b64.Std.AppendEncode(buf[:0], src)
+		buf, _ = b64.Std.AppendDecode(buf[:0], enc)
+	})
+}
+
+// FuzzRoundTrip 测试任意数据编码后都能解码还原，且编码结果与 encoding/base64 一致
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte(""))
+	f.Add([]byte("f"))
+	f.Add([]byte("foobar"))
+	f.Add([]byte("\x00\xff\xfe\xfd\xfc\xfb\xfa\xf9\xf8"))
+	f.Fuzz(func(t *testing.T, src []byte) {
+		for _, tt := range encodings {
+			enc := tt.enc.AppendEncode(nil, src)
+			if want := tt.std.EncodeToString(src); string(enc) != want {
+				t.Fatalf("%s: AppendEncode(%x) = %q，预期 %q", tt.name, src, enc, want)
+			}
+			dec, err := tt.enc.AppendDecode(nil, enc)
+			if err != nil || !bytes.Equal(dec, src) {
+				t.Fatalf("%s: AppendDecode(%q) = (%x, %v)，预期 %x", tt.name, enc, dec, err, src)
+			}
+		}
+	})
+}
+
+// FuzzDecode 测试任意输入的解码结果与 encoding/base64 一致（输入中不含换行符时）
+func FuzzDecode(f *testing.F) {
+	f.Add([]byte("Zm9vYmFy"))
+	f.Add([]byte("Zm9vYg=="))
+	f.Add([]byte("Zm9vYmE="))
+	f.Add([]byte("Zm9v-_+/"))
+	f.Add([]byte("Zg=a"))
+	f.Fuzz(func(t *testing.T, src []byte) {
+		if bytes.ContainsAny(src, "\r\n") {
+			return
+		}
+		for _, tt := range encodings {
+			got, err := tt.enc.AppendDecode(nil, src)
+			want, stdErr := tt.std.AppendDecode(nil, src)
+			if (err == nil) != (stdErr == nil) {
+				t.Fatalf("%s: AppendDecode(%q) err = %v，encoding/base64 为 %v", tt.name, src, err, stdErr)
+			}
+			if err == nil && !bytes.Equal(got, want) {
+				t.Fatalf("%s: AppendDecode(%q) = %x，预期 %x", tt.name, src, got, want)
+			}
+		}
+	})
+}
+
+// BenchmarkEncode 对比 AppendEncode 与 encoding/base64 的 AppendEncode、EncodeToString
+func BenchmarkEncode(b *testing.B) {
+	for _, n := range []int{16, 256, 4096} {
+		src := make([]byte, n)
+		rand.New(rand.NewSource(1)).Read(src)
+		buf := make([]byte, 0, base64.StdEncoding.EncodedLen(n))
+		b.Run(fmt.Sprintf("n=%d/encx", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				buf = b64.Std.AppendEncode(buf[:0], src)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				buf = base64.StdEncoding.AppendEncode(buf[:0], src)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib-string", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkString = base64.StdEncoding.EncodeToString(src)
+			}
+		})
+	}
+}
+
+// BenchmarkDecode 对比 AppendDecode 与 encoding/base64 的 AppendDecode、DecodeString
+func BenchmarkDecode(b *testing.B) {
+	for _, n := range []int{16, 256, 4096} {
+		src := make([]byte, n)
+		rand.New(rand.NewSource(1)).Read(src)
+		enc := base64.StdEncoding.EncodeToString(src)
+		buf := make([]byte, 0, n+2)
+		b.Run(fmt.Sprintf("n=%d/encx", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				buf, _ = b64.Std.AppendDecode(buf[:0], []byte(enc))
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				buf, _ = base64.StdEncoding.AppendDecode(buf[:0], []byte(enc))
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib-string", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkBytes, _ = base64.StdEncoding.DecodeString(enc)
+			}
+		})
+	}
+}
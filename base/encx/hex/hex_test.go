@@ -0,0 +1,147 @@
+package hex_test
+
+import (
+	"bytes"
+	stdhex "encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/encx/hex"
+)
+
+// TestAgainstStdlib 测试各种长度下编码结果与 encoding/hex 一致，且大小写混合的输入都能解码
+func TestAgainstStdlib(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := range 100 {
+		src := make([]byte, n)
+		r.Read(src)
+		enc := hex.AppendEncode([]byte("prefix"), src)
+		if got, want := string(enc), "prefix"+stdhex.EncodeToString(src); got != want {
+			t.Fatalf("AppendEncode = %q，预期 %q", got, want)
+		}
+		for _, s := range []string{string(enc[6:]), strings.ToUpper(string(enc[6:]))} {
+			dec, err := hex.AppendDecode([]byte("prefix"), []byte(s))
+			if err != nil {
+				t.Fatalf("AppendDecode(%q) 失败: %v", s, err)
+			}
+			if !bytes.Equal(dec[6:], src) || string(dec[:6]) != "prefix" {
+				t.Fatalf("AppendDecode(%q) = %x，预期 %x", s, dec[6:], src)
+			}
+		}
+	}
+}
+
+// TestDecodeInvalid 测试每个位置上的每个非法字符都能被发现，并报告正确的位置
+func TestDecodeInvalid(t *testing.T) {
+	valid := []byte(strings.Repeat("0123456789abcdefABCDEF", 2))[:40]
+	for c := range 256 {
+		if strings.IndexByte("0123456789abcdefABCDEF", byte(c)) >= 0 {
+			continue
+		}
+		for pos := range valid {
+			src := bytes.Clone(valid)
+			src[pos] = byte(c)
+			dst, err := hex.AppendDecode([]byte("x"), src)
+			if !errors.Is(err, hex.ErrInvalidByte) {
+				t.Fatalf("字符 0x%02x 位于 %d: err = %v，预期 ErrInvalidByte", c, pos, err)
+			}
+			if !strings.Contains(err.Error(), fmt.Sprintf("位置 %d", pos)) {
+				t.Fatalf("错误信息 %q 未包含位置 %d", err, pos)
+			}
+			if string(dst) != "x" {
+				t.Fatalf("出错时 dst = %q，预期保持原样", dst)
+			}
+		}
+	}
+	if _, err := hex.AppendDecode(nil, []byte("abc")); !errors.Is(err, hex.ErrLength) {
+		t.Errorf("奇数长度: err = %v，预期 ErrLength", err)
+	}
+}
+
+// TestAllocs 测试复用缓冲区时不分配内存
+func TestAllocs(t *testing.T) {
+	src := bytes.Repeat([]byte{0xab}, 100)
+	enc := hex.AppendEncode(nil, src)
+	buf := make([]byte, 0, 256)
+	benchkit.AssertAllocs(t, 0, func() {
+		buf = hex.AppendEncode(buf[:0], src)
+		buf, _ = hex.AppendDecode(buf[:0], enc)
+	})
+}
+
+// FuzzDecode 测试解码结果与 encoding/hex 一致，成功时可以往返
+func FuzzDecode(f *testing.F) {
+	f.Add([]byte("00ff10aB"))
+	f.Add([]byte("0123456789abcdefABCDEF0123456789"))
+	f.Add([]byte("0123456789abcdeg"))
+	f.Fuzz(func(t *testing.T, src []byte) {
+		got, err := hex.AppendDecode(nil, src)
+		want, stdErr := stdhex.AppendDecode(nil, src)
+		if (err == nil) != (stdErr == nil) {
+			t.Fatalf("AppendDecode(%q) err = %v，encoding/hex 为 %v", src, err, stdErr)
+		}
+		if err != nil {
+			return
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("AppendDecode(%q) = %x，预期 %x", src, got, want)
+		}
+		if enc := hex.AppendEncode(nil, got); !bytes.EqualFold(enc, src) {
+			t.Fatalf("往返结果 %q 与 %q 不一致", enc, src)
+		}
+	})
+}
+
+// BenchmarkEncode 对比 AppendEncode 与 encoding/hex
+func BenchmarkEncode(b *testing.B) {
+	for _, n := range []int{16, 256, 4096} {
+		src := make([]byte, n)
+		rand.New(rand.NewSource(1)).Read(src)
+		buf := make([]byte, 0, 2*n)
+		b.Run(fmt.Sprintf("n=%d/encx", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				buf = hex.AppendEncode(buf[:0], src)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				buf = stdhex.AppendEncode(buf[:0], src)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib-string", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkString = stdhex.EncodeToString(src)
+			}
+		})
+	}
+}
+
+// BenchmarkDecode 对比 AppendDecode 与 encoding/hex
+func BenchmarkDecode(b *testing.B) {
+	for _, n := range []int{16, 256, 4096} {
+		src := make([]byte, n)
+		rand.New(rand.NewSource(1)).Read(src)
+		enc := hex.AppendEncode(nil, src)
+		buf := make([]byte, 0, n)
+		b.Run(fmt.Sprintf("n=%d/encx", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				buf, _ = hex.AppendDecode(buf[:0], enc)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/stdlib", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				buf, _ = stdhex.AppendDecode(buf[:0], enc)
+			}
+		})
+	}
+}
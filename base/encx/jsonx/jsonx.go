@@ -0,0 +1,140 @@
+// Package jsonx 提供 JSON 标量值的 Append 形式编码，输出与 encoding/json 的 json.Marshal 逐字节相同，
+// 供 cmd/jsonc 生成的代码与手写的编码器使用，复用缓冲区时不产生内存分配。
+//
+// 字符串的编码与 json.Marshal 的默认行为一致：转义 <、>、& 以及 U+2028、U+2029，
+// 非法的 UTF-8 字节替换为 U+FFFD（Go 1.24 及以前的 encoding/json 写作转义形式 \ufffd，两者解码结果相同）。
+// 主循环每次检查 8 个字节，整段都不需要转义时一次跳过，以 ASCII 为主的文本比逐字节查表快数倍。
+package jsonx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/moweilong/efficient-go/base/encx/b64"
+)
+
+// ErrUnsupportedValue 表示浮点数为 NaN 或 ±Inf，JSON 中没有对应的表示
+var ErrUnsupportedValue = errors.New("jsonx: 不支持的浮点数值")
+
+const (
+	lo8 = 0x0101010101010101
+	hi8 = 0x8080808080808080
+	hex = "0123456789abcdef"
+)
+
+// hasZero 返回 x 中是否有值为 0 的字节
+func hasZero(x uint64) bool { return (x-lo8)&^x&hi8 != 0 }
+
+// needEscape 返回 8 个字节中是否有需要转义或不是 ASCII 的字节
+func needEscape(x uint64) bool {
+	return (x-0x20*lo8)&^x&hi8 != 0 || x&hi8 != 0 ||
+		hasZero(x^'"'*lo8) || hasZero(x^'\\'*lo8) ||
+		hasZero(x^'<'*lo8) || hasZero(x^'>'*lo8) || hasZero(x^'&'*lo8)
+}
+
+// safe 标记不需要转义的 ASCII 字节
+var safe = func() (t [utf8.RuneSelf]bool) {
+	for b := ' '; b < utf8.RuneSelf; b++ {
+		t[b] = true
+	}
+	t['"'], t['\\'], t['<'], t['>'], t['&'] = false, false, false, false, false
+	return t
+}()
+
+// AppendString 把 s 编码为 JSON 字符串（含两侧引号）追加到 dst
+func AppendString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start, slow := 0, 0 // [i, slow) 内已知有需要逐字节处理的字节，不再做 8 字节检查
+	for i := 0; i < len(s); {
+		if i >= slow && len(s)-i >= 8 {
+			if !needEscape(binary.LittleEndian.Uint64([]byte(s[i : i+8]))) {
+				i += 8
+				continue
+			}
+			slow = i + 8
+		}
+		if b := s[i]; b < utf8.RuneSelf {
+			if safe[b] {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default: // 其余控制字符以及 <、>、&
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			dst = append(dst, s[start:i]...)
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+		case c == '\u2028' || c == '\u2029':
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[c&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// AppendBytes 把 b 按 json.Marshal 对 []byte 的规则编码追加到 dst：
+// nil 编码为 null，否则为标准 base64 编码的字符串
+func AppendBytes(dst, b []byte) []byte {
+	if b == nil {
+		return append(dst, "null"...)
+	}
+	dst = append(dst, '"')
+	dst = b64.Std.AppendEncode(dst, b)
+	return append(dst, '"')
+}
+
+// AppendFloat 把 bits 位（32 或 64）的浮点数 f 按 json.Marshal 的格式追加到 dst：
+// 绝对值在 [1e-6, 1e21) 内时使用普通小数形式，否则使用指数形式，都取能精确还原的最短表示
+// f 为 NaN 或 ±Inf 时返回原来的 dst 与包装了 ErrUnsupportedValue 的错误
+func AppendFloat(dst []byte, f float64, bits int) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return dst, fmt.Errorf("%w %v", ErrUnsupportedValue, f)
+	}
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// 把 e-09 写成 e-9，与 ECMAScript 的格式一致
+		if n := len(dst); n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, nil
+}
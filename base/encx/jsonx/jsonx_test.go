@@ -0,0 +1,174 @@
+package jsonx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/encx/jsonx"
+)
+
+// marshal 返回 json.Marshal 的结果
+// 旧版本的 encoding/json 把非法的 UTF-8 字节写作 \ufffd，统一为 U+FFFD 后再比较
+func marshal(t testing.TB, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) 失败: %v", v, err)
+	}
+	return strings.ReplaceAll(string(b), `\ufffd`, string(utf8.RuneError))
+}
+
+// TestAppendString 测试字符串的编码与 json.Marshal 一致
+func TestAppendString(t *testing.T) {
+	testCases := []string{
+		"",
+		"hello, world",
+		`引号" 反斜杠\ 斜杠/`,
+		"\b\f\n\r\t\x00\x01\x1f\x7f",
+		"<script>alert('&')</script>",
+		"行分隔符 段分隔符 ",
+		"非法\xff\xfe的 UTF-8\xe4\xb8",
+		"超过八个字节的纯 ASCII 文本，中间有一个\"引号",
+		strings.Repeat("abcdefg", 10) + "\n",
+		"\U0001F600 emoji",
+	}
+	for _, s := range testCases {
+		if got, want := string(jsonx.AppendString([]byte("x"), s)), "x"+marshal(t, s); got != want {
+			t.Errorf("AppendString(%q) = %s，预期 %s", s, got, want)
+		}
+	}
+}
+
+// TestAppendStringAllBytes 测试每个字节在 8 字节块的各个位置上的编码都与 json.Marshal 一致
+func TestAppendStringAllBytes(t *testing.T) {
+	for b := range 256 {
+		for pos := range 16 {
+			buf := []byte(strings.Repeat("a", 16))
+			buf[pos] = byte(b)
+			s := string(buf)
+			if got, want := string(jsonx.AppendString(nil, s)), marshal(t, s); got != want {
+				t.Fatalf("AppendString(%q) = %s，预期 %s", s, got, want)
+			}
+		}
+	}
+	for r := rune(0); r < 0x3000; r++ {
+		s := "ab" + string(r) + "cdefghij"
+		if got, want := string(jsonx.AppendString(nil, s)), marshal(t, s); got != want {
+			t.Fatalf("AppendString(%q) = %s，预期 %s", s, got, want)
+		}
+	}
+}
+
+// FuzzAppendString 测试任意输入的编码都与 json.Marshal 一致
+func FuzzAppendString(f *testing.F) {
+	f.Add("hello")
+	f.Add("< \xff>")
+	f.Fuzz(func(t *testing.T, s string) {
+		if got, want := string(jsonx.AppendString(nil, s)), marshal(t, s); got != want {
+			t.Fatalf("AppendString(%q) = %s，预期 %s", s, got, want)
+		}
+	})
+}
+
+// TestAppendBytes 测试 []byte 的编码与 json.Marshal 一致
+func TestAppendBytes(t *testing.T) {
+	for _, b := range [][]byte{nil, {}, {0}, []byte("hello"), {0xff, 0xfe, 0xfd, 0xfc}} {
+		if got, want := string(jsonx.AppendBytes(nil, b)), marshal(t, b); got != want {
+			t.Errorf("AppendBytes(%v) = %s，预期 %s", b, got, want)
+		}
+	}
+}
+
+// TestAppendFloat 测试浮点数的格式与 json.Marshal 一致
+func TestAppendFloat(t *testing.T) {
+	testCases := []float64{
+		0, math.Copysign(0, -1), 1, -1, 0.1, 1.5, 100, 1e20, 1e21, 123456789e15,
+		1e-6, 9.99e-7, 1e-7, 1e-9, 5e-324, math.MaxFloat64, -math.MaxFloat32, math.SmallestNonzeroFloat32,
+	}
+	r := rand.New(rand.NewSource(1))
+	for range 10000 {
+		f := math.Float64frombits(r.Uint64())
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			testCases = append(testCases, f)
+		}
+	}
+	for _, f := range testCases {
+		got, err := jsonx.AppendFloat(nil, f, 64)
+		if err != nil || string(got) != marshal(t, f) {
+			t.Fatalf("AppendFloat(%v, 64) = %s, %v，预期 %s", f, got, err, marshal(t, f))
+		}
+		f32 := float32(f)
+		if math.IsInf(float64(f32), 0) {
+			continue
+		}
+		got, err = jsonx.AppendFloat(nil, float64(f32), 32)
+		if err != nil || string(got) != marshal(t, f32) {
+			t.Fatalf("AppendFloat(%v, 32) = %s, %v，预期 %s", f32, got, err, marshal(t, f32))
+		}
+	}
+}
+
+// TestAppendFloatUnsupported 测试 NaN 与 ±Inf 返回错误且不修改 dst
+func TestAppendFloatUnsupported(t *testing.T) {
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		dst, err := jsonx.AppendFloat([]byte("keep"), f, 64)
+		if !errors.Is(err, jsonx.ErrUnsupportedValue) || string(dst) != "keep" {
+			t.Errorf("AppendFloat(%v) = %q, %v，预期 ErrUnsupportedValue", f, dst, err)
+		}
+	}
+}
+
+// TestAllocs 测试复用缓冲区时不分配内存
+func TestAllocs(t *testing.T) {
+	dst := make([]byte, 0, 256)
+	s := "需要转义的\"文本\"<>\n与纯 ASCII 文本"
+	benchkit.AssertAllocs(t, 0, func() {
+		dst = jsonx.AppendString(dst[:0], s)
+		dst, _ = jsonx.AppendFloat(dst, 1.25e-9, 64)
+		dst = jsonx.AppendBytes(dst, []byte(s))
+	})
+}
+
+var benchStrings = map[string]string{
+	"ascii":   strings.Repeat("The quick brown fox jumps over the lazy dog. ", 4),
+	"escaped": strings.Repeat("line\t\"quoted\" <b>&</b>\n", 8),
+	"cjk":     strings.Repeat("敏捷的棕色狐狸跳过了懒狗。", 8),
+}
+
+// BenchmarkAppendString 对比 AppendString 与 json.Marshal 编码字符串的开销
+func BenchmarkAppendString(b *testing.B) {
+	for name, s := range benchStrings {
+		b.Run(name+"/jsonx", func(b *testing.B) {
+			dst := make([]byte, 0, 2*len(s))
+			b.SetBytes(int64(len(s)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dst = jsonx.AppendString(dst[:0], s)
+			}
+			benchkit.SinkBytes = dst
+		})
+		b.Run(name+"/encoding_json", func(b *testing.B) {
+			b.SetBytes(int64(len(s)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkBytes, _ = json.Marshal(s)
+			}
+		})
+	}
+}
+
+// BenchmarkAppendFloat 测试浮点数格式化的开销
+func BenchmarkAppendFloat(b *testing.B) {
+	dst := make([]byte, 0, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dst, _ = jsonx.AppendFloat(dst[:0], 3.14159+float64(i&7), 64)
+	}
+	benchkit.SinkBytes = dst
+}
@@ -0,0 +1,127 @@
+// Package enum 为以 iota 定义的整数常量提供运行时描述，
+// 用于校验来自用户输入的取值并在名称与取值之间转换。
+package enum
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// ErrUnknown 表示名称不属于该枚举
+var ErrUnknown = errors.New("enum: 未知的枚举名称")
+
+// Integer 是枚举可用的底层整数类型
+type Integer interface {
+	bit.Signed | bit.Unsigned
+}
+
+// Of 描述枚举类型 T 的全部合法取值，通常在包级变量中构造一次后只读使用
+type Of[T Integer] struct {
+	names  []string
+	values []T
+	// contiguous 为 true 时取值恰好是 [min, max] 中的每个整数，IsValid 只需比较范围
+	contiguous bool
+	min, max   T
+}
+
+// New 按 iota 的方式构造枚举：第 i 个名称对应取值 i，名称为 "_" 的位置被跳过
+func New[T Integer](names ...string) *Of[T] {
+	e := &Of[T]{}
+	for i, name := range names {
+		if name != "_" {
+			e.Add(name, T(i))
+		}
+	}
+	return e
+}
+
+// Add 注册一个取值，返回 e 以便链式调用；名称为空或重复时 panic
+func (e *Of[T]) Add(name string, v T) *Of[T] {
+	if name == "" {
+		panic("enum: 名称不能为空")
+	}
+	for _, n := range e.names {
+		if n == name {
+			panic("enum: 名称重复 " + name)
+		}
+	}
+	e.names = append(e.names, name)
+	e.values = append(e.values, v)
+	e.update()
+	return e
+}
+
+// update 重新计算取值范围及是否连续
+func (e *Of[T]) update() {
+	e.min, e.max = e.values[0], e.values[0]
+	for _, v := range e.values[1:] {
+		e.min = min(e.min, v)
+		e.max = max(e.max, v)
+	}
+	// 取值互不相同且个数等于范围宽度时即为连续
+	e.contiguous = uint64(e.max-e.min) == uint64(len(e.values)-1) && !e.hasDuplicates()
+}
+
+func (e *Of[T]) hasDuplicates() bool {
+	seen := make(map[T]struct{}, len(e.values))
+	for _, v := range e.values {
+		if _, ok := seen[v]; ok {
+			return true
+		}
+		seen[v] = struct{}{}
+	}
+	return false
+}
+
+// Len 返回已注册的名称个数
+func (e *Of[T]) Len() int {
+	return len(e.names)
+}
+
+// IsValid 报告 v 是否为已注册的取值
+func (e *Of[T]) IsValid(v T) bool {
+	if len(e.values) == 0 {
+		return false
+	}
+	if e.contiguous {
+		return v >= e.min && v <= e.max
+	}
+	for _, x := range e.values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Name 返回 v 对应的名称，同一取值注册了多个名称时返回最先注册的
+func (e *Of[T]) Name(v T) (string, bool) {
+	for i, x := range e.values {
+		if x == v {
+			return e.names[i], true
+		}
+	}
+	return "", false
+}
+
+// Parse 将名称解析为取值，未知名称返回包装了 ErrUnknown 的错误
+func (e *Of[T]) Parse(name string) (T, error) {
+	for i, n := range e.names {
+		if n == name {
+			return e.values[i], nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q", ErrUnknown, name)
+}
+
+// Names 按注册顺序返回全部名称
+func (e *Of[T]) Names() []string {
+	return append([]string(nil), e.names...)
+}
+
+// Values 按注册顺序返回全部取值
+func (e *Of[T]) Values() []T {
+	return append([]T(nil), e.values...)
+}
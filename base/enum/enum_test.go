@@ -0,0 +1,95 @@
+package enum_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/enum"
+)
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+)
+
+var weekdays = enum.New[Weekday]("Sunday", "Monday", "Tuesday")
+
+type Code uint16
+
+var codes = enum.New[Code]().Add("OK", 200).Add("NotFound", 404).Add("Missing", 404).Add("Error", 500)
+
+// TestIsValid 测试连续与稀疏两种取值的校验
+func TestIsValid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		valid    func() bool
+		expected bool
+	}{
+		{"连续-下界", func() bool { return weekdays.IsValid(Sunday) }, true},
+		{"连续-上界", func() bool { return weekdays.IsValid(Tuesday) }, true},
+		{"连续-越界", func() bool { return weekdays.IsValid(3) }, false},
+		{"连续-负数", func() bool { return weekdays.IsValid(-1) }, false},
+		{"稀疏-命中", func() bool { return codes.IsValid(404) }, true},
+		{"稀疏-范围内未注册", func() bool { return codes.IsValid(300) }, false},
+		{"空枚举", func() bool { return enum.New[uint8]().IsValid(0) }, false},
+		{"跳过空白", func() bool { return enum.New[uint8]("A", "_", "C").IsValid(1) }, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.valid(); got != tc.expected {
+				t.Errorf("IsValid = %v，预期 %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestParse 测试名称解析与反查
+func TestParse(t *testing.T) {
+	if v, err := weekdays.Parse("Monday"); err != nil || v != Monday {
+		t.Errorf("Parse(Monday) = (%v, %v)", v, err)
+	}
+	if v, err := codes.Parse("Missing"); err != nil || v != 404 {
+		t.Errorf("Parse(Missing) = (%v, %v)", v, err)
+	}
+	if _, err := weekdays.Parse("monday"); !errors.Is(err, enum.ErrUnknown) {
+		t.Errorf("Parse(monday) 错误为 %v，预期 ErrUnknown", err)
+	}
+
+	if name, ok := codes.Name(404); !ok || name != "NotFound" {
+		t.Errorf("Name(404) = (%q, %v)，预期 NotFound", name, ok)
+	}
+	if _, ok := codes.Name(201); ok {
+		t.Errorf("Name(201) 不应存在")
+	}
+}
+
+// TestNames 测试名称与取值按注册顺序返回
+func TestNames(t *testing.T) {
+	if names := weekdays.Names(); !slices.Equal(names, []string{"Sunday", "Monday", "Tuesday"}) {
+		t.Errorf("Names() = %v", names)
+	}
+	if values := codes.Values(); !slices.Equal(values, []Code{200, 404, 404, 500}) {
+		t.Errorf("Values() = %v", values)
+	}
+	if codes.Len() != 4 {
+		t.Errorf("Len() = %d，预期 4", codes.Len())
+	}
+}
+
+// TestAddPanics 测试非法注册
+func TestAddPanics(t *testing.T) {
+	for _, name := range []string{"", "Sunday"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Add(%q) 应 panic", name)
+				}
+			}()
+			enum.New[Weekday]("Sunday").Add(name, 5)
+		}()
+	}
+}
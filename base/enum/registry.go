@@ -0,0 +1,138 @@
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ErrCollision 表示注册时名称或取值与已有条目冲突
+var ErrCollision = errors.New("enum: 注册冲突")
+
+// Registry 维护枚举名称与取值之间的双向映射，两个方向的查找均为 O(1)
+//
+// 与 Of 不同，Registry 在注册时检测冲突：每个取值只有一个规范名称，
+// 额外的名称需通过 Alias 显式声明，只用于 Parse。
+// 生成的枚举与手写枚举可以共用同一套解析与格式化代码。
+//
+// 同一个 Registry 可以被多个 goroutine 并发使用。
+type Registry[T Integer] struct {
+	mu      sync.RWMutex
+	byName  map[string]T // 包括别名
+	byValue map[T]string // 取值到规范名称
+	ordered []T          // 按注册顺序排列的取值
+}
+
+// NewRegistry 创建一个空的 Registry
+func NewRegistry[T Integer]() *Registry[T] {
+	return &Registry[T]{byName: make(map[string]T), byValue: make(map[T]string)}
+}
+
+// Register 注册取值 v 及其规范名称 name，名称或取值已存在时返回包装了 ErrCollision 的错误
+func (r *Registry[T]) Register(name string, v T) error {
+	if name == "" {
+		return errors.New("enum: 名称不能为空")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.byName[name]; ok {
+		return fmt.Errorf("%w: 名称 %q 已对应取值 %d", ErrCollision, name, old)
+	}
+	if old, ok := r.byValue[v]; ok {
+		return fmt.Errorf("%w: 取值 %d 已注册为 %q，不能再注册为 %q", ErrCollision, v, old, name)
+	}
+	r.byName[name] = v
+	r.byValue[v] = name
+	r.ordered = append(r.ordered, v)
+	return nil
+}
+
+// MustRegister 与 Register 相同，但注册失败时 panic，适合在 init 或包级变量中使用
+func (r *Registry[T]) MustRegister(name string, v T) T {
+	if err := r.Register(name, v); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Alias 为已注册的取值 v 增加一个只用于 Parse 的名称
+func (r *Registry[T]) Alias(name string, v T) error {
+	if name == "" {
+		return errors.New("enum: 名称不能为空")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byValue[v]; !ok {
+		return fmt.Errorf("enum: 取值 %d 未注册，不能设置别名 %q", v, name)
+	}
+	if old, ok := r.byName[name]; ok {
+		return fmt.Errorf("%w: 名称 %q 已对应取值 %d", ErrCollision, name, old)
+	}
+	r.byName[name] = v
+	return nil
+}
+
+// Len 返回已注册的取值个数，不含别名
+func (r *Registry[T]) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.ordered)
+}
+
+// IsValid 报告 v 是否为已注册的取值
+func (r *Registry[T]) IsValid(v T) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.byValue[v]
+	return ok
+}
+
+// Name 返回 v 的规范名称
+func (r *Registry[T]) Name(v T) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.byValue[v]
+	return name, ok
+}
+
+// Parse 将规范名称或别名解析为取值，未知名称返回包装了 ErrUnknown 的错误
+func (r *Registry[T]) Parse(name string) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if v, ok := r.byName[name]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("%w %q", ErrUnknown, name)
+}
+
+// Format 返回 v 的规范名称，未注册的取值输出为十进制数值
+func (r *Registry[T]) Format(v T) string {
+	if name, ok := r.Name(v); ok {
+		return name
+	}
+	if v < 0 {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// Names 按注册顺序返回全部规范名称
+func (r *Registry[T]) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, len(r.ordered))
+	for i, v := range r.ordered {
+		names[i] = r.byValue[v]
+	}
+	return names
+}
+
+// Values 按注册顺序返回全部取值
+func (r *Registry[T]) Values() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]T(nil), r.ordered...)
+}
@@ -0,0 +1,121 @@
+package enum_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/enum"
+)
+
+// newRegistry 返回注册了 Sunday/Monday/Tuesday 的注册表
+func newRegistry() *enum.Registry[Weekday] {
+	r := enum.NewRegistry[Weekday]()
+	r.MustRegister("Sunday", Sunday)
+	r.MustRegister("Monday", Monday)
+	r.MustRegister("Tuesday", Tuesday)
+	return r
+}
+
+// TestRegistryLookup 测试双向查找与别名
+func TestRegistryLookup(t *testing.T) {
+	r := newRegistry()
+	if err := r.Alias("Mon", Monday); err != nil {
+		t.Fatalf("Alias 失败: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		expected Weekday
+	}{
+		{"Sunday", Sunday},
+		{"Monday", Monday},
+		{"Mon", Monday},
+		{"Tuesday", Tuesday},
+	}
+	for _, tc := range testCases {
+		v, err := r.Parse(tc.name)
+		if err != nil || v != tc.expected {
+			t.Errorf("Parse(%q) = (%v, %v)，预期 %v", tc.name, v, err, tc.expected)
+		}
+	}
+	if _, err := r.Parse("Wednesday"); !errors.Is(err, enum.ErrUnknown) {
+		t.Errorf("Parse(Wednesday) 错误为 %v，预期 ErrUnknown", err)
+	}
+
+	if s := r.Format(Monday); s != "Monday" {
+		t.Errorf("Format(Monday) = %q，预期规范名称 Monday", s)
+	}
+	if s := r.Format(-3); s != "-3" {
+		t.Errorf("Format(-3) = %q", s)
+	}
+	if !r.IsValid(Tuesday) || r.IsValid(3) {
+		t.Errorf("IsValid 结果错误")
+	}
+	if names := r.Names(); !slices.Equal(names, []string{"Sunday", "Monday", "Tuesday"}) {
+		t.Errorf("Names() = %v，不应包含别名", names)
+	}
+	if values := r.Values(); !slices.Equal(values, []Weekday{Sunday, Monday, Tuesday}) || r.Len() != 3 {
+		t.Errorf("Values() = %v", values)
+	}
+
+	u := enum.NewRegistry[uint64]()
+	if s := u.Format(math.MaxUint64); s != strconv.FormatUint(math.MaxUint64, 10) {
+		t.Errorf("Format(MaxUint64) = %q", s)
+	}
+}
+
+// TestRegistryCollision 测试注册冲突检测
+func TestRegistryCollision(t *testing.T) {
+	r := newRegistry()
+	testCases := []struct {
+		name      string
+		register  func() error
+		collision bool
+	}{
+		{"名称重复", func() error { return r.Register("Monday", 7) }, true},
+		{"取值重复", func() error { return r.Register("Lundi", Monday) }, true},
+		{"别名与名称重复", func() error { return r.Alias("Sunday", Monday) }, true},
+		{"名称为空", func() error { return r.Register("", 9) }, false},
+		{"别名指向未注册取值", func() error { return r.Alias("X", 9) }, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.register()
+			if err == nil {
+				t.Fatalf("应返回错误")
+			}
+			if errors.Is(err, enum.ErrCollision) != tc.collision {
+				t.Errorf("错误 %v 与 ErrCollision 的关系不符合预期", err)
+			}
+		})
+	}
+	if r.Len() != 3 {
+		t.Errorf("失败的注册不应生效，Len() = %d", r.Len())
+	}
+}
+
+// TestRegistryConcurrent 测试并发注册与查找
+func TestRegistryConcurrent(t *testing.T) {
+	r := enum.NewRegistry[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := g * 100; i < (g+1)*100; i++ {
+				r.MustRegister("v"+strconv.Itoa(i), i)
+				if v, err := r.Parse("v" + strconv.Itoa(i)); err != nil || v != i {
+					t.Errorf("Parse = (%d, %v)，预期 %d", v, err, i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if r.Len() != 800 {
+		t.Errorf("Len() = %d，预期 800", r.Len())
+	}
+}
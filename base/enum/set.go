@@ -0,0 +1,107 @@
+package enum
+
+import (
+	"iter"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Set 是取值在 [0, 64) 内的枚举集合，以单个 uint64 位图存储，零值为空集，增删查均为 O(1) 且不分配内存
+type Set[E Integer] struct {
+	bits uint64
+}
+
+// NewSet 返回包含 es 的集合
+func NewSet[E Integer](es ...E) Set[E] {
+	var s Set[E]
+	for _, e := range es {
+		s.Add(e)
+	}
+	return s
+}
+
+// bitOf 返回 e 对应的位，超出 [0, 64) 时 panic
+func bitOf[E Integer](e E) uint64 {
+	if e < 0 || uint64(e) >= 64 {
+		panic("enum: Set 只支持 [0, 64) 内的取值")
+	}
+	return 1 << uint64(e)
+}
+
+// Add 将 e 加入集合
+func (s *Set[E]) Add(e E) {
+	s.bits |= bitOf(e)
+}
+
+// Remove 将 e 移出集合
+func (s *Set[E]) Remove(e E) {
+	s.bits &^= bitOf(e)
+}
+
+// Contains 报告 e 是否在集合中，超出 [0, 64) 的取值总是返回 false
+func (s Set[E]) Contains(e E) bool {
+	if e < 0 || uint64(e) >= 64 {
+		return false
+	}
+	return s.bits&(1<<uint64(e)) != 0
+}
+
+// Union 返回 s 与 o 的并集
+func (s Set[E]) Union(o Set[E]) Set[E] {
+	return Set[E]{s.bits | o.bits}
+}
+
+// Intersect 返回 s 与 o 的交集
+func (s Set[E]) Intersect(o Set[E]) Set[E] {
+	return Set[E]{s.bits & o.bits}
+}
+
+// Difference 返回属于 s 但不属于 o 的元素
+func (s Set[E]) Difference(o Set[E]) Set[E] {
+	return Set[E]{s.bits &^ o.bits}
+}
+
+// Len 返回集合中的元素个数
+func (s Set[E]) Len() int {
+	return bits.OnesCount64(s.bits)
+}
+
+// IsEmpty 报告集合是否为空
+func (s Set[E]) IsEmpty() bool {
+	return s.bits == 0
+}
+
+// Bits 返回底层位图
+func (s Set[E]) Bits() uint64 {
+	return s.bits
+}
+
+// All 按取值从小到大遍历集合中的元素
+func (s Set[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for b := s.bits; b != 0; b &= b - 1 {
+			if !yield(E(bits.TrailingZeros64(b))) {
+				return
+			}
+		}
+	}
+}
+
+// Format 使用 e 中的名称格式化集合，如 "{Monday, Friday}"，未注册的取值输出为数字
+func (s Set[E]) Format(e *Of[E]) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for x := range s.All() {
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		if name, ok := e.Name(x); ok {
+			sb.WriteString(name)
+		} else {
+			sb.WriteString(strconv.FormatInt(int64(x), 10))
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
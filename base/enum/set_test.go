@@ -0,0 +1,90 @@
+package enum_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/enum"
+)
+
+// TestSet 测试集合的增删查与集合运算
+func TestSet(t *testing.T) {
+	var s enum.Set[Weekday]
+	if !s.IsEmpty() || s.Contains(Sunday) {
+		t.Fatalf("零值应为空集")
+	}
+	s.Add(Monday)
+	s.Add(Tuesday)
+	s.Add(Monday)
+	if s.Len() != 2 || !s.Contains(Monday) || s.Contains(Sunday) {
+		t.Errorf("Add 后 Len = %d，Bits = %03b", s.Len(), s.Bits())
+	}
+	s.Remove(Monday)
+	if s.Contains(Monday) || s.Len() != 1 {
+		t.Errorf("Remove 后 Bits = %03b", s.Bits())
+	}
+	if s.Contains(-1) || s.Contains(64) {
+		t.Errorf("越界取值不应在集合中")
+	}
+
+	a := enum.NewSet(Sunday, Monday)
+	b := enum.NewSet(Monday, Tuesday)
+	testCases := []struct {
+		name     string
+		got      enum.Set[Weekday]
+		expected []Weekday
+	}{
+		{"Union", a.Union(b), []Weekday{Sunday, Monday, Tuesday}},
+		{"Intersect", a.Intersect(b), []Weekday{Monday}},
+		{"Difference", a.Difference(b), []Weekday{Sunday}},
+	}
+	for _, tc := range testCases {
+		if got := slices.Collect(tc.got.All()); !slices.Equal(got, tc.expected) {
+			t.Errorf("%s = %v，预期 %v", tc.name, got, tc.expected)
+		}
+	}
+}
+
+// TestSetFormat 测试使用枚举名称格式化集合
+func TestSetFormat(t *testing.T) {
+	s := enum.NewSet[Code](0, 63)
+	if got := s.Format(enum.New[Code]("Zero")); got != "{Zero, 63}" {
+		t.Errorf("Format = %q，预期 {Zero, 63}", got)
+	}
+	if got := enum.NewSet[Weekday]().Format(weekdays); got != "{}" {
+		t.Errorf("空集 Format = %q", got)
+	}
+}
+
+// TestSetPanics 测试越界取值
+func TestSetPanics(t *testing.T) {
+	for _, e := range []Weekday{-1, 64} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Add(%d) 应 panic", e)
+				}
+			}()
+			var s enum.Set[Weekday]
+			s.Add(e)
+		}()
+	}
+}
+
+var sinkBool bool
+
+// BenchmarkSet 对比位图集合与 map 集合的查询开销
+func BenchmarkSet(b *testing.B) {
+	b.Run("bitmask", func(b *testing.B) {
+		s := enum.NewSet(Sunday, Tuesday)
+		for i := 0; i < b.N; i++ {
+			sinkBool = s.Contains(Weekday(i & 3))
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		s := map[Weekday]struct{}{Sunday: {}, Tuesday: {}}
+		for i := 0; i < b.N; i++ {
+			_, sinkBool = s[Weekday(i&3)]
+		}
+	})
+}
@@ -0,0 +1,381 @@
+// Package fixed 实现定点十进制数 Dec64：以 int64 保存缩放后的整数，并显式记录小数位数，
+// 适合金额等要求精确十进制运算的场景。
+//
+// 0.1 这样的十进制小数无法用二进制浮点数精确表示，累加后会出现 0.30000000000000004 这样的误差；
+// Dec64 把 12.34 保存为整数 1234 与小数位数 2，加减乘法都是精确的整数运算。
+// 所有运算都检测溢出并返回错误，而不是静默回绕；需要舍入的运算（除法、降低精度）显式指定舍入方式。
+// 解析与格式化直接处理十进制数字，不经过浮点数转换。
+//
+// 与 math/big 或基于 big.Int 的十进制库相比，Dec64 是 16 字节的值类型，运算不分配内存，
+// 代价是有效数字不超过 18 位（int64 的范围）。
+package fixed
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrOverflow 表示运算结果超出 Dec64 的表示范围
+	ErrOverflow = errors.New("fixed: 数值溢出")
+	// ErrScale 表示小数位数超出 [0, MaxScale]
+	ErrScale = errors.New("fixed: 小数位数超出范围")
+	// ErrDivByZero 表示除数为零
+	ErrDivByZero = errors.New("fixed: 除数为零")
+	// ErrSyntax 表示无法解析的十进制字符串
+	ErrSyntax = errors.New("fixed: 非法的十进制数")
+)
+
+// MaxScale 是小数位数的上限
+const MaxScale = 18
+
+// maxValue 是缩放后整数绝对值的上限；排除 math.MinInt64，使取反与取绝对值永远不会溢出
+const maxValue = math.MaxInt64
+
+// pow10[i] 是 10 的 i 次方
+var pow10 = func() (p [MaxScale + 2]uint64) {
+	p[0] = 1
+	for i := 1; i < len(p); i++ {
+		p[i] = p[i-1] * 10
+	}
+	return p
+}()
+
+// RoundingMode 是舍入方式，舍入都针对绝对值进行，正负数对称
+type RoundingMode uint8
+
+const (
+	HalfUp   RoundingMode = iota // 四舍五入：恰好一半时远离零
+	HalfEven                     // 银行家舍入：恰好一半时舍入到偶数，大量累加时舍入误差相互抵消
+	Down                         // 截断：直接舍去，向零取整
+)
+
+// Dec64 是定点十进制数，值为 v / 10^scale，零值为 0
+// 小数位数是值的一部分：Parse("1.50") 的小数位数为 2，格式化时保留末尾的 0。
+type Dec64 struct {
+	v     int64
+	scale uint8
+}
+
+// New 返回值为 v / 10^scale 的 Dec64，scale 不在 [0, MaxScale] 内或 v 为 math.MinInt64 时 panic
+func New(v int64, scale int) Dec64 {
+	if scale < 0 || scale > MaxScale {
+		panic("fixed: 小数位数超出范围")
+	}
+	if v == math.MinInt64 {
+		panic("fixed: 数值溢出")
+	}
+	return Dec64{v: v, scale: uint8(scale)}
+}
+
+// FromInt 返回值为整数 i 的 Dec64，小数位数为 0；i 为 math.MinInt64 时 panic
+func FromInt(i int64) Dec64 {
+	return New(i, 0)
+}
+
+// Raw 返回缩放后的整数与小数位数，值为 v / 10^scale
+func (d Dec64) Raw() (v int64, scale int) {
+	return d.v, int(d.scale)
+}
+
+// Scale 返回小数位数
+func (d Dec64) Scale() int {
+	return int(d.scale)
+}
+
+// Sign 返回 d 的符号：-1、0 或 1
+func (d Dec64) Sign() int {
+	switch {
+	case d.v < 0:
+		return -1
+	case d.v > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero 返回 d 是否为 0
+func (d Dec64) IsZero() bool {
+	return d.v == 0
+}
+
+// Neg 返回 -d
+func (d Dec64) Neg() Dec64 {
+	return Dec64{v: -d.v, scale: d.scale}
+}
+
+// Abs 返回 d 的绝对值
+func (d Dec64) Abs() Dec64 {
+	if d.v < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// abs 返回缩放后整数的绝对值
+func (d Dec64) abs() uint64 {
+	if d.v < 0 {
+		return uint64(-d.v)
+	}
+	return uint64(d.v)
+}
+
+// signed 以 neg 为符号构造 Dec64，u 必须不超过 maxValue
+func signed(u uint64, scale int, neg bool) Dec64 {
+	if neg {
+		return Dec64{v: -int64(u), scale: uint8(scale)}
+	}
+	return Dec64{v: int64(u), scale: uint8(scale)}
+}
+
+// rescale 把 d 的小数位数提高到 scale（scale >= d.scale），溢出时 ok 为 false
+func (d Dec64) rescale(scale int) (int64, bool) {
+	m := pow10[scale-int(d.scale)]
+	hi, lo := bits.Mul64(d.abs(), m)
+	if hi != 0 || lo > maxValue {
+		return 0, false
+	}
+	if d.v < 0 {
+		return -int64(lo), true
+	}
+	return int64(lo), true
+}
+
+// Cmp 比较 d 与 e 的值，返回 -1、0 或 1；小数位数不同但值相等时返回 0
+func (d Dec64) Cmp(e Dec64) int {
+	if ds, es := d.Sign(), e.Sign(); ds != es || ds == 0 {
+		return cmpInt(ds, es)
+	}
+	// 同号时比较绝对值对齐小数位数后的 128 位乘积
+	s := max(d.scale, e.scale)
+	c := mul64(d.abs(), pow10[s-d.scale]).cmp(mul64(e.abs(), pow10[s-e.scale]))
+	if d.v < 0 {
+		return -c
+	}
+	return c
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Add 返回 d+e，小数位数取两者中较大的一个
+func (d Dec64) Add(e Dec64) (Dec64, error) {
+	s := int(max(d.scale, e.scale))
+	a, ok1 := d.rescale(s)
+	b, ok2 := e.rescale(s)
+	sum, overflow := addInt64(a, b)
+	if !ok1 || !ok2 || overflow {
+		return Dec64{}, ErrOverflow
+	}
+	return Dec64{v: sum, scale: uint8(s)}, nil
+}
+
+// Sub 返回 d-e，小数位数取两者中较大的一个
+func (d Dec64) Sub(e Dec64) (Dec64, error) {
+	return d.Add(e.Neg())
+}
+
+// addInt64 返回 a+b 以及结果是否超出 [-maxValue, maxValue]
+func addInt64(a, b int64) (int64, bool) {
+	s := a + b
+	return s, (a^s)&(b^s) < 0 || s == math.MinInt64
+}
+
+// Mul 返回精确的乘积 d×e，小数位数为两者之和，超过 MaxScale 时返回 ErrScale
+// 需要控制结果的小数位数时使用 MulRound
+func (d Dec64) Mul(e Dec64) (Dec64, error) {
+	s := int(d.scale) + int(e.scale)
+	if s > MaxScale {
+		return Dec64{}, ErrScale
+	}
+	hi, lo := bits.Mul64(d.abs(), e.abs())
+	if hi != 0 || lo > maxValue {
+		return Dec64{}, ErrOverflow
+	}
+	return signed(lo, s, (d.v < 0) != (e.v < 0)), nil
+}
+
+// MulRound 返回 d×e 按 mode 舍入到 scale 位小数的结果
+// 乘积先以 128 位精确计算再舍入，中间结果超出 int64 但舍入后能表示时不会溢出
+func (d Dec64) MulRound(e Dec64, scale int, mode RoundingMode) (Dec64, error) {
+	if scale < 0 || scale > MaxScale {
+		return Dec64{}, ErrScale
+	}
+	p := mul64(d.abs(), e.abs())
+	neg := (d.v < 0) != (e.v < 0)
+	// 乘积的小数位数为 d.scale+e.scale，与目标相差 k 位
+	k := int(d.scale) + int(e.scale) - scale
+	if k <= 0 {
+		p, ok := p.mul(pow10[-k])
+		if !ok || p.hi != 0 || p.lo > maxValue {
+			return Dec64{}, ErrOverflow
+		}
+		return signed(p.lo, scale, neg), nil
+	}
+	// k 最大为 36，拆成两个不超过 10^18 的因子
+	d1, d2 := pow10[min(k, MaxScale)], pow10[max(k-MaxScale, 0)]
+	q, ok := quo(p, d1, d2, mode)
+	if !ok {
+		return Dec64{}, ErrOverflow
+	}
+	return signed(q, scale, neg), nil
+}
+
+// Div 返回 d÷e 按 mode 舍入到 scale 位小数的结果，e 为 0 时返回 ErrDivByZero
+func (d Dec64) Div(e Dec64, scale int, mode RoundingMode) (Dec64, error) {
+	if scale < 0 || scale > MaxScale {
+		return Dec64{}, ErrScale
+	}
+	if e.v == 0 {
+		return Dec64{}, ErrDivByZero
+	}
+	neg := (d.v < 0) != (e.v < 0)
+	// 结果 = (a/10^sa) / (b/10^sb) × 10^scale = a × 10^(scale+sb-sa) / b
+	k := scale + int(e.scale) - int(d.scale)
+	n := u128{0, d.abs()}
+	d1, d2 := e.abs(), uint64(1)
+	if k >= 0 {
+		// k 最大为 36，分两次乘
+		var ok1, ok2 bool
+		n, ok1 = n.mul(pow10[min(k, MaxScale)])
+		n, ok2 = n.mul(pow10[max(k-MaxScale, 0)])
+		if !ok1 || !ok2 {
+			return Dec64{}, ErrOverflow
+		}
+	} else {
+		d2 = pow10[-k]
+	}
+	q, ok := quo(n, d1, d2, mode)
+	if !ok {
+		return Dec64{}, ErrOverflow
+	}
+	return signed(q, scale, neg), nil
+}
+
+// Round 返回按 mode 舍入到 scale 位小数的结果；scale 大于当前小数位数时补 0，可能溢出
+func (d Dec64) Round(scale int, mode RoundingMode) (Dec64, error) {
+	if scale < 0 || scale > MaxScale {
+		return Dec64{}, ErrScale
+	}
+	if scale >= int(d.scale) {
+		v, ok := d.rescale(scale)
+		if !ok {
+			return Dec64{}, ErrOverflow
+		}
+		return Dec64{v: v, scale: uint8(scale)}, nil
+	}
+	q, _ := quo(u128{0, d.abs()}, pow10[int(d.scale)-scale], 1, mode) // 缩小后不会溢出
+	return signed(q, scale, d.v < 0), nil
+}
+
+// Float64 返回最接近 d 的 float64，只应用于展示或统计，不应参与金额计算
+func (d Dec64) Float64() float64 {
+	return float64(d.v) / float64(pow10[d.scale])
+}
+
+// Parse 解析十进制字符串，如 "-12.340"，小数位数为小数点后的位数（保留末尾的 0）
+// 接受可选的正负号，不接受指数形式与千位分隔符；有效数字超出 int64 时返回 ErrOverflow
+func Parse(s string) (Dec64, error) {
+	i, neg := 0, false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		i++
+	}
+	var u uint64
+	digits, scale, dot := 0, 0, false
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && !dot:
+			dot = true
+		case '0' <= c && c <= '9':
+			hi, lo := bits.Mul64(u, 10)
+			lo, carry := bits.Add64(lo, uint64(c-'0'), 0)
+			if hi != 0 || carry != 0 || lo > maxValue {
+				return Dec64{}, fmt.Errorf("%w %q", ErrOverflow, s)
+			}
+			u = lo
+			digits++
+			if dot {
+				scale++
+			}
+		default:
+			return Dec64{}, fmt.Errorf("%w %q", ErrSyntax, s)
+		}
+	}
+	if digits == 0 {
+		return Dec64{}, fmt.Errorf("%w %q", ErrSyntax, s)
+	}
+	if scale > MaxScale {
+		return Dec64{}, fmt.Errorf("%w %q", ErrScale, s)
+	}
+	return signed(u, scale, neg), nil
+}
+
+// MustParse 与 Parse 相同，解析失败时 panic，用于初始化常量
+func MustParse(s string) Dec64 {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Append 把 d 的十进制表示追加到 dst，保留全部小数位数，如 "-0.050"
+func (d Dec64) Append(dst []byte) []byte {
+	var buf [24]byte // 符号 + 19 位数字 + 小数点 + 前导 0
+	i := len(buf)
+	u := d.abs()
+	for n := 0; n < int(d.scale); n++ {
+		i--
+		buf[i] = byte('0' + u%10)
+		u /= 10
+	}
+	if d.scale > 0 {
+		i--
+		buf[i] = '.'
+	}
+	for {
+		i--
+		buf[i] = byte('0' + u%10)
+		u /= 10
+		if u == 0 {
+			break
+		}
+	}
+	if d.v < 0 {
+		i--
+		buf[i] = '-'
+	}
+	return append(dst, buf[i:]...)
+}
+
+// String 返回 d 的十进制表示
+func (d Dec64) String() string {
+	var buf [24]byte
+	return string(d.Append(buf[:0]))
+}
+
+// MarshalText 实现 encoding.TextMarshaler，在 JSON 中编码为字符串，避免被解析为浮点数
+func (d Dec64) MarshalText() ([]byte, error) {
+	return d.Append(nil), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (d *Dec64) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
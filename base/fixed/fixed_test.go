@@ -0,0 +1,508 @@
+package fixed_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/fixed"
+)
+
+// TestParse 测试解析与格式化
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  string
+		v     int64
+		scale int
+		err   error
+	}{
+		{"0", "0", 0, 0, nil},
+		{"12.34", "12.34", 1234, 2, nil},
+		{"-12.340", "-12.340", -12340, 3, nil},
+		{"+1.5", "1.5", 15, 1, nil},
+		{".5", "0.5", 5, 1, nil},
+		{"5.", "5", 5, 0, nil},
+		{"-0.05", "-0.05", -5, 2, nil},
+		{"9223372036854775807", "9223372036854775807", 9223372036854775807, 0, nil},
+		{"-9.223372036854775807", "-9.223372036854775807", -9223372036854775807, 18, nil},
+		{"0.000000000000000001", "0.000000000000000001", 1, 18, nil},
+		{"9223372036854775808", "", 0, 0, fixed.ErrOverflow},
+		{"-9223372036854775808", "", 0, 0, fixed.ErrOverflow},
+		{"0.0000000000000000001", "", 0, 0, fixed.ErrScale},
+		{"", "", 0, 0, fixed.ErrSyntax},
+		{"-", "", 0, 0, fixed.ErrSyntax},
+		{".", "", 0, 0, fixed.ErrSyntax},
+		{"1.2.3", "", 0, 0, fixed.ErrSyntax},
+		{"1e3", "", 0, 0, fixed.ErrSyntax},
+		{"1,000", "", 0, 0, fixed.ErrSyntax},
+		{" 1", "", 0, 0, fixed.ErrSyntax},
+	}
+	for _, tt := range tests {
+		d, err := fixed.Parse(tt.in)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Parse(%q) err = %v，预期 %v", tt.in, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if v, s := d.Raw(); v != tt.v || s != tt.scale {
+			t.Errorf("Parse(%q) = (%d, %d)，预期 (%d, %d)", tt.in, v, s, tt.v, tt.scale)
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q，预期 %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestNewPanic 测试非法参数 panic
+func TestNewPanic(t *testing.T) {
+	for _, f := range []func(){
+		func() { fixed.New(1, -1) },
+		func() { fixed.New(1, fixed.MaxScale+1) },
+		func() { fixed.New(-1<<63, 0) },
+		func() { fixed.MustParse("x") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("预期 panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// TestCmp 测试跨小数位数的比较
+func TestCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.50", "1.5", 0},
+		{"0", "-0.00", 0},
+		{"1.01", "1.1", -1},
+		{"-1.01", "-1.1", 1},
+		{"-1", "0.000001", -1},
+		{"9223372036854775807", "9.223372036854775807", 1},
+		{"0.922337203685477580", "0.922337203685477581", -1},
+	}
+	for _, tt := range tests {
+		a, b := fixed.MustParse(tt.a), fixed.MustParse(tt.b)
+		if got := a.Cmp(b); got != tt.want {
+			t.Errorf("Cmp(%s, %s) = %d，预期 %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := b.Cmp(a); got != -tt.want {
+			t.Errorf("Cmp(%s, %s) = %d，预期 %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+// TestAddSub 测试加减法的精确性与溢出检测
+func TestAddSub(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		sum, dif string
+		err      error
+	}{
+		{"0.1", "0.2", "0.3", "-0.1", nil},
+		{"1.5", "0.25", "1.75", "1.25", nil},
+		{"-3", "1.000", "-2.000", "-4.000", nil},
+		{"9223372036854775806", "1", "9223372036854775807", "9223372036854775805", nil},
+		{"9223372036854775807", "1", "", "", fixed.ErrOverflow},
+		{"922337203685477581", "0.1", "", "", fixed.ErrOverflow}, // 对齐小数位数时溢出
+	}
+	for _, tt := range tests {
+		a, b := fixed.MustParse(tt.a), fixed.MustParse(tt.b)
+		sum, err := a.Add(b)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s + %s err = %v，预期 %v", tt.a, tt.b, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if sum.String() != tt.sum {
+			t.Errorf("%s + %s = %s，预期 %s", tt.a, tt.b, sum, tt.sum)
+		}
+		if dif, _ := a.Sub(b); dif.String() != tt.dif {
+			t.Errorf("%s - %s = %s，预期 %s", tt.a, tt.b, dif, tt.dif)
+		}
+	}
+	if _, err := fixed.MustParse("-9223372036854775807").Sub(fixed.FromInt(1)); !errors.Is(err, fixed.ErrOverflow) {
+		t.Errorf("负向溢出 err = %v，预期 ErrOverflow", err)
+	}
+}
+
+// TestMul 测试精确乘法
+func TestMul(t *testing.T) {
+	tests := []struct {
+		a, b, want string
+		err        error
+	}{
+		{"1.5", "2.25", "3.375", nil},
+		{"-0.1", "0.1", "-0.01", nil},
+		{"3037000499", "3037000499", "9223372030926249001", nil},
+		{"3037000500", "3037000500", "", fixed.ErrOverflow},
+		{"0.000000001", "0.0000000001", "", fixed.ErrScale},
+	}
+	for _, tt := range tests {
+		got, err := fixed.MustParse(tt.a).Mul(fixed.MustParse(tt.b))
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s × %s err = %v，预期 %v", tt.a, tt.b, err, tt.err)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("%s × %s = %s，预期 %s", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// TestMulRound 测试带舍入的乘法
+func TestMulRound(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		scale int
+		mode  fixed.RoundingMode
+		want  string
+		err   error
+	}{
+		{"19.99", "0.075", 2, fixed.HalfUp, "1.50", nil}, // 1.49925
+		{"0.25", "0.1", 2, fixed.HalfUp, "0.03", nil},    // 0.025
+		{"0.25", "0.1", 2, fixed.HalfEven, "0.02", nil},
+		{"-0.25", "0.1", 2, fixed.HalfUp, "-0.03", nil},
+		{"-0.25", "0.1", 2, fixed.HalfEven, "-0.02", nil},
+		{"0.29", "0.1", 2, fixed.Down, "0.02", nil},
+		{"1.5", "2", 3, fixed.HalfUp, "3.000", nil},
+		// 中间乘积超出 int64，舍入后仍可表示
+		{"0.000000003037000500", "3037000500", 0, fixed.HalfUp, "9", nil},
+		{"9.223372036854775807", "9.223372036854775807", 18, fixed.HalfUp, "", fixed.ErrOverflow},
+		{"922337203685477580", "922337203685477580", 0, fixed.HalfUp, "", fixed.ErrOverflow},
+		{"1", "1", 19, fixed.HalfUp, "", fixed.ErrScale},
+	}
+	for _, tt := range tests {
+		got, err := fixed.MustParse(tt.a).MulRound(fixed.MustParse(tt.b), tt.scale, tt.mode)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s × %s err = %v，预期 %v", tt.a, tt.b, err, tt.err)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("%s × %s（%d 位，模式 %d）= %s，预期 %s", tt.a, tt.b, tt.scale, tt.mode, got, tt.want)
+		}
+	}
+}
+
+// TestDiv 测试带舍入的除法
+func TestDiv(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		scale int
+		mode  fixed.RoundingMode
+		want  string
+		err   error
+	}{
+		{"1", "3", 4, fixed.HalfUp, "0.3333", nil},
+		{"2", "3", 4, fixed.HalfUp, "0.6667", nil},
+		{"2", "3", 4, fixed.Down, "0.6666", nil},
+		{"-2", "3", 2, fixed.HalfUp, "-0.67", nil},
+		{"10.00", "4", 1, fixed.HalfUp, "2.5", nil},
+		{"10.00", "4", 0, fixed.HalfUp, "3", nil},
+		{"10.00", "4", 0, fixed.HalfEven, "2", nil},
+		{"14", "4", 0, fixed.HalfEven, "4", nil},
+		{"1", "-0.000000000000000007", 0, fixed.HalfUp, "-142857142857142857", nil},
+		{"0.5", "0.25", 18, fixed.HalfUp, "2.000000000000000000", nil},
+		{"9223372036854775807", "1", 18, fixed.HalfUp, "", fixed.ErrOverflow},
+		{"9223372036854775807", "0.1", 0, fixed.HalfUp, "", fixed.ErrOverflow},
+		// 商恰为 MaxUint64，进位时曾回绕为 0
+		{"8301034833169298227", "45", 2, fixed.HalfUp, "", fixed.ErrOverflow},
+		{"8301034833169298227", "45", 2, fixed.HalfEven, "", fixed.ErrOverflow},
+		{"8301034833169298227", "45", 2, fixed.Down, "", fixed.ErrOverflow},
+		{"1", "0.00", 2, fixed.HalfUp, "", fixed.ErrDivByZero},
+		{"1", "1", -1, fixed.HalfUp, "", fixed.ErrScale},
+	}
+	for _, tt := range tests {
+		got, err := fixed.MustParse(tt.a).Div(fixed.MustParse(tt.b), tt.scale, tt.mode)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s ÷ %s err = %v，预期 %v", tt.a, tt.b, err, tt.err)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("%s ÷ %s（%d 位，模式 %d）= %s，预期 %s", tt.a, tt.b, tt.scale, tt.mode, got, tt.want)
+		}
+	}
+}
+
+// TestRound 测试调整小数位数
+func TestRound(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale int
+		mode  fixed.RoundingMode
+		want  string
+	}{
+		{"2.345", 2, fixed.HalfUp, "2.35"},
+		{"2.345", 2, fixed.HalfEven, "2.34"},
+		{"2.355", 2, fixed.HalfEven, "2.36"},
+		{"-2.345", 2, fixed.HalfUp, "-2.35"},
+		{"-2.349", 2, fixed.Down, "-2.34"},
+		{"0.5", 0, fixed.HalfEven, "0"},
+		{"1.5", 0, fixed.HalfEven, "2"},
+		{"1.5", 3, fixed.HalfUp, "1.500"},
+	}
+	for _, tt := range tests {
+		got, err := fixed.MustParse(tt.in).Round(tt.scale, tt.mode)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("Round(%s, %d, %d) = %s, %v，预期 %s", tt.in, tt.scale, tt.mode, got, err, tt.want)
+		}
+	}
+	if _, err := fixed.FromInt(1<<62).Round(1, fixed.HalfUp); !errors.Is(err, fixed.ErrOverflow) {
+		t.Errorf("Round 补 0 溢出 err = %v，预期 ErrOverflow", err)
+	}
+}
+
+// TestAgainstBig 用 big.Rat 校验随机数据的除法与舍入乘法
+func TestAgainstBig(t *testing.T) {
+	rng := uint64(1)
+	next := func() uint64 {
+		rng ^= rng << 13
+		rng ^= rng >> 7
+		rng ^= rng << 17
+		return rng
+	}
+	for range 20000 {
+		a := fixed.New(int64(next()>>(1+next()%63))*sign(next()), int(next()%19))
+		b := fixed.New(int64(next()>>(1+next()%63))*sign(next()), int(next()%19))
+		scale := int(next() % 19)
+		mode := fixed.RoundingMode(next() % 3)
+
+		if !b.IsZero() {
+			got, err := a.Div(b, scale, mode)
+			want, ok := roundRat(new(big.Rat).Quo(rat(a), rat(b)), scale, mode)
+			check(t, "Div", a, b, got, err, want, ok)
+		}
+		got, err := a.MulRound(b, scale, mode)
+		want, ok := roundRat(new(big.Rat).Mul(rat(a), rat(b)), scale, mode)
+		check(t, "MulRound", a, b, got, err, want, ok)
+	}
+}
+
+func sign(x uint64) int64 {
+	if x&1 == 0 {
+		return -1
+	}
+	return 1
+}
+
+func check(t *testing.T, op string, a, b, got fixed.Dec64, err error, want string, ok bool) {
+	t.Helper()
+	switch {
+	case !ok:
+		if !errors.Is(err, fixed.ErrOverflow) {
+			t.Fatalf("%s(%s, %s) = %s, %v，预期 ErrOverflow", op, a, b, got, err)
+		}
+	case err != nil || got.String() != want:
+		t.Fatalf("%s(%s, %s) = %s, %v，预期 %s", op, a, b, got, err, want)
+	}
+}
+
+// rat 把 d 精确转换为 big.Rat
+func rat(d fixed.Dec64) *big.Rat {
+	v, s := d.Raw()
+	return new(big.Rat).SetFrac(big.NewInt(v), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(s)), nil))
+}
+
+// roundRat 是参照实现：把 r 按 mode 舍入到 scale 位小数并格式化，结果超出 int64 时 ok 为 false
+func roundRat(r *big.Rat, scale int, mode fixed.RoundingMode) (string, bool) {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	x := new(big.Rat).Mul(r, new(big.Rat).SetInt(p))
+	neg := x.Sign() < 0
+	x.Abs(x)
+	q, m := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	if mode != fixed.Down {
+		c := new(big.Int).Lsh(m, 1).Cmp(x.Denom())
+		if c > 0 || c == 0 && (mode == fixed.HalfUp || q.Bit(0) == 1) {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() || q.Int64() == -1<<63 {
+		return "", false
+	}
+	v := q.Int64()
+	if neg {
+		v = -v
+	}
+	return fixed.New(v, scale).String(), true
+}
+
+// TestText 测试文本编解码
+func TestText(t *testing.T) {
+	var d fixed.Dec64
+	if err := d.UnmarshalText([]byte("-7.250")); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := d.MarshalText()
+	if string(b) != "-7.250" {
+		t.Errorf("MarshalText = %q，预期 %q", b, "-7.250")
+	}
+	if err := d.UnmarshalText([]byte("abc")); !errors.Is(err, fixed.ErrSyntax) {
+		t.Errorf("err = %v，预期 ErrSyntax", err)
+	}
+	if got := fixed.MustParse("-1.25").Float64(); got != -1.25 {
+		t.Errorf("Float64 = %v，预期 -1.25", got)
+	}
+}
+
+// TestAllocs 测试运算与复用缓冲区的格式化不分配内存
+func TestAllocs(t *testing.T) {
+	a, b := fixed.MustParse("123.45"), fixed.MustParse("6.7")
+	buf := make([]byte, 0, 32)
+	benchkit.AssertAllocs(t, 0, func() {
+		s, _ := a.Add(b)
+		p, _ := s.MulRound(b, 2, fixed.HalfEven)
+		q, _ := p.Div(a, 4, fixed.HalfUp)
+		buf = q.Append(buf[:0])
+		_, _ = fixed.Parse("-98.765")
+	})
+}
+
+// FuzzParse 测试任意输入解析不 panic，且解析成功的值格式化后再解析得到相同的结果
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"0", "-12.340", ".5", "9223372036854775807", "1.2.3"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := fixed.Parse(s)
+		if err != nil {
+			return
+		}
+		e, err := fixed.Parse(d.String())
+		if err != nil || e != d {
+			t.Fatalf("Parse(%q) = %s，再次解析得到 %s, %v", s, d, e, err)
+		}
+	})
+}
+
+// bigDec 模拟 shopspring/decimal 一类库的表示：任意精度整数与十进制指数，值为 value × 10^exp
+type bigDec struct {
+	value *big.Int
+	exp   int32
+}
+
+func (d bigDec) add(e bigDec) bigDec {
+	if d.exp > e.exp {
+		d, e = e, d
+	}
+	// 把指数较大的一方对齐到较小的指数
+	v := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(e.exp-d.exp)), nil)
+	v.Mul(v, e.value)
+	return bigDec{v.Add(v, d.value), d.exp}
+}
+
+func (d bigDec) mul(e bigDec) bigDec {
+	return bigDec{new(big.Int).Mul(d.value, e.value), d.exp + e.exp}
+}
+
+// BenchmarkAdd 对比加法
+func BenchmarkAdd(b *testing.B) {
+	x, y := fixed.MustParse("12345.67"), fixed.MustParse("0.891")
+	b.Run("Dec64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s, _ := x.Add(y)
+			benchkit.SinkInt = s.Sign()
+		}
+	})
+	bx, by := bigDec{big.NewInt(1234567), -2}, bigDec{big.NewInt(891), -3}
+	b.Run("bigDec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkInt = bx.add(by).value.Sign()
+		}
+	})
+	rx, ry := rat(x), rat(y)
+	b.Run("big.Rat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkInt = new(big.Rat).Add(rx, ry).Sign()
+		}
+	})
+}
+
+// BenchmarkMul 对比乘法
+func BenchmarkMul(b *testing.B) {
+	x, y := fixed.MustParse("12345.67"), fixed.MustParse("0.891")
+	b.Run("Dec64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p, _ := x.Mul(y)
+			benchkit.SinkInt = p.Sign()
+		}
+	})
+	b.Run("Dec64/MulRound", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p, _ := x.MulRound(y, 2, fixed.HalfEven)
+			benchkit.SinkInt = p.Sign()
+		}
+	})
+	bx, by := bigDec{big.NewInt(1234567), -2}, bigDec{big.NewInt(891), -3}
+	b.Run("bigDec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkInt = bx.mul(by).value.Sign()
+		}
+	})
+	rx, ry := rat(x), rat(y)
+	b.Run("big.Rat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkInt = new(big.Rat).Mul(rx, ry).Sign()
+		}
+	})
+}
+
+// BenchmarkDiv 对比除法
+func BenchmarkDiv(b *testing.B) {
+	x, y := fixed.MustParse("12345.67"), fixed.MustParse("3")
+	b.Run("Dec64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, _ := x.Div(y, 4, fixed.HalfUp)
+			benchkit.SinkInt = q.Sign()
+		}
+	})
+	rx, ry := rat(x), rat(y)
+	b.Run("big.Rat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkString = new(big.Rat).Quo(rx, ry).FloatString(4)
+		}
+	})
+}
+
+// BenchmarkParse 对比解析
+func BenchmarkParse(b *testing.B) {
+	const s = "-12345.6789"
+	b.Run("Dec64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d, _ := fixed.Parse(s)
+			benchkit.SinkInt = d.Sign()
+		}
+	})
+	b.Run("big.Rat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r, _ := new(big.Rat).SetString(s)
+			benchkit.SinkInt = r.Sign()
+		}
+	})
+}
+
+// BenchmarkString 对比格式化
+func BenchmarkString(b *testing.B) {
+	d := fixed.MustParse("-12345.6789")
+	b.Run("Dec64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkString = d.String()
+		}
+	})
+	r := rat(d)
+	b.Run("big.Rat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkString = r.FloatString(4)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package fixed
+
+import "math/bits"
+
+// u128 是 128 位无符号整数，用于乘除法的中间结果，避免先舍入再运算带来的精度损失
+type u128 struct {
+	hi, lo uint64
+}
+
+// mul64 返回 a×b 的完整 128 位乘积
+func mul64(a, b uint64) u128 {
+	hi, lo := bits.Mul64(a, b)
+	return u128{hi, lo}
+}
+
+// mul 返回 x×m，结果超出 128 位时 ok 为 false
+func (x u128) mul(m uint64) (u128, bool) {
+	h1, l1 := bits.Mul64(x.lo, m)
+	h2, l2 := bits.Mul64(x.hi, m)
+	hi, carry := bits.Add64(h1, l2, 0)
+	return u128{hi, l1}, h2 == 0 && carry == 0
+}
+
+// add 返回 x+y，调用方保证不溢出
+func (x u128) add(y u128) u128 {
+	lo, carry := bits.Add64(x.lo, y.lo, 0)
+	return u128{x.hi + y.hi + carry, lo}
+}
+
+// divmod 返回 x/d 的商与余数
+func (x u128) divmod(d uint64) (u128, uint64) {
+	qhi, r := x.hi/d, x.hi%d
+	qlo, r := bits.Div64(r, x.lo, d)
+	return u128{qhi, qlo}, r
+}
+
+// cmp 比较 x 与 y，返回 -1、0 或 1
+func (x u128) cmp(y u128) int {
+	switch {
+	case x.hi != y.hi:
+		if x.hi < y.hi {
+			return -1
+		}
+		return 1
+	case x.lo < y.lo:
+		return -1
+	case x.lo > y.lo:
+		return 1
+	}
+	return 0
+}
+
+// quo 返回按 mode 舍入后的 n/(d1×d2)，商超出 int64 的正数范围时 ok 为 false
+// 除数拆成两个 64 位因子，依次做向下取整的除法：⌊⌊n/d1⌋/d2⌋ = ⌊n/(d1×d2)⌋，
+// 余数 R = r2×d1 + r1，与 d1×d2 的一半比较决定是否进位，整个过程不需要 128 位除数
+func quo(n u128, d1, d2 uint64, mode RoundingMode) (uint64, bool) {
+	q1, r1 := n.divmod(d1)
+	q, r2 := q1.divmod(d2)
+	if q.hi != 0 {
+		return 0, false
+	}
+	if mode != Down && (r1 != 0 || r2 != 0) {
+		r := mul64(r2, d1).add(u128{0, r1})
+		r = r.add(r) // 2R
+		switch c := r.cmp(mul64(d1, d2)); {
+		case c > 0, c == 0 && mode == HalfUp, c == 0 && mode == HalfEven && q.lo&1 == 1:
+			if q.lo >= maxValue { // 进位后超出范围，q.lo 为 MaxUint64 时还会回绕为 0
+				return 0, false
+			}
+			q.lo++
+		}
+	}
+	return q.lo, q.lo <= maxValue
+}
@@ -0,0 +1,55 @@
+package flame
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Stacks 是折叠栈到数值的映射，键为以 ";" 连接、从根到叶排列的函数名
+type Stacks map[string]int64
+
+// Fold 按调用栈汇总 p 中第 index 列数值，index 通常由 SampleIndex 得到
+// 相同调用栈的样本被合并，数值为 0 的调用栈被省略；index 越界时 panic
+func (p *Profile) Fold(index int) Stacks {
+	if index < 0 || index >= len(p.SampleTypes) {
+		panic("flame: 样本列下标越界")
+	}
+	s := make(Stacks)
+	for _, sample := range p.Samples {
+		if v := sample.Values[index]; v != 0 && len(sample.Stack) > 0 {
+			s[strings.Join(sample.Stack, ";")] += v
+		}
+	}
+	return s
+}
+
+// Merge 将 o 中的数值累加到 s，用于合并多次运行的剖析
+func (s Stacks) Merge(o Stacks) {
+	for k, v := range o {
+		s[k] += v
+	}
+}
+
+// Total 返回所有调用栈数值之和
+func (s Stacks) Total() int64 {
+	var total int64
+	for _, v := range s {
+		total += v
+	}
+	return total
+}
+
+// WriteTo 以折叠栈格式将 s 写入 w，每行 "栈 数值"，按栈排序以使输出稳定、便于比较
+func (s Stacks) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	for _, k := range slices.Sorted(maps.Keys(s)) {
+		m, _ := fmt.Fprintf(bw, "%s %d\n", k, s[k]) // 写入错误由 bufio.Writer 保留，在 Flush 时返回
+		n += int64(m)
+	}
+	return n, bw.Flush()
+}
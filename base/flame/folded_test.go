@@ -0,0 +1,49 @@
+package flame_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/flame"
+)
+
+// TestFold 测试按调用栈汇总与折叠栈输出
+func TestFold(t *testing.T) {
+	p, err := flame.Parse(bytes.NewReader(testProfile()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		typ      string
+		expected string
+	}{
+		{"cpu", "main.main 20\nmain.main;main.work;main.inlined 15\n"},
+		{"samples", "[unknown] 1\nmain.main 2\nmain.main;main.work;main.inlined 2\n"},
+		{"", "main.main 20\nmain.main;main.work;main.inlined 15\n"},
+	}
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		s := p.Fold(p.SampleIndex(tc.typ))
+		n, err := s.WriteTo(&buf)
+		if err != nil || buf.String() != tc.expected || n != int64(buf.Len()) {
+			t.Errorf("Fold(%q) 输出 %q (%d, %v)，预期 %q", tc.typ, buf.String(), n, err, tc.expected)
+		}
+	}
+
+	if i := p.SampleIndex("inuse_space"); i != -1 {
+		t.Errorf("SampleIndex(inuse_space) = %d，预期 -1", i)
+	}
+
+	s := p.Fold(p.SampleIndex("cpu"))
+	s.Merge(p.Fold(p.SampleIndex("cpu")))
+	if s["main.main"] != 40 || s.Total() != 70 {
+		t.Errorf("合并后 = %v", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("下标越界应 panic")
+		}
+	}()
+	p.Fold(2)
+}
@@ -0,0 +1,255 @@
+// Package flame 读取 pprof 剖析文件并转换为折叠栈（folded stacks）格式，
+// 即每行 "root;caller;leaf 数值"，可直接交给 flamegraph.pl、speedscope 等火焰图工具。
+//
+// 为了不引入依赖，包内实现了一个只解析所需字段的 profile.proto 解码器。
+package flame
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrFormat 表示输入不是合法的 pprof 剖析数据
+var ErrFormat = errors.New("flame: 非法的 pprof 数据")
+
+// ValueType 描述样本中一列数值的含义，例如 {"cpu", "nanoseconds"}
+type ValueType struct {
+	Type, Unit string
+}
+
+// Sample 是一个样本：调用栈及其各列数值
+type Sample struct {
+	Stack  []string // 函数名，从根（最外层调用方）到叶（采样点）排列，内联函数也单独成帧
+	Values []int64  // 与 Profile.SampleTypes 一一对应
+}
+
+// Profile 是解码后的剖析数据
+type Profile struct {
+	SampleTypes []ValueType
+	Samples     []Sample
+}
+
+// Parse 读取 pprof 剖析数据，支持 gzip 压缩（runtime/pprof 的默认输出）与未压缩两种形式
+func Parse(r io.Reader) (*Profile, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+// SampleIndex 返回类型为 typ 的数值列下标，例如 "cpu"、"alloc_space"、"inuse_space"、"contentions"、"delay"；
+// typ 为空时返回最后一列，与 go tool pprof 的默认选择相同；找不到时返回 -1
+func (p *Profile) SampleIndex(typ string) int {
+	if typ == "" {
+		return len(p.SampleTypes) - 1
+	}
+	for i, st := range p.SampleTypes {
+		if st.Type == typ {
+			return i
+		}
+	}
+	return -1
+}
+
+// 解码过程中使用的中间结构
+type (
+	rawSample struct {
+		locations []uint64
+		values    []int64
+	}
+	rawLocation struct {
+		functions []uint64 // 第一个为最内层（被内联）的函数
+	}
+)
+
+// decode 解码未压缩的 profile.proto 消息
+func decode(data []byte) (*Profile, error) {
+	var (
+		p         Profile
+		types     [][2]int64
+		samples   []rawSample
+		locations = make(map[uint64]rawLocation)
+		functions = make(map[uint64]int64) // 函数 ID → 名称在字符串表中的下标
+		strs      []string
+	)
+	err := fields(data, func(num int, v uint64, b []byte) error {
+		switch num {
+		case 1: // sample_type
+			var vt [2]int64
+			err := fields(b, func(num int, v uint64, _ []byte) error {
+				if num == 1 || num == 2 {
+					vt[num-1] = int64(v)
+				}
+				return nil
+			})
+			types = append(types, vt)
+			return err
+		case 2: // sample
+			var s rawSample
+			err := fields(b, func(num int, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					return repeated(v, b, func(x uint64) { s.locations = append(s.locations, x) })
+				case 2:
+					return repeated(v, b, func(x uint64) { s.values = append(s.values, int64(x)) })
+				}
+				return nil
+			})
+			samples = append(samples, s)
+			return err
+		case 4: // location
+			var id uint64
+			var loc rawLocation
+			err := fields(b, func(num int, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					id = v
+				case 4: // line
+					return fields(b, func(num int, v uint64, _ []byte) error {
+						if num == 1 {
+							loc.functions = append(loc.functions, v)
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+			locations[id] = loc
+			return err
+		case 5: // function
+			var id uint64
+			var name int64
+			err := fields(b, func(num int, v uint64, _ []byte) error {
+				switch num {
+				case 1:
+					id = v
+				case 2:
+					name = int64(v)
+				}
+				return nil
+			})
+			functions[id] = name
+			return err
+		case 6: // string_table
+			strs = append(strs, string(b))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	str := func(i int64) string {
+		if i < 0 || i >= int64(len(strs)) {
+			return ""
+		}
+		return strs[i]
+	}
+	for _, vt := range types {
+		p.SampleTypes = append(p.SampleTypes, ValueType{str(vt[0]), str(vt[1])})
+	}
+	p.Samples = make([]Sample, len(samples))
+	for i, s := range samples {
+		if len(s.values) != len(types) {
+			return nil, fmt.Errorf("%w: 样本有 %d 列数值，样本类型有 %d 个", ErrFormat, len(s.values), len(types))
+		}
+		var stack []string
+		// location_id 从叶到根排列，每个位置内的函数也从内到外排列，这里整体反转为从根到叶
+		for j := len(s.locations) - 1; j >= 0; j-- {
+			fns := locations[s.locations[j]].functions
+			if len(fns) == 0 {
+				stack = append(stack, "[unknown]")
+				continue
+			}
+			for k := len(fns) - 1; k >= 0; k-- {
+				name, ok := functions[fns[k]]
+				if !ok || str(name) == "" {
+					stack = append(stack, "[unknown]")
+					continue
+				}
+				stack = append(stack, str(name))
+			}
+		}
+		p.Samples[i] = Sample{Stack: stack, Values: s.values}
+	}
+	return &p, nil
+}
+
+// fields 依次解析 data 中的每个字段并调用 f：varint 字段的值在 v 中，
+// 长度前缀字段的内容在 b 中且 b 不为 nil，定长字段不解析其值
+func fields(data []byte, f func(num int, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrFormat
+		}
+		data = data[n:]
+		num := int(key >> 3)
+		var v uint64
+		var b []byte
+		switch key & 7 {
+		case 0: // varint
+			v, n = binary.Uvarint(data)
+			if n <= 0 {
+				return ErrFormat
+			}
+			data = data[n:]
+		case 1: // 64 位定长
+			if len(data) < 8 {
+				return ErrFormat
+			}
+			data = data[8:]
+		case 2: // 长度前缀
+			l, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < l {
+				return ErrFormat
+			}
+			b = data[n : n+int(l)]
+			data = data[n+int(l):]
+		case 5: // 32 位定长
+			if len(data) < 4 {
+				return ErrFormat
+			}
+			data = data[4:]
+		default:
+			return fmt.Errorf("%w: 未知的编码类型 %d", ErrFormat, key&7)
+		}
+		if err := f(num, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repeated 处理 repeated 整数字段：b 为 nil 时 v 为单个元素，否则 b 为 packed 编码的多个 varint
+func repeated(v uint64, b []byte, add func(uint64)) error {
+	if b == nil {
+		add(v)
+		return nil
+	}
+	for len(b) > 0 {
+		x, n := binary.Uvarint(b)
+		if n <= 0 {
+			return ErrFormat
+		}
+		add(x)
+		b = b[n:]
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package flame_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/flame"
+)
+
+// pb 是构造 profile.proto 测试数据的最小编码器
+type pb []byte
+
+func (p pb) varint(num int, v uint64) pb {
+	p = binary.AppendUvarint(p, uint64(num)<<3)
+	return binary.AppendUvarint(p, v)
+}
+
+func (p pb) bytes(num int, b []byte) pb {
+	p = binary.AppendUvarint(p, uint64(num)<<3|2)
+	p = binary.AppendUvarint(p, uint64(len(b)))
+	return append(p, b...)
+}
+
+func (p pb) packed(num int, vs ...uint64) pb {
+	var b []byte
+	for _, v := range vs {
+		b = binary.AppendUvarint(b, v)
+	}
+	return p.bytes(num, b)
+}
+
+// testProfile 返回一个手工编码的剖析：main.work 被内联了 main.inlined，
+// 样本分别使用 packed 与非 packed 编码
+func testProfile() []byte {
+	var p pb
+	p = p.bytes(1, pb{}.varint(1, 3).varint(2, 4)) // samples/count
+	p = p.bytes(1, pb{}.varint(1, 1).varint(2, 2)) // cpu/nanoseconds
+	p = p.bytes(2, pb{}.packed(1, 2, 1).packed(2, 1, 10))
+	p = p.bytes(2, pb{}.varint(1, 1).varint(2, 2).varint(2, 20))
+	p = p.bytes(2, pb{}.packed(1, 2, 1).packed(2, 1, 5))
+	p = p.bytes(2, pb{}.packed(1, 9).packed(2, 1, 0))
+	p = p.bytes(4, pb{}.varint(1, 1).bytes(4, pb{}.varint(1, 1).varint(2, 10)))
+	p = p.bytes(4, pb{}.varint(1, 2).bytes(4, pb{}.varint(1, 3)).bytes(4, pb{}.varint(1, 2)))
+	p = p.bytes(5, pb{}.varint(1, 1).varint(2, 5))
+	p = p.bytes(5, pb{}.varint(1, 2).varint(2, 6))
+	p = p.bytes(5, pb{}.varint(1, 3).varint(2, 7))
+	for _, s := range []string{"", "cpu", "nanoseconds", "samples", "count", "main.main", "main.work", "main.inlined"} {
+		p = p.bytes(6, []byte(s))
+	}
+	return p
+}
+
+// TestParse 测试解码手工构造的剖析数据
+func TestParse(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(testProfile())
+	zw.Close()
+
+	for name, data := range map[string][]byte{"未压缩": testProfile(), "gzip": gz.Bytes()} {
+		p, err := flame.Parse(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Parse 失败: %v", name, err)
+		}
+		wantTypes := []flame.ValueType{{"samples", "count"}, {"cpu", "nanoseconds"}}
+		if !reflect.DeepEqual(p.SampleTypes, wantTypes) {
+			t.Errorf("%s: SampleTypes = %v", name, p.SampleTypes)
+		}
+		want := []flame.Sample{
+			{Stack: []string{"main.main", "main.work", "main.inlined"}, Values: []int64{1, 10}},
+			{Stack: []string{"main.main"}, Values: []int64{2, 20}},
+			{Stack: []string{"main.main", "main.work", "main.inlined"}, Values: []int64{1, 5}},
+			{Stack: []string{"[unknown]"}, Values: []int64{1, 0}},
+		}
+		if !reflect.DeepEqual(p.Samples, want) {
+			t.Errorf("%s: Samples = %v", name, p.Samples)
+		}
+	}
+}
+
+// TestParseErrors 测试非法输入
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"截断的 varint", []byte{0x08, 0x80}},
+		{"长度超出", []byte{0x0a, 0x05, 0x01}},
+		{"未知的编码类型", []byte{0x0b}},
+		{"数值列数不符", pb{}.bytes(1, pb{}.varint(1, 0)).bytes(2, pb{}.packed(2, 1, 2))},
+	}
+	for _, tc := range testCases {
+		if _, err := flame.Parse(bytes.NewReader(tc.data)); !errors.Is(err, flame.ErrFormat) {
+			t.Errorf("%s: 错误为 %v，预期 ErrFormat", tc.name, err)
+		}
+	}
+}
+
+var sink [][]byte
+
+//go:noinline
+func allocateForProfile() {
+	for range 1000 {
+		sink = append(sink, make([]byte, 1024))
+	}
+}
+
+// TestParseRuntimeProfile 测试解析 runtime/pprof 生成的堆剖析
+func TestParseRuntimeProfile(t *testing.T) {
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+	allocateForProfile()
+	runtime.GC()
+
+	var buf bytes.Buffer
+	if err := pprof.Lookup("allocs").WriteTo(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	p, err := flame.Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse 失败: %v", err)
+	}
+	i := p.SampleIndex("alloc_space")
+	if i < 0 {
+		t.Fatalf("缺少 alloc_space，SampleTypes = %v", p.SampleTypes)
+	}
+	found := slices.ContainsFunc(p.Samples, func(s flame.Sample) bool {
+		return len(s.Stack) > 0 && strings.HasSuffix(s.Stack[len(s.Stack)-1], "allocateForProfile") && s.Values[i] > 0
+	})
+	if !found {
+		t.Errorf("未找到 allocateForProfile 的分配样本")
+	}
+}
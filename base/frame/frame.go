@@ -0,0 +1,160 @@
+// Package frame 在字节流上实现以 uvarint 长度为前缀的消息分帧，用于构建简单的二进制协议。
+//
+// 每帧的格式为 uvarint(len(payload)) 后跟 payload，长度前缀与 encoding/binary 的 Uvarint 兼容。
+// Writer 把长度前缀与不太大的帧内容合并为一次 Write，避免在无缓冲的连接上产生两次系统调用；
+// Reader 复用内部缓冲区，稳定状态下读取不分配内存。
+//
+// 两端都限制单帧的最大长度：对端声明的长度超过上限时直接返回 ErrTooLarge，不会按声明的长度分配内存；
+// 即使声明的长度在上限以内，缓冲区也随数据实际到达逐步扩大，发送方无法只凭一个长度前缀让接收方分配大块内存。
+package frame
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/moweilong/efficient-go/base/varint"
+)
+
+// ErrTooLarge 表示帧的长度超出上限
+var ErrTooLarge = errors.New("frame: 帧长度超出上限")
+
+// DefaultMaxSize 是 maxSize 不为正数时使用的单帧长度上限
+const DefaultMaxSize = 4 << 20
+
+const (
+	// coalesceSize 是与长度前缀合并写入的最大帧长度，更大的帧分两次写入，避免整帧复制
+	coalesceSize = 32 << 10
+	// growChunk 是读取时缓冲区每次扩大的上限
+	growChunk = 64 << 10
+)
+
+// Writer 向底层 io.Writer 写入帧
+type Writer struct {
+	w   io.Writer
+	max int
+	buf []byte // 长度前缀与待合并的帧内容
+}
+
+// NewWriter 创建一个 Writer，单帧长度不超过 maxSize，maxSize 不为正数时使用 DefaultMaxSize
+func NewWriter(w io.Writer, maxSize int) *Writer {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &Writer{w: w, max: maxSize}
+}
+
+// WriteFrame 写入一帧，p 的长度超出上限时返回 ErrTooLarge 且不写入任何数据
+// 不超过 32KiB 的帧与长度前缀合并为一次 Write 调用
+func (w *Writer) WriteFrame(p []byte) error {
+	if len(p) > w.max {
+		return fmt.Errorf("%w：%d 字节，上限 %d", ErrTooLarge, len(p), w.max)
+	}
+	w.buf = varint.AppendUvarint(w.buf[:0], uint64(len(p)))
+	if len(p) <= coalesceSize {
+		w.buf = append(w.buf, p...)
+		_, err := w.w.Write(w.buf)
+		return err
+	}
+	if _, err := w.w.Write(w.buf); err != nil {
+		return err
+	}
+	_, err := w.w.Write(p)
+	return err
+}
+
+// byteReader 是可以逐字节读取长度前缀的 io.Reader
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// Reader 从底层 io.Reader 读取帧
+// 除 io.EOF 以外的错误发生后，流的位置不再位于帧的边界上，不应继续读取
+type Reader struct {
+	r   byteReader
+	max int
+	buf []byte // ReadFrame 复用的缓冲区
+}
+
+// NewReader 创建一个 Reader，单帧长度不超过 maxSize，maxSize 不为正数时使用 DefaultMaxSize
+// r 未实现 io.ByteReader 时使用 bufio.Reader 包装，此时 Reader 可能从 r 中多读取数据
+func NewReader(r io.Reader, maxSize int) *Reader {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Reader{r: br, max: maxSize}
+}
+
+// ReadFrame 读取下一帧，返回的切片指向内部缓冲区，只在下一次调用 ReadFrame 之前有效
+// 流恰好在帧的边界上结束时返回 io.EOF，在帧的中间结束时返回 io.ErrUnexpectedEOF；
+// 内部缓冲区保留读取过的最大帧的容量
+func (r *Reader) ReadFrame() ([]byte, error) {
+	buf, err := r.AppendFrame(r.buf[:0])
+	if err != nil {
+		return nil, err
+	}
+	r.buf = buf
+	return buf, nil
+}
+
+// AppendFrame 读取下一帧并把内容追加到 dst，适合需要保留帧内容的场景
+// 出错时返回原来的 dst 与错误，错误与 ReadFrame 相同
+func (r *Reader) AppendFrame(dst []byte) ([]byte, error) {
+	n, err := r.readLen()
+	if err != nil {
+		return dst, err
+	}
+	if n > uint64(r.max) {
+		return dst, fmt.Errorf("%w：%d 字节，上限 %d", ErrTooLarge, n, r.max)
+	}
+	start := len(dst)
+	for rem := int(n); rem > 0; {
+		k := min(rem, growChunk)
+		dst = slices.Grow(dst, k)
+		if _, err := io.ReadFull(r.r, dst[len(dst):len(dst)+k]); err != nil {
+			return dst[:start], noEOF(err)
+		}
+		dst = dst[:len(dst)+k]
+		rem -= k
+	}
+	return dst, nil
+}
+
+// readLen 读取 uvarint 长度前缀
+func (r *Reader) readLen() (uint64, error) {
+	var v uint64
+	var s uint
+	for i := range varint.MaxLen64 {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			if i > 0 {
+				err = noEOF(err)
+			}
+			return 0, err
+		}
+		if b < 0x80 {
+			if i == varint.MaxLen64-1 && b > 1 {
+				break
+			}
+			return v | uint64(b)<<s, nil
+		}
+		v |= uint64(b&0x7f) << s
+		s += 7
+	}
+	return 0, fmt.Errorf("frame: 长度前缀非法: %w", varint.ErrOverflow)
+}
+
+// noEOF 把帧中间出现的 io.EOF 转换为 io.ErrUnexpectedEOF
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
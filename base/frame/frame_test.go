@@ -0,0 +1,216 @@
+package frame_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/frame"
+	"github.com/moweilong/efficient-go/base/varint"
+)
+
+// frames 返回长度覆盖前缀字节数边界与合并写入阈值的帧
+func frames() [][]byte {
+	var fs [][]byte
+	for i, n := range []int{0, 1, 127, 128, 16383, 16384, 32 << 10, 32<<10 + 1, 200 << 10} {
+		fs = append(fs, bytes.Repeat([]byte{byte(i + 1)}, n))
+	}
+	return fs
+}
+
+// TestRoundTrip 测试写入的帧能被原样读出，底层 Reader 每次只返回部分数据时也能正确拼接
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := frame.NewWriter(&buf, 0)
+	for _, f := range frames() {
+		if err := w.WriteFrame(f); err != nil {
+			t.Fatalf("WriteFrame(%d 字节) 失败: %v", len(f), err)
+		}
+	}
+	data := buf.Bytes()
+
+	readers := map[string]io.Reader{
+		"bytes.Reader": bytes.NewReader(data),
+		"OneByte":      iotest.OneByteReader(bytes.NewReader(data)),
+		"Half":         iotest.HalfReader(bytes.NewReader(data)),
+	}
+	for name, src := range readers {
+		r := frame.NewReader(src, 0)
+		for i, want := range frames() {
+			got, err := r.ReadFrame()
+			if err != nil {
+				t.Fatalf("%s: 第 %d 帧 ReadFrame 失败: %v", name, i, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%s: 第 %d 帧内容不一致，长度 %d，预期 %d", name, i, len(got), len(want))
+			}
+		}
+		if _, err := r.ReadFrame(); err != io.EOF {
+			t.Errorf("%s: 读完后 err = %v，预期 io.EOF", name, err)
+		}
+	}
+}
+
+// TestFormat 测试长度前缀与 encoding/binary 的 Uvarint 兼容
+func TestFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := frame.NewWriter(&buf, 0).WriteFrame(bytes.Repeat([]byte("x"), 300)); err != nil {
+		t.Fatal(err)
+	}
+	n, k := binary.Uvarint(buf.Bytes())
+	if n != 300 || k != 2 || buf.Len() != 302 {
+		t.Errorf("长度前缀 = (%d, %d)，总长 %d，预期 (300, 2)，总长 302", n, k, buf.Len())
+	}
+}
+
+// countWriter 记录 Write 的调用次数
+type countWriter struct {
+	calls int
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.calls++
+	return len(p), nil
+}
+
+// TestCoalesce 测试小帧只调用一次 Write，大帧不复制内容而是分两次写入
+func TestCoalesce(t *testing.T) {
+	for _, tc := range []struct {
+		size  int
+		calls int
+	}{{0, 1}, {100, 1}, {32 << 10, 1}, {32<<10 + 1, 2}} {
+		cw := &countWriter{}
+		if err := frame.NewWriter(cw, 0).WriteFrame(make([]byte, tc.size)); err != nil {
+			t.Fatal(err)
+		}
+		if cw.calls != tc.calls {
+			t.Errorf("%d 字节的帧调用 Write %d 次，预期 %d 次", tc.size, cw.calls, tc.calls)
+		}
+	}
+}
+
+// TestMaxSize 测试两端的长度上限
+func TestMaxSize(t *testing.T) {
+	var buf bytes.Buffer
+	w := frame.NewWriter(&buf, 10)
+	if err := w.WriteFrame(make([]byte, 11)); !errors.Is(err, frame.ErrTooLarge) {
+		t.Errorf("写入超长帧 err = %v，预期 ErrTooLarge", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("写入超长帧失败后写入了 %d 字节", buf.Len())
+	}
+	if err := w.WriteFrame(make([]byte, 10)); err != nil {
+		t.Errorf("写入恰好等于上限的帧失败: %v", err)
+	}
+
+	r := frame.NewReader(bytes.NewReader(varint.AppendUvarint(nil, 11)), 10)
+	if _, err := r.ReadFrame(); !errors.Is(err, frame.ErrTooLarge) {
+		t.Errorf("读取超长帧 err = %v，预期 ErrTooLarge", err)
+	}
+}
+
+// TestLazyGrow 测试声明的长度很大但数据没有到达时，不会按声明的长度分配内存
+func TestLazyGrow(t *testing.T) {
+	data := varint.AppendUvarint(nil, 1<<30)
+	data = append(data, make([]byte, 100)...)
+	r := frame.NewReader(bytes.NewReader(data), 1<<31)
+	dst, err := r.AppendFrame(nil)
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("err = %v，预期 io.ErrUnexpectedEOF", err)
+	}
+	if len(dst) != 0 || cap(dst) > 1<<20 {
+		t.Errorf("出错后 len = %d，cap = %d，预期不按声明的长度分配", len(dst), cap(dst))
+	}
+}
+
+// TestReadErrors 测试截断与非法的长度前缀
+func TestReadErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"空流", nil, io.EOF},
+		{"长度前缀截断", []byte{0x80}, io.ErrUnexpectedEOF},
+		{"内容截断", []byte{3, 'a', 'b'}, io.ErrUnexpectedEOF},
+		{"长度前缀溢出", bytes.Repeat([]byte{0xff}, 10), varint.ErrOverflow},
+		{"长度前缀过长", append(bytes.Repeat([]byte{0x80}, 10), 0), varint.ErrOverflow},
+	}
+	for _, tc := range testCases {
+		r := frame.NewReader(bytes.NewReader(tc.data), 0)
+		if _, err := r.ReadFrame(); !errors.Is(err, tc.err) {
+			t.Errorf("%s: err = %v，预期 %v", tc.name, err, tc.err)
+		}
+	}
+
+	// AppendFrame 出错时返回原来的 dst
+	r := frame.NewReader(bytes.NewReader([]byte{3, 'a'}), 0)
+	if dst, err := r.AppendFrame([]byte("keep")); err == nil || string(dst) != "keep" {
+		t.Errorf("AppendFrame = %q, %v，预期保持 dst 不变并返回错误", dst, err)
+	}
+}
+
+// TestWriteError 测试底层 Writer 的错误原样返回
+func TestWriteError(t *testing.T) {
+	for _, size := range []int{10, 64 << 10} {
+		w := frame.NewWriter(failWriter{}, 0)
+		if err := w.WriteFrame(make([]byte, size)); !errors.Is(err, errWrite) {
+			t.Errorf("%d 字节: err = %v，预期 errWrite", size, err)
+		}
+	}
+}
+
+var errWrite = errors.New("写入失败")
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errWrite }
+
+// TestAllocs 测试稳定状态下写入与读取都不分配内存
+func TestAllocs(t *testing.T) {
+	var buf bytes.Buffer
+	w := frame.NewWriter(&buf, 0)
+	payload := make([]byte, 1000)
+	src := bytes.NewReader(nil)
+	r := frame.NewReader(src, 0)
+	round := func() {
+		buf.Reset()
+		_ = w.WriteFrame(payload)
+		src.Reset(buf.Bytes())
+		_, _ = r.ReadFrame()
+	}
+	round()
+	benchkit.AssertAllocs(t, 0, round)
+}
+
+// BenchmarkRoundTrip 测试不同帧长度下写入并读取一帧的开销
+func BenchmarkRoundTrip(b *testing.B) {
+	for _, size := range []int{64, 4 << 10, 256 << 10} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			var buf bytes.Buffer
+			w := frame.NewWriter(&buf, 0)
+			payload := make([]byte, size)
+			src := bytes.NewReader(nil)
+			r := frame.NewReader(src, 0)
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				if err := w.WriteFrame(payload); err != nil {
+					b.Fatal(err)
+				}
+				src.Reset(buf.Bytes())
+				f, err := r.ReadFrame()
+				if err != nil {
+					b.Fatal(err)
+				}
+				benchkit.SinkInt = len(f)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Package group 提供与 errgroup 类似的任务组：等待一组 goroutine 结束并返回第一个错误，
+// 此外还把任务中的 panic 转换为错误、支持每个任务的超时，并用位集合实现并发数限制。
+package group
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+)
+
+// PanicError 是任务 panic 时转换得到的错误
+type PanicError struct {
+	Value any    // recover 得到的值
+	Stack []byte // panic 时的调用栈
+}
+
+// Error 实现 error 接口
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("group: 任务 panic: %v\n%s", e.Value, e.Stack)
+}
+
+// Unwrap 在 panic 的值是 error 时返回它，使 errors.Is 与 errors.As 可以识别
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Group 是一组任务，零值即可使用，此时没有并发限制与超时，任务的上下文为 context.Background()
+//
+// Group 不能在使用后复制。
+type Group struct {
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	timeout time.Duration
+
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+
+	// 并发限制：每个运行中的任务占用 tokens 中的一位，没有空闲位时在 cond 上等待
+	tokens *bitset.Atomic
+	mu     sync.Mutex
+	cond   *sync.Cond
+}
+
+// WithContext 返回一个新的 Group 与派生的上下文，任一任务返回错误或 Wait 返回时上下文被取消
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{ctx: ctx, cancel: cancel}, ctx
+}
+
+// SetLimit 将同时运行的任务数限制为 n，n 不为正数表示不限制；有任务运行时调用会 panic
+//
+// 限制由一个 n 位的原子位集合实现：启动任务时用 CAS 认领一个空闲位，任务结束时清除该位。
+// 未达到限制时 Go 不需要加锁，也不像 channel 信号量那样需要经过调度器。
+func (g *Group) SetLimit(n int) {
+	if g.tokens != nil && g.tokens.Count() > 0 {
+		panic("group: 有任务运行时不能修改并发限制")
+	}
+	if n <= 0 {
+		g.tokens = nil
+		return
+	}
+	g.tokens = bitset.NewAtomic(n)
+	g.cond = sync.NewCond(&g.mu)
+}
+
+// SetTimeout 设置之后通过 Go 启动的每个任务的超时时间，d 不为正数表示不限制
+// 超时通过任务的上下文通知，任务应在上下文结束后尽快返回
+func (g *Group) SetTimeout(d time.Duration) {
+	g.timeout = d
+}
+
+// Go 在新的 goroutine 中运行 f；达到并发限制时阻塞，直到有任务结束
+func (g *Group) Go(f func(ctx context.Context) error) {
+	token := -1
+	if g.tokens != nil {
+		token = g.acquire()
+	}
+	g.start(token, f)
+}
+
+// TryGo 与 Go 相同，但达到并发限制时不阻塞而是返回 false
+func (g *Group) TryGo(f func(ctx context.Context) error) bool {
+	token := -1
+	if g.tokens != nil {
+		i, ok := g.tokens.SetFirstClear()
+		if !ok {
+			return false
+		}
+		token = i
+	}
+	g.start(token, f)
+	return true
+}
+
+// Wait 等待所有任务结束，返回第一个非 nil 的错误
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	return g.err
+}
+
+// start 启动任务，token 为其占用的位，-1 表示没有并发限制
+func (g *Group) start(token int, f func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if token >= 0 {
+			defer g.release(token)
+		}
+		if err := g.run(f); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel(err)
+				}
+			})
+		}
+	}()
+}
+
+// run 以任务的上下文运行 f，并将 panic 转换为 *PanicError
+func (g *Group) run(f func(ctx context.Context) error) (err error) {
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return f(ctx)
+}
+
+// acquire 认领一个空闲位，没有空闲位时等待
+func (g *Group) acquire() int {
+	if i, ok := g.tokens.SetFirstClear(); ok {
+		return i
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for {
+		// 持有锁期间检查与等待之间不会错过 release 的通知
+		if i, ok := g.tokens.SetFirstClear(); ok {
+			return i
+		}
+		g.cond.Wait()
+	}
+}
+
+// release 释放位 i 并唤醒一个等待者
+func (g *Group) release(i int) {
+	g.tokens.Clear(i)
+	g.mu.Lock()
+	g.cond.Signal()
+	g.mu.Unlock()
+}
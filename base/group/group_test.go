@@ -0,0 +1,170 @@
+package group_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/group"
+)
+
+// TestGroup 测试等待全部任务并返回第一个错误
+func TestGroup(t *testing.T) {
+	var g group.Group
+	var n atomic.Int32
+	for range 10 {
+		g.Go(func(context.Context) error {
+			n.Add(1)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil || n.Load() != 10 {
+		t.Errorf("Wait() = %v，执行了 %d 个任务", err, n.Load())
+	}
+
+	errBoom := errors.New("boom")
+	g2, ctx := group.WithContext(context.Background())
+	g2.Go(func(context.Context) error { return errBoom })
+	g2.Go(func(ctx context.Context) error {
+		<-ctx.Done() // 其他任务失败后上下文被取消
+		return ctx.Err()
+	})
+	if err := g2.Wait(); !errors.Is(err, errBoom) {
+		t.Errorf("Wait() = %v，预期 %v", err, errBoom)
+	}
+	if !errors.Is(context.Cause(ctx), errBoom) {
+		t.Errorf("上下文的取消原因为 %v", context.Cause(ctx))
+	}
+}
+
+// TestPanic 测试 panic 被转换为 *PanicError
+func TestPanic(t *testing.T) {
+	var g group.Group
+	errInner := errors.New("inner")
+	g.Go(func(context.Context) error { panic(errInner) })
+	err := g.Wait()
+	var pe *group.PanicError
+	if !errors.As(err, &pe) || !errors.Is(err, errInner) {
+		t.Fatalf("Wait() = %v，预期包装 inner 的 *PanicError", err)
+	}
+	if !strings.Contains(string(pe.Stack), "group_test") {
+		t.Errorf("调用栈中没有 panic 的位置:\n%s", pe.Stack)
+	}
+
+	var g2 group.Group
+	g2.Go(func(context.Context) error { panic("plain") })
+	if err := g2.Wait(); !errors.As(err, &pe) || pe.Value != "plain" || errors.Unwrap(err) != nil {
+		t.Errorf("Wait() = %v", err)
+	}
+}
+
+// TestTimeout 测试每个任务的超时
+func TestTimeout(t *testing.T) {
+	var g group.Group
+	g.SetTimeout(5 * time.Millisecond)
+	start := time.Now()
+	g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err := g.Wait(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() = %v，预期 DeadlineExceeded", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("超时未生效")
+	}
+}
+
+// TestLimit 测试并发数不超过限制
+func TestLimit(t *testing.T) {
+	var g group.Group
+	g.SetLimit(3)
+	var running, peak atomic.Int32
+	for range 50 {
+		g.Go(func(context.Context) error {
+			cur := running.Add(1)
+			for {
+				p := peak.Load()
+				if cur <= p || peak.CompareAndSwap(p, cur) {
+					break
+				}
+			}
+			time.Sleep(100 * time.Microsecond)
+			running.Add(-1)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if p := peak.Load(); p > 3 || p < 1 {
+		t.Errorf("同时运行的任务数峰值为 %d，限制为 3", p)
+	}
+}
+
+// TestTryGo 测试达到限制时 TryGo 返回 false
+func TestTryGo(t *testing.T) {
+	var g group.Group
+	g.SetLimit(1)
+	release := make(chan struct{})
+	if !g.TryGo(func(context.Context) error { <-release; return nil }) {
+		t.Fatalf("未达到限制时 TryGo 应成功")
+	}
+	if g.TryGo(func(context.Context) error { return nil }) {
+		t.Errorf("达到限制时 TryGo 应返回 false")
+	}
+	close(release)
+	g.Wait()
+	if !g.TryGo(func(context.Context) error { return nil }) {
+		t.Errorf("任务结束后 TryGo 应成功")
+	}
+	g.Wait()
+}
+
+// BenchmarkSpawn 对比 Group 与 sync.WaitGroup 启动空任务的开销，以及并发限制的额外开销
+func BenchmarkSpawn(b *testing.B) {
+	b.Run("WaitGroup", func(b *testing.B) {
+		b.ReportAllocs()
+		var wg sync.WaitGroup
+		for i := 0; i < b.N; i++ {
+			wg.Add(1)
+			go wg.Done()
+		}
+		wg.Wait()
+	})
+	b.Run("Group", func(b *testing.B) {
+		b.ReportAllocs()
+		var g group.Group
+		for i := 0; i < b.N; i++ {
+			g.Go(func(context.Context) error { return nil })
+		}
+		g.Wait()
+	})
+	b.Run("Group/limit=8", func(b *testing.B) {
+		b.ReportAllocs()
+		var g group.Group
+		g.SetLimit(8)
+		for i := 0; i < b.N; i++ {
+			g.Go(func(context.Context) error { return nil })
+		}
+		g.Wait()
+	})
+	b.Run("chan-semaphore/limit=8", func(b *testing.B) {
+		b.ReportAllocs()
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, 8)
+		for i := 0; i < b.N; i++ {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-sem
+			}()
+		}
+		wg.Wait()
+	})
+}
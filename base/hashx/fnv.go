@@ -0,0 +1,32 @@
+package hashx
+
+// FNV-1a 64 位参数
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// FNV1a 是 64 位 FNV-1a 哈希，结果与 hash/fnv.New64a 相同，但不需要创建 hash.Hash64 对象
+type FNV1a struct{}
+
+var _ Hasher = FNV1a{}
+
+// Sum64 返回 b 的 FNV-1a 哈希值
+func (FNV1a) Sum64(b []byte) uint64 {
+	h := uint64(fnvOffset64)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// Sum64String 返回 s 的 FNV-1a 哈希值
+func (FNV1a) Sum64String(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
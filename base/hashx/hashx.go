@@ -0,0 +1,19 @@
+// Package hashx 以统一的 Hasher 接口提供几种 64 位非加密哈希函数，便于按场景选择与替换：
+//
+//   - FNV1a：实现最简单，结果跨进程、跨平台稳定，但逐字节处理，长键较慢，分布也最弱
+//   - Wy：wyhash 风格，每次处理 8～48 字节并用 64×64→128 位乘法混合，长短键都很快，结果稳定
+//   - Short：16 字节以内的短键只需两次非对齐读取与两次乘法，比 Wy 更快，长键交给 Wy
+//   - MapHash：标准库 hash/maphash，使用运行时的硬件加速实现，种子随机，结果只在进程内有效，可抵御哈希洪水攻击
+//   - Seeded：在 MapHash 的基础上为整数键提供快速路径，并通过 Comparable 支持任意可比较类型，供哈希容器持有
+//
+// 需要持久化或跨进程比较哈希值时使用 FNV1a 或 Wy，只在内存中使用时优先 MapHash，实现哈希容器时使用 Seeded。
+// 各长度下的性能对比见 BenchmarkHashers。
+package hashx
+
+// Hasher 是 64 位非加密哈希函数，实现必须可以被多个 goroutine 并发调用
+type Hasher interface {
+	// Sum64 返回 b 的哈希值
+	Sum64(b []byte) uint64
+	// Sum64String 返回 s 的哈希值，与 Sum64([]byte(s)) 相同但不复制数据
+	Sum64String(s string) uint64
+}
@@ -0,0 +1,120 @@
+package hashx_test
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/hashx"
+)
+
+var hashers = []struct {
+	name string
+	h    hashx.Hasher
+}{
+	{"FNV1a", hashx.FNV1a{}},
+	{"Wy", hashx.Wy{}},
+	{"Short", hashx.Short{}},
+	{"MapHash", hashx.NewMapHash()},
+	{"Seeded", hashx.NewSeeded()},
+}
+
+// TestFNV1a 测试结果与 hash/fnv 一致
+func TestFNV1a(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := range 100 {
+		b := make([]byte, n)
+		r.Read(b)
+		f := fnv.New64a()
+		f.Write(b)
+		if got, want := (hashx.FNV1a{}).Sum64(b), f.Sum64(); got != want {
+			t.Fatalf("len=%d: Sum64 = %#x，hash/fnv 为 %#x", n, got, want)
+		}
+	}
+}
+
+// TestStringBytes 测试各实现的 Sum64 与 Sum64String 结果相同，且多次调用结果稳定
+func TestStringBytes(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, tt := range hashers {
+		for n := range 200 {
+			b := make([]byte, n)
+			r.Read(b)
+			h := tt.h.Sum64(b)
+			if s := tt.h.Sum64String(string(b)); s != h {
+				t.Fatalf("%s len=%d: Sum64 = %#x，Sum64String = %#x", tt.name, n, h, s)
+			}
+			if again := tt.h.Sum64(b); again != h {
+				t.Fatalf("%s len=%d: 两次结果不同", tt.name, n)
+			}
+		}
+	}
+}
+
+// TestPrefixes 测试同一缓冲区各长度前缀的哈希值互不相同，覆盖各分支的边界（3/4、16/17、48/49 字节等）
+func TestPrefixes(t *testing.T) {
+	buf := make([]byte, 300) // 全零输入最容易暴露长度未参与混合的问题
+	for _, tt := range hashers {
+		seen := make(map[uint64]int)
+		for n := range len(buf) {
+			h := tt.h.Sum64(buf[:n])
+			if m, ok := seen[h]; ok {
+				t.Fatalf("%s: 长度 %d 与 %d 的哈希值相同", tt.name, n, m)
+			}
+			seen[h] = n
+		}
+	}
+}
+
+// TestSeed 测试 Wy 的种子影响结果，MapHash 不同实例的结果不同
+func TestSeed(t *testing.T) {
+	b := []byte("efficient-go")
+	if (hashx.Wy{Seed: 1}).Sum64(b) == (hashx.Wy{Seed: 2}).Sum64(b) {
+		t.Errorf("Wy 不同种子的结果相同")
+	}
+	if hashx.NewMapHash().Sum64(b) == hashx.NewMapHash().Sum64(b) {
+		t.Errorf("MapHash 不同实例的结果相同")
+	}
+}
+
+// TestAvalanche 测试翻转输入的任意一位时输出平均约有一半的位发生变化
+// FNV-1a 的雪崩效应较弱，只检查 Wy 与 MapHash
+func TestAvalanche(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for _, tt := range hashers[1:] {
+		for _, n := range []int{3, 8, 16, 40, 100} {
+			b := make([]byte, n)
+			var flips, trials int
+			for range 200 {
+				r.Read(b)
+				h := tt.h.Sum64(b)
+				i := r.Intn(n * 8)
+				b[i/8] ^= 1 << (i % 8)
+				flips += bits.OnesCount64(h ^ tt.h.Sum64(b))
+				trials++
+			}
+			if avg := float64(flips) / float64(trials); avg < 28 || avg > 36 {
+				t.Errorf("%s len=%d: 平均翻转 %.1f 位，预期约 32", tt.name, n, avg)
+			}
+		}
+	}
+}
+
+// BenchmarkHashers 对比各实现在不同键长下的开销
+func BenchmarkHashers(b *testing.B) {
+	for _, n := range []int{4, 8, 16, 32, 64, 256, 1024} {
+		buf := make([]byte, n)
+		rand.New(rand.NewSource(int64(n))).Read(buf)
+		for _, tt := range hashers {
+			b.Run(fmt.Sprintf("len=%d/%s", n, tt.name), func(b *testing.B) {
+				b.SetBytes(int64(n))
+				for i := 0; i < b.N; i++ {
+					benchkit.SinkU64 = tt.h.Sum64(buf)
+				}
+			})
+		}
+	}
+}
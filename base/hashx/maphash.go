@@ -0,0 +1,28 @@
+package hashx
+
+import "hash/maphash"
+
+// MapHash 是对 hash/maphash 的封装，零值不可用，应使用 NewMapHash 创建
+//
+// 种子在创建时随机生成，相同输入在不同的 MapHash 实例与不同进程中得到不同的哈希值，
+// 攻击者无法预先构造大量冲突的键。
+type MapHash struct {
+	seed maphash.Seed
+}
+
+var _ Hasher = MapHash{}
+
+// NewMapHash 创建使用随机种子的 MapHash
+func NewMapHash() MapHash {
+	return MapHash{seed: maphash.MakeSeed()}
+}
+
+// Sum64 返回 b 的哈希值
+func (m MapHash) Sum64(b []byte) uint64 {
+	return maphash.Bytes(m.seed, b)
+}
+
+// Sum64String 返回 s 的哈希值
+func (m MapHash) Sum64String(s string) uint64 {
+	return maphash.String(m.seed, s)
+}
@@ -0,0 +1,179 @@
+package hashx_test
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/hashx"
+)
+
+// TestSeeded 测试同一实例的哈希值稳定，不同实例的哈希值不同
+func TestSeeded(t *testing.T) {
+	a, b := hashx.NewSeeded(), hashx.NewSeeded()
+	if a.Sum64String("key") != a.Sum64([]byte("key")) {
+		t.Errorf("Sum64String 与 Sum64 结果不同")
+	}
+	if a.Sum64String("key") != a.Sum64String("key") || a.Uint64(42) != a.Uint64(42) {
+		t.Errorf("同一实例对相同输入的哈希值不同")
+	}
+	if a.Sum64String("key") == b.Sum64String("key") || a.Uint64(42) == b.Uint64(42) {
+		t.Errorf("不同实例的哈希值相同")
+	}
+	var h maphash.Hash
+	h.SetSeed(a.Seed())
+	h.WriteString("key")
+	if h.Sum64() != a.Sum64String("key") {
+		t.Errorf("Seed 返回的种子与 Sum64String 使用的不同")
+	}
+}
+
+// TestComparable 测试各类型的键走对应的路径
+func TestComparable(t *testing.T) {
+	type point struct{ x, y int }
+	type id int64
+	s := hashx.NewSeeded()
+	testCases := []struct {
+		name      string
+		got, want uint64
+	}{
+		{"int", hashx.Comparable(s, 7), s.Uint64(7)},
+		{"int64 负数", hashx.Comparable(s, int64(-1)), s.Uint64(^uint64(0))},
+		{"uint32", hashx.Comparable(s, uint32(9)), s.Uint64(9)},
+		{"string", hashx.Comparable(s, "abc"), s.Sum64String("abc")},
+		{"自定义整数类型", hashx.Comparable(s, id(7)), hashx.Comparable(s, id(7))},
+		{"结构体", hashx.Comparable(s, point{1, 2}), hashx.Comparable(s, point{1, 2})},
+	}
+	for _, tc := range testCases {
+		if tc.got != tc.want {
+			t.Errorf("%s: 0x%x，预期 0x%x", tc.name, tc.got, tc.want)
+		}
+	}
+	if hashx.Comparable(s, point{1, 2}) == hashx.Comparable(s, point{2, 1}) {
+		t.Errorf("不同的结构体得到相同的哈希值")
+	}
+}
+
+// TestUint64Avalanche 测试翻转整数的任一位时，平均约一半的输出位翻转，且每个输出位的翻转概率都接近 1/2
+func TestUint64Avalanche(t *testing.T) {
+	const trials = 2000
+	r := rand.New(rand.NewSource(1))
+	s := hashx.NewSeeded()
+	worst := 0.0
+	for in := range 64 {
+		var counts [64]int
+		for range trials {
+			x := r.Uint64()
+			d := s.Uint64(x) ^ s.Uint64(x^1<<in)
+			for out := range 64 {
+				counts[out] += int(d >> out & 1)
+			}
+		}
+		for _, c := range counts {
+			worst = max(worst, abs(float64(c)/trials-0.5))
+		}
+	}
+	if worst > 0.06 {
+		t.Errorf("最大偏差 %.3f，超过 0.06", worst)
+	}
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// TestUint64Buckets 测试连续整数在低位掩码分桶下分布均匀
+func TestUint64Buckets(t *testing.T) {
+	const keys, buckets = 1 << 16, 1 << 10
+	s := hashx.NewSeeded()
+	for _, stride := range []uint64{1, 1 << 10, 1 << 32} {
+		var counts [buckets]int
+		for i := range uint64(keys) {
+			counts[s.Uint64(i*stride)&(buckets-1)]++
+		}
+		// 卡方检验：自由度 1023，显著性 0.001 下的临界值约为 1174
+		expected := float64(keys) / buckets
+		chi2 := 0.0
+		for _, c := range counts {
+			d := float64(c) - expected
+			chi2 += d * d / expected
+		}
+		if chi2 > 1174 {
+			t.Errorf("步长 %d: 卡方统计量 %.0f 超过临界值", stride, chi2)
+		}
+	}
+}
+
+// TestCombine 测试 Combine 与顺序有关，CombineUnordered 与顺序无关
+func TestCombine(t *testing.T) {
+	s := hashx.NewSeeded()
+	a, b := s.Sum64String("a"), s.Sum64String("b")
+	if hashx.Combine(a, b) == hashx.Combine(b, a) {
+		t.Errorf("Combine 的结果与顺序无关")
+	}
+	if hashx.CombineUnordered(a, b) != hashx.CombineUnordered(b, a) {
+		t.Errorf("CombineUnordered 的结果与顺序有关")
+	}
+	if hashx.CombineUnordered(hashx.CombineUnordered(a, a), b) == b {
+		t.Errorf("重复的元素相互抵消")
+	}
+}
+
+// TestCombineAvalanche 测试翻转任一输入的一位时，Combine 的输出平均翻转约一半的位
+// 对大量输入对取平均，单个种子或输入对的偶然偏差不会导致测试失败
+func TestCombineAvalanche(t *testing.T) {
+	s := hashx.NewSeeded()
+	const pairs = 256
+	var flipsA, flipsB int
+	for p := range pairs {
+		a, b := s.Uint64(uint64(p)), s.Sum64String(fmt.Sprint(p))
+		h := hashx.Combine(a, b)
+		for i := range 64 {
+			flipsA += bits.OnesCount64(h ^ hashx.Combine(a^1<<i, b))
+			flipsB += bits.OnesCount64(h ^ hashx.Combine(a, b^1<<i))
+		}
+	}
+	for _, tc := range []struct {
+		name  string
+		flips int
+	}{{"a", flipsA}, {"b", flipsB}} {
+		if avg := float64(tc.flips) / (pairs * 64); avg < 31 || avg > 33 {
+			t.Errorf("翻转 %s 的一位时 Combine 平均翻转 %.2f 位，预期约 32", tc.name, avg)
+		}
+	}
+}
+
+// BenchmarkComparable 对比 Comparable 的快速路径与直接调用 maphash.Comparable
+func BenchmarkComparable(b *testing.B) {
+	s := hashx.NewSeeded()
+	seed := s.Seed()
+	b.Run("uint64/Seeded", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = hashx.Comparable(s, uint64(i))
+		}
+	})
+	b.Run("uint64/maphash", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkU64 = maphash.Comparable(seed, uint64(i))
+		}
+	})
+	for _, n := range []int{8, 32} {
+		key := fmt.Sprintf("%0*d", n, 0)
+		b.Run(fmt.Sprintf("string/len=%d/Seeded", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkU64 = hashx.Comparable(s, key)
+			}
+		})
+		b.Run(fmt.Sprintf("string/len=%d/maphash", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkU64 = maphash.Comparable(seed, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package hashx
+
+import (
+	"math/bits"
+
+	"github.com/moweilong/efficient-go/base/unsafeconv"
+)
+
+// Short 是针对 16 字节以内短键优化的哈希，结果只取决于输入与 Seed，跨进程稳定
+//
+// 短键最多用两次非对齐读取装入两个 uint64（SWAR，把多个字节放在一个寄存器中一起处理），
+// 长度 4～7、8～16 的键首尾两次读取相互重叠，恰好覆盖全部字节，整个过程没有逐字节循环与分支嵌套，
+// 随后只需两次 64×64→128 位乘法完成混合。超过 16 字节的键交给 Wy 处理。
+// 适合 map 键、标识符、短 URL 路径等绝大多数长度很短的场景。
+type Short struct {
+	Seed uint64
+}
+
+var _ Hasher = Short{}
+
+// Sum64 返回 b 的哈希值
+func (s Short) Sum64(b []byte) uint64 {
+	return short(b, s.Seed)
+}
+
+// Sum64String 返回 str 的哈希值
+func (s Short) Sum64String(str string) uint64 {
+	return short(unsafeconv.StringToBytes(str), s.Seed)
+}
+
+// short 计算 p 的哈希值，长度参与混合，使内容相同、长度不同的输入（如 "a" 与 "aaa"）得到不同结果
+func short(p []byte, seed uint64) uint64 {
+	n := len(p)
+	var a, b uint64
+	switch {
+	case n > 16:
+		return wyhash(p, seed)
+	case n >= 8:
+		a, b = r8(p), r8(p[n-8:])
+	case n >= 4:
+		a, b = r4(p), r4(p[n-4:])
+	case n > 0:
+		a = uint64(p[0])<<16 | uint64(p[n>>1])<<8 | uint64(p[n-1])
+	}
+	// 第一次乘法保留完整的 128 位乘积，高低两半分别参与第二次乘法，使每个输入位都能影响全部输出位
+	hi, lo := bits.Mul64(a^seed^wyp1, b^wyp2)
+	return mix(lo^wyp0^uint64(n), hi^seed^wyp3)
+}
@@ -0,0 +1,113 @@
+package hashx_test
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/hashx"
+)
+
+// TestShortLengths 测试长度不同、字节重复的短键互不冲突，以及超过 16 字节时与 Wy 一致
+func TestShortLengths(t *testing.T) {
+	h := hashx.Short{}
+	seen := make(map[uint64]string)
+	for n := range 17 {
+		for _, c := range []byte{0, 'a', 0xff} {
+			if n == 0 && c != 0 {
+				continue
+			}
+			s := string(bytesOf(c, n))
+			v := h.Sum64String(s)
+			if prev, ok := seen[v]; ok {
+				t.Fatalf("%q 与 %q 的哈希值相同", s, prev)
+			}
+			seen[v] = s
+		}
+	}
+	long := []byte("a key that is longer than sixteen bytes")
+	if (hashx.Short{Seed: 7}).Sum64(long) != (hashx.Wy{Seed: 7}).Sum64(long) {
+		t.Errorf("长键应交给 Wy 处理")
+	}
+}
+
+func bytesOf(c byte, n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = c
+	}
+	return b
+}
+
+// TestShortAvalanche 测试严格雪崩准则：翻转任一输入位时，每个输出位翻转的概率都接近 1/2
+func TestShortAvalanche(t *testing.T) {
+	const trials = 2000
+	r := rand.New(rand.NewSource(1))
+	h := hashx.Short{}
+	// 1 字节的输入只有 256 种，随机抽样的结果高度相关，统计量不可靠，因此从 2 字节开始
+	for _, n := range []int{2, 3, 4, 7, 8, 12, 16} {
+		b := make([]byte, n)
+		worst := 0.0
+		for in := range n * 8 {
+			var counts [64]int
+			for range trials {
+				r.Read(b)
+				x := h.Sum64(b)
+				b[in/8] ^= 1 << (in % 8)
+				d := x ^ h.Sum64(b)
+				for d != 0 {
+					counts[bits.TrailingZeros64(d)]++
+					d &= d - 1
+				}
+			}
+			for _, c := range counts {
+				worst = max(worst, math.Abs(float64(c)/trials-0.5))
+			}
+		}
+		// 2000 次试验的标准差约为 0.011，偏差超过 0.06 说明存在明显的相关性
+		if worst > 0.06 {
+			t.Errorf("len=%d: 输出位翻转概率的最大偏差为 %.3f", n, worst)
+		}
+	}
+}
+
+// TestShortBuckets 测试结构相似的键（如 "user:123"）在 2 的幂个桶中分布均匀
+// 哈希表通常只取低位作为桶下标，低位分布不均会直接导致冲突链变长
+func TestShortBuckets(t *testing.T) {
+	const keys, buckets = 1 << 16, 1 << 10
+	h := hashx.Short{}
+	for _, format := range []string{"user:%d", "%d", "k%08d"} {
+		var counts [buckets]int
+		for i := range keys {
+			counts[h.Sum64String(fmt.Sprintf(format, i))&(buckets-1)]++
+		}
+		// 卡方检验：自由度 1023，显著性 0.001 下的临界值约为 1174
+		expected := float64(keys) / buckets
+		chi2 := 0.0
+		for _, c := range counts {
+			d := float64(c) - expected
+			chi2 += d * d / expected
+		}
+		if chi2 > 1174 {
+			t.Errorf("%q: 卡方统计量 %.0f 超过临界值", format, chi2)
+		}
+	}
+}
+
+// BenchmarkShort 对比短键下 Short 与其他实现的开销
+func BenchmarkShort(b *testing.B) {
+	for _, n := range []int{3, 8, 12, 16} {
+		key := strconv.Itoa(1e15)[:n]
+		for _, tt := range hashers {
+			b.Run(fmt.Sprintf("len=%d/%s", n, tt.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					benchkit.SinkU64 = tt.h.Sum64String(key)
+				}
+			})
+		}
+	}
+}
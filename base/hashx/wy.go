@@ -0,0 +1,87 @@
+package hashx
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/moweilong/efficient-go/base/unsafeconv"
+)
+
+// wyhash 的常量
+const (
+	wyp0 = 0xa0761d6478bd642f
+	wyp1 = 0xe7037ed1a0b428db
+	wyp2 = 0x8ebc6af09c88c6e3
+	wyp3 = 0x589965cc75374cc3
+)
+
+// Wy 是 wyhash 风格的哈希，结果只取决于输入与 Seed，跨进程稳定
+//
+// 16 字节以内的键用两到四次重叠的非对齐读取覆盖全部字节，没有逐字节循环；
+// 更长的键每轮用三条独立的乘法链处理 48 字节，充分利用 CPU 的指令级并行。
+type Wy struct {
+	Seed uint64
+}
+
+var _ Hasher = Wy{}
+
+// Sum64 返回 b 的哈希值
+func (w Wy) Sum64(b []byte) uint64 {
+	return wyhash(b, w.Seed)
+}
+
+// Sum64String 返回 s 的哈希值
+func (w Wy) Sum64String(s string) uint64 {
+	return wyhash(unsafeconv.StringToBytes(s), w.Seed)
+}
+
+// mix 返回 a×b 的 128 位乘积高低两半的异或，是 wyhash 的核心混合步骤
+func mix(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return hi ^ lo
+}
+
+func r8(p []byte) uint64 {
+	return binary.LittleEndian.Uint64(p)
+}
+
+func r4(p []byte) uint64 {
+	return uint64(binary.LittleEndian.Uint32(p))
+}
+
+// wyhash 计算 p 的哈希值
+func wyhash(p []byte, seed uint64) uint64 {
+	n := len(p)
+	seed ^= mix(seed^wyp0, wyp1)
+	var a, b uint64
+	switch {
+	case n >= 4 && n <= 16:
+		// 首尾各取两个 4 字节，中间两次的位置随长度移动，覆盖全部字节
+		q := (n >> 3) << 2
+		a = r4(p)<<32 | r4(p[q:])
+		b = r4(p[n-4:])<<32 | r4(p[n-4-q:])
+	case n > 0 && n < 4:
+		a = uint64(p[0])<<16 | uint64(p[n>>1])<<8 | uint64(p[n-1])
+	case n > 16:
+		i := 0
+		if n > 48 {
+			see1, see2 := seed, seed
+			for ; n-i > 48; i += 48 {
+				seed = mix(r8(p[i:])^wyp1, r8(p[i+8:])^seed)
+				see1 = mix(r8(p[i+16:])^wyp2, r8(p[i+24:])^see1)
+				see2 = mix(r8(p[i+32:])^wyp3, r8(p[i+40:])^see2)
+			}
+			seed ^= see1 ^ see2
+		}
+		for ; n-i > 16; i += 16 {
+			seed = mix(r8(p[i:])^wyp1, r8(p[i+8:])^seed)
+		}
+		// 最后 16 字节可能与已处理的部分重叠
+		a = r8(p[n-16:])
+		b = r8(p[n-8:])
+	}
+	a ^= wyp1
+	b ^= seed
+	hi, lo := bits.Mul64(a, b)
+	return mix(lo^wyp0^uint64(n), hi^wyp1)
+}
@@ -0,0 +1,188 @@
+package intcomp_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/intcomp"
+	"github.com/moweilong/efficient-go/base/varint"
+)
+
+var packings = []intcomp.Packing{intcomp.Varint, intcomp.BitPack}
+
+// timestamps 返回 n 个纳秒时间戳，间隔约 1 秒并带有毫秒级抖动，模拟定时采样的监控数据
+func timestamps(n int) []uint64 {
+	r := rand.New(rand.NewSource(1))
+	ts := make([]uint64, n)
+	t := uint64(1_700_000_000_000_000_000)
+	for i := range ts {
+		t += 1_000_000_000 + uint64(r.Int63n(2_000_000))
+		ts[i] = t
+	}
+	return ts
+}
+
+// TestDeltaRoundTrip 测试不同长度、不同存储方式下编解码可逆
+func TestDeltaRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	inputs := [][]uint64{
+		nil,
+		{0},
+		{math.MaxUint64},
+		{0, math.MaxUint64},
+		{5, 5, 5, 5},
+		{math.MaxUint64, 0, 1 << 63, 7}, // 非递减以外的序列按模 2^64 回绕
+		timestamps(1000),
+	}
+	for _, n := range []int{2, 127, 128, 129, 130, 300} {
+		s := make([]uint64, n)
+		for i := range s {
+			s[i] = r.Uint64() >> r.Intn(64)
+		}
+		slices.Sort(s)
+		inputs = append(inputs, s)
+	}
+	for _, src := range inputs {
+		for _, p := range packings {
+			enc := intcomp.DeltaEncode([]byte("x"), src, p)
+			got, err := intcomp.DeltaDecode([]uint64{42}, enc[1:])
+			if err != nil {
+				t.Fatalf("存储方式 %d，%d 个元素: DeltaDecode 失败: %v", p, len(src), err)
+			}
+			if got[0] != 42 || !slices.Equal(got[1:], src) {
+				t.Fatalf("存储方式 %d，%d 个元素: 解码结果与原始数据不一致", p, len(src))
+			}
+		}
+	}
+}
+
+// TestDeltaCorrupt 测试截断、多余字节与非法的头部
+func TestDeltaCorrupt(t *testing.T) {
+	src := timestamps(300)
+	for _, p := range packings {
+		enc := intcomp.DeltaEncode(nil, src, p)
+		for n := range len(enc) {
+			if _, err := intcomp.DeltaDecode(nil, enc[:n]); !errors.Is(err, intcomp.ErrCorrupt) {
+				t.Fatalf("存储方式 %d，截断为 %d 字节: err = %v，预期 ErrCorrupt", p, n, err)
+			}
+		}
+		if _, err := intcomp.DeltaDecode(nil, append(enc, 0)); !errors.Is(err, intcomp.ErrCorrupt) {
+			t.Errorf("存储方式 %d，末尾有多余字节: err = %v，预期 ErrCorrupt", p, err)
+		}
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"未知的存储方式", []byte{9, 1, 0}},
+		{"位宽为 0", []byte{byte(intcomp.BitPack), 2, 0, 0, 0}},
+		{"位宽超过 64", []byte{byte(intcomp.BitPack), 2, 0, 65, 0}},
+		{"元素个数过大", varint.AppendUvarint([]byte{byte(intcomp.Varint)}, 1<<62)},
+		{"元素个数过大（位打包）", append(varint.AppendUvarint([]byte{byte(intcomp.BitPack)}, 1<<62), 0, 64, 0)},
+	}
+	for _, tc := range testCases {
+		got, err := intcomp.DeltaDecode([]uint64{1}, tc.data)
+		if !errors.Is(err, intcomp.ErrCorrupt) {
+			t.Errorf("%s: err = %v，预期 ErrCorrupt", tc.name, err)
+		}
+		if !slices.Equal(got, []uint64{1}) {
+			t.Errorf("%s: 出错时 dst = %v，预期保持不变", tc.name, got)
+		}
+	}
+}
+
+// TestDeltaAllocs 测试复用缓冲区时编解码不分配内存
+func TestDeltaAllocs(t *testing.T) {
+	src := timestamps(1000)
+	enc := make([]byte, 0, 16<<10)
+	dec := make([]uint64, 0, len(src))
+	for _, p := range packings {
+		benchkit.AssertAllocs(t, 0, func() {
+			enc = intcomp.DeltaEncode(enc[:0], src, p)
+			dec, _ = intcomp.DeltaDecode(dec[:0], enc)
+		})
+	}
+}
+
+// FuzzDelta 测试任意序列编解码可逆，任意输入解码不 panic
+func FuzzDelta(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9}, false)
+	f.Add([]byte{0xff, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, true)
+	f.Fuzz(func(t *testing.T, data []byte, bitPack bool) {
+		_, _ = intcomp.DeltaDecode(nil, data)
+
+		src := make([]uint64, len(data)/8)
+		for i := range src {
+			src[i] = binary.LittleEndian.Uint64(data[8*i:])
+		}
+		p := intcomp.Varint
+		if bitPack {
+			p = intcomp.BitPack
+		}
+		got, err := intcomp.DeltaDecode(nil, intcomp.DeltaEncode(nil, src, p))
+		if err != nil || !slices.Equal(got, src) {
+			t.Fatalf("往返失败: %v", err)
+		}
+	})
+}
+
+// BenchmarkDeltaEncode 测试时间戳数据的编码速度与压缩效果，bytes/value 为每个元素平均占用的字节数
+func BenchmarkDeltaEncode(b *testing.B) {
+	src := timestamps(4096)
+	dst := make([]byte, 0, len(src)*varint.MaxLen64)
+	b.Run("raw", func(b *testing.B) {
+		b.SetBytes(int64(len(src) * 8))
+		for i := 0; i < b.N; i++ {
+			dst = dst[:0]
+			for _, v := range src {
+				dst = binary.LittleEndian.AppendUint64(dst, v)
+			}
+		}
+		b.ReportMetric(float64(len(dst))/float64(len(src)), "bytes/value")
+	})
+	b.Run("varint", func(b *testing.B) {
+		b.SetBytes(int64(len(src) * 8))
+		for i := 0; i < b.N; i++ {
+			dst = varint.AppendUvarints(dst[:0], src)
+		}
+		b.ReportMetric(float64(len(dst))/float64(len(src)), "bytes/value")
+	})
+	for _, p := range packings {
+		b.Run(fmt.Sprintf("delta+%s", packingName(p)), func(b *testing.B) {
+			b.SetBytes(int64(len(src) * 8))
+			for i := 0; i < b.N; i++ {
+				dst = intcomp.DeltaEncode(dst[:0], src, p)
+			}
+			b.ReportMetric(float64(len(dst))/float64(len(src)), "bytes/value")
+		})
+	}
+}
+
+// BenchmarkDeltaDecode 测试时间戳数据的解码速度
+func BenchmarkDeltaDecode(b *testing.B) {
+	src := timestamps(4096)
+	dst := make([]uint64, 0, len(src))
+	for _, p := range packings {
+		enc := intcomp.DeltaEncode(nil, src, p)
+		b.Run(fmt.Sprintf("delta+%s", packingName(p)), func(b *testing.B) {
+			b.SetBytes(int64(len(src) * 8))
+			for i := 0; i < b.N; i++ {
+				dst, _ = intcomp.DeltaDecode(dst[:0], enc)
+			}
+		})
+	}
+}
+
+func packingName(p intcomp.Packing) string {
+	if p == intcomp.BitPack {
+		return "bitpack"
+	}
+	return "varint"
+}
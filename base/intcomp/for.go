@@ -0,0 +1,101 @@
+package intcomp
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+
+	"github.com/moweilong/efficient-go/base/bit/bitpack"
+	"github.com/moweilong/efficient-go/base/varint"
+)
+
+// BlockSize 是 FOR 编码的块大小
+// 128 个任意位宽的整数恰好占满整数个字节，各块的打包数据按字节对齐，
+// 块内的循环次数固定，便于编译器展开，也与 SIMD 位打包方案的常用块大小一致
+const BlockSize = 128
+
+// FOREncode 以 FOR（frame of reference）方案编码 src 并追加到 dst：
+// 把 src 按 BlockSize 分块，每块减去块内最小值后以能容纳最大余数的统一位宽打包。
+// 列式存储中同一列的取值往往集中在某个范围内（如价格、状态码、某一天内的时间戳），
+// 与差分编码不同，FOR 不要求有序，也不需要从头累加，任一块都可以独立解码
+func FOREncode(dst []byte, src []uint64) []byte {
+	dst = varint.AppendUvarint(dst, uint64(len(src)))
+	var buf [BlockSize]uint64
+	for len(src) > 0 {
+		block := src[:min(BlockSize, len(src))]
+		src = src[len(block):]
+
+		lo, hi := block[0], block[0]
+		for _, v := range block {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		// 位宽为 0 表示块内所有值相同，不需要打包数据
+		width := bits.Len64(hi - lo)
+		dst = varint.AppendUvarint(dst, lo)
+		dst = append(dst, byte(width))
+		if width == 0 {
+			continue
+		}
+		residuals := buf[:len(block)]
+		for i, v := range block {
+			residuals[i] = v - lo
+		}
+		dst = bitpack.Pack64(dst, residuals, width)
+	}
+	return dst
+}
+
+// FORDecode 解码 FOREncode 的结果，把还原的序列追加到 dst
+// src 必须恰好是一个完整的编码结果，否则返回 ErrCorrupt
+func FORDecode(dst []uint64, src []byte) ([]uint64, error) {
+	n, k, err := varint.Uvarint(src)
+	if err != nil {
+		return dst, fmt.Errorf("%w：元素个数: %w", ErrCorrupt, err)
+	}
+	src = src[k:]
+	// 每块至少占 2 字节（最小值与位宽），据此拒绝声明的元素个数过大的输入，避免按它分配内存；
+	// 块数不用 (n+BlockSize-1)/BlockSize 计算，n 接近 MaxUint64 时加法会溢出。
+	// 通过检查后 n 不超过 len(src)/2*BlockSize，转换为 int 不会溢出
+	blocks := n / BlockSize
+	if n%BlockSize != 0 {
+		blocks++
+	}
+	if blocks > uint64(len(src))/2 {
+		return dst, fmt.Errorf("%w：元素个数 %d 超出数据长度", ErrCorrupt, n)
+	}
+	start := len(dst)
+	dst = slices.Grow(dst, int(n))
+	var buf [BlockSize]uint64
+	for rem := int(n); rem > 0; {
+		m := min(BlockSize, rem)
+		rem -= m
+		lo, k, err := varint.Uvarint(src)
+		if err != nil {
+			return dst[:start], fmt.Errorf("%w：块的最小值: %w", ErrCorrupt, err)
+		}
+		src = src[k:]
+		if len(src) == 0 || src[0] > 64 {
+			return dst[:start], fmt.Errorf("%w：非法的位宽", ErrCorrupt)
+		}
+		width := int(src[0])
+		src = src[1:]
+		if width == 0 {
+			for range m {
+				dst = append(dst, lo)
+			}
+			continue
+		}
+		size := bitpack.PackedLen(m, width)
+		if len(src) < size {
+			return dst[:start], fmt.Errorf("%w：块数据不完整", ErrCorrupt)
+		}
+		for _, r := range bitpack.Unpack64(buf[:0], src[:size], width, m) {
+			dst = append(dst, lo+r)
+		}
+		src = src[size:]
+	}
+	if err := trailing(src); err != nil {
+		return dst[:start], err
+	}
+	return dst, nil
+}
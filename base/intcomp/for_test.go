@@ -0,0 +1,158 @@
+package intcomp_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/intcomp"
+	"github.com/moweilong/efficient-go/base/varint"
+)
+
+// column 返回 n 个集中在 [base, base+spread) 内的无序整数，模拟列式存储中的一列
+func column(n int, base, spread uint64) []uint64 {
+	r := rand.New(rand.NewSource(3))
+	col := make([]uint64, n)
+	for i := range col {
+		col[i] = base + uint64(r.Int63n(int64(spread)))
+	}
+	return col
+}
+
+// TestFORRoundTrip 测试不同长度与取值分布下编解码可逆
+func TestFORRoundTrip(t *testing.T) {
+	constant := make([]uint64, 300)
+	for i := range constant {
+		constant[i] = 7
+	}
+	inputs := [][]uint64{
+		nil,
+		{0},
+		{math.MaxUint64},
+		{0, math.MaxUint64}, // 余数需要 64 位
+		constant,            // 位宽为 0 的块
+		column(1000, 1<<40, 1000),
+	}
+	for _, n := range []int{1, 127, 128, 129, 256, 257} {
+		inputs = append(inputs, column(n, 100, 1<<20))
+	}
+	for _, src := range inputs {
+		enc := intcomp.FOREncode([]byte("x"), src)
+		got, err := intcomp.FORDecode([]uint64{42}, enc[1:])
+		if err != nil {
+			t.Fatalf("%d 个元素: FORDecode 失败: %v", len(src), err)
+		}
+		if got[0] != 42 || !slices.Equal(got[1:], src) {
+			t.Fatalf("%d 个元素: 解码结果与原始数据不一致", len(src))
+		}
+	}
+}
+
+// TestFORSize 测试编码长度：每块为最小值、位宽与打包的余数
+func TestFORSize(t *testing.T) {
+	src := column(1024, 1000, 256) // 8 块，余数不超过 255
+	enc := intcomp.FOREncode(nil, src)
+	if limit := 2 + 8*(2+1+128); len(enc) > limit {
+		t.Errorf("编码长度 = %d，预期不超过 %d", len(enc), limit)
+	}
+	constant := make([]uint64, 1024)
+	if enc := intcomp.FOREncode(nil, constant); len(enc) != 2+8*2 {
+		t.Errorf("全部相同的值编码长度 = %d，预期 %d", len(enc), 2+8*2)
+	}
+}
+
+// TestFORCorrupt 测试截断、多余字节与非法的块头
+func TestFORCorrupt(t *testing.T) {
+	enc := intcomp.FOREncode(nil, column(300, 5, 1<<30))
+	for n := range len(enc) {
+		if _, err := intcomp.FORDecode(nil, enc[:n]); !errors.Is(err, intcomp.ErrCorrupt) {
+			t.Fatalf("截断为 %d 字节: err = %v，预期 ErrCorrupt", n, err)
+		}
+	}
+	if _, err := intcomp.FORDecode(nil, append(enc, 0)); !errors.Is(err, intcomp.ErrCorrupt) {
+		t.Errorf("末尾有多余字节: err = %v，预期 ErrCorrupt", err)
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"位宽超过 64", []byte{1, 0, 65, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{"元素个数过大", varint.AppendUvarint(nil, 1<<62)},
+		{"元素个数接近 MaxUint64", append(varint.AppendUvarint(nil, ^uint64(0)), 0, 0)},
+		{"元素个数为 MaxUint64-BlockSize+2", append(varint.AppendUvarint(nil, ^uint64(0)-intcomp.BlockSize+2), 0, 0)},
+	}
+	for _, tc := range testCases {
+		got, err := intcomp.FORDecode([]uint64{1}, tc.data)
+		if !errors.Is(err, intcomp.ErrCorrupt) {
+			t.Errorf("%s: err = %v，预期 ErrCorrupt", tc.name, err)
+		}
+		if !slices.Equal(got, []uint64{1}) {
+			t.Errorf("%s: 出错时 dst = %v，预期保持不变", tc.name, got)
+		}
+	}
+}
+
+// TestFORAllocs 测试复用缓冲区时编解码不分配内存
+func TestFORAllocs(t *testing.T) {
+	src := column(1000, 1<<32, 1<<16)
+	enc := make([]byte, 0, 16<<10)
+	dec := make([]uint64, 0, len(src))
+	benchkit.AssertAllocs(t, 0, func() {
+		enc = intcomp.FOREncode(enc[:0], src)
+		dec, _ = intcomp.FORDecode(dec[:0], enc)
+	})
+}
+
+// FuzzFOR 测试任意序列编解码可逆，任意输入解码不 panic
+func FuzzFOR(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9}, uint8(0))
+	f.Add(make([]byte, 8*130), uint8(40))
+	f.Add(append(varint.AppendUvarint(nil, ^uint64(0)), 0, 0), uint8(0))
+	f.Fuzz(func(t *testing.T, data []byte, shift uint8) {
+		_, _ = intcomp.FORDecode(nil, data)
+
+		// shift 控制取值范围，覆盖从很窄到 64 位的各种位宽
+		src := make([]uint64, len(data)/8)
+		for i := range src {
+			src[i] = binary.LittleEndian.Uint64(data[8*i:]) >> (shift % 64)
+		}
+		got, err := intcomp.FORDecode(nil, intcomp.FOREncode(nil, src))
+		if err != nil || !slices.Equal(got, src) {
+			t.Fatalf("往返失败: %v", err)
+		}
+	})
+}
+
+// BenchmarkFOR 对比 FOR 块编码与差分编码在无序整数列上的速度与压缩效果，bytes/value 为每个元素平均占用的字节数
+func BenchmarkFOR(b *testing.B) {
+	src := column(4096, 1_700_000_000, 100_000)
+	b.Run("encode/FOR", func(b *testing.B) {
+		var enc []byte
+		b.SetBytes(int64(len(src) * 8))
+		for i := 0; i < b.N; i++ {
+			enc = intcomp.FOREncode(enc[:0], src)
+		}
+		b.ReportMetric(float64(len(enc))/float64(len(src)), "bytes/value")
+	})
+	b.Run("encode/delta+varint", func(b *testing.B) {
+		var enc []byte
+		b.SetBytes(int64(len(src) * 8))
+		for i := 0; i < b.N; i++ {
+			enc = intcomp.DeltaEncode(enc[:0], src, intcomp.Varint)
+		}
+		b.ReportMetric(float64(len(enc))/float64(len(src)), "bytes/value")
+	})
+	enc := intcomp.FOREncode(nil, src)
+	dst := make([]uint64, 0, len(src))
+	b.Run("decode/FOR", func(b *testing.B) {
+		b.SetBytes(int64(len(src) * 8))
+		for i := 0; i < b.N; i++ {
+			dst, _ = intcomp.FORDecode(dst[:0], enc)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// Package memlayout 分析结构体的内存布局：各字段的偏移、对齐与填充，
+// 并给出使结构体最小的字段顺序，可在测试中防止布局退化。
+package memlayout
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Field 描述结构体中的一个字段
+type Field struct {
+	Name    string
+	Type    reflect.Type
+	Offset  uintptr
+	Size    uintptr
+	Align   uintptr
+	Padding uintptr // 该字段之后、下一个字段（或结构体末尾）之前的填充字节数
+}
+
+// Layout 是结构体的内存布局
+type Layout struct {
+	Type    reflect.Type
+	Size    uintptr
+	Align   uintptr
+	Padding uintptr // 所有填充字节数之和
+	Fields  []Field // 按偏移排列
+}
+
+// Analyze 返回结构体类型 T 的内存布局；T 不是结构体时 panic
+func Analyze[T any]() Layout {
+	return Of(reflect.TypeFor[T]())
+}
+
+// Of 返回结构体类型 t 的内存布局；t 不是结构体时 panic
+func Of(t reflect.Type) Layout {
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("memlayout: %s 不是结构体", t))
+	}
+	l := Layout{
+		Type:   t,
+		Size:   t.Size(),
+		Align:  uintptr(t.Align()),
+		Fields: make([]Field, t.NumField()),
+	}
+	for i := range l.Fields {
+		sf := t.Field(i)
+		end := t.Size()
+		if i+1 < t.NumField() {
+			end = t.Field(i + 1).Offset
+		}
+		l.Fields[i] = Field{
+			Name:    sf.Name,
+			Type:    sf.Type,
+			Offset:  sf.Offset,
+			Size:    sf.Type.Size(),
+			Align:   uintptr(sf.Type.Align()),
+			Padding: end - sf.Offset - sf.Type.Size(),
+		}
+		l.Padding += l.Fields[i].Padding
+	}
+	return l
+}
+
+// String 返回各字段的布局表格
+func (l Layout) String() string {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "field\ttype\toffset\tsize\talign\tpadding")
+	for _, f := range l.Fields {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\n", f.Name, f.Type, f.Offset, f.Size, f.Align, f.Padding)
+	}
+	tw.Flush()
+	fmt.Fprintf(&sb, "%s: size %d, align %d, padding %d\n", l.Type, l.Size, l.Align, l.Padding)
+	return sb.String()
+}
+
+// Suggestion 是重排字段后的布局建议
+type Suggestion struct {
+	Order []string // 建议的字段顺序
+	Size  uintptr  // 按建议顺序排列后的结构体大小
+	Saved uintptr  // 相比当前顺序节省的字节数，为 0 表示当前顺序已是最优
+}
+
+// Suggest 返回使结构体类型 T 最小的字段顺序；T 不是结构体时 panic
+//
+// Go 中类型的大小总是其对齐的整数倍，按对齐从大到小排列即可消除字段间的填充；
+// 零大小字段排在最前，因为位于末尾的零大小字段会使编译器额外填充。
+// 对齐相同的字段保持原有的相对顺序。
+func Suggest[T any]() Suggestion {
+	return suggest(Of(reflect.TypeFor[T]()))
+}
+
+// suggest 根据布局 l 计算建议
+func suggest(l Layout) Suggestion {
+	fields := slices.Clone(l.Fields)
+	slices.SortStableFunc(fields, func(a, b Field) int {
+		if (a.Size == 0) != (b.Size == 0) {
+			if a.Size == 0 {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(b.Align, a.Align)
+	})
+
+	s := Suggestion{Order: make([]string, len(fields))}
+	var off uintptr
+	for i, f := range fields {
+		s.Order[i] = f.Name
+		off = alignUp(off, f.Align) + f.Size
+	}
+	if n := len(fields); n > 0 && fields[n-1].Size == 0 && off > 0 {
+		off++ // 末尾的零大小字段不能指向结构体之后的内存
+	}
+	s.Size = alignUp(off, l.Align)
+	s.Saved = l.Size - s.Size
+	return s
+}
+
+// alignUp 将 x 向上取整为 a 的倍数，a 为 2 的幂
+func alignUp(x, a uintptr) uintptr {
+	return (x + a - 1) &^ (a - 1)
+}
@@ -0,0 +1,99 @@
+package memlayout_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/memlayout"
+)
+
+type loose struct {
+	A bool
+	B int64
+	C bool
+	D int32
+	E bool
+}
+
+type tight struct {
+	B int64
+	D int32
+	A bool
+	C bool
+	E bool
+}
+
+type trailingEmpty struct {
+	N int32
+	Z struct{}
+}
+
+// TestAnalyze 测试字段的偏移、对齐与填充
+func TestAnalyze(t *testing.T) {
+	l := memlayout.Analyze[loose]()
+	if l.Size != 32 || l.Align != 8 || l.Padding != 17 {
+		t.Errorf("Size = %d，Align = %d，Padding = %d，预期 32、8、17", l.Size, l.Align, l.Padding)
+	}
+	want := []struct {
+		offset, size, padding uintptr
+	}{{0, 1, 7}, {8, 8, 0}, {16, 1, 3}, {20, 4, 0}, {24, 1, 7}}
+	for i, w := range want {
+		f := l.Fields[i]
+		if f.Offset != w.offset || f.Size != w.size || f.Padding != w.padding {
+			t.Errorf("Fields[%d] = %+v，预期偏移 %d、大小 %d、填充 %d", i, f, w.offset, w.size, w.padding)
+		}
+	}
+	if s := l.String(); !strings.Contains(s, "padding 17") || !strings.Contains(s, "int32") {
+		t.Errorf("String() =\n%s", s)
+	}
+}
+
+// TestSuggest 测试建议的字段顺序与大小
+func TestSuggest(t *testing.T) {
+	testCases := []struct {
+		name  string
+		got   memlayout.Suggestion
+		order []string
+		size  uintptr
+		saved uintptr
+	}{
+		{"loose", memlayout.Suggest[loose](), []string{"B", "D", "A", "C", "E"}, 16, 16},
+		{"tight", memlayout.Suggest[tight](), []string{"B", "D", "A", "C", "E"}, 16, 0},
+		{"末尾的零大小字段", memlayout.Suggest[trailingEmpty](), []string{"Z", "N"}, 4, 4},
+		{"空结构体", memlayout.Suggest[struct{}](), []string{}, 0, 0},
+	}
+	for _, tc := range testCases {
+		if !reflect.DeepEqual(tc.got.Order, tc.order) || tc.got.Size != tc.size || tc.got.Saved != tc.saved {
+			t.Errorf("%s: Suggest = %+v，预期顺序 %v、大小 %d、节省 %d", tc.name, tc.got, tc.order, tc.size, tc.saved)
+		}
+	}
+	// 建议的大小应与按该顺序实际声明的结构体一致
+	if got := memlayout.Analyze[tight]().Size; got != memlayout.Suggest[loose]().Size {
+		t.Errorf("tight 的实际大小 %d 与建议大小不符", got)
+	}
+}
+
+func TestAnalyzeNonStruct(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("非结构体类型应 panic")
+		}
+	}()
+	memlayout.Analyze[int]()
+}
+
+// ExampleSuggest 演示在测试中防止结构体布局退化
+func ExampleSuggest() {
+	type entry struct {
+		used  bool
+		key   uint64
+		dirty bool
+	}
+	// 在测试中可改为 t.Errorf
+	if s := memlayout.Suggest[entry](); s.Saved > 0 {
+		fmt.Printf("entry 重排为 %s 可节省 %d 字节\n", strings.Join(s.Order, ", "), s.Saved)
+	}
+	// Output: entry 重排为 key, used, dirty 可节省 8 字节
+}
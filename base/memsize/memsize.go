@@ -0,0 +1,152 @@
+// Package memsize 通过反射测量值占用的内存：类型本身的大小、经由指针可达的全部内存，
+// 以及结构体各字段之间的填充，用于在调整结构体布局时以数据代替猜测。
+package memsize
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"unsafe"
+
+	"github.com/moweilong/efficient-go/base/memlayout"
+	"github.com/moweilong/efficient-go/base/units"
+)
+
+// Field 描述结构体中的一个字段，与 memlayout.Field 相同
+type Field = memlayout.Field
+
+// Report 是一个值的内存占用
+type Report struct {
+	Type    reflect.Type
+	Shallow uintptr // 类型本身的大小，即 unsafe.Sizeof
+	Deep    uintptr // Shallow 加上经由指针、切片、字符串、map、接口等可达的内存，共享的内存只计一次
+	Padding uintptr // 结构体直接字段之间及末尾的填充字节数之和，非结构体为 0
+	Fields  []Field // 值为结构体时的各字段，按偏移排列
+}
+
+// Of 测量 v 的内存占用，v 为 nil 时返回零值
+//
+// Deep 是堆内存的估算：不计分配器的大小级别取整与 map、channel 的内部结构开销，
+// 接口中非指针形态的动态值按装箱计入其大小。
+func Of(v any) Report {
+	if v == nil {
+		return Report{}
+	}
+	rv := reflect.ValueOf(v)
+	t := rv.Type()
+	r := Report{Type: t, Shallow: t.Size()}
+	w := walker{seen: make(map[uintptr]bool)}
+	r.Deep = r.Shallow + w.walk(rv)
+
+	if t.Kind() == reflect.Struct {
+		l := memlayout.Of(t)
+		r.Fields, r.Padding = l.Fields, l.Padding
+	}
+	return r
+}
+
+// String 返回各字段的布局表格与汇总信息
+func (r Report) String() string {
+	if r.Type == nil {
+		return "<nil>"
+	}
+	var sb strings.Builder
+	if len(r.Fields) > 0 {
+		tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "field\ttype\toffset\tsize\talign\tpadding")
+		for _, f := range r.Fields {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\n", f.Name, f.Type, f.Offset, f.Size, f.Align, f.Padding)
+		}
+		tw.Flush()
+	}
+	fmt.Fprintf(&sb, "%s: shallow %s, deep %s, padding %s\n",
+		r.Type, units.ByteSize(r.Shallow), units.ByteSize(r.Deep), units.ByteSize(r.Padding))
+	return sb.String()
+}
+
+// walker 累计经由引用可达的内存，seen 记录已计入的内存起始地址以处理共享与环
+type walker struct {
+	seen map[uintptr]bool
+}
+
+// visit 报告地址 p 是否首次出现；p 为 0 时返回 false
+func (w *walker) visit(p uintptr) bool {
+	if p == 0 || w.seen[p] {
+		return false
+	}
+	w.seen[p] = true
+	return true
+}
+
+// walk 返回 v 引用的、不包含在 v 自身大小内的内存字节数
+func (w *walker) walk(v reflect.Value) uintptr {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || !w.visit(v.Pointer()) {
+			return 0
+		}
+		e := v.Elem()
+		return e.Type().Size() + w.walk(e)
+
+	case reflect.Slice:
+		if v.IsNil() || !w.visit(v.Pointer()) {
+			return 0
+		}
+		n := uintptr(v.Cap()) * v.Type().Elem().Size()
+		for i := range v.Len() {
+			n += w.walk(v.Index(i))
+		}
+		return n
+
+	case reflect.String:
+		s := v.String()
+		if !w.visit(uintptr(unsafe.Pointer(unsafe.StringData(s)))) {
+			return 0
+		}
+		return uintptr(len(s))
+
+	case reflect.Array:
+		var n uintptr
+		for i := range v.Len() {
+			n += w.walk(v.Index(i))
+		}
+		return n
+
+	case reflect.Struct:
+		var n uintptr
+		for i := range v.NumField() {
+			n += w.walk(v.Field(i))
+		}
+		return n
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		e := v.Elem()
+		switch e.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+			return w.walk(e)
+		}
+		return e.Type().Size() + w.walk(e)
+
+	case reflect.Map:
+		if v.IsNil() || !w.visit(v.Pointer()) {
+			return 0
+		}
+		t := v.Type()
+		n := uintptr(v.Len()) * (t.Key().Size() + t.Elem().Size())
+		for it := v.MapRange(); it.Next(); {
+			n += w.walk(it.Key()) + w.walk(it.Value())
+		}
+		return n
+
+	case reflect.Chan:
+		if v.IsNil() || !w.visit(v.Pointer()) {
+			return 0
+		}
+		return uintptr(v.Cap()) * v.Type().Elem().Size()
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package memsize_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/memsize"
+)
+
+// loose 的字段顺序导致大量填充
+type loose struct {
+	A bool
+	B int64
+	C bool
+}
+
+// tight 与 loose 字段相同，但按对齐从大到小排列
+type tight struct {
+	B int64
+	A bool
+	C bool
+}
+
+type node struct {
+	next *node
+	val  int64
+}
+
+// TestOfLayout 测试结构体的字段布局与填充
+func TestOfLayout(t *testing.T) {
+	testCases := []struct {
+		name    string
+		v       any
+		shallow uintptr
+		padding uintptr
+	}{
+		{"loose", loose{}, 24, 14},
+		{"tight", tight{}, 16, 6},
+		{"空结构体", struct{}{}, 0, 0},
+		{"非结构体", int32(1), 4, 0},
+	}
+	for _, tc := range testCases {
+		r := memsize.Of(tc.v)
+		if r.Shallow != tc.shallow || r.Padding != tc.padding {
+			t.Errorf("%s: Shallow = %d，Padding = %d，预期 %d、%d", tc.name, r.Shallow, r.Padding, tc.shallow, tc.padding)
+		}
+	}
+
+	r := memsize.Of(loose{})
+	if len(r.Fields) != 3 {
+		t.Fatalf("Fields = %v", r.Fields)
+	}
+	a := r.Fields[0]
+	if a.Name != "A" || a.Offset != 0 || a.Size != 1 || a.Align != 1 || a.Padding != 7 {
+		t.Errorf("Fields[0] = %+v", a)
+	}
+	if c := r.Fields[2]; c.Offset != 16 || c.Padding != 7 {
+		t.Errorf("Fields[2] = %+v", c)
+	}
+	if s := r.String(); !strings.Contains(s, "padding 14B") || !strings.Contains(s, "int64") {
+		t.Errorf("String() =\n%s", s)
+	}
+}
+
+// TestOfDeep 测试经由引用可达的内存统计
+func TestOfDeep(t *testing.T) {
+	shared := new(int64)
+	v := struct {
+		name  string
+		ids   []int32
+		p, q  *int64
+		m     map[int32]string
+		iface any
+	}{
+		name:  "hello",
+		ids:   make([]int32, 2, 4),
+		p:     shared,
+		q:     shared,
+		m:     map[int32]string{1: "ab"},
+		iface: int64(7),
+	}
+	r := memsize.Of(v)
+	// 字符串 5 + 切片底层数组 16 + 共享的 int64 只计一次 8 + map 条目 (4+16) 与其值 2 + 装箱的 int64 8
+	want := r.Shallow + 5 + 16 + 8 + 20 + 2 + 8
+	if r.Deep != want {
+		t.Errorf("Deep = %d，预期 %d", r.Deep, want)
+	}
+
+	// 环形链表只计算一次
+	a, b := &node{val: 1}, &node{val: 2}
+	a.next, b.next = b, a
+	if r := memsize.Of(a); r.Deep != 8+2*16 {
+		t.Errorf("环形链表的 Deep = %d，预期 %d", r.Deep, 8+2*16)
+	}
+
+	if r := memsize.Of(nil); r.Type != nil || r.Deep != 0 || r.String() != "<nil>" {
+		t.Errorf("Of(nil) = %+v", r)
+	}
+}
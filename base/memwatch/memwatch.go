@@ -0,0 +1,156 @@
+// Package memwatch 采集运行时的内存统计并计算一段时间内的变化：堆增长、分配量、GC 次数与停顿时间，
+// 可在测试中度量一段代码的内存行为，也可在长期运行的服务中定期采样。
+package memwatch
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/units"
+)
+
+// Snapshot 是某一时刻的内存统计
+type Snapshot struct {
+	Time       time.Time
+	HeapAlloc  uint64        // 堆上存活与尚未回收的对象占用的字节数
+	TotalAlloc uint64        // 累计分配的字节数
+	Mallocs    uint64        // 累计分配的对象数
+	NumGC      uint32        // 已完成的 GC 次数
+	PauseTotal time.Duration // 累计 STW 停顿时间
+}
+
+// Take 读取当前的内存统计
+// runtime.ReadMemStats 会短暂地暂停所有 goroutine（通常为数十微秒），不宜在热路径上频繁调用
+func Take() Snapshot {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return Snapshot{
+		Time:       time.Now(),
+		HeapAlloc:  ms.HeapAlloc,
+		TotalAlloc: ms.TotalAlloc,
+		Mallocs:    ms.Mallocs,
+		NumGC:      ms.NumGC,
+		PauseTotal: time.Duration(ms.PauseTotalNs),
+	}
+}
+
+// Delta 是两个时刻之间内存统计的变化
+type Delta struct {
+	Elapsed    time.Duration
+	HeapGrowth int64  // HeapAlloc 的变化，GC 回收多于新分配时为负数
+	PeakHeap   uint64 // 期间观察到的最大 HeapAlloc，只在采样点上观察，可能低于真实峰值
+	AllocBytes uint64 // 期间分配的字节数
+	Allocs     uint64 // 期间分配的对象数
+	GCs        uint32 // 期间完成的 GC 次数
+	Pause      time.Duration
+}
+
+// Sub 返回从 prev 到 s 的变化
+func (s Snapshot) Sub(prev Snapshot) Delta {
+	return Delta{
+		Elapsed:    s.Time.Sub(prev.Time),
+		HeapGrowth: int64(s.HeapAlloc) - int64(prev.HeapAlloc),
+		PeakHeap:   max(s.HeapAlloc, prev.HeapAlloc),
+		AllocBytes: s.TotalAlloc - prev.TotalAlloc,
+		Allocs:     s.Mallocs - prev.Mallocs,
+		GCs:        s.NumGC - prev.NumGC,
+		Pause:      s.PauseTotal - prev.PauseTotal,
+	}
+}
+
+// String 返回变化的摘要，例如 "heap +1.5MiB (peak 3MiB), alloc 12MiB in 3402 objects, 2 GCs, pause 120µs over 1.2s"
+func (d Delta) String() string {
+	sign := "+"
+	growth := d.HeapGrowth
+	if growth < 0 {
+		sign, growth = "-", -growth
+	}
+	return fmt.Sprintf("heap %s%s (peak %s), alloc %s in %d objects, %d GCs, pause %s over %s",
+		sign, units.ByteSize(growth), units.ByteSize(d.PeakHeap), units.ByteSize(d.AllocBytes), d.Allocs,
+		d.GCs, units.FormatDuration(d.Pause), units.FormatDuration(d.Elapsed))
+}
+
+// Measure 运行 f 并返回其间的内存统计变化
+// 其他 goroutine 的分配也会计入，度量时应避免并发的无关工作
+func Measure(f func()) Delta {
+	start := Take()
+	f()
+	return Take().Sub(start)
+}
+
+// Sampler 按固定间隔在后台采集内存统计
+type Sampler struct {
+	interval time.Duration
+	onSample func(Delta)
+
+	mu    sync.Mutex
+	start Snapshot
+	peak  uint64
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// NewSampler 创建每隔 interval 采样一次的 Sampler，interval 不为正数时 panic
+// onSample 不为 nil 时在每次采样后以相邻两次采样之间的变化调用，在 Sampler 的后台 goroutine 中执行
+func NewSampler(interval time.Duration, onSample func(Delta)) *Sampler {
+	if interval <= 0 {
+		panic("memwatch: 采样间隔必须为正数")
+	}
+	return &Sampler{interval: interval, onSample: onSample}
+}
+
+// Start 记录起始快照并开始后台采样；Sampler 已在运行时 panic
+func (s *Sampler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		panic("memwatch: Sampler 已在运行")
+	}
+	s.start = Take()
+	s.peak = s.start.HeapAlloc
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run(s.start, s.stop, s.done)
+}
+
+// run 是后台采样循环
+func (s *Sampler) run(last Snapshot, stop, done chan struct{}) {
+	defer close(done)
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			cur := Take()
+			s.mu.Lock()
+			s.peak = max(s.peak, cur.HeapAlloc)
+			s.mu.Unlock()
+			if s.onSample != nil {
+				s.onSample(cur.Sub(last))
+			}
+			last = cur
+		}
+	}
+}
+
+// Stop 停止采样并返回从 Start 到现在的总变化，PeakHeap 为所有采样点中的最大值；Sampler 未运行时 panic
+// Stop 之后可以再次 Start
+func (s *Sampler) Stop() Delta {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop = nil
+	s.mu.Unlock()
+	if stop == nil {
+		panic("memwatch: Sampler 未在运行")
+	}
+	close(stop)
+	<-done
+
+	d := Take().Sub(s.start)
+	d.PeakHeap = max(d.PeakHeap, s.peak)
+	return d
+}
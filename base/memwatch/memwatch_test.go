@@ -0,0 +1,88 @@
+package memwatch_test
+
+import (
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/memwatch"
+)
+
+var sink [][]byte
+
+// TestMeasure 测试代码区间内的分配量与 GC 次数
+func TestMeasure(t *testing.T) {
+	d := memwatch.Measure(func() {
+		for range 256 {
+			sink = append(sink, make([]byte, 4096))
+		}
+		runtime.GC()
+	})
+	sink = nil
+	if d.AllocBytes < 256*4096 || d.Allocs < 256 {
+		t.Errorf("AllocBytes = %d，Allocs = %d，预期至少 %d、256", d.AllocBytes, d.Allocs, 256*4096)
+	}
+	if d.GCs < 1 || d.Elapsed <= 0 {
+		t.Errorf("GCs = %d，Elapsed = %v", d.GCs, d.Elapsed)
+	}
+	if d.PeakHeap == 0 {
+		t.Errorf("PeakHeap 为 0")
+	}
+	if s := d.String(); !strings.Contains(s, "GCs") || !strings.HasPrefix(s, "heap ") {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+// TestSub 测试快照相减
+func TestSub(t *testing.T) {
+	base := time.Unix(0, 0)
+	a := memwatch.Snapshot{Time: base, HeapAlloc: 3 << 20, TotalAlloc: 10, Mallocs: 1, NumGC: 2, PauseTotal: time.Millisecond}
+	b := memwatch.Snapshot{Time: base.Add(time.Second), HeapAlloc: 1 << 20, TotalAlloc: 50, Mallocs: 5, NumGC: 4, PauseTotal: 3 * time.Millisecond}
+	d := b.Sub(a)
+	want := memwatch.Delta{
+		Elapsed:    time.Second,
+		HeapGrowth: -2 << 20,
+		PeakHeap:   3 << 20,
+		AllocBytes: 40,
+		Allocs:     4,
+		GCs:        2,
+		Pause:      2 * time.Millisecond,
+	}
+	if d != want {
+		t.Errorf("Sub = %+v，预期 %+v", d, want)
+	}
+	if s := d.String(); !strings.HasPrefix(s, "heap -2MiB (peak 3MiB)") {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+// TestSampler 测试后台采样的回调与汇总
+func TestSampler(t *testing.T) {
+	var samples atomic.Int32
+	s := memwatch.NewSampler(time.Millisecond, func(memwatch.Delta) { samples.Add(1) })
+	for round := range 2 { // Stop 之后可以再次 Start
+		s.Start()
+		deadline := time.Now().Add(20 * time.Millisecond)
+		for time.Now().Before(deadline) {
+			sink = append(sink, make([]byte, 1024))
+		}
+		runtime.GC()
+		sink = nil
+		d := s.Stop()
+		if d.GCs < 1 || d.AllocBytes == 0 || d.PeakHeap == 0 {
+			t.Errorf("第 %d 轮 Stop() = %+v", round, d)
+		}
+	}
+	if samples.Load() == 0 {
+		t.Errorf("没有触发采样回调")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("未运行时 Stop 应 panic")
+		}
+	}()
+	s.Stop()
+}
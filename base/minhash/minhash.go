@@ -0,0 +1,125 @@
+// Package minhash 用 MinHash 签名估计集合之间的 Jaccard 相似度 |A∩B| / |A∪B|。
+//
+// 对集合中的每个元素计算 k 个独立的哈希值，签名记录每个哈希函数下的最小值。
+// 对于随机的哈希函数，两个集合在第 i 个位置最小值相同的概率恰好等于它们的 Jaccard 相似度，
+// 因此签名中相同位置的比例就是相似度的无偏估计，标准误差约为 sqrt(J(1-J)/k)。
+// 签名大小固定为 k 个 uint64，与集合大小无关，适合大规模去重与相似文档检索。
+package minhash
+
+import (
+	"math"
+
+	"github.com/moweilong/efficient-go/base/hashx"
+	"github.com/moweilong/efficient-go/base/unsafeconv"
+)
+
+// hasher 计算元素的基础哈希值，k 个哈希函数由它与各自的种子混合得到
+// 使用固定种子的 Wy，使不同进程生成的签名可以相互比较
+var hasher = hashx.Wy{Seed: 0x6d696e68617368} // "minhash"
+
+// Signature 是集合的 MinHash 签名，零值不可用，应使用 New 创建
+type Signature struct {
+	mins []uint64
+}
+
+// MaxK 是哈希函数个数的上限
+const MaxK = 1024
+
+// New 创建使用 k 个哈希函数的空签名，k 越大估计越准确，k 不在 [1, MaxK] 内时 panic
+func New(k int) *Signature {
+	if k <= 0 || k > MaxK {
+		panic("minhash: 哈希函数个数必须在 [1, MaxK] 内")
+	}
+	s := &Signature{mins: make([]uint64, k)}
+	s.Reset()
+	return s
+}
+
+// K 返回哈希函数个数
+func (s *Signature) K() int {
+	return len(s.mins)
+}
+
+// Reset 将签名恢复为空集合
+func (s *Signature) Reset() {
+	for i := range s.mins {
+		s.mins[i] = math.MaxUint64
+	}
+}
+
+// Add 将元素 item 加入集合，重复加入同一个元素不改变签名
+func (s *Signature) Add(item []byte) {
+	s.addHash(hasher.Sum64(item))
+}
+
+// AddString 与 Add 相同，但不复制字符串
+func (s *Signature) AddString(item string) {
+	s.Add(unsafeconv.StringToBytes(item))
+}
+
+// addHash 用基础哈希值 h 更新 k 个最小值
+func (s *Signature) addHash(h uint64) {
+	for i := range s.mins {
+		if v := mix(h ^ seeds[i]); v < s.mins[i] {
+			s.mins[i] = v
+		}
+	}
+}
+
+// Jaccard 返回 s 与 o 所代表集合的 Jaccard 相似度估计值，两者都为空集合时返回 1
+// k 不同时 panic
+func (s *Signature) Jaccard(o *Signature) float64 {
+	s.mustMatch(o)
+	same := 0
+	for i, v := range s.mins {
+		if v == o.mins[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(s.mins))
+}
+
+// Merge 将 s 更新为 s 与 o 所代表集合的并集的签名，k 不同时 panic
+// 等价于把 o 的全部元素加入 s，可用于合并分布式计算的部分结果
+func (s *Signature) Merge(o *Signature) {
+	s.mustMatch(o)
+	for i, v := range o.mins {
+		s.mins[i] = min(s.mins[i], v)
+	}
+}
+
+// Clone 返回 s 的副本
+func (s *Signature) Clone() *Signature {
+	return &Signature{mins: append([]uint64(nil), s.mins...)}
+}
+
+// Values 返回签名的底层数据，用于序列化；修改返回值会影响 s
+func (s *Signature) Values() []uint64 {
+	return s.mins
+}
+
+func (s *Signature) mustMatch(o *Signature) {
+	if len(s.mins) != len(o.mins) {
+		panic("minhash: 签名的哈希函数个数不同")
+	}
+}
+
+// mix 是 splitmix64 的终结函数，把基础哈希值与种子混合为相互独立的哈希值
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	return h ^ h>>31
+}
+
+// seeds 是各哈希函数的种子，由 splitmix64 序列生成
+var seeds = func() []uint64 {
+	s := make([]uint64, MaxK)
+	x := uint64(0)
+	for i := range s {
+		x += 0x9e3779b97f4a7c15
+		s[i] = mix(x)
+	}
+	return s
+}()
@@ -0,0 +1,103 @@
+package minhash_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/minhash"
+)
+
+// randomSets 返回两个随机集合，交集大小为 common，各自独有 onlyA 与 onlyB 个元素，以及它们的精确 Jaccard 相似度
+func randomSets(r *rand.Rand, common, onlyA, onlyB int) (a, b []string, exact float64) {
+	next := func() string { return fmt.Sprintf("item-%d", r.Int63()) }
+	for range common {
+		x := next()
+		a, b = append(a, x), append(b, x)
+	}
+	for range onlyA {
+		a = append(a, next())
+	}
+	for range onlyB {
+		b = append(b, next())
+	}
+	return a, b, float64(common) / float64(common+onlyA+onlyB)
+}
+
+func signatureOf(k int, items []string) *minhash.Signature {
+	s := minhash.New(k)
+	for _, x := range items {
+		s.AddString(x)
+	}
+	return s
+}
+
+// TestJaccard 在随机集合上对比估计值与精确的 Jaccard 相似度
+func TestJaccard(t *testing.T) {
+	const k = 256
+	r := rand.New(rand.NewSource(1))
+	tests := []struct {
+		common, onlyA, onlyB int
+	}{
+		{0, 500, 500},
+		{100, 450, 450},
+		{500, 250, 250},
+		{900, 50, 50},
+		{1000, 0, 0},
+		{200, 1000, 0}, // 子集
+	}
+	for _, tt := range tests {
+		a, b, exact := randomSets(r, tt.common, tt.onlyA, tt.onlyB)
+		got := signatureOf(k, a).Jaccard(signatureOf(k, b))
+		// 估计值的标准差为 sqrt(J(1-J)/k)，允许 4 倍标准差加上少量余量
+		if tol := 4*math.Sqrt(exact*(1-exact)/k) + 0.01; math.Abs(got-exact) > tol {
+			t.Errorf("%+v: 估计值 %.3f，精确值 %.3f，超出允许误差 %.3f", tt, got, exact, tol)
+		}
+	}
+}
+
+// TestMerge 测试合并后的签名与并集的签名相同
+func TestMerge(t *testing.T) {
+	a := []string{"a", "b", "c"}
+	b := []string{"c", "d"}
+	merged := signatureOf(64, a)
+	merged.Merge(signatureOf(64, b))
+	union := signatureOf(64, []string{"d", "c", "b", "a", "a"})
+	if merged.Jaccard(union) != 1 {
+		t.Errorf("合并后的签名与并集的签名不同")
+	}
+
+	c := merged.Clone()
+	c.Add([]byte("e"))
+	if merged.Jaccard(union) != 1 || c.Jaccard(union) == 1 {
+		t.Errorf("Clone 应返回独立的副本")
+	}
+	c.Reset()
+	if c.Jaccard(minhash.New(64)) != 1 || c.K() != 64 || len(c.Values()) != 64 {
+		t.Errorf("Reset 后应与空签名相同")
+	}
+}
+
+// TestMismatch 测试 k 不同时 panic
+func TestMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("k 不同时应 panic")
+		}
+	}()
+	minhash.New(16).Jaccard(minhash.New(32))
+}
+
+// BenchmarkAdd 测量不同 k 下加入一个元素的开销
+func BenchmarkAdd(b *testing.B) {
+	item := []byte("the quick brown fox")
+	for _, k := range []int{64, 128, 256} {
+		b.Run(fmt.Sprintf("k=%d", k), func(b *testing.B) {
+			s := minhash.New(k)
+			for i := 0; i < b.N; i++ {
+				s.Add(item)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+//go:build arm64 || ppc64 || ppc64le
+
+package pad
+
+// CacheLineSize 是目标架构的缓存行大小（字节），Apple M 系列等 arm64 处理器与 POWER 为 128 字节
+const CacheLineSize = 128
@@ -0,0 +1,6 @@
+//go:build s390x
+
+package pad
+
+// CacheLineSize 是目标架构的缓存行大小（字节）
+const CacheLineSize = 256
@@ -0,0 +1,33 @@
+// Package pad 提供按缓存行填充的类型，用于避免伪共享（false sharing）。
+//
+// 多个 CPU 核心频繁写入位于同一缓存行的不同变量时，缓存一致性协议会让该缓存行在核心之间来回失效，
+// 即使它们逻辑上毫无关联，性能也会大幅下降。把这些变量分别填充到独立的缓存行即可消除这种干扰，
+// 代价是更多的内存，因此只应用于被多个 goroutine 高频写入的字段。
+package pad
+
+import "sync/atomic"
+
+// CacheLinePad 占用一整个缓存行，放在两个字段之间可使它们位于不同的缓存行：
+//
+//	type queue struct {
+//		head atomic.Uint64
+//		_    pad.CacheLinePad
+//		tail atomic.Uint64
+//	}
+type CacheLinePad struct{ _ [CacheLineSize]byte }
+
+// PaddedUint64 是恰好占用一个缓存行的 atomic.Uint64，[N]PaddedUint64 中的各元素必然位于不同的缓存行，
+// 适合按 CPU 或 goroutine 分片的计数器
+type PaddedUint64 struct {
+	atomic.Uint64
+	_ [CacheLineSize - 8]byte
+}
+
+// Padded 在 T 之后追加一个缓存行的填充，使 []Padded[T] 中相邻元素的 Value 之间至少相隔一个缓存行
+//
+// 受泛型限制填充长度无法按 T 的大小扣减，Padded[T] 比一个缓存行多占 unsafe.Sizeof(T) 字节；
+// T 为 uint64 时使用更紧凑的 PaddedUint64。
+type Padded[T any] struct {
+	Value T
+	_     CacheLinePad
+}
@@ -0,0 +1,63 @@
+package pad_test
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+
+	"github.com/moweilong/efficient-go/base/pad"
+)
+
+// TestSizes 测试各类型的大小与相邻元素的间距
+func TestSizes(t *testing.T) {
+	if got := unsafe.Sizeof(pad.CacheLinePad{}); got != pad.CacheLineSize {
+		t.Errorf("CacheLinePad 的大小为 %d，预期 %d", got, pad.CacheLineSize)
+	}
+	if got := unsafe.Sizeof(pad.PaddedUint64{}); got != pad.CacheLineSize {
+		t.Errorf("PaddedUint64 的大小为 %d，预期 %d", got, pad.CacheLineSize)
+	}
+
+	var us [2]pad.PaddedUint64
+	if d := uintptr(unsafe.Pointer(&us[1])) - uintptr(unsafe.Pointer(&us[0])); d < pad.CacheLineSize {
+		t.Errorf("相邻 PaddedUint64 相距 %d 字节", d)
+	}
+	var ps [2]pad.Padded[int32]
+	if d := uintptr(unsafe.Pointer(&ps[1].Value)) - uintptr(unsafe.Pointer(&ps[0].Value)); d < pad.CacheLineSize {
+		t.Errorf("相邻 Padded[int32] 的 Value 相距 %d 字节", d)
+	}
+
+	us[0].Add(3)
+	if us[0].Load() != 3 || us[1].Load() != 0 {
+		t.Errorf("PaddedUint64 的原子操作结果不正确")
+	}
+}
+
+// BenchmarkFalseSharing 多个 goroutine 各自递增数组中自己的计数器，
+// 对比计数器紧密排列与按缓存行填充时的吞吐；单核环境下两者没有差别
+func BenchmarkFalseSharing(b *testing.B) {
+	workers := max(runtime.GOMAXPROCS(0), 2)
+	run := func(b *testing.B, inc func(w int)) {
+		var wg sync.WaitGroup
+		per := b.N/workers + 1
+		for w := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range per {
+					inc(w)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	b.Run("packed", func(b *testing.B) {
+		counters := make([]atomic.Uint64, workers)
+		run(b, func(w int) { counters[w].Add(1) })
+	})
+	b.Run("padded", func(b *testing.B) {
+		counters := make([]pad.PaddedUint64, workers)
+		run(b, func(w int) { counters[w].Add(1) })
+	})
+}
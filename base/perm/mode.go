@@ -0,0 +1,78 @@
+package perm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mode 是 chmod 风格的三组权限，依次为属主、属组与其他用户，取值与文件模式的低 9 位一致
+type Mode uint16
+
+// NewMode 由三组权限组成 Mode
+func NewMode(user, group, other Perm) Mode {
+	return Mode(user&All)<<6 | Mode(group&All)<<3 | Mode(other&All)
+}
+
+// User 返回属主的权限
+func (m Mode) User() Perm { return Perm(m>>6) & All }
+
+// Group 返回属组的权限
+func (m Mode) Group() Perm { return Perm(m>>3) & All }
+
+// Other 返回其他用户的权限
+func (m Mode) Other() Perm { return Perm(m) & All }
+
+// String 返回 ls -l 风格的 "rwxr-xr-x" 形式
+func (m Mode) String() string {
+	return m.User().String() + m.Group().String() + m.Other().String()
+}
+
+// Octal 返回三位八进制形式，如 "755"
+func (m Mode) Octal() string {
+	return m.User().Octal() + m.Group().Octal() + m.Other().Octal()
+}
+
+// ParseMode 解析 "rwxr-xr-x" 这类九字符形式，或 "755"、"0755"、"0o755" 这类 chmod 风格的八进制形式
+// 八进制不足三位时与 chmod 相同，高位补 0（"5" 即 "005"）；不支持 setuid 等特殊位
+func ParseMode(s string) (Mode, error) {
+	if len(s) == 9 && strings.Trim(s, "rwx-") == "" {
+		var ps [3]Perm
+		for i := range ps {
+			p, err := Parse(s[3*i : 3*i+3])
+			if err != nil {
+				return 0, fmt.Errorf("%w %q", ErrSyntax, s)
+			}
+			ps[i] = p
+		}
+		return NewMode(ps[0], ps[1], ps[2]), nil
+	}
+
+	digits := strings.TrimPrefix(s, "0o")
+	if digits == "" || strings.Trim(digits, "01234567") != "" {
+		return 0, fmt.Errorf("%w %q", ErrSyntax, s)
+	}
+	digits = strings.TrimLeft(digits, "0")
+	if len(digits) > 3 {
+		return 0, fmt.Errorf("%w %q：超出三组权限的范围", ErrSyntax, s)
+	}
+	var m Mode
+	for i := range len(digits) {
+		m = m<<3 | Mode(digits[i]-'0')
+	}
+	return m, nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出 "rwxr-xr-x" 形式
+func (m Mode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 ParseMode 支持的所有形式
+func (m *Mode) UnmarshalText(text []byte) error {
+	v, err := ParseMode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
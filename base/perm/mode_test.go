@@ -0,0 +1,73 @@
+package perm_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/perm"
+)
+
+// TestParseMode 测试 chmod 风格的八进制与 rwx 形式的解析
+func TestParseMode(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected perm.Mode
+	}{
+		{"755", perm.NewMode(perm.All, perm.Read|perm.Execute, perm.Read|perm.Execute)},
+		{"0755", 0o755},
+		{"0o644", 0o644},
+		{"600", 0o600},
+		{"5", 0o005},
+		{"0", 0},
+		{"000", 0},
+		{"rwxr-xr-x", 0o755},
+		{"rw-r-----", 0o640},
+		{"---------", 0},
+	}
+	for _, tc := range testCases {
+		m, err := perm.ParseMode(tc.input)
+		if err != nil || m != tc.expected {
+			t.Errorf("ParseMode(%q) = (%o, %v)，预期 %o", tc.input, m, err, tc.expected)
+		}
+	}
+
+	for _, bad := range []string{"", "0o", "758", "4755", "-755", "rwxr-xr-", "rwxr-xr-xx", "rwxrwxrwz", "xwrr-xr-x"} {
+		if _, err := perm.ParseMode(bad); !errors.Is(err, perm.ErrSyntax) {
+			t.Errorf("ParseMode(%q) 错误为 %v，预期 ErrSyntax", bad, err)
+		}
+	}
+	if _, err := perm.Parse("755"); !errors.Is(err, perm.ErrSyntax) {
+		t.Errorf("Parse(%q) 错误为 %v，预期 ErrSyntax", "755", err)
+	}
+}
+
+// TestModeFormat 测试 String/Octal、各组权限的访问及与 ParseMode 的往返
+func TestModeFormat(t *testing.T) {
+	for m := perm.Mode(0); m <= 0o777; m++ {
+		for _, s := range []string{m.String(), m.Octal()} {
+			if v, err := perm.ParseMode(s); err != nil || v != m {
+				t.Fatalf("ParseMode(%q) = (%o, %v)，预期 %o", s, v, err, m)
+			}
+		}
+	}
+	m := perm.Mode(0o751)
+	if m.String() != "rwxr-x--x" || m.Octal() != "751" {
+		t.Errorf("String() = %q, Octal() = %q", m.String(), m.Octal())
+	}
+	if m.User() != perm.All || m.Group() != perm.Read|perm.Execute || m.Other() != perm.Execute {
+		t.Errorf("User/Group/Other = %v %v %v", m.User(), m.Group(), m.Other())
+	}
+}
+
+// TestModeText 测试通过 encoding.TextMarshaler 在 JSON 中以字符串表示
+func TestModeText(t *testing.T) {
+	data, err := json.Marshal(perm.Mode(0o644))
+	if err != nil || string(data) != `"rw-r--r--"` {
+		t.Fatalf("Marshal = (%s, %v)", data, err)
+	}
+	var m perm.Mode
+	if err := json.Unmarshal([]byte(`"0755"`), &m); err != nil || m != 0o755 {
+		t.Errorf("Unmarshal = (%o, %v)", m, err)
+	}
+}
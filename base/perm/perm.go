@@ -0,0 +1,118 @@
+// Package perm 提供类 Unix 文件模式的 rwx 权限位类型，支持 "rwx"/"r-x" 与八进制字符串的解析和格式化。
+//
+// Perm 是单组 rwx 权限（一个八进制位），Mode 是 chmod 风格的属主、属组与其他用户三组权限，
+// 解析 "755"、"0755" 与 "rwxr-xr-x" 这类字符串。
+package perm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSyntax 表示无法解析的权限字符串
+var ErrSyntax = errors.New("perm: 非法的权限字符串")
+
+// Perm 是一组 rwx 权限位，取值与 chmod 的单个八进制位一致
+type Perm uint8
+
+const (
+	Execute Perm = 1 << iota // 001
+	Write                    // 010
+	Read                     // 100
+
+	None Perm = 0
+	All       = Read | Write | Execute
+)
+
+// letters 按字符串中的位置排列，第 i 个字符对应 bits[i]
+const letters = "rwx"
+
+var bits = [3]Perm{Read, Write, Execute}
+
+// Grant 返回增加了 ps 中全部权限后的结果
+func (p Perm) Grant(ps ...Perm) Perm {
+	for _, x := range ps {
+		p |= x
+	}
+	return p & All
+}
+
+// Revoke 返回去掉了 ps 中全部权限后的结果
+func (p Perm) Revoke(ps ...Perm) Perm {
+	for _, x := range ps {
+		p &^= x
+	}
+	return p
+}
+
+// Can 报告 p 是否包含 want 中的全部权限，want 为 None 时总是返回 true
+func (p Perm) Can(want Perm) bool {
+	return p&want == want
+}
+
+// String 返回 "rwx" 形式，未授予的位置以 '-' 占位，如 "r-x"
+func (p Perm) String() string {
+	var buf [3]byte
+	for i, b := range bits {
+		if p&b != 0 {
+			buf[i] = letters[i]
+		} else {
+			buf[i] = '-'
+		}
+	}
+	return string(buf[:])
+}
+
+// Octal 返回单个八进制数字形式，如 "5"
+func (p Perm) Octal() string {
+	return string(rune('0' + p&All))
+}
+
+// Parse 解析 "rwx"、"r-x" 这类三字符形式，或 "5"、"05"、"0o5" 这类八进制形式
+// 只接受单组权限，"755" 这类 chmod 风格的多位八进制应使用 ParseMode
+func Parse(s string) (Perm, error) {
+	if len(s) == 3 && strings.IndexFunc(s, isDigit) < 0 {
+		var p Perm
+		for i := range 3 {
+			switch s[i] {
+			case letters[i]:
+				p |= bits[i]
+			case '-':
+			default:
+				return 0, fmt.Errorf("%w %q", ErrSyntax, s)
+			}
+		}
+		return p, nil
+	}
+
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0o"), "0")
+	switch {
+	case digits == "" && s != "" && s != "0o":
+		return None, nil // "0"、"00"、"0o0" 等
+	case len(digits) == 1 && digits[0] >= '0' && digits[0] <= '7':
+		return Perm(digits[0] - '0'), nil
+	case len(digits) > 1 && strings.Trim(digits, "01234567") == "":
+		return 0, fmt.Errorf("%w %q：Perm 只表示单个八进制位，三组权限请使用 ParseMode", ErrSyntax, s)
+	}
+	return 0, fmt.Errorf("%w %q", ErrSyntax, s)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出 "rwx" 形式
+func (p Perm) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 Parse 支持的所有形式
+func (p *Perm) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
@@ -0,0 +1,95 @@
+package perm_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/perm"
+)
+
+// TestParse 测试 rwx 与八进制两种形式的解析
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected perm.Perm
+	}{
+		{"rwx", perm.All},
+		{"r-x", perm.Read | perm.Execute},
+		{"---", perm.None},
+		{"-w-", perm.Write},
+		{"7", perm.All},
+		{"5", perm.Read | perm.Execute},
+		{"06", perm.Read | perm.Write},
+		{"0o4", perm.Read},
+		{"0", perm.None},
+		{"0o0", perm.None},
+	}
+	for _, tc := range testCases {
+		p, err := perm.Parse(tc.input)
+		if err != nil || p != tc.expected {
+			t.Errorf("Parse(%q) = (%v, %v)，预期 %v", tc.input, p, err, tc.expected)
+		}
+	}
+
+	for _, bad := range []string{"", "rw", "xwr", "r-xx", "8", "0o", "17", "rw1"} {
+		if _, err := perm.Parse(bad); !errors.Is(err, perm.ErrSyntax) {
+			t.Errorf("Parse(%q) 错误为 %v，预期 ErrSyntax", bad, err)
+		}
+	}
+}
+
+// TestFormat 测试 String/Octal 及与 Parse 的往返
+func TestFormat(t *testing.T) {
+	for p := perm.None; p <= perm.All; p++ {
+		for _, s := range []string{p.String(), p.Octal()} {
+			if v, err := perm.Parse(s); err != nil || v != p {
+				t.Errorf("Parse(%q) = (%v, %v)，预期 %v", s, v, err, p)
+			}
+		}
+	}
+	if s := (perm.Read | perm.Execute).String(); s != "r-x" {
+		t.Errorf("String() = %q，预期 r-x", s)
+	}
+	if s := (perm.Read | perm.Write).Octal(); s != "6" {
+		t.Errorf("Octal() = %q，预期 6", s)
+	}
+}
+
+// TestGrantRevokeCan 测试权限的授予、撤销与检查
+func TestGrantRevokeCan(t *testing.T) {
+	p := perm.None.Grant(perm.Read, perm.Write)
+	if !p.Can(perm.Read) || !p.Can(perm.Read|perm.Write) || p.Can(perm.Execute) {
+		t.Errorf("Grant 后 %v 的 Can 结果错误", p)
+	}
+	p = p.Revoke(perm.Write)
+	if p != perm.Read {
+		t.Errorf("Revoke 后为 %v，预期 r--", p)
+	}
+	if !p.Can(perm.None) {
+		t.Errorf("Can(None) 应总为 true")
+	}
+	if g := perm.None.Grant(0xFF); g != perm.All {
+		t.Errorf("Grant 应忽略 rwx 以外的位，得到 %v", g)
+	}
+}
+
+// TestText 测试文本序列化
+func TestText(t *testing.T) {
+	type acl struct {
+		Owner perm.Perm `json:"owner"`
+		Other perm.Perm `json:"other"`
+	}
+	data, err := json.Marshal(acl{Owner: perm.All, Other: perm.Read})
+	if err != nil || string(data) != `{"owner":"rwx","other":"r--"}` {
+		t.Fatalf("Marshal = (%s, %v)", data, err)
+	}
+
+	var a acl
+	if err := json.Unmarshal([]byte(`{"owner":"7","other":"r-x"}`), &a); err != nil || a.Owner != perm.All || a.Other != perm.Read|perm.Execute {
+		t.Errorf("Unmarshal = (%+v, %v)", a, err)
+	}
+	if err := json.Unmarshal([]byte(`{"owner":"rwz"}`), &a); err == nil {
+		t.Errorf("非法字符串应返回错误")
+	}
+}
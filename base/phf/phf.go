@@ -0,0 +1,166 @@
+// Package phf 为固定的键集合构建最小完美哈希函数（minimal perfect hash function），
+// 把 n 个键一一映射到 [0, n)，查找时只需一次键哈希与一次混合，没有冲突，也不需要探测。
+//
+// 构建采用 CHD（compress, hash, displace）算法：先把键按哈希值分到约 n/4 个桶中，
+// 再按桶的大小从大到小依次为每个桶寻找一个位移值（pilot），使桶内所有键落到尚未占用的不同槽位上。
+// 查找时用键所在桶的位移值重新计算槽位即可，表的大小约为每个键 1 字节（每桶一个 uint32）。
+//
+// 完美哈希只对构建时的键有意义，其他键也会得到 [0, n) 内的某个序号，调用方需要比对键本身确认命中。
+// 静态的键表可以用 cmd/phfgen 在生成阶段完成构建，运行时不再有构建开销。
+package phf
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+	"github.com/moweilong/efficient-go/base/hashx"
+)
+
+var (
+	// ErrDuplicateKey 表示键列表中存在重复的键
+	ErrDuplicateKey = errors.New("phf: 重复的键")
+	// ErrBuild 表示尝试了所有种子仍无法构建，通常意味着键的数量超出了支持的范围
+	ErrBuild = errors.New("phf: 构建失败")
+)
+
+const (
+	bucketSize  = 4        // 每个桶的平均键数，越大表越小但构建越慢
+	maxPilot    = 1 << 24  // 单个桶尝试的位移值上限，超过后换一个种子重新构建
+	maxAttempts = 16       // 种子的尝试次数
+	initialSeed = 0x706866 // "phf"，固定的初始种子使构建结果可复现
+)
+
+// Table 是最小完美哈希表，由 Build 或 cmd/phfgen 生成
+// 字段导出以便生成的代码以字面量形式定义表，不应手工修改
+type Table struct {
+	Seed   uint64   // 键哈希的种子
+	Size   int      // 键的个数
+	Pilots []uint32 // 每个桶的位移值
+}
+
+// Index 返回 key 的序号
+// key 属于构建时的键集合时，不同的键得到 [0, Size) 内互不相同的序号；
+// 其他键也会得到 [0, Size) 内的某个序号，空表时返回 0
+func (t *Table) Index(key string) int {
+	if t.Size == 0 {
+		return 0
+	}
+	return t.slot(hashx.Wy{Seed: t.Seed}.Sum64String(key))
+}
+
+// IndexBytes 与 Index 相同，参数为 []byte
+func (t *Table) IndexBytes(key []byte) int {
+	if t.Size == 0 {
+		return 0
+	}
+	return t.slot(hashx.Wy{Seed: t.Seed}.Sum64(key))
+}
+
+// slot 返回哈希值为 h 的键所在的槽位
+func (t *Table) slot(h uint64) int {
+	p := t.Pilots[reduce(h, len(t.Pilots))]
+	return reduce(displace(h, p), t.Size)
+}
+
+// reduce 把 h 均匀地映射到 [0, n)，用乘法取高位代替取模
+func reduce(h uint64, n int) int {
+	hi, _ := bits.Mul64(h, uint64(n))
+	return int(hi)
+}
+
+// displace 用位移值 p 扰动键的哈希值
+// 同一个桶中的键哈希值高位相近，先与 p 的散列异或再经过 splitmix64 的终结步骤充分混合，使槽位彼此独立
+func displace(h uint64, p uint32) uint64 {
+	x := h ^ uint64(p)*0x9e3779b97f4a7c15
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// Build 为 keys 构建最小完美哈希表，keys 中有重复的键时返回 ErrDuplicateKey
+// 构建结果只取决于 keys 的内容与顺序，相同的输入总是得到相同的表
+func Build(keys []string) (*Table, error) {
+	seen := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		if _, ok := seen[k]; ok {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateKey, k)
+		}
+		seen[k] = struct{}{}
+	}
+	if len(keys) == 0 {
+		return &Table{}, nil
+	}
+
+	seed := uint64(initialSeed)
+	for range maxAttempts {
+		if t := build(keys, seed); t != nil {
+			return t, nil
+		}
+		seed += 0x9e3779b97f4a7c15
+	}
+	return nil, ErrBuild
+}
+
+// build 使用指定的种子构建，某个桶找不到可用的位移值时返回 nil
+func build(keys []string, seed uint64) *Table {
+	t := &Table{
+		Seed:   seed,
+		Size:   len(keys),
+		Pilots: make([]uint32, (len(keys)+bucketSize-1)/bucketSize),
+	}
+	h := hashx.Wy{Seed: seed}
+	buckets := make([][]uint64, len(t.Pilots))
+	for _, k := range keys {
+		x := h.Sum64String(k)
+		b := reduce(x, len(buckets))
+		buckets[b] = append(buckets[b], x)
+	}
+
+	// 大桶约束最多，趁空闲槽位多时优先放置
+	order := make([]int, len(buckets))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return len(buckets[b]) - len(buckets[a])
+	})
+
+	taken := bitset.New(t.Size)
+	slots := make([]int, 0, bucketSize*4)
+	for _, b := range order {
+		if len(buckets[b]) == 0 {
+			break
+		}
+		p, ok := place(buckets[b], t.Size, taken, slots)
+		if !ok {
+			return nil
+		}
+		t.Pilots[b] = p
+	}
+	return t
+}
+
+// place 为一个桶寻找位移值，使桶内的键落在互不相同且未被占用的槽位上，找到后标记这些槽位
+func place(hashes []uint64, n int, taken *bitset.BitSet, slots []int) (uint32, bool) {
+next:
+	for p := uint32(0); p < maxPilot; p++ {
+		slots = slots[:0]
+		for _, h := range hashes {
+			s := reduce(displace(h, p), n)
+			if taken.Test(s) || slices.Contains(slots, s) {
+				continue next
+			}
+			slots = append(slots, s)
+		}
+		for _, s := range slots {
+			taken.Set(s)
+		}
+		return p, true
+	}
+	return 0, false
+}
@@ -0,0 +1,124 @@
+package phf_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/phf"
+)
+
+// keys 返回 n 个互不相同的键
+func keys(n int) []string {
+	ks := make([]string, n)
+	for i := range ks {
+		ks[i] = "key-" + strconv.Itoa(i)
+	}
+	return ks
+}
+
+// TestBuild 测试构建出的哈希函数把键一一映射到 [0, n)
+func TestBuild(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 7, 100, 1000, 50000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			ks := keys(n)
+			tbl, err := phf.Build(ks)
+			if err != nil {
+				t.Fatalf("Build 失败: %v", err)
+			}
+			seen := make([]bool, n)
+			for _, k := range ks {
+				i := tbl.Index(k)
+				if i < 0 || i >= n {
+					t.Fatalf("Index(%q) = %d，超出 [0, %d)", k, i, n)
+				}
+				if seen[i] {
+					t.Fatalf("Index(%q) = %d 与其他键冲突", k, i)
+				}
+				seen[i] = true
+				if j := tbl.IndexBytes([]byte(k)); j != i {
+					t.Fatalf("IndexBytes(%q) = %d，Index 为 %d", k, j, i)
+				}
+			}
+			if i := tbl.Index("not-a-key"); i < 0 || i >= n {
+				t.Errorf("集合外的键得到序号 %d，超出 [0, %d)", i, n)
+			}
+		})
+	}
+}
+
+// TestBuildEmpty 测试空键集合
+func TestBuildEmpty(t *testing.T) {
+	tbl, err := phf.Build(nil)
+	if err != nil {
+		t.Fatalf("Build 失败: %v", err)
+	}
+	if i := tbl.Index("x"); i != 0 {
+		t.Errorf("空表 Index = %d，预期 0", i)
+	}
+}
+
+// TestBuildDuplicate 测试重复的键
+func TestBuildDuplicate(t *testing.T) {
+	if _, err := phf.Build([]string{"a", "b", "a"}); !errors.Is(err, phf.ErrDuplicateKey) {
+		t.Errorf("err = %v，预期 ErrDuplicateKey", err)
+	}
+}
+
+// TestDeterministic 测试相同的输入得到相同的表
+func TestDeterministic(t *testing.T) {
+	ks := keys(500)
+	a, err := phf.Build(ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := phf.Build(ks)
+	if a.Seed != b.Seed || a.Size != b.Size || !slices.Equal(a.Pilots, b.Pilots) {
+		t.Errorf("两次构建的结果不同")
+	}
+}
+
+// BenchmarkBuild 测试构建的开销
+func BenchmarkBuild(b *testing.B) {
+	for _, n := range []int{1000, 100000} {
+		ks := keys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := phf.Build(ks); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLookup 对比完美哈希加键比对与 map[string]int 的查找开销
+func BenchmarkLookup(b *testing.B) {
+	for _, n := range []int{32, 10000} {
+		ks := keys(n)
+		tbl, err := phf.Build(ks)
+		if err != nil {
+			b.Fatal(err)
+		}
+		slots := make([]string, n)
+		m := make(map[string]int, n)
+		for i, k := range ks {
+			slots[tbl.Index(k)] = k
+			m[k] = i
+		}
+		b.Run(fmt.Sprintf("n=%d/phf", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				k := ks[i%n]
+				benchkit.SinkBool = slots[tbl.Index(k)] == k
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/map", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, benchkit.SinkBool = m[ks[i%n]]
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package pool 在 sync.Pool 之上提供类型安全的对象池，附带可选的重置钩子与命中统计，
+// 用于复用频繁创建的临时对象以降低分配与 GC 压力。
+package pool
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Pool 是元素类型为 T 的对象池，零值不可用，应使用 New 创建
+//
+// T 应为指针等引用类型：sync.Pool 以 any 保存对象，非指针类型在 Put 时会被装箱，
+// 每次 Put 反而引入一次分配。与 sync.Pool 相同，池中的对象可能在任意一次 GC 时被回收。
+//
+// 同一个 Pool 可以被多个 goroutine 并发使用。
+type Pool[T any] struct {
+	p     sync.Pool
+	new   func() T
+	reset func(T)
+
+	hits, misses, news, puts atomic.Uint64
+}
+
+// Stats 是对象池的累计统计
+type Stats struct {
+	Hits   uint64 // Get 从池中取得对象的次数
+	Misses uint64 // Get 时池为空、需要新建对象的次数
+	News   uint64 // 调用构造函数的次数，包括 Prefill 预先创建的对象
+	Puts   uint64 // Put 归还对象的次数
+}
+
+// HitRatio 返回 Get 的命中率，没有 Get 时返回 0
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// New 创建以 newFn 为构造函数的对象池；newFn 为 nil 时 panic
+func New[T any](newFn func() T) *Pool[T] {
+	if newFn == nil {
+		panic("pool: 构造函数不能为 nil")
+	}
+	return &Pool[T]{new: newFn}
+}
+
+// WithReset 设置 Put 时调用的重置函数并返回 p，应在使用 p 之前调用
+// 重置在归还时而不是取出时执行，使池中的对象不持有对其他对象的引用，例如清空切片或 map
+func (p *Pool[T]) WithReset(reset func(T)) *Pool[T] {
+	p.reset = reset
+	return p
+}
+
+// Get 从池中取出一个对象，池为空时调用构造函数新建
+func (p *Pool[T]) Get() T {
+	if v := p.p.Get(); v != nil {
+		p.hits.Add(1)
+		return v.(T)
+	}
+	p.misses.Add(1)
+	p.news.Add(1)
+	return p.new()
+}
+
+// Put 重置 v 并将其归还池中，归还后调用方不应再使用 v
+func (p *Pool[T]) Put(v T) {
+	if p.reset != nil {
+		p.reset(v)
+	}
+	p.puts.Add(1)
+	p.p.Put(v)
+}
+
+// Prefill 预先创建 n 个对象放入池中，使启动后的首批 Get 不必新建对象
+func (p *Pool[T]) Prefill(n int) {
+	for range n {
+		p.news.Add(1)
+		p.p.Put(p.new())
+	}
+}
+
+// Stats 返回累计统计；各计数器分别读取，并发使用时彼此之间可能不完全一致
+func (p *Pool[T]) Stats() Stats {
+	return Stats{
+		Hits:   p.hits.Load(),
+		Misses: p.misses.Load(),
+		News:   p.news.Load(),
+		Puts:   p.puts.Load(),
+	}
+}
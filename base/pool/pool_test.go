@@ -0,0 +1,113 @@
+package pool_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/pool"
+)
+
+func newBuffer() *bytes.Buffer { return new(bytes.Buffer) }
+
+// TestPool 测试取出、归还、重置与统计
+func TestPool(t *testing.T) {
+	p := pool.New(newBuffer).WithReset((*bytes.Buffer).Reset)
+
+	b := p.Get()
+	if s := p.Stats(); s.Misses != 1 || s.News != 1 || s.Hits != 0 {
+		t.Errorf("首次 Get 后 Stats = %+v", s)
+	}
+	b.WriteString("hello")
+	p.Put(b)
+	if b.Len() != 0 {
+		t.Errorf("Put 后缓冲区未被重置: %q", b.String())
+	}
+	if s := p.Stats(); s.Puts != 1 {
+		t.Errorf("Put 后 Stats = %+v", s)
+	}
+
+	// 竞态检测器会随机丢弃 sync.Pool 中的对象，此时不保证命中
+	if benchkit.RaceEnabled {
+		return
+	}
+	if got := p.Get(); got != b {
+		t.Errorf("Get 未取回刚归还的对象")
+	}
+	if s := p.Stats(); s.Hits != 1 || s.HitRatio() != 0.5 {
+		t.Errorf("命中后 Stats = %+v，HitRatio = %v", s, s.HitRatio())
+	}
+}
+
+// TestPrefill 测试预先创建的对象计入 News 而不计入 Misses
+func TestPrefill(t *testing.T) {
+	p := pool.New(newBuffer)
+	p.Prefill(3)
+	if s := p.Stats(); s.News != 3 || s.Misses != 0 || s.HitRatio() != 0 {
+		t.Errorf("Prefill 后 Stats = %+v", s)
+	}
+	if benchkit.RaceEnabled {
+		return
+	}
+	p.Get()
+	if s := p.Stats(); s.Hits != 1 || s.News != 3 {
+		t.Errorf("Get 预先创建的对象后 Stats = %+v", s)
+	}
+}
+
+// TestPoolConcurrent 测试并发使用时统计的一致性
+func TestPoolConcurrent(t *testing.T) {
+	p := pool.New(newBuffer).WithReset((*bytes.Buffer).Reset)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				b := p.Get()
+				b.WriteByte('x')
+				p.Put(b)
+			}
+		}()
+	}
+	wg.Wait()
+	s := p.Stats()
+	if s.Hits+s.Misses != 8000 || s.Puts != 8000 || s.News != s.Misses {
+		t.Errorf("Stats = %+v", s)
+	}
+}
+
+func TestNewNil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("构造函数为 nil 时应 panic")
+		}
+	}()
+	pool.New[*bytes.Buffer](nil)
+}
+
+// BenchmarkPool 对比每次新建与从池中复用 4KB 缓冲区
+func BenchmarkPool(b *testing.B) {
+	data := bytes.Repeat([]byte("x"), 4096)
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := new(bytes.Buffer)
+			buf.Write(data)
+			benchkit.SinkInt += buf.Len()
+		}
+	})
+	b.Run("pool", func(b *testing.B) {
+		b.ReportAllocs()
+		p := pool.New(newBuffer).WithReset((*bytes.Buffer).Reset)
+		for i := 0; i < b.N; i++ {
+			buf := p.Get()
+			buf.Write(data)
+			benchkit.SinkInt += buf.Len()
+			p.Put(buf)
+		}
+		s := p.Stats()
+		benchkit.ReportHitRatio(b, int(s.Hits), int(s.Misses))
+	})
+}
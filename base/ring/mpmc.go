@@ -0,0 +1,102 @@
+// Package ring 提供基于环形数组的有界无锁队列。
+package ring
+
+import (
+	"sync/atomic"
+
+	"github.com/moweilong/efficient-go/base/bit"
+	"github.com/moweilong/efficient-go/base/pad"
+)
+
+// slot 是 MPMC 中的一个槽位，seq 表示槽位当前处于哪一轮、是否可写或可读
+type slot[T any] struct {
+	seq atomic.Uint64
+	val T
+}
+
+// MPMC 是多生产者多消费者的有界队列，零值不可用，应使用 NewMPMC 创建
+//
+// 实现采用 Dmitry Vyukov 的有界 MPMC 队列：每个槽位带有序号，
+// 生产者与消费者只需在各自的位置计数器上做一次 CAS 认领槽位，热路径上没有锁。
+// 对槽位 i，序号等于入队位置 pos 时可写，等于 pos+1 时可读，读取后置为 pos+容量，留给下一轮。
+type MPMC[T any] struct {
+	_     pad.CacheLinePad
+	head  atomic.Uint64 // 下一个入队位置
+	_     pad.CacheLinePad
+	tail  atomic.Uint64 // 下一个出队位置
+	_     pad.CacheLinePad
+	mask  uint64
+	slots []slot[T]
+}
+
+// NewMPMC 创建容量至少为 capacity 的队列，容量向上取整为 2 的幂；capacity 不为正数时 panic
+func NewMPMC[T any](capacity int) *MPMC[T] {
+	if capacity <= 0 {
+		panic("ring: 容量必须为正数")
+	}
+	n := bit.NextPowerOfTwo(uint64(capacity))
+	q := &MPMC[T]{mask: n - 1, slots: make([]slot[T], n)}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// Cap 返回队列容量
+func (q *MPMC[T]) Cap() int {
+	return len(q.slots)
+}
+
+// Len 返回队列中元素个数的近似值，并发修改时只作参考
+func (q *MPMC[T]) Len() int {
+	head, tail := q.head.Load(), q.tail.Load()
+	if head <= tail {
+		return 0
+	}
+	return int(min(head-tail, uint64(len(q.slots))))
+}
+
+// TryPush 将 v 入队，队列已满时返回 false
+func (q *MPMC[T]) TryPush(v T) bool {
+	pos := q.head.Load()
+	for {
+		s := &q.slots[pos&q.mask]
+		switch dif := int64(s.seq.Load() - pos); {
+		case dif == 0:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				s.val = v
+				s.seq.Store(pos + 1)
+				return true
+			}
+			pos = q.head.Load()
+		case dif < 0: // 槽位上一轮的元素尚未被取走
+			return false
+		default: // 其他生产者已认领该位置
+			pos = q.head.Load()
+		}
+	}
+}
+
+// TryPop 出队一个元素，队列为空时返回零值与 false
+func (q *MPMC[T]) TryPop() (T, bool) {
+	pos := q.tail.Load()
+	for {
+		s := &q.slots[pos&q.mask]
+		switch dif := int64(s.seq.Load() - (pos + 1)); {
+		case dif == 0:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				v := s.val
+				var zero T
+				s.val = zero // 释放引用，避免已出队的元素无法被回收
+				s.seq.Store(pos + q.mask + 1)
+				return v, true
+			}
+			pos = q.tail.Load()
+		case dif < 0: // 槽位尚未被写入
+			var zero T
+			return zero, false
+		default: // 其他消费者已认领该位置
+			pos = q.tail.Load()
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package ring_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/ring"
+)
+
+// TestMPMC 测试单线程下的先进先出、满与空
+func TestMPMC(t *testing.T) {
+	q := ring.NewMPMC[int](3)
+	if q.Cap() != 4 {
+		t.Fatalf("Cap() = %d，预期向上取整为 4", q.Cap())
+	}
+	for round := range 3 { // 多轮以覆盖槽位序号的回绕
+		for i := range 4 {
+			if !q.TryPush(round*10 + i) {
+				t.Fatalf("第 %d 轮第 %d 次入队失败", round, i)
+			}
+		}
+		if q.TryPush(99) {
+			t.Fatalf("队列已满时入队应失败")
+		}
+		if q.Len() != 4 {
+			t.Errorf("Len() = %d，预期 4", q.Len())
+		}
+		for i := range 4 {
+			if v, ok := q.TryPop(); !ok || v != round*10+i {
+				t.Fatalf("第 %d 轮出队得到 (%d, %v)，预期 %d", round, v, ok, round*10+i)
+			}
+		}
+		if _, ok := q.TryPop(); ok {
+			t.Fatalf("队列为空时出队应失败")
+		}
+	}
+}
+
+// TestMPMCConcurrent 多个生产者与消费者并发读写，检查每个元素恰好被取出一次
+func TestMPMCConcurrent(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 20000
+	q := ring.NewMPMC[int](64)
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				for !q.TryPush(p*perProducer + i) {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	seen := make([][]int, consumers)
+	var cwg sync.WaitGroup
+	var mu sync.Mutex
+	remaining := producers * perProducer
+	for c := range consumers {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			for {
+				mu.Lock()
+				if remaining == 0 {
+					mu.Unlock()
+					return
+				}
+				mu.Unlock()
+				v, ok := q.TryPop()
+				if !ok {
+					runtime.Gosched()
+					continue
+				}
+				seen[c] = append(seen[c], v)
+				mu.Lock()
+				remaining--
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	cwg.Wait()
+
+	count := make([]int, producers*perProducer)
+	for _, vs := range seen {
+		last := make([]int, producers) // 同一生产者的元素应按顺序被同一消费者看到
+		for p := range last {
+			last[p] = -1
+		}
+		for _, v := range vs {
+			count[v]++
+			p := v / perProducer
+			if v <= last[p] {
+				t.Fatalf("同一生产者的元素乱序: %d 在 %d 之后", v, last[p])
+			}
+			last[p] = v
+		}
+	}
+	for v, n := range count {
+		if n != 1 {
+			t.Fatalf("元素 %d 被取出 %d 次", v, n)
+		}
+	}
+}
+
+// mutexQueue 是用互斥锁保护的环形队列，作为对照
+type mutexQueue[T any] struct {
+	mu         sync.Mutex
+	buf        []T
+	head, size int
+}
+
+func (q *mutexQueue[T]) push(v T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size == len(q.buf) {
+		return false
+	}
+	q.buf[(q.head+q.size)%len(q.buf)] = v
+	q.size++
+	return true
+}
+
+func (q *mutexQueue[T]) pop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if q.size == 0 {
+		return zero, false
+	}
+	v := q.buf[q.head]
+	q.head = (q.head + 1) % len(q.buf)
+	q.size--
+	return v, true
+}
+
+// BenchmarkMPMC 对比无锁队列、互斥锁队列与带缓冲 channel 在并发入队出队下的吞吐
+func BenchmarkMPMC(b *testing.B) {
+	const capacity = 1024
+	b.Run("MPMC", func(b *testing.B) {
+		q := ring.NewMPMC[int](capacity)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				for !q.TryPush(1) {
+					runtime.Gosched()
+				}
+				for {
+					if _, ok := q.TryPop(); ok {
+						break
+					}
+					runtime.Gosched()
+				}
+			}
+		})
+	})
+	b.Run("mutex", func(b *testing.B) {
+		q := &mutexQueue[int]{buf: make([]int, capacity)}
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				for !q.push(1) {
+					runtime.Gosched()
+				}
+				for {
+					if _, ok := q.pop(); ok {
+						break
+					}
+					runtime.Gosched()
+				}
+			}
+		})
+	})
+	b.Run("chan", func(b *testing.B) {
+		ch := make(chan int, capacity)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				ch <- 1
+				<-ch
+			}
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package ring
+
+import (
+	"sync/atomic"
+
+	"github.com/moweilong/efficient-go/base/bit"
+	"github.com/moweilong/efficient-go/base/pad"
+)
+
+// SPSC 是单生产者单消费者的有界队列，零值不可用，应使用 NewSPSC 创建
+//
+// 只允许一个 goroutine 调用 TryPush、一个 goroutine 调用 TryPop（两者可以不同），
+// 因此入队与出队都只需一次原子读写，不需要 CAS。读写位置分别位于独立的缓存行，
+// 双方各自缓存对方的位置，只有缓存值显示队列满或空时才重新读取，进一步减少缓存行在核心间的传递。
+type SPSC[T any] struct {
+	_         pad.CacheLinePad
+	head      atomic.Uint64 // 下一个出队位置，只由消费者写入
+	tailCache uint64        // 消费者缓存的 tail
+	_         pad.CacheLinePad
+	tail      atomic.Uint64 // 下一个入队位置，只由生产者写入
+	headCache uint64        // 生产者缓存的 head
+	_         pad.CacheLinePad
+	mask      uint64
+	buf       []T
+}
+
+// NewSPSC 创建容量至少为 capacity 的队列，容量向上取整为 2 的幂；capacity 不为正数时 panic
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	if capacity <= 0 {
+		panic("ring: 容量必须为正数")
+	}
+	n := bit.NextPowerOfTwo(uint64(capacity))
+	return &SPSC[T]{mask: n - 1, buf: make([]T, n)}
+}
+
+// Cap 返回队列容量
+func (q *SPSC[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Len 返回队列中元素个数的近似值，并发修改时只作参考
+func (q *SPSC[T]) Len() int {
+	head, tail := q.head.Load(), q.tail.Load()
+	if head >= tail {
+		return 0
+	}
+	return int(tail - head)
+}
+
+// TryPush 将 v 入队，队列已满时返回 false；只能由唯一的生产者调用
+func (q *SPSC[T]) TryPush(v T) bool {
+	t := q.tail.Load()
+	if t-q.headCache == uint64(len(q.buf)) {
+		q.headCache = q.head.Load()
+		if t-q.headCache == uint64(len(q.buf)) {
+			return false
+		}
+	}
+	q.buf[t&q.mask] = v
+	q.tail.Store(t + 1)
+	return true
+}
+
+// TryPop 出队一个元素，队列为空时返回零值与 false；只能由唯一的消费者调用
+func (q *SPSC[T]) TryPop() (T, bool) {
+	h := q.head.Load()
+	if h == q.tailCache {
+		q.tailCache = q.tail.Load()
+		if h == q.tailCache {
+			var zero T
+			return zero, false
+		}
+	}
+	v := q.buf[h&q.mask]
+	var zero T
+	q.buf[h&q.mask] = zero // 释放引用，避免已出队的元素无法被回收
+	q.head.Store(h + 1)
+	return v, true
+}
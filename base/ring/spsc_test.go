@@ -0,0 +1,99 @@
+package ring_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/ring"
+)
+
+// TestSPSC 测试单线程下的先进先出、满与空
+func TestSPSC(t *testing.T) {
+	q := ring.NewSPSC[string](2)
+	for round := range 3 {
+		if !q.TryPush("a") || !q.TryPush("b") {
+			t.Fatalf("第 %d 轮入队失败", round)
+		}
+		if q.TryPush("c") {
+			t.Fatalf("队列已满时入队应失败")
+		}
+		if q.Len() != 2 {
+			t.Errorf("Len() = %d，预期 2", q.Len())
+		}
+		for _, want := range []string{"a", "b"} {
+			if v, ok := q.TryPop(); !ok || v != want {
+				t.Fatalf("出队得到 (%q, %v)，预期 %q", v, ok, want)
+			}
+		}
+		if _, ok := q.TryPop(); ok {
+			t.Fatalf("队列为空时出队应失败")
+		}
+	}
+}
+
+// TestSPSCStress 生产者与消费者并发运行，检查元素不丢失、不重复且保持顺序
+func TestSPSCStress(t *testing.T) {
+	const n = 200000
+	q := ring.NewSPSC[int](16)
+	go func() {
+		for i := range n {
+			for !q.TryPush(i) {
+				runtime.Gosched()
+			}
+		}
+	}()
+	for want := 0; want < n; {
+		v, ok := q.TryPop()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		if v != want {
+			t.Fatalf("出队得到 %d，预期 %d", v, want)
+		}
+		want++
+	}
+	if _, ok := q.TryPop(); ok {
+		t.Errorf("全部取出后队列应为空")
+	}
+}
+
+// BenchmarkSPSC 对比 SPSC 队列与带缓冲 channel 在流水线两个阶段之间传递元素的吞吐
+func BenchmarkSPSC(b *testing.B) {
+	const capacity = 1024
+	b.Run("SPSC", func(b *testing.B) {
+		q := ring.NewSPSC[int](capacity)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range b.N {
+				for {
+					if _, ok := q.TryPop(); ok {
+						break
+					}
+					runtime.Gosched()
+				}
+			}
+		}()
+		for i := 0; i < b.N; i++ {
+			for !q.TryPush(i) {
+				runtime.Gosched()
+			}
+		}
+		<-done
+	})
+	b.Run("chan", func(b *testing.B) {
+		ch := make(chan int, capacity)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range b.N {
+				<-ch
+			}
+		}()
+		for i := 0; i < b.N; i++ {
+			ch <- i
+		}
+		<-done
+	})
+}
@@ -0,0 +1,120 @@
+// Package shardmap 提供分片加锁的并发 map：键按哈希分配到多个分片，每个分片由独立的读写锁保护，
+// 不同分片上的操作互不阻塞。写操作频繁时，争用明显低于一把大锁保护的 map 与 sync.Map。
+package shardmap
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/moweilong/efficient-go/base/bit"
+	"github.com/moweilong/efficient-go/base/hashx"
+	"github.com/moweilong/efficient-go/base/pad"
+)
+
+// shard 是一个分片，填充到独立的缓存行，避免相邻分片的锁互相干扰
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+	_  pad.CacheLinePad
+}
+
+// Map 是分片加锁的并发 map，零值不可用，应使用 New 创建
+type Map[K comparable, V any] struct {
+	seed   hashx.Seeded
+	mask   uint64
+	shards []shard[K, V]
+}
+
+// New 创建至少有 shards 个分片的 Map，分片数向上取整为 2 的幂；
+// shards 不为正数时使用 4 倍的 GOMAXPROCS
+func New[K comparable, V any](shards int) *Map[K, V] {
+	if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+	n := bit.NextPowerOfTwo(uint64(shards))
+	m := &Map[K, V]{seed: hashx.NewSeeded(), mask: n - 1, shards: make([]shard[K, V], n)}
+	for i := range m.shards {
+		m.shards[i].m = make(map[K]V)
+	}
+	return m
+}
+
+// shardFor 返回键 k 所在的分片
+func (m *Map[K, V]) shardFor(k K) *shard[K, V] {
+	return &m.shards[hashx.Comparable(m.seed, k)&m.mask]
+}
+
+// Load 返回键 k 对应的值
+func (m *Map[K, V]) Load(k K) (V, bool) {
+	s := m.shardFor(k)
+	s.mu.RLock()
+	v, ok := s.m[k]
+	s.mu.RUnlock()
+	return v, ok
+}
+
+// Store 设置键 k 对应的值
+func (m *Map[K, V]) Store(k K, v V) {
+	s := m.shardFor(k)
+	s.mu.Lock()
+	s.m[k] = v
+	s.mu.Unlock()
+}
+
+// LoadOrStore 返回键 k 已有的值与 true；键不存在时存入 v 并返回 v 与 false
+func (m *Map[K, V]) LoadOrStore(k K, v V) (V, bool) {
+	s := m.shardFor(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.m[k]; ok {
+		return old, true
+	}
+	s.m[k] = v
+	return v, false
+}
+
+// Delete 删除键 k
+func (m *Map[K, V]) Delete(k K) {
+	s := m.shardFor(k)
+	s.mu.Lock()
+	delete(s.m, k)
+	s.mu.Unlock()
+}
+
+// Len 返回元素个数；逐个分片统计，并发修改时结果不是某一时刻的精确值
+func (m *Map[K, V]) Len() int {
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Range 依次对每个键值对调用 f，f 返回 false 时停止
+//
+// 每个分片的内容先在读锁下复制出来，再在锁外调用 f，因此 f 中可以修改 Map；
+// 同一分片内的键值对来自同一时刻，不同分片之间不保证一致。
+func (m *Map[K, V]) Range(f func(K, V) bool) {
+	type entry struct {
+		k K
+		v V
+	}
+	var buf []entry
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		buf = buf[:0]
+		for k, v := range s.m {
+			buf = append(buf, entry{k, v})
+		}
+		s.mu.RUnlock()
+		for _, e := range buf {
+			if !f(e.k, e.v) {
+				return
+			}
+		}
+	}
+}
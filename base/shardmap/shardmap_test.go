@@ -0,0 +1,137 @@
+package shardmap_test
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/shardmap"
+)
+
+// TestMap 测试基本的读写删除与遍历
+func TestMap(t *testing.T) {
+	m := shardmap.New[string, int](3)
+	for i := range 100 {
+		m.Store(strconv.Itoa(i), i)
+	}
+	if m.Len() != 100 {
+		t.Fatalf("Len() = %d，预期 100", m.Len())
+	}
+	if v, ok := m.Load("42"); !ok || v != 42 {
+		t.Errorf("Load(42) = (%d, %v)", v, ok)
+	}
+	if v, loaded := m.LoadOrStore("42", -1); !loaded || v != 42 {
+		t.Errorf("LoadOrStore 已有键 = (%d, %v)", v, loaded)
+	}
+	if v, loaded := m.LoadOrStore("new", 7); loaded || v != 7 {
+		t.Errorf("LoadOrStore 新键 = (%d, %v)", v, loaded)
+	}
+	m.Delete("new")
+	m.Delete("missing")
+	if _, ok := m.Load("new"); ok {
+		t.Errorf("Delete 后仍能 Load")
+	}
+
+	sum, n := 0, 0
+	m.Range(func(k string, v int) bool {
+		if k != strconv.Itoa(v) {
+			t.Errorf("Range 得到不匹配的键值对 %q: %d", k, v)
+		}
+		m.Delete(k) // Range 中可以修改 Map
+		sum += v
+		n++
+		return true
+	})
+	if n != 100 || sum != 99*100/2 || m.Len() != 0 {
+		t.Errorf("Range 遍历 %d 个元素，和为 %d，之后 Len() = %d", n, sum, m.Len())
+	}
+
+	m.Store("a", 1)
+	m.Store("b", 2)
+	calls := 0
+	m.Range(func(string, int) bool { calls++; return false })
+	if calls != 1 {
+		t.Errorf("f 返回 false 后应停止遍历，实际调用 %d 次", calls)
+	}
+}
+
+// TestMapConcurrent 测试并发读写
+func TestMapConcurrent(t *testing.T) {
+	m := shardmap.New[int, int](0)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				k := g*1000 + i
+				m.Store(k, k)
+				if v, ok := m.Load(k); !ok || v != k {
+					t.Errorf("Load(%d) = (%d, %v)", k, v, ok)
+				}
+				if i%2 == 0 {
+					m.Delete(k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if m.Len() != 4000 {
+		t.Errorf("Len() = %d，预期 4000", m.Len())
+	}
+}
+
+// lockedMap 是一把读写锁保护的 map，作为对照
+type lockedMap struct {
+	mu sync.RWMutex
+	m  map[int]int
+}
+
+// BenchmarkWriteHeavy 对比 90% 写、10% 读的负载下三种并发 map 的吞吐
+func BenchmarkWriteHeavy(b *testing.B) {
+	const keys = 1 << 16
+	op := func(i uint64, load func(int), store func(int)) {
+		k := int(i*0x9E3779B97F4A7C15>>48) % keys
+		if i%10 == 0 {
+			load(k)
+		} else {
+			store(k)
+		}
+	}
+	b.Run("shardmap", func(b *testing.B) {
+		m := shardmap.New[int, int](0)
+		var seq atomic.Uint64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				op(seq.Add(1), func(k int) { m.Load(k) }, func(k int) { m.Store(k, k) })
+			}
+		})
+	})
+	b.Run("RWMutex", func(b *testing.B) {
+		m := &lockedMap{m: make(map[int]int)}
+		var seq atomic.Uint64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				op(seq.Add(1), func(k int) {
+					m.mu.RLock()
+					_ = m.m[k]
+					m.mu.RUnlock()
+				}, func(k int) {
+					m.mu.Lock()
+					m.m[k] = k
+					m.mu.Unlock()
+				})
+			}
+		})
+	})
+	b.Run("sync.Map", func(b *testing.B) {
+		var m sync.Map
+		var seq atomic.Uint64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				op(seq.Add(1), func(k int) { m.Load(k) }, func(k int) { m.Store(k, k) })
+			}
+		})
+	})
+}
@@ -0,0 +1,89 @@
+// Package simhash 计算文本的 64 位 SimHash 指纹，用于近似重复检测。
+//
+// 普通哈希对输入的微小改动极其敏感，SimHash 则相反：每个特征（词或短语）的哈希值在每一位上投票，
+// 该位为 1 记 +权重、为 0 记 -权重，最终各位按票数正负取 1 或 0。内容相近的文本共享大部分特征，
+// 投票结果也大多相同，指纹之间的汉明距离很小。网页去重中通常认为距离不超过 3 的两篇文档近似重复。
+package simhash
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/moweilong/efficient-go/base/bit"
+	"github.com/moweilong/efficient-go/base/hashx"
+)
+
+// hasher 计算特征的哈希值，使用固定种子使指纹跨进程稳定，可以持久化后比较
+var hasher = hashx.Wy{Seed: 0x73696d68617368} // "simhash"
+
+// Builder 逐个累加带权重的特征并生成指纹，零值即可使用
+type Builder struct {
+	votes [64]int64
+}
+
+// Add 加入权重为 weight 的特征，权重通常为词频或 TF-IDF
+func (b *Builder) Add(feature string, weight int) {
+	h := hasher.Sum64String(feature)
+	w := int64(weight)
+	for i := range b.votes {
+		// 根据第 i 位为 1 或 0 得到 +w 或 -w，避免分支预测失败
+		b.votes[i] += (int64(h>>i&1)*2 - 1) * w
+	}
+}
+
+// Sum 返回当前的指纹，票数为正的位为 1
+func (b *Builder) Sum() uint64 {
+	var fp uint64
+	for i, v := range b.votes {
+		if v > 0 {
+			fp |= 1 << i
+		}
+	}
+	return fp
+}
+
+// Reset 清空已加入的特征
+func (b *Builder) Reset() {
+	b.votes = [64]int64{}
+}
+
+// Fingerprint 返回特征列表 tokens 的指纹，每个特征的权重为 1，重复出现的特征累加权重
+func Fingerprint(tokens []string) uint64 {
+	var b Builder
+	for _, t := range tokens {
+		b.Add(t, 1)
+	}
+	return b.Sum()
+}
+
+// HammingDistance 返回两个指纹不同的位数，基于 bit.HammingDistance 的 popcount 实现
+func HammingDistance(a, b uint64) int {
+	return bit.HammingDistance(a, b)
+}
+
+// Near 返回两个指纹的汉明距离是否不超过 k
+func Near(a, b uint64, k int) bool {
+	return HammingDistance(a, b) <= k
+}
+
+// Shingles 将文本切分为转为小写的单词后，返回所有连续 n 个单词组成的短语（w-shingling）
+// 以短语而不是单个单词为特征可以保留词序，单词数少于 n 时返回由全部单词组成的一个短语
+func Shingles(text string, n int) []string {
+	if n <= 0 {
+		panic("simhash: 短语长度必须大于 0")
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return nil
+	}
+	if len(words) <= n {
+		return []string{strings.Join(words, " ")}
+	}
+	out := make([]string, 0, len(words)-n+1)
+	for i := 0; i+n <= len(words); i++ {
+		out = append(out, strings.Join(words[i:i+n], " "))
+	}
+	return out
+}
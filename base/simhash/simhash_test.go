@@ -0,0 +1,91 @@
+package simhash_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/simhash"
+)
+
+// TestShingles 测试文本切分为短语
+func TestShingles(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want []string
+	}{
+		{"The quick, brown fox!", 2, []string{"the quick", "quick brown", "brown fox"}},
+		{"One two", 3, []string{"one two"}},
+		{"  ... ", 2, nil},
+	}
+	for _, tt := range tests {
+		if got := simhash.Shingles(tt.text, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("Shingles(%q, %d) = %q，预期 %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestFingerprint 测试指纹与 Builder 一致、与特征顺序无关，权重影响结果
+func TestFingerprint(t *testing.T) {
+	tokens := []string{"a", "b", "c", "b"}
+	var b simhash.Builder
+	b.Add("c", 1)
+	b.Add("b", 2)
+	b.Add("a", 1)
+	if got, want := b.Sum(), simhash.Fingerprint(tokens); got != want {
+		t.Errorf("Builder.Sum() = %#x，Fingerprint = %#x", got, want)
+	}
+	b.Reset()
+	if b.Sum() != 0 {
+		t.Errorf("Reset 后 Sum() = %#x", b.Sum())
+	}
+	if simhash.HammingDistance(0b1011, 0b0110) != 3 || !simhash.Near(0b1011, 0b0110, 3) || simhash.Near(0b1011, 0b0110, 2) {
+		t.Errorf("HammingDistance 或 Near 结果错误")
+	}
+}
+
+// randomText 返回由 n 个随机单词组成的文本
+func randomText(r *rand.Rand, n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%d", r.Intn(5000))
+	}
+	return words
+}
+
+// TestNearDuplicate 测试少量修改后的文本指纹距离小，无关文本的指纹距离接近 32
+func TestNearDuplicate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var near, far int
+	const trials = 50
+	for range trials {
+		doc := randomText(r, 300)
+		edited := slices.Clone(doc)
+		for range 3 { // 修改 1% 的单词
+			edited[r.Intn(len(edited))] = "edited"
+		}
+		fp := simhash.Fingerprint(simhash.Shingles(strings.Join(doc, " "), 3))
+		near += simhash.HammingDistance(fp, simhash.Fingerprint(simhash.Shingles(strings.Join(edited, " "), 3)))
+		other := simhash.Fingerprint(simhash.Shingles(strings.Join(randomText(r, 300), " "), 3))
+		far += simhash.HammingDistance(fp, other)
+	}
+	if avg := float64(near) / trials; avg > 6 {
+		t.Errorf("近似重复文本的平均距离为 %.1f，预期不超过 6", avg)
+	}
+	if avg := float64(far) / trials; avg < 26 || avg > 38 {
+		t.Errorf("无关文本的平均距离为 %.1f，预期约 32", avg)
+	}
+}
+
+// BenchmarkFingerprint 测量 300 词文本从切分到生成指纹的开销
+func BenchmarkFingerprint(b *testing.B) {
+	text := strings.Join(randomText(rand.New(rand.NewSource(1)), 300), " ")
+	b.SetBytes(int64(len(text)))
+	for i := 0; i < b.N; i++ {
+		benchkit.SinkU64 = simhash.Fingerprint(simhash.Shingles(text, 3))
+	}
+}
@@ -0,0 +1,142 @@
+// Package singleflight 合并对同一个键的并发调用：同一时刻只有一个调用真正执行，
+// 其余调用等待并共享它的结果，避免缓存失效时大量请求同时重复计算（缓存击穿）。
+//
+// 与 golang.org/x/sync/singleflight 相比，Group 是泛型的，并且可以把成功的结果缓存一小段时间，
+// 使紧随其后的调用也不必重复计算。
+package singleflight
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ErrGoexit 是 fn 调用 runtime.Goexit（例如测试中的 t.FailNow）时等待同一个调用的其他调用者得到的错误
+var ErrGoexit = errors.New("singleflight: 函数调用了 runtime.Goexit")
+
+// PanicError 是 fn panic 时等待同一个调用的其他调用者得到的错误，发起调用的 goroutine 会重新 panic
+type PanicError struct {
+	Value any    // recover 得到的值
+	Stack []byte // panic 时的调用栈
+}
+
+// Error 实现 error 接口
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("singleflight: 函数 panic: %v\n%s", e.Value, e.Stack)
+}
+
+// call 是一次正在执行或已缓存的调用
+type call[V any] struct {
+	wg      sync.WaitGroup
+	val     V
+	err     error
+	done    bool      // 已执行完成，此后 val 为缓存的结果
+	expires time.Time // 缓存的过期时间
+}
+
+// Group 合并对同一个键的并发调用，零值即可使用，此时不缓存结果
+type Group[K comparable, V any] struct {
+	ttl time.Duration
+	mu  sync.Mutex
+	m   map[K]*call[V]
+}
+
+// New 创建一个 Group，ttl 为成功结果的缓存时间，不为正数表示不缓存
+//
+// 返回错误的调用从不缓存，下一次调用会重新执行。过期的结果在下一次访问同一个键时才被删除，
+// 键空间很大且 ttl 较长时应定期调用 Forget 或 Purge。
+func New[K comparable, V any](ttl time.Duration) *Group[K, V] {
+	return &Group[K, V]{ttl: ttl}
+}
+
+// Do 执行 fn 并返回其结果，同一个键同一时刻只有一个 fn 在执行，
+// 其他调用者等待并得到相同的结果；shared 表示结果是否来自其他调用者的执行或缓存
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[K]*call[V])
+	}
+	if c, ok := g.m[key]; ok {
+		if !c.done {
+			g.mu.Unlock()
+			c.wg.Wait()
+			return c.val, c.err, true
+		}
+		if time.Now().Before(c.expires) {
+			g.mu.Unlock()
+			return c.val, nil, true
+		}
+		delete(g.m, key)
+	}
+	c := new(call[V])
+	c.wg.Add(1)
+	g.m[key] = c
+	g.mu.Unlock()
+
+	g.doCall(key, c, fn)
+	return c.val, c.err, false
+}
+
+// doCall 执行 fn 并发布结果，fn panic 时先让等待者返回错误再重新 panic；
+// fn 调用 runtime.Goexit 时等待者得到 ErrGoexit，发起调用的 goroutine 继续退出
+func (g *Group[K, V]) doCall(key K, c *call[V], fn func() (V, error)) {
+	normal, recovered := false, false
+	var p any
+	defer func() {
+		// 既没有正常返回也没有 recover 到 panic，说明 fn 调用了 runtime.Goexit
+		if !normal && !recovered {
+			c.err = ErrGoexit
+		}
+		g.mu.Lock()
+		if c.err != nil || g.ttl <= 0 {
+			// 调用期间键可能已被 Forget 并由新的调用占用
+			if g.m[key] == c {
+				delete(g.m, key)
+			}
+		} else {
+			c.done = true
+			c.expires = time.Now().Add(g.ttl)
+		}
+		g.mu.Unlock()
+		c.wg.Done()
+		if recovered {
+			panic(p)
+		}
+	}()
+
+	func() {
+		defer func() {
+			if normal {
+				return
+			}
+			// Goexit 时 recover 返回 nil；自 Go 1.21 起 panic(nil) 会得到 *runtime.PanicNilError
+			if p = recover(); p != nil {
+				recovered = true
+				c.err = &PanicError{Value: p, Stack: debug.Stack()}
+			}
+		}()
+		c.val, c.err = fn()
+		normal = true
+	}()
+}
+
+// Forget 删除键 key 的缓存结果，正在执行的调用不受影响，但之后的调用会重新执行 fn
+func (g *Group[K, V]) Forget(key K) {
+	g.mu.Lock()
+	delete(g.m, key)
+	g.mu.Unlock()
+}
+
+// Purge 删除所有过期的缓存结果
+func (g *Group[K, V]) Purge() {
+	now := time.Now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for k, c := range g.m {
+		if c.done && !now.Before(c.expires) {
+			delete(g.m, k)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package singleflight_test
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/singleflight"
+)
+
+// TestDo 测试并发调用只执行一次并共享结果
+func TestDo(t *testing.T) {
+	var g singleflight.Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	var sharedCount atomic.Int32
+	started := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		close(started)
+		if v, err, shared := g.Do("k", fn); v != 42 || err != nil || shared {
+			t.Errorf("Do() = %d, %v, %v", v, err, shared)
+		}
+	}()
+	<-started
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, shared := g.Do("k", fn)
+			if v != 42 || err != nil {
+				t.Errorf("Do() = %d, %v", v, err)
+			}
+			if shared {
+				sharedCount.Add(1)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond) // 等待其余调用进入等待
+	close(release)
+	wg.Wait()
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn 执行了 %d 次，预期 1", got)
+	}
+	if got := sharedCount.Load(); got != n {
+		t.Errorf("%d 个调用共享了结果，预期 %d", got, n)
+	}
+
+	// 不缓存时下一次调用重新执行
+	if _, _, shared := g.Do("k", func() (int, error) { return 1, nil }); shared {
+		t.Errorf("调用结束后结果不应被共享")
+	}
+}
+
+// TestTTL 测试成功结果在 ttl 内被缓存，错误结果不缓存
+func TestTTL(t *testing.T) {
+	g := singleflight.New[int, string](50 * time.Millisecond)
+	var calls atomic.Int32
+	fn := func() (string, error) {
+		calls.Add(1)
+		return "v", nil
+	}
+	g.Do(1, fn)
+	if v, _, shared := g.Do(1, fn); v != "v" || !shared || calls.Load() != 1 {
+		t.Errorf("ttl 内的调用应使用缓存：v=%q shared=%v calls=%d", v, shared, calls.Load())
+	}
+
+	g.Forget(1)
+	g.Do(1, fn)
+	if calls.Load() != 2 {
+		t.Errorf("Forget 后应重新执行")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	g.Purge()
+	if _, _, shared := g.Do(1, fn); shared || calls.Load() != 3 {
+		t.Errorf("过期后应重新执行")
+	}
+
+	errBoom := errors.New("boom")
+	var errCalls int
+	failing := func() (string, error) {
+		errCalls++
+		return "", errBoom
+	}
+	for range 3 {
+		if _, err, _ := g.Do(2, failing); !errors.Is(err, errBoom) {
+			t.Errorf("Do() 错误 = %v", err)
+		}
+	}
+	if errCalls != 3 {
+		t.Errorf("错误结果不应缓存，fn 执行了 %d 次", errCalls)
+	}
+}
+
+// TestPanic 测试 fn panic 时调用者重新 panic，之后的调用重新执行
+func TestPanic(t *testing.T) {
+	g := singleflight.New[string, int](time.Minute)
+	func() {
+		defer func() {
+			if recover() != "boom" {
+				t.Errorf("调用者应重新 panic")
+			}
+		}()
+		g.Do("k", func() (int, error) { panic("boom") })
+	}()
+	if v, err, shared := g.Do("k", func() (int, error) { return 1, nil }); v != 1 || err != nil || shared {
+		t.Errorf("panic 后 Do() = %d, %v, %v", v, err, shared)
+	}
+}
+
+// TestGoexit 测试 fn 调用 runtime.Goexit 时发起调用的 goroutine 正常退出而不是 panic，
+// 等待者得到 ErrGoexit，之后的调用重新执行
+func TestGoexit(t *testing.T) {
+	g := singleflight.New[string, int](time.Minute)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		g.Do("k", func() (int, error) {
+			close(started)
+			<-release
+			runtime.Goexit()
+			return 0, nil
+		})
+		t.Errorf("Goexit 后 Do 不应返回")
+	}()
+	<-started
+
+	waited := make(chan error)
+	go func() {
+		_, err, shared := g.Do("k", func() (int, error) { return 0, errors.New("等待者不应执行 fn") })
+		if !shared {
+			t.Errorf("等待者应共享正在执行的调用")
+		}
+		waited <- err
+	}()
+	time.Sleep(50 * time.Millisecond) // 等待第二个调用进入等待
+	close(release)
+	<-exited
+	if err := <-waited; !errors.Is(err, singleflight.ErrGoexit) {
+		t.Errorf("等待者得到 %v，预期 ErrGoexit", err)
+	}
+	if v, err, shared := g.Do("k", func() (int, error) { return 1, nil }); v != 1 || err != nil || shared {
+		t.Errorf("Goexit 后 Do() = %d, %v, %v", v, err, shared)
+	}
+}
+
+// TestPanicNil 测试 fn 以 nil 值 panic 时仍按 panic 处理
+func TestPanicNil(t *testing.T) {
+	var g singleflight.Group[string, int]
+	defer func() {
+		if _, ok := recover().(*runtime.PanicNilError); !ok {
+			t.Errorf("调用者应以 *runtime.PanicNilError 重新 panic")
+		}
+	}()
+	g.Do("k", func() (int, error) { panic(nil) })
+}
+
+// BenchmarkDo 测量缓存命中与无争用调用的开销
+func BenchmarkDo(b *testing.B) {
+	fn := func() (int, error) { return 1, nil }
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		var g singleflight.Group[int, int]
+		for i := 0; i < b.N; i++ {
+			g.Do(i&1023, fn)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		g := singleflight.New[int, int](time.Hour)
+		for i := 0; i < b.N; i++ {
+			g.Do(i&1023, fn)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+// Package slab 提供定长内存块的分配器：内存以 slab（一次性分配的大块内存）为单位预先申请，
+// 再切分为大小相同的块分发，空闲块由位集合记录。
+// 适用于对象大小一致、分配与释放都很频繁的场景，可以把大量小分配合并为少数几次大分配，降低 GC 的扫描与标记开销。
+package slab
+
+import (
+	"cmp"
+	"slices"
+	"unsafe"
+
+	"github.com/moweilong/efficient-go/base/bit/bitset"
+)
+
+// slab 是一块切分为 n 个定长块的连续内存
+type slab struct {
+	mem   []byte
+	used  *bitset.BitSet // 第 i 位为 1 表示第 i 块已分配
+	free  int            // 空闲块个数
+	next  int            // 下一次查找空闲块的起点，避免每次从头扫描
+	avail bool           // 是否在 Allocator.avail 中
+}
+
+// base 返回 slab 内存的起始地址
+func (s *slab) base() uintptr {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(s.mem)))
+}
+
+// byBase 按起始地址比较 slab 与地址 p，用于二分查找
+func byBase(s *slab, p uintptr) int {
+	return cmp.Compare(s.base(), p)
+}
+
+// Allocator 是定长块分配器，零值不可用，应使用 New 创建
+//
+// Allocator 不能被多个 goroutine 并发使用。分配出的块引用 slab 的内存，
+// 只要仍有块被引用，整个 slab 就不会被 GC 回收。
+type Allocator struct {
+	blockSize int
+	perSlab   int
+	slabs     []*slab // 按起始地址排序，Free 时二分查找块所属的 slab
+	avail     []*slab // 有空闲块的 slab
+	allocated int
+}
+
+// New 创建块大小为 blockSize 字节、每个 slab 含 blocksPerSlab 个块的分配器；参数不为正数时 panic
+func New(blockSize, blocksPerSlab int) *Allocator {
+	if blockSize <= 0 || blocksPerSlab <= 0 {
+		panic("slab: 块大小与每个 slab 的块数必须为正数")
+	}
+	return &Allocator{blockSize: blockSize, perSlab: blocksPerSlab}
+}
+
+// BlockSize 返回块大小
+func (a *Allocator) BlockSize() int {
+	return a.blockSize
+}
+
+// Len 返回已分配且未释放的块数
+func (a *Allocator) Len() int {
+	return a.allocated
+}
+
+// Cap 返回所有 slab 的总块数
+func (a *Allocator) Cap() int {
+	return len(a.slabs) * a.perSlab
+}
+
+// Alloc 分配一个长度与容量均为 BlockSize 的块，内容全部为 0
+// 没有空闲块时申请一个新的 slab
+func (a *Allocator) Alloc() []byte {
+	if len(a.avail) == 0 {
+		a.grow()
+	}
+	s := a.avail[len(a.avail)-1]
+	i, ok := s.used.NextClear(s.next)
+	if !ok {
+		i, _ = s.used.NextClear(0) // free > 0 保证一定存在
+	}
+	s.used.Set(i)
+	s.next = i + 1
+	if s.next == a.perSlab {
+		s.next = 0
+	}
+	s.free--
+	a.allocated++
+	if s.free == 0 {
+		s.avail = false
+		a.avail = a.avail[:len(a.avail)-1]
+	}
+
+	off := i * a.blockSize
+	b := s.mem[off : off+a.blockSize : off+a.blockSize]
+	clear(b)
+	return b
+}
+
+// Free 释放由 Alloc 分配的块，释放后调用方不应再使用 b
+// b 不是本分配器分配的块或已被释放时 panic
+func (a *Allocator) Free(b []byte) {
+	if cap(b) != a.blockSize {
+		panic("slab: 释放的不是本分配器分配的块")
+	}
+	p := uintptr(unsafe.Pointer(unsafe.SliceData(b)))
+	// 找到起始地址不大于 p 的最后一个 slab
+	j, found := slices.BinarySearchFunc(a.slabs, p, byBase)
+	if !found {
+		j--
+	}
+	if j < 0 {
+		panic("slab: 释放的不是本分配器分配的块")
+	}
+	s := a.slabs[j]
+	off := p - s.base()
+	if off >= uintptr(len(s.mem)) || off%uintptr(a.blockSize) != 0 {
+		panic("slab: 释放的不是本分配器分配的块")
+	}
+	i := int(off) / a.blockSize
+	if !s.used.Test(i) {
+		panic("slab: 重复释放")
+	}
+	s.used.Clear(i)
+	s.free++
+	a.allocated--
+	if !s.avail {
+		s.avail = true
+		a.avail = append(a.avail, s)
+	}
+}
+
+// grow 申请一个新的 slab
+func (a *Allocator) grow() {
+	s := &slab{
+		mem:   make([]byte, a.blockSize*a.perSlab),
+		used:  bitset.New(a.perSlab),
+		free:  a.perSlab,
+		avail: true,
+	}
+	i, _ := slices.BinarySearchFunc(a.slabs, s.base(), byBase)
+	a.slabs = slices.Insert(a.slabs, i, s)
+	a.avail = append(a.avail, s)
+}
@@ -0,0 +1,137 @@
+package slab_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/slab"
+)
+
+// TestAllocFree 随机分配与释放，检查块互不重叠、内容清零且计数正确
+func TestAllocFree(t *testing.T) {
+	a := slab.New(24, 100)
+	r := rand.New(rand.NewSource(1))
+	live := make(map[*byte][]byte)
+	for step := range 20000 {
+		if len(live) == 0 || r.Intn(3) != 0 {
+			b := a.Alloc()
+			if len(b) != 24 || cap(b) != 24 {
+				t.Fatalf("块的长度与容量为 %d、%d，预期 24", len(b), cap(b))
+			}
+			for i, c := range b {
+				if c != 0 {
+					t.Fatalf("第 %d 步分配的块第 %d 字节为 %d，预期 0", step, i, c)
+				}
+			}
+			if _, dup := live[&b[0]]; dup {
+				t.Fatalf("第 %d 步分配了仍在使用的块", step)
+			}
+			for i := range b {
+				b[i] = byte(step) // 写满整块，与其他块重叠时会破坏它们的内容
+			}
+			live[&b[0]] = b
+		} else {
+			for k, b := range live {
+				if b[0] != b[len(b)-1] {
+					t.Fatalf("块的内容被其他块覆盖")
+				}
+				a.Free(b)
+				delete(live, k)
+				break
+			}
+		}
+		if a.Len() != len(live) {
+			t.Fatalf("Len() = %d，预期 %d", a.Len(), len(live))
+		}
+	}
+	if a.Cap()%100 != 0 || a.Cap() < a.Len() {
+		t.Errorf("Cap() = %d，Len() = %d", a.Cap(), a.Len())
+	}
+}
+
+// TestReuse 测试释放后的块被优先复用而不是申请新的 slab
+func TestReuse(t *testing.T) {
+	a := slab.New(8, 4)
+	blocks := make([][]byte, 4)
+	for i := range blocks {
+		blocks[i] = a.Alloc()
+	}
+	if a.Cap() != 4 {
+		t.Fatalf("Cap() = %d，预期 4", a.Cap())
+	}
+	a.Free(blocks[2])
+	if b := a.Alloc(); &b[0] != &blocks[2][0] {
+		t.Errorf("未复用刚释放的块")
+	}
+	if a.Cap() != 4 {
+		t.Errorf("复用后 Cap() = %d，预期 4", a.Cap())
+	}
+	a.Alloc()
+	if a.Cap() != 8 || a.Len() != 5 {
+		t.Errorf("Cap() = %d，Len() = %d，预期 8、5", a.Cap(), a.Len())
+	}
+}
+
+// TestFreePanics 测试非法释放
+func TestFreePanics(t *testing.T) {
+	a := slab.New(16, 4)
+	b := a.Alloc()
+	other := slab.New(16, 4).Alloc()
+	testCases := []struct {
+		name string
+		b    []byte
+	}{
+		{"其他分配器的块", other},
+		{"普通切片", make([]byte, 16)},
+		{"块内部的偏移", b[1:]},
+		{"容量不符", b[:8:8]},
+	}
+	for _, tc := range testCases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: 应 panic", tc.name)
+				}
+			}()
+			a.Free(tc.b)
+		}()
+	}
+
+	a.Free(b)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("重复释放应 panic")
+		}
+	}()
+	a.Free(b)
+}
+
+// BenchmarkAlloc 对比 slab 分配与逐个 make 的开销
+func BenchmarkAlloc(b *testing.B) {
+	const live = 1024
+	b.Run("make", func(b *testing.B) {
+		b.ReportAllocs()
+		stop := benchkit.TrackGC(b)
+		ring := make([][]byte, live)
+		for i := 0; i < b.N; i++ {
+			ring[i%live] = make([]byte, 64)
+		}
+		stop()
+		benchkit.SinkBytes = ring[0]
+	})
+	b.Run("slab", func(b *testing.B) {
+		b.ReportAllocs()
+		stop := benchkit.TrackGC(b)
+		a := slab.New(64, 4096)
+		ring := make([][]byte, live)
+		for i := 0; i < b.N; i++ {
+			if old := ring[i%live]; old != nil {
+				a.Free(old)
+			}
+			ring[i%live] = a.Alloc()
+		}
+		stop()
+		benchkit.SinkBytes = ring[0]
+	})
+}
@@ -0,0 +1,55 @@
+// Package sliceutil 提供构建切片的辅助类型。
+package sliceutil
+
+import "slices"
+
+// Builder 逐个追加元素构建切片，零值即可使用
+//
+// 与直接 append 相比，Builder 让调用方预先声明预期的元素个数：容量一次分配到位，
+// 之后的追加不会因为多次扩容而反复复制与分配。预期不准确时仍按 append 的策略自动扩容。
+type Builder[T any] struct {
+	buf []T
+}
+
+// NewBuilder 返回预留了 expected 个元素容量的 Builder
+func NewBuilder[T any](expected int) *Builder[T] {
+	return &Builder[T]{buf: make([]T, 0, expected)}
+}
+
+// Grow 保证之后至少还能追加 n 个元素而不再分配，n 为负数时 panic
+func (b *Builder[T]) Grow(n int) {
+	b.buf = slices.Grow(b.buf, n)
+}
+
+// Reserve 保证总容量至少为 n 个元素，n 不大于当前容量时不做任何事
+func (b *Builder[T]) Reserve(n int) {
+	if n > cap(b.buf) {
+		b.buf = slices.Grow(b.buf, n-len(b.buf))
+	}
+}
+
+// Append 追加元素
+func (b *Builder[T]) Append(v ...T) {
+	b.buf = append(b.buf, v...)
+}
+
+// Len 返回已追加的元素个数
+func (b *Builder[T]) Len() int {
+	return len(b.buf)
+}
+
+// Cap 返回当前容量
+func (b *Builder[T]) Cap() int {
+	return cap(b.buf)
+}
+
+// Slice 返回已构建的切片，不复制元素
+// 返回值的容量被截断为长度，调用方对其 append 不会覆盖 Builder 之后追加的元素
+func (b *Builder[T]) Slice() []T {
+	return b.buf[:len(b.buf):len(b.buf)]
+}
+
+// Reset 清空 Builder 并释放底层数组，之前 Slice 返回的切片不受影响
+func (b *Builder[T]) Reset() {
+	b.buf = nil
+}
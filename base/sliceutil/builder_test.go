@@ -0,0 +1,91 @@
+package sliceutil_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/sliceutil"
+)
+
+// TestBuilder 测试追加、预留与切片的独立性
+func TestBuilder(t *testing.T) {
+	var b sliceutil.Builder[int]
+	b.Reserve(4)
+	if b.Cap() < 4 || b.Len() != 0 {
+		t.Fatalf("Reserve(4) 后 Len = %d，Cap = %d", b.Len(), b.Cap())
+	}
+	b.Append(1, 2)
+	b.Append(3)
+	b.Grow(10)
+	if b.Cap()-b.Len() < 10 {
+		t.Errorf("Grow(10) 后剩余容量为 %d", b.Cap()-b.Len())
+	}
+	c := b.Cap()
+	b.Reserve(c - 1)
+	if b.Cap() != c {
+		t.Errorf("Reserve 小于当前容量时不应改变容量")
+	}
+
+	s := b.Slice()
+	if !slices.Equal(s, []int{1, 2, 3}) || cap(s) != 3 {
+		t.Fatalf("Slice() = %v，容量 %d", s, cap(s))
+	}
+	_ = append(s, 99) // 容量已截断，不会写入 Builder 的底层数组
+	b.Append(4)
+	if got := b.Slice(); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Slice() = %v", got)
+	}
+
+	b.Reset()
+	if b.Len() != 0 || b.Cap() != 0 || !slices.Equal(s, []int{1, 2, 3}) {
+		t.Errorf("Reset 后 Len = %d，Cap = %d，之前的切片为 %v", b.Len(), b.Cap(), s)
+	}
+}
+
+// TestBuilderAllocs 测试声明了准确的预期大小时只分配一次
+func TestBuilderAllocs(t *testing.T) {
+	benchkit.AssertAllocs(t, 1, func() {
+		b := sliceutil.NewBuilder[int](1000)
+		for i := range 1000 {
+			b.Append(i)
+		}
+		benchkit.Sink(b.Slice())
+	})
+}
+
+// BenchmarkBuilder 对比不预留容量的 append 与 Builder 构建 10000 个元素的开销
+func BenchmarkBuilder(b *testing.B) {
+	const n = 10000
+	b.Run("append", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var s []int
+			for j := range n {
+				s = append(s, j)
+			}
+			benchkit.Sink(s)
+		}
+	})
+	b.Run("Builder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sb := sliceutil.NewBuilder[int](n)
+			for j := range n {
+				sb.Append(j)
+			}
+			benchkit.Sink(sb.Slice())
+		}
+	})
+}
+
+func ExampleBuilder() {
+	words := []string{"efficient", "go", "slice"}
+	b := sliceutil.NewBuilder[int](len(words))
+	for _, w := range words {
+		b.Append(len(w))
+	}
+	fmt.Println(b.Slice(), b.Cap())
+	// Output: [9 2 5] 3
+}
@@ -0,0 +1,95 @@
+// Package smallbytes 提供小缓冲优化（small buffer optimization）的字节容器：
+// 短内容直接存放在结构体内部，只有超过内联容量时才分配堆内存。
+package smallbytes
+
+// InlineSize 是 Buffer 内联存储的容量，覆盖大多数键、ID 与短字段
+const InlineSize = 32
+
+// Buffer 是可追加的字节容器，零值即为可用的空容器
+//
+// 内容不超过 InlineSize 字节时存放在结构体内部的数组中；Buffer 作为局部变量且不逃逸时，
+// 整个过程没有堆分配。超过后内容被搬到堆上的切片，此后按 append 的策略扩容。
+// 溢出后按值复制的 Buffer 会共享同一个堆切片，不应在复制后同时写入两者。
+type Buffer struct {
+	inline [InlineSize]byte
+	n      int    // 内联时的长度
+	heap   []byte // 溢出到堆后的内容，为 nil 表示仍在内联存储中
+}
+
+// Len 返回内容的字节数
+func (b *Buffer) Len() int {
+	if b.heap != nil {
+		return len(b.heap)
+	}
+	return b.n
+}
+
+// Spilled 报告内容是否已溢出到堆上
+func (b *Buffer) Spilled() bool {
+	return b.heap != nil
+}
+
+// Bytes 返回内容，不复制数据；返回值在下一次修改 Buffer 之前有效
+// 内联时返回值指向 Buffer 自身，会使 Buffer 随返回值一起逃逸
+func (b *Buffer) Bytes() []byte {
+	if b.heap != nil {
+		return b.heap
+	}
+	return b.inline[:b.n:b.n]
+}
+
+// String 返回内容的字符串副本
+func (b *Buffer) String() string {
+	return string(b.Bytes())
+}
+
+// Write 追加 p，总是返回 len(p), nil，实现 io.Writer
+func (b *Buffer) Write(p []byte) (int, error) {
+	if b.heap == nil && b.n+len(p) <= InlineSize {
+		b.n += copy(b.inline[b.n:], p)
+		return len(p), nil
+	}
+	b.spill(len(p))
+	b.heap = append(b.heap, p...)
+	return len(p), nil
+}
+
+// WriteString 追加 s，总是返回 len(s), nil，实现 io.StringWriter
+func (b *Buffer) WriteString(s string) (int, error) {
+	if b.heap == nil && b.n+len(s) <= InlineSize {
+		b.n += copy(b.inline[b.n:], s)
+		return len(s), nil
+	}
+	b.spill(len(s))
+	b.heap = append(b.heap, s...)
+	return len(s), nil
+}
+
+// WriteByte 追加一个字节，总是返回 nil，实现 io.ByteWriter
+func (b *Buffer) WriteByte(c byte) error {
+	if b.heap == nil && b.n < InlineSize {
+		b.inline[b.n] = c
+		b.n++
+		return nil
+	}
+	b.spill(1)
+	b.heap = append(b.heap, c)
+	return nil
+}
+
+// Reset 清空内容；已溢出的堆内存被保留以便复用，内容再次变短也不会回到内联存储
+func (b *Buffer) Reset() {
+	b.n = 0
+	if b.heap != nil {
+		b.heap = b.heap[:0]
+	}
+}
+
+// spill 在内联存储无法再容纳 extra 字节时把内容搬到堆上，容量至少为内联容量的两倍
+func (b *Buffer) spill(extra int) {
+	if b.heap != nil {
+		return
+	}
+	b.heap = make([]byte, b.n, max(b.n+extra, 2*InlineSize))
+	copy(b.heap, b.inline[:b.n])
+}
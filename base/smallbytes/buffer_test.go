@@ -0,0 +1,104 @@
+package smallbytes_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/smallbytes"
+)
+
+// TestBuffer 测试内联与溢出两种状态下的追加结果
+func TestBuffer(t *testing.T) {
+	var b smallbytes.Buffer
+	var want strings.Builder
+	for i := 0; want.Len() < 200; i++ {
+		switch i % 3 {
+		case 0:
+			b.WriteString("key:")
+			want.WriteString("key:")
+		case 1:
+			b.Write([]byte("value"))
+			want.WriteString("value")
+		case 2:
+			b.WriteByte('|')
+			want.WriteByte('|')
+		}
+		if b.String() != want.String() || b.Len() != want.Len() {
+			t.Fatalf("第 %d 次写入后内容为 %q，预期 %q", i, b.String(), want.String())
+		}
+		if b.Spilled() != (want.Len() > smallbytes.InlineSize) {
+			t.Fatalf("长度 %d 时 Spilled() = %v", want.Len(), b.Spilled())
+		}
+	}
+
+	b.Reset()
+	if b.Len() != 0 || b.String() != "" {
+		t.Errorf("Reset 后 Len = %d", b.Len())
+	}
+	b.WriteString("short")
+	if !bytes.Equal(b.Bytes(), []byte("short")) {
+		t.Errorf("Reset 后再次写入得到 %q", b.Bytes())
+	}
+}
+
+// TestBoundary 测试恰好填满内联存储时不溢出
+func TestBoundary(t *testing.T) {
+	var b smallbytes.Buffer
+	b.WriteString(strings.Repeat("x", smallbytes.InlineSize-1))
+	b.WriteByte('y')
+	if b.Spilled() {
+		t.Errorf("写入 %d 字节后不应溢出", smallbytes.InlineSize)
+	}
+	if bs := b.Bytes(); cap(bs) != len(bs) {
+		t.Errorf("内联时 Bytes() 的容量应等于长度，以免调用方 append 覆盖内部数组")
+	}
+	b.WriteByte('z')
+	if !b.Spilled() || b.Len() != smallbytes.InlineSize+1 {
+		t.Errorf("超过内联容量后 Spilled() = %v，Len() = %d", b.Spilled(), b.Len())
+	}
+}
+
+// TestAllocs 测试短内容不分配堆内存
+func TestAllocs(t *testing.T) {
+	key := []byte("user:")
+	benchkit.AssertAllocs(t, 0, func() {
+		var b smallbytes.Buffer
+		b.Write(key)
+		b.WriteString("1234567")
+		b.WriteByte('#')
+		benchkit.SinkInt += len(b.Bytes())
+	})
+}
+
+// BenchmarkShortKey 对比用 bytes.Buffer 与 smallbytes.Buffer 拼接短键
+func BenchmarkShortKey(b *testing.B) {
+	ids := []string{"42", "1024", "987654321"}
+	b.Run("bytes.Buffer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var buf bytes.Buffer
+			buf.WriteString("user:")
+			buf.WriteString(ids[i%len(ids)])
+			benchkit.SinkInt += len(buf.Bytes())
+		}
+	})
+	b.Run("smallbytes.Buffer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var buf smallbytes.Buffer
+			buf.WriteString("user:")
+			buf.WriteString(ids[i%len(ids)])
+			benchkit.SinkInt += len(buf.Bytes())
+		}
+	})
+}
+
+func ExampleBuffer() {
+	var b smallbytes.Buffer
+	fmt.Fprintf(&b, "order:%d", 42)
+	fmt.Println(b.String(), b.Spilled())
+	// Output: order:42 false
+}
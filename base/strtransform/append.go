@@ -0,0 +1,65 @@
+package strtransform
+
+import "slices"
+
+// asciiOptions 是 AppendApply 的 ASCII 快速路径支持的选项
+const asciiOptions = UPPER | LOWER | REV | TRIM
+
+// AppendApply 将 Apply(string(src), opts) 的结果追加到 dst 并返回新的切片
+//
+// 当 opts 只包含 UPPER、LOWER、REV、TRIM 且 src 全部为 ASCII 时，
+// 转换在一次遍历中逐字节完成，dst 容量足够时不分配内存；其他情况回退到 Apply。
+func AppendApply(dst, src []byte, opts Options) []byte {
+	if opts.Bits()&^asciiOptions == 0 {
+		if out, ok := appendASCII(dst, src, opts); ok {
+			return out
+		}
+	}
+	return append(dst, Apply(string(src), opts)...)
+}
+
+// appendASCII 是 AppendApply 的快速路径，遇到非 ASCII 字节时返回 false 且不修改 dst 的内容
+func appendASCII(dst, src []byte, opts Options) ([]byte, bool) {
+	if opts.Has(TRIM) {
+		for len(src) > 0 && isASCIISpace(src[0]) {
+			src = src[1:]
+		}
+		for len(src) > 0 && isASCIISpace(src[len(src)-1]) {
+			src = src[:len(src)-1]
+		}
+	}
+
+	// 与 Apply 一致：先 UPPER 后 LOWER，两者同时设置时结果为小写
+	// 需要转换的字节范围 [lo, hi]，ASCII 字母的大小写只差 0x20 这一位
+	var lo, hi byte = 1, 0
+	switch {
+	case opts.Has(LOWER):
+		lo, hi = 'A', 'Z'
+	case opts.Has(UPPER):
+		lo, hi = 'a', 'z'
+	}
+
+	start, n := len(dst), len(src)
+	dst = slices.Grow(dst, n)[:start+n]
+	out := dst[start:]
+	rev := opts.Has(REV)
+	for i, c := range src {
+		if c >= 0x80 {
+			return dst[:start], false
+		}
+		if lo <= c && c <= hi {
+			c ^= 0x20
+		}
+		if rev {
+			out[n-1-i] = c
+		} else {
+			out[i] = c
+		}
+	}
+	return dst, true
+}
+
+// isASCIISpace 与 unicode.IsSpace 在 ASCII 范围内的判断一致
+func isASCIISpace(c byte) bool {
+	return c == ' ' || '\t' <= c && c <= '\r'
+}
@@ -0,0 +1,82 @@
+package strtransform_test
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// TestAppendApply 测试 AppendApply 与 Apply 的结果一致，覆盖快速路径与回退路径
+func TestAppendApply(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	alphabet := []string{"a", "Z", " ", "\t", "0", "_", "é", "世", " "}
+	all := []strtransform.Option{
+		strtransform.UPPER, strtransform.LOWER, strtransform.REV, strtransform.TRIM, strtransform.CAP, strtransform.SNAKE,
+	}
+	for range 2000 {
+		var sb strings.Builder
+		for range r.Intn(12) {
+			sb.WriteString(alphabet[r.Intn(len(alphabet))])
+		}
+		src := sb.String()
+		var opts strtransform.Options
+		for _, o := range all {
+			if r.Intn(3) == 0 {
+				opts.Set(o)
+			}
+		}
+
+		prefix := []byte("prefix:")
+		got := strtransform.AppendApply(bytes.Clone(prefix), []byte(src), opts)
+		want := string(prefix) + strtransform.Apply(src, opts)
+		if string(got) != want {
+			t.Fatalf("AppendApply(%q, %v) = %q，预期 %q", src, opts, got, want)
+		}
+	}
+}
+
+// TestAppendApplyAllocs 测试 ASCII 快速路径在 dst 容量足够时不分配内存
+func TestAppendApplyAllocs(t *testing.T) {
+	src := []byte("  Hello, Efficient Go!  ")
+	dst := make([]byte, 0, 64)
+	opts := strtransform.NewOptions(strtransform.UPPER, strtransform.REV, strtransform.TRIM)
+	if dst = strtransform.AppendApply(dst, src, opts); string(dst) != "!OG TNEICIFFE ,OLLEH" {
+		t.Errorf("AppendApply = %q", dst)
+	}
+	benchkit.AssertAllocs(t, 0, func() {
+		dst = strtransform.AppendApply(dst[:0], src, opts)
+	})
+}
+
+var sinkBytes []byte
+
+// BenchmarkAppendApply 对比 AppendApply 与 strings.ToUpper、Apply 的开销
+func BenchmarkAppendApply(b *testing.B) {
+	src := []byte(strings.Repeat("The quick brown fox jumps over the lazy dog. ", 4))
+	s := string(src)
+	opts := strtransform.NewOptions(strtransform.UPPER)
+	b.Run("AppendApply", func(b *testing.B) {
+		b.ReportAllocs()
+		dst := make([]byte, 0, len(src))
+		for i := 0; i < b.N; i++ {
+			dst = strtransform.AppendApply(dst[:0], src, opts)
+		}
+		sinkBytes = dst
+	})
+	b.Run("strings.ToUpper", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkString = strings.ToUpper(s)
+		}
+	})
+	b.Run("Apply", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkString = strtransform.Apply(s, opts)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package strtransform
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// batchChunk 是 ApplyAll 中每个 worker 一次领取的元素个数，足够大以摊薄同步开销
+const batchChunk = 256
+
+// ApplyAll 对 ss 中的每个字符串执行 Apply，返回与 ss 一一对应、顺序相同的结果
+//
+// parallelism 为使用的 goroutine 数，不大于 0 时取 GOMAXPROCS；
+// 元素较少时直接在当前 goroutine 中执行。各 worker 按块领取下标并直接写入结果的对应位置，无需额外排序。
+func ApplyAll(ss []string, opts Options, parallelism int) []string {
+	out := make([]string, len(ss))
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	chunks := (len(ss) + batchChunk - 1) / batchChunk
+	parallelism = min(parallelism, chunks)
+	if parallelism <= 1 {
+		for i, s := range ss {
+			out[i] = Apply(s, opts)
+		}
+		return out
+	}
+
+	var next atomic.Int64 // 下一个待领取的块序号
+	var wg sync.WaitGroup
+	wg.Add(parallelism)
+	for range parallelism {
+		go func() {
+			defer wg.Done()
+			for {
+				start := int(next.Add(1)-1) * batchChunk
+				if start >= len(ss) {
+					return
+				}
+				end := min(start+batchChunk, len(ss))
+				for i := start; i < end; i++ {
+					out[i] = Apply(ss[i], opts)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,52 @@
+package strtransform_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// rows 返回 n 个互不相同的输入字符串
+func rows(n int) []string {
+	ss := make([]string, n)
+	for i := range ss {
+		ss[i] = "  Row Number " + strconv.Itoa(i) + " fooBar "
+	}
+	return ss
+}
+
+// TestApplyAll 测试并行批量转换的结果与逐个 Apply 一致且顺序不变
+func TestApplyAll(t *testing.T) {
+	opts := strtransform.NewOptions(strtransform.TRIM, strtransform.SNAKE, strtransform.UPPER)
+	for _, n := range []int{0, 1, 255, 256, 1000, 5000} {
+		ss := rows(n)
+		for _, p := range []int{-1, 0, 1, 3, 64} {
+			t.Run(fmt.Sprintf("n=%d/p=%d", n, p), func(t *testing.T) {
+				out := strtransform.ApplyAll(ss, opts, p)
+				if len(out) != n {
+					t.Fatalf("结果长度为 %d，预期 %d", len(out), n)
+				}
+				for i, s := range ss {
+					if want := strtransform.Apply(s, opts); out[i] != want {
+						t.Fatalf("out[%d] = %q，预期 %q", i, out[i], want)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkApplyAll 对比不同并行度下的批量转换
+func BenchmarkApplyAll(b *testing.B) {
+	ss := rows(100000)
+	opts := strtransform.NewOptions(strtransform.TRIM, strtransform.SLUG)
+	for _, p := range []int{1, 4, 0} {
+		b.Run(fmt.Sprintf("parallelism=%d", p), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				strtransform.ApplyAll(ss, opts, p)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package strtransform
+
+import (
+	"iter"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 字符类别，用于切分单词
+const (
+	classSep = iota
+	classLower
+	classUpper
+	classDigit
+)
+
+func classOf(r rune) int {
+	switch {
+	case r < utf8.RuneSelf:
+		switch {
+		case 'a' <= r && r <= 'z':
+			return classLower
+		case 'A' <= r && r <= 'Z':
+			return classUpper
+		case '0' <= r && r <= '9':
+			return classDigit
+		}
+		return classSep
+	case unicode.IsUpper(r):
+		return classUpper
+	case unicode.IsLetter(r):
+		return classLower
+	case unicode.IsDigit(r):
+		return classDigit
+	}
+	return classSep
+}
+
+// words 将 s 切分为单词，返回的单词是 s 的子串，不分配内存
+// 分隔符（空白、下划线、连字符及其他标点）结束一个单词；小写字母或数字后出现大写字母时开始新单词（fooBar）；
+// 连续大写字母后跟小写字母时，最后一个大写字母属于下一个单词（HTTPServer → HTTP、Server）
+func words(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		start := -1 // 当前单词的起始下标，-1 表示不在单词中
+		prev := classSep
+		for i, r := range s {
+			c := classOf(r)
+			switch {
+			case c == classSep:
+				if start >= 0 && !yield(s[start:i]) {
+					return
+				}
+				start = -1
+			case start < 0:
+				start = i
+			case c == classUpper && (prev == classLower || prev == classDigit):
+				if !yield(s[start:i]) {
+					return
+				}
+				start = i
+			case c == classLower && prev == classUpper && i-start > 1:
+				// 回退到上一个大写字母，它是新单词的首字母
+				_, size := utf8.DecodeLastRuneInString(s[:i])
+				if i-size > start {
+					if !yield(s[start : i-size]) {
+						return
+					}
+					start = i - size
+				}
+			}
+			prev = c
+		}
+		if start >= 0 {
+			yield(s[start:])
+		}
+	}
+}
+
+// delimit 将 s 的各单词转换为小写并以 sep 连接，用于 snake_case 与 kebab-case
+func delimit(s string, sep byte) string {
+	var sb strings.Builder
+	sb.Grow(len(s) + len(s)/4)
+	for w := range words(s) {
+		if sb.Len() > 0 {
+			sb.WriteByte(sep)
+		}
+		writeLower(&sb, w)
+	}
+	return sb.String()
+}
+
+// camel 将 s 转换为 lowerCamelCase
+func camel(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for w := range words(s) {
+		if sb.Len() == 0 {
+			writeLower(&sb, w)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(w)
+		sb.WriteRune(unicode.ToUpper(r))
+		writeLower(&sb, w[size:])
+	}
+	return sb.String()
+}
+
+// writeLower 将 w 的小写形式写入 sb，ASCII 字符不经过 unicode 表查询
+func writeLower(sb *strings.Builder, w string) {
+	for _, r := range w {
+		if 'A' <= r && r <= 'Z' {
+			sb.WriteByte(byte(r) + 'a' - 'A')
+		} else if r < utf8.RuneSelf {
+			sb.WriteByte(byte(r))
+		} else {
+			sb.WriteRune(unicode.ToLower(r))
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package strtransform_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// TestCaseStyles 测试 TRIM 与各命名风格转换
+func TestCaseStyles(t *testing.T) {
+	testCases := []struct {
+		input string
+		snake string
+		kebab string
+		camel string
+	}{
+		{"fooBar", "foo_bar", "foo-bar", "fooBar"},
+		{"FooBar", "foo_bar", "foo-bar", "fooBar"},
+		{"HTTPServer", "http_server", "http-server", "httpServer"},
+		{"parseHTTPRequest", "parse_http_request", "parse-http-request", "parseHttpRequest"},
+		{"user_id", "user_id", "user-id", "userId"},
+		{"  hello   world--again ", "hello_world_again", "hello-world-again", "helloWorldAgain"},
+		{"v2Api", "v2_api", "v2-api", "v2Api"},
+		{"ID", "id", "id", "id"},
+		{"ÉcoleNormale", "école_normale", "école-normale", "écoleNormale"},
+		{"", "", "", ""},
+		{"__", "", "", ""},
+	}
+	for _, tc := range testCases {
+		expected := map[strtransform.Option]string{
+			strtransform.SNAKE: tc.snake,
+			strtransform.KEBAB: tc.kebab,
+			strtransform.CAMEL: tc.camel,
+		}
+		for opt, want := range expected {
+			opts := strtransform.NewOptions(opt)
+			if got := strtransform.Apply(tc.input, opts); got != want {
+				t.Errorf("Apply(%q, %v) = %q，预期 %q", tc.input, opts, got, want)
+			}
+		}
+	}
+}
+
+// TestCaseStyleCombinations 测试命名风格与其他选项的组合
+func TestCaseStyleCombinations(t *testing.T) {
+	testCases := []struct {
+		input    string
+		opts     strtransform.Options
+		expected string
+	}{
+		{"  padded  ", strtransform.NewOptions(strtransform.TRIM), "padded"},
+		{"maxRetryCount", strtransform.NewOptions(strtransform.SNAKE, strtransform.UPPER), "MAX_RETRY_COUNT"},
+		{"max_retry_count", strtransform.NewOptions(strtransform.SNAKE, strtransform.CAMEL), "maxRetryCount"},
+		{" Foo Bar ", strtransform.NewOptions(strtransform.TRIM, strtransform.KEBAB, strtransform.REV), "rab-oof"},
+	}
+	for _, tc := range testCases {
+		if got := strtransform.Apply(tc.input, tc.opts); got != tc.expected {
+			t.Errorf("Apply(%q, %v) = %q，预期 %q", tc.input, tc.opts, got, tc.expected)
+		}
+	}
+}
+
+var sinkString string
+
+// BenchmarkCaseStyles 测量各命名风格转换的开销，每次转换应只有结果字符串一次分配
+func BenchmarkCaseStyles(b *testing.B) {
+	const input = "parseHTTPRequestHeaderValue"
+	for _, opt := range []strtransform.Option{strtransform.SNAKE, strtransform.KEBAB, strtransform.CAMEL} {
+		opts := strtransform.NewOptions(opt)
+		b.Run(opts.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sinkString = strtransform.Apply(input, opts)
+			}
+		})
+	}
+}
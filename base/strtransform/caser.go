@@ -0,0 +1,57 @@
+package strtransform
+
+import (
+	"sync"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// 大小写转换的种类
+const (
+	kindUpper = iota
+	kindLower
+	kindTitle
+)
+
+// caserKey 标识一种语言下的一种大小写转换
+type caserKey struct {
+	tag  language.Tag
+	kind int
+}
+
+// casers 缓存 caserKey 到 *sync.Pool 的映射
+// cases.Caser 带有内部状态，不能被多个 goroutine 共享，因此每种转换缓存一个对象池而不是单个 Caser
+var casers sync.Map
+
+// caseString 使用缓存的 Caser 对 s 执行 tag 语言下的 kind 转换
+func caseString(s string, tag language.Tag, kind int) string {
+	key := caserKey{tag, kind}
+	v, ok := casers.Load(key)
+	if !ok {
+		v, _ = casers.LoadOrStore(key, &sync.Pool{New: func() any {
+			var c cases.Caser
+			switch kind {
+			case kindUpper:
+				c = cases.Upper(tag)
+			case kindLower:
+				c = cases.Lower(tag)
+			default:
+				c = cases.Title(tag)
+			}
+			return &c
+		}})
+	}
+	pool := v.(*sync.Pool)
+	c := pool.Get().(*cases.Caser)
+	s = c.String(s) // String 会先调用 Reset
+	pool.Put(c)
+	return s
+}
+
+// caserFunc 返回执行 kind 转换的函数，用于 optionSteps
+func caserFunc(kind int) func(string, language.Tag) string {
+	return func(s string, tag language.Tag) string {
+		return caseString(s, tag, kind)
+	}
+}
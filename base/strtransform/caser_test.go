@@ -0,0 +1,83 @@
+package strtransform_test
+
+import (
+	"sync"
+	"testing"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// TestApplyIn 测试按语言规则的大小写转换
+func TestApplyIn(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		opts     strtransform.Options
+		tag      language.Tag
+		expected string
+	}{
+		{"土耳其语大写", "istanbul", strtransform.NewOptions(strtransform.UPPER), language.Turkish, "İSTANBUL"},
+		{"土耳其语小写", "ISPARTA", strtransform.NewOptions(strtransform.LOWER), language.Turkish, "ısparta"},
+		{"土耳其语首字母大写", "iyi günler", strtransform.NewOptions(strtransform.CAP), language.Turkish, "İyi Günler"},
+		{"荷兰语 IJ 双字母", "ijsland", strtransform.NewOptions(strtransform.CAP), language.Dutch, "IJsland"},
+		{"英语与 Apply 一致", "HELLO PEOPLE!", strtransform.NewOptions(strtransform.LOWER, strtransform.REV, strtransform.CAP), language.English, "!Elpoep Olleh"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := strtransform.ApplyIn(tc.input, tc.opts, tc.tag); got != tc.expected {
+				t.Errorf("ApplyIn(%q, %v, %v) = %q，预期 %q", tc.input, tc.opts, tc.tag, got, tc.expected)
+			}
+			if got := strtransform.FromOptionsIn(tc.opts, tc.tag).Apply(tc.input); got != tc.expected {
+				t.Errorf("FromOptionsIn(%v, %v).Apply(%q) = %q，预期 %q", tc.opts, tc.tag, tc.input, got, tc.expected)
+			}
+		})
+	}
+
+	// 不指定语言时沿用 strings.ToUpper 的规则
+	if got := strtransform.Apply("istanbul", strtransform.NewOptions(strtransform.UPPER)); got != "ISTANBUL" {
+		t.Errorf("Apply = %q，预期 ISTANBUL", got)
+	}
+	p := strtransform.NewPipeline(strtransform.LowerIn(language.Turkish), strtransform.UpperIn(language.Turkish), strtransform.TitleIn(language.Turkish))
+	if got := p.Apply("Iİ"); got != "Ii" {
+		t.Errorf("Pipeline.Apply = %q，预期 Ii", got)
+	}
+}
+
+// TestApplyInConcurrent 测试缓存的 Caser 可以被并发使用
+func TestApplyInConcurrent(t *testing.T) {
+	opts := strtransform.NewOptions(strtransform.CAP)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				if got := strtransform.ApplyIn("iyi günler", opts, language.Turkish); got != "İyi Günler" {
+					t.Errorf("ApplyIn = %q", got)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// BenchmarkTitle 对比缓存 Caser 与每次新建 Caser 的开销
+func BenchmarkTitle(b *testing.B) {
+	const input = "the quick brown fox"
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkString = strtransform.Title(input)
+		}
+	})
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkString = cases.Title(language.English).String(input)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package strtransform
+
+import (
+	"iter"
+	"strings"
+	"unicode"
+)
+
+// 字素簇切分所需的字符属性，是 UAX #29 扩展字素簇规则的简化子集
+const (
+	gbOther = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend      // 组合记号、变体选择符、肤色修饰符
+	gbZWJ         // 零宽连接符 U+200D
+	gbSpacingMark // 印度系文字的元音附标等
+	gbRI          // 区域指示符，两两组成国旗
+	gbL           // 谚文初声
+	gbV           // 谚文中声
+	gbT           // 谚文终声
+	gbLV          // 无终声的谚文音节
+	gbLVT         // 有终声的谚文音节
+)
+
+// viramas 是 GB9c 适用文字（天城文、孟加拉文、古吉拉特文、奥里亚文、泰卢固文、马拉雅拉姆文）的半音符，
+// 其后同一文字的辅音与前一个辅音组成连字
+var viramas = map[rune]bool{
+	0x094D: true, 0x09CD: true, 0x0ACD: true, 0x0B4D: true, 0x0C4D: true, 0x0D4D: true,
+}
+
+func graphemeProp(r rune) int {
+	switch {
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r == 0x200D:
+		return gbZWJ
+	case r < 0x20 || r == 0x7F:
+		return gbControl
+	case r < 0x300:
+		return gbOther // 快速路径：ASCII 与拉丁字母没有其他属性
+	case 0x1F1E6 <= r && r <= 0x1F1FF:
+		return gbRI
+	case 0x1F3FB <= r && r <= 0x1F3FF, 0xFE00 <= r && r <= 0xFE0F, 0xE0020 <= r && r <= 0xE007F, r == 0x200C:
+		return gbExtend
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return gbExtend
+	case unicode.Is(unicode.Mc, r):
+		return gbSpacingMark
+	case 0x1100 <= r && r <= 0x115F:
+		return gbL
+	case 0x1160 <= r && r <= 0x11A7:
+		return gbV
+	case 0x11A8 <= r && r <= 0x11FF:
+		return gbT
+	case 0xAC00 <= r && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case unicode.Is(unicode.Cc, r) || r == 0x2028 || r == 0x2029:
+		return gbControl
+	}
+	return gbOther
+}
+
+// graphemes 将 s 切分为字素簇，即用户感知的单个"字符"，返回的字素簇是 s 的子串
+//
+// 实现覆盖常用规则：CR LF、组合记号与 ZWJ 表情序列、肤色修饰符、国旗、印度系文字的元音附标与半音符连字、谚文字母组合；
+// 不处理 Prepend 等罕见属性，ZWJ 之后的字符总是并入当前字素簇。
+func graphemes(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		start := 0
+		var prevRune rune
+		prev := -1
+		riCount := 0 // 当前字素簇末尾连续的区域指示符个数
+		for i, r := range s {
+			p := graphemeProp(r)
+			if prev >= 0 && isBoundary(prev, p, prevRune, r, riCount) {
+				if !yield(s[start:i]) {
+					return
+				}
+				start = i
+				riCount = 0
+			}
+			if p == gbRI {
+				riCount++
+			} else {
+				riCount = 0
+			}
+			prev, prevRune = p, r
+		}
+		if start < len(s) {
+			yield(s[start:])
+		}
+	}
+}
+
+// isBoundary 报告属性为 prev 与 cur 的两个相邻字符之间是否为字素簇边界
+func isBoundary(prev, cur int, prevRune, curRune rune, riCount int) bool {
+	switch {
+	case prev == gbCR && cur == gbLF: // GB3
+		return false
+	case prev == gbCR || prev == gbLF || prev == gbControl, cur == gbCR || cur == gbLF || cur == gbControl: // GB4、GB5
+		return true
+	case prev == gbL && (cur == gbL || cur == gbV || cur == gbLV || cur == gbLVT): // GB6
+		return false
+	case (prev == gbLV || prev == gbV) && (cur == gbV || cur == gbT): // GB7
+		return false
+	case (prev == gbLVT || prev == gbT) && cur == gbT: // GB8
+		return false
+	case cur == gbExtend || cur == gbZWJ || cur == gbSpacingMark: // GB9、GB9a
+		return false
+	case prev == gbZWJ: // GB11（简化）
+		return false
+	case viramas[prevRune] && curRune>>7 == prevRune>>7 && unicode.IsLetter(curRune): // GB9c（简化）：上述文字各占一个 128 码位的区块
+		return false
+	case prev == gbRI && cur == gbRI: // GB12、GB13：区域指示符两两成对
+		return riCount%2 == 0
+	}
+	return true
+}
+
+// reverseGraphemes 按字素簇反转字符串，组合字符、表情序列与国旗保持完整
+func reverseGraphemes(s string) string {
+	// 先按字素簇倒序收集边界，再一次性拷贝
+	var ends []int
+	for g := range graphemes(s) {
+		ends = append(ends, len(g))
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	end := len(s)
+	for i := len(ends) - 1; i >= 0; i-- {
+		sb.WriteString(s[end-ends[i] : end])
+		end -= ends[i]
+	}
+	return sb.String()
+}
@@ -0,0 +1,72 @@
+package strtransform_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// TestGraphemeReverse 测试按字素簇反转
+func TestGraphemeReverse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ASCII", "hello", "olleh"},
+		{"组合重音符", "café!", "!éfac"},
+		{"ZWJ 家庭表情", "a👨‍👩‍👧b", "b👨‍👩‍👧a"},
+		{"肤色修饰符", "👍🏽👎", "👎👍🏽"},
+		{"国旗", "🇨🇳🇺🇸", "🇺🇸🇨🇳"},
+		{"变体选择符", "❤️x", "x❤️"},
+		{"天城文连字", "नमस्ते", "स्तेमन"},
+		{"泰米尔文元音附标", "தமிழ்", "ழ்மித"},
+		{"谚文字母组合", "한글", "글한"},
+		{"谚文音节", "한글", "글한"},
+		{"CRLF", "a\r\nb", "b\r\na"},
+		{"空字符串", "", ""},
+	}
+	opts := strtransform.NewOptions(strtransform.REV, strtransform.GRAPHEME)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := strtransform.Apply(tc.input, opts); got != tc.expected {
+				t.Errorf("Apply(%q) = %q，预期 %q", tc.input, got, tc.expected)
+			}
+			// 反转两次应还原
+			if got := strtransform.Apply(strtransform.Apply(tc.input, opts), opts); got != tc.input {
+				t.Errorf("两次反转得到 %q，预期 %q", got, tc.input)
+			}
+		})
+	}
+}
+
+// TestGraphemeEdgeCases 说明不设置 GRAPHEME 时按 rune 反转会拆散组合字符，以及其他边界情况
+func TestGraphemeEdgeCases(t *testing.T) {
+	got := strtransform.Apply("é", strtransform.NewOptions(strtransform.REV))
+	if got != "́e" {
+		t.Errorf("按 rune 反转得到 %q，预期组合记号被移到前面", got)
+	}
+	// 落单的区域指示符自成一簇，因此奇数个时两次反转不能还原
+	if got := strtransform.Apply("🇨🇳🇯", strtransform.NewOptions(strtransform.REV, strtransform.GRAPHEME)); got != "🇯🇨🇳" {
+		t.Errorf("按字素簇反转得到 %q，预期 🇯🇨🇳", got)
+	}
+	if got := strtransform.Apply("abc", strtransform.NewOptions(strtransform.GRAPHEME)); got != "abc" {
+		t.Errorf("单独设置 GRAPHEME 不应改变字符串，得到 %q", got)
+	}
+}
+
+// BenchmarkReverse 对比按 rune 与按字素簇反转的开销
+func BenchmarkReverse(b *testing.B) {
+	const input = "Hello, 世界! café 👨‍👩‍👧 🇨🇳 नमस्ते"
+	for _, opts := range []strtransform.Options{
+		strtransform.NewOptions(strtransform.REV),
+		strtransform.NewOptions(strtransform.REV, strtransform.GRAPHEME),
+	} {
+		b.Run(opts.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sinkString = strtransform.Apply(input, opts)
+			}
+		})
+	}
+}
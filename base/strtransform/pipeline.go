@@ -0,0 +1,120 @@
+package strtransform
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Step 是流水线中的单个转换步骤，自定义步骤只需实现同样的函数签名
+type Step func(string) string
+
+// 预定义步骤，与同名选项的行为一致
+var (
+	Upper Step = strings.ToUpper   // UPPER
+	Lower Step = strings.ToLower   // LOWER
+	Trim  Step = strings.TrimSpace // TRIM
+)
+
+// Title 按英语规则将每个单词的首字母大写，其余字母小写（CAP）
+func Title(s string) string {
+	return caseString(s, language.English, kindTitle)
+}
+
+// UpperIn 返回按 tag 的语言规则转换为大写的步骤
+func UpperIn(tag language.Tag) Step {
+	return func(s string) string { return caseString(s, tag, kindUpper) }
+}
+
+// LowerIn 返回按 tag 的语言规则转换为小写的步骤
+func LowerIn(tag language.Tag) Step {
+	return func(s string) string { return caseString(s, tag, kindLower) }
+}
+
+// TitleIn 返回按 tag 的语言规则将单词首字母大写的步骤
+func TitleIn(tag language.Tag) Step {
+	return func(s string) string { return caseString(s, tag, kindTitle) }
+}
+
+// Reverse 按 rune 反转（REV）
+func Reverse(s string) string {
+	return reverse(s)
+}
+
+// ReverseGraphemes 按字素簇反转（REV|GRAPHEME）
+func ReverseGraphemes(s string) string {
+	return reverseGraphemes(s)
+}
+
+// Snake 转换为 snake_case（SNAKE）
+func Snake(s string) string {
+	return delimit(s, '_')
+}
+
+// Kebab 转换为 kebab-case（KEBAB）
+func Kebab(s string) string {
+	return delimit(s, '-')
+}
+
+// Camel 转换为 camelCase（CAMEL）
+func Camel(s string) string {
+	return camel(s)
+}
+
+// Pipeline 按添加顺序依次执行各步骤，用于需要显式控制顺序的场景，
+// 例如 Apply 总是先 LOWER 后 CAP，而 Pipeline 可以先 Title 再 Lower
+//
+// Pipeline 构造完成后可以被多个 goroutine 并发使用。
+type Pipeline struct {
+	steps []Step
+}
+
+// NewPipeline 返回依次执行 steps 的 Pipeline
+func NewPipeline(steps ...Step) *Pipeline {
+	return &Pipeline{steps: append([]Step(nil), steps...)}
+}
+
+// FromOptions 返回与 Apply(s, opts) 行为一致的 Pipeline，可在其基础上继续追加步骤
+func FromOptions(opts Options) *Pipeline {
+	return fromOptions(opts, nil)
+}
+
+// FromOptionsIn 返回与 ApplyIn(s, opts, tag) 行为一致的 Pipeline
+func FromOptionsIn(opts Options, tag language.Tag) *Pipeline {
+	return fromOptions(opts, &tag)
+}
+
+func fromOptions(opts Options, lang *language.Tag) *Pipeline {
+	p := &Pipeline{}
+	for _, st := range optionSteps {
+		if !opts.Has(st.opt) {
+			continue
+		}
+		if lang != nil && st.localized != nil {
+			localized, tag := st.localized, *lang
+			p.steps = append(p.steps, func(s string) string { return localized(s, tag) })
+		} else {
+			p.steps = append(p.steps, st.step(opts))
+		}
+	}
+	return p
+}
+
+// Then 在末尾追加步骤并返回 p，便于链式调用
+func (p *Pipeline) Then(steps ...Step) *Pipeline {
+	p.steps = append(p.steps, steps...)
+	return p
+}
+
+// Len 返回步骤个数
+func (p *Pipeline) Len() int {
+	return len(p.steps)
+}
+
+// Apply 依次执行所有步骤
+func (p *Pipeline) Apply(s string) string {
+	for _, step := range p.steps {
+		s = step(s)
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package strtransform_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// TestPipelineOrder 测试步骤按添加顺序执行
+func TestPipelineOrder(t *testing.T) {
+	const input = "hello WORLD"
+	testCases := []struct {
+		name     string
+		p        *strtransform.Pipeline
+		expected string
+	}{
+		{"先 Lower 后 Title", strtransform.NewPipeline(strtransform.Lower, strtransform.Title), "Hello World"},
+		{"先 Title 后 Lower", strtransform.NewPipeline(strtransform.Title, strtransform.Lower), "hello world"},
+		{"先 Reverse 后 Upper", strtransform.NewPipeline(strtransform.Reverse, strtransform.Upper), "DLROW OLLEH"},
+		{"空流水线", strtransform.NewPipeline(), input},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.p.Apply(input); got != tc.expected {
+				t.Errorf("Apply(%q) = %q，预期 %q", input, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestPipelineCustomStep 测试插入自定义步骤
+func TestPipelineCustomStep(t *testing.T) {
+	redact := func(s string) string { return strings.ReplaceAll(s, "secret", "redacted") }
+	p := strtransform.NewPipeline(strtransform.Trim, redact).Then(strtransform.Snake, strtransform.Upper)
+	if got := p.Apply("  mySecret secretValue "); got != "MY_SECRET_REDACTED_VALUE" {
+		t.Errorf("Apply = %q", got)
+	}
+	if p.Len() != 4 {
+		t.Errorf("Len() = %d，预期 4", p.Len())
+	}
+}
+
+// TestFromOptions 测试由 Options 构造的流水线与 Apply 行为一致
+func TestFromOptions(t *testing.T) {
+	inputs := []string{"  HELLO people! ", "parseHTTPRequest", "é 👨‍👩‍👧"}
+	for _, opts := range []strtransform.Options{
+		strtransform.NewOptions(strtransform.LOWER, strtransform.REV, strtransform.CAP),
+		strtransform.NewOptions(strtransform.TRIM, strtransform.SNAKE, strtransform.UPPER),
+		strtransform.NewOptions(strtransform.REV, strtransform.GRAPHEME),
+		strtransform.NewOptions(),
+	} {
+		p := strtransform.FromOptions(opts)
+		for _, in := range inputs {
+			if got, want := p.Apply(in), strtransform.Apply(in, opts); got != want {
+				t.Errorf("FromOptions(%v).Apply(%q) = %q，Apply 为 %q", opts, in, got, want)
+			}
+		}
+	}
+
+	p := strtransform.FromOptions(strtransform.NewOptions(strtransform.LOWER)).Then(strtransform.Kebab)
+	if got := p.Apply("Foo Bar"); got != "foo-bar" {
+		t.Errorf("追加步骤后 Apply = %q", got)
+	}
+}
@@ -0,0 +1,84 @@
+package strtransform
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// specialLatin 是无法通过 Unicode 分解去掉附加符号得到的拉丁字母的转写
+var specialLatin = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "ae", 'œ': "oe", 'Œ': "oe", 'ø': "o", 'Ø': "o",
+	'đ': "d", 'Đ': "d", 'ð': "d", 'Ð': "d", 'þ': "th", 'Þ': "th", 'ł': "l", 'Ł': "l", 'ı': "i",
+}
+
+// Slug 生成 URL 安全的 slug（SLUG）：去掉字母的附加符号（é → e、ß → ss）并转换为小写，
+// 其余字符视为分隔符，连续的分隔符合并为一个 '-'，首尾不留 '-'；无法转写的字符（如汉字）被丢弃
+//
+// 转换在一次遍历中完成，结果只分配一次。
+func Slug(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	dash := false // 上一个输出之后是否遇到过分隔符
+	write := func(c byte) {
+		if dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+		}
+		dash = false
+		sb.WriteByte(c)
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+				write(c)
+			case 'A' <= c && c <= 'Z':
+				write(c + 'a' - 'A')
+			default:
+				dash = true
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if t, ok := specialLatin[r]; ok {
+			for j := 0; j < len(t); j++ {
+				write(t[j])
+			}
+		} else if unicode.Is(unicode.Mn, r) {
+			// 已分解（NFD）输入中的组合附加符号直接去掉，不视为分隔符，"re\u0301sume\u0301" → "resume"
+		} else if !writeBase(s[i:i+size], write) {
+			dash = true
+		}
+		i += size
+	}
+	return sb.String()
+}
+
+// writeBase 依次写出字符 r（UTF-8 编码）分解后的全部 ASCII 字母与数字的小写形式，
+// 例如 "É" → "e"、"ﬁ" → "fi"；分解结果中不含 ASCII 字母或数字时返回 false
+func writeBase(r string, write func(byte)) bool {
+	d := norm.NFD.PropertiesString(r).Decomposition()
+	if len(d) == 0 {
+		// 没有规范分解形式，但兼容分解可能得到 ASCII（如全角字母 Ａ、连字 ﬁ）
+		d = norm.NFKD.PropertiesString(r).Decomposition()
+	}
+	ok := false
+	for _, c := range d {
+		switch {
+		case 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+			write(c)
+		case 'A' <= c && c <= 'Z':
+			write(c + 'a' - 'A')
+		default:
+			continue
+		}
+		ok = true
+	}
+	return ok
+}
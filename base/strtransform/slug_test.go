@@ -0,0 +1,48 @@
+package strtransform_test
+
+import (
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// TestSlug 测试 slug 生成与转写
+func TestSlug(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"  Crème Brûlée -- Recipe #1 ", "creme-brulee-recipe-1"},
+		{"Straße in Köln", "strasse-in-koln"},
+		{"Ærøskøbing Łódź", "aeroskobing-lodz"},
+		{"Ｆｕｌｌｗｉｄｔｈ　１２３", "fullwidth-123"},
+		{"中文标题 Go 语言", "go"},
+		{"re\u0301sume\u0301", "resume"},
+		{"Cre\u0300me Bru\u0302le\u0301e", "creme-brulee"},
+		{"\ufb01le \ufb02ow", "file-flow"},
+		{"Ⅻ ㎏", "xii-kg"},
+		{"already-a-slug", "already-a-slug"},
+		{"---", ""},
+		{"", ""},
+	}
+	opts := strtransform.NewOptions(strtransform.SLUG)
+	for _, tc := range testCases {
+		if got := strtransform.Apply(tc.input, opts); got != tc.expected {
+			t.Errorf("Apply(%q, SLUG) = %q，预期 %q", tc.input, got, tc.expected)
+		}
+		// slug 是幂等的
+		if got := strtransform.Slug(tc.expected); got != tc.expected {
+			t.Errorf("Slug(%q) = %q，应保持不变", tc.expected, got)
+		}
+	}
+}
+
+// BenchmarkSlug 测量 slug 生成的开销
+func BenchmarkSlug(b *testing.B) {
+	const input = "  Crème Brûlée -- The Ultimate Recipe for Straße Cafés #1 "
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkString = strtransform.Slug(input)
+	}
+}
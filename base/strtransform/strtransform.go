@@ -0,0 +1,118 @@
+// Package strtransform 根据位掩码选项对字符串执行大小写转换、反转等处理，
+// 多个选项可以用 | 组合后一次性传入 Apply。
+package strtransform
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/moweilong/efficient-go/base/bit/flags"
+)
+
+// Option 是单个转换选项，每个选项占用一个独立的位
+type Option uint32
+
+const (
+	UPPER    Option = 1 << iota // 转换为大写
+	LOWER                       // 转换为小写
+	CAP                         // 单词首字母大写
+	REV                         // 按字符反转
+	TRIM                        // 去掉首尾空白
+	SNAKE                       // 转换为 snake_case
+	CAMEL                       // 转换为 camelCase
+	KEBAB                       // 转换为 kebab-case
+	GRAPHEME                    // 与 REV 同时设置时按字素簇反转，保持组合字符与表情序列完整
+	SLUG                        // 转换为 URL 安全的 slug
+)
+
+// Options 是转换选项的组合
+type Options = flags.Flags[Option]
+
+func init() {
+	// 注册名称后 Options 的 String、文本与 JSON 序列化均使用 "UPPER|REV" 形式
+	r := flags.RegistryFor[Option]()
+	r.MustRegister("UPPER", UPPER)
+	r.MustRegister("LOWER", LOWER)
+	r.MustRegister("CAP", CAP)
+	r.MustRegister("REV", REV)
+	r.MustRegister("TRIM", TRIM)
+	r.MustRegister("SNAKE", SNAKE)
+	r.MustRegister("CAMEL", CAMEL)
+	r.MustRegister("KEBAB", KEBAB)
+	r.MustRegister("GRAPHEME", GRAPHEME)
+	r.MustRegister("SLUG", SLUG)
+}
+
+// NewOptions 返回设置了 opts 中所有选项的 Options
+func NewOptions(opts ...Option) Options {
+	return flags.New(opts...)
+}
+
+// ParseOptions 解析 "LOWER|REV" 形式的选项字符串
+func ParseOptions(s string) (Options, error) {
+	var opts Options
+	err := opts.UnmarshalText([]byte(s))
+	return opts, err
+}
+
+// Apply 按 TRIM、SNAKE、KEBAB、CAMEL、SLUG、UPPER、LOWER、REV、CAP 的固定顺序对 s 执行 opts 中设置的转换
+// 同时设置多种命名风格时后执行的生效；SNAKE|UPPER 可得到 SCREAMING_SNAKE_CASE
+// 需要其他顺序或自定义步骤时使用 Pipeline，需要特定语言的大小写规则时使用 ApplyIn
+func Apply(s string, opts Options) string {
+	return apply(s, opts, nil)
+}
+
+// ApplyIn 与 Apply 相同，但 UPPER、LOWER、CAP 按 tag 的语言规则转换，
+// 例如土耳其语中 i 的大写为 İ、I 的小写为 ı
+func ApplyIn(s string, opts Options, tag language.Tag) string {
+	return apply(s, opts, &tag)
+}
+
+// apply 执行 opts 中的转换，lang 不为 nil 时大小写转换使用对应语言的规则
+func apply(s string, opts Options, lang *language.Tag) string {
+	for _, st := range optionSteps {
+		if !opts.Has(st.opt) {
+			continue
+		}
+		if lang != nil && st.localized != nil {
+			s = st.localized(s, *lang)
+		} else {
+			s = st.step(opts)(s)
+		}
+	}
+	return s
+}
+
+// optionSteps 按 Apply 的执行顺序列出各选项对应的步骤，localized 为指定语言时使用的实现
+var optionSteps = []struct {
+	opt       Option
+	step      func(Options) Step
+	localized func(string, language.Tag) string
+}{
+	{TRIM, fixed(Trim), nil},
+	{SNAKE, fixed(Snake), nil},
+	{KEBAB, fixed(Kebab), nil},
+	{CAMEL, fixed(Camel), nil},
+	{SLUG, fixed(Slug), nil},
+	{UPPER, fixed(Upper), caserFunc(kindUpper)},
+	{LOWER, fixed(Lower), caserFunc(kindLower)},
+	{REV, func(opts Options) Step {
+		if opts.Has(GRAPHEME) {
+			return ReverseGraphemes
+		}
+		return Reverse
+	}, nil},
+	{CAP, fixed(Title), caserFunc(kindTitle)},
+}
+
+func fixed(step Step) func(Options) Step {
+	return func(Options) Step { return step }
+}
+
+// reverse 按 rune 反转字符串，会拆散组合字符与表情序列，需要保持完整时使用 GRAPHEME
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,73 @@
+package strtransform_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// TestApply 测试位掩码技术在多配置场景中的应用
+// 验证通过|组合配置项、通过&查询配置项的正确性
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		opts     strtransform.Options
+		expected string
+	}{
+		{
+			name:     "LOWER|REV|CAP组合",
+			input:    "HELLO PEOPLE!",
+			opts:     strtransform.NewOptions(strtransform.LOWER, strtransform.REV, strtransform.CAP),
+			expected: "!Elpoep Olleh",
+		},
+		{
+			name:     "UPPER|REV组合",
+			input:    "hello",
+			opts:     strtransform.NewOptions(strtransform.UPPER, strtransform.REV),
+			expected: "OLLEH",
+		},
+		{
+			name:     "多字节字符反转",
+			input:    "你好,go",
+			opts:     strtransform.NewOptions(strtransform.REV),
+			expected: "og,好你",
+		},
+		{
+			name:     "无配置",
+			input:    "test",
+			opts:     strtransform.NewOptions(),
+			expected: "test",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := strtransform.Apply(tc.input, tc.opts); got != tc.expected {
+				t.Errorf("Apply(%q, %v) = %q，预期 %q", tc.input, tc.opts, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestParseOptions 测试选项的解析与序列化
+func TestParseOptions(t *testing.T) {
+	opts, err := strtransform.ParseOptions("REV|UPPER")
+	if err != nil || opts != strtransform.NewOptions(strtransform.UPPER, strtransform.REV) {
+		t.Fatalf("ParseOptions = (%v, %v)", opts, err)
+	}
+	if s := opts.String(); s != "UPPER|REV" {
+		t.Errorf("String() = %q，预期 UPPER|REV", s)
+	}
+	if _, err := strtransform.ParseOptions("UPPER|SHOUT"); err == nil {
+		t.Errorf("未知选项应返回错误")
+	}
+
+	var cfg struct {
+		Opts strtransform.Options `json:"opts"`
+	}
+	if err := json.Unmarshal([]byte(`{"opts":"LOWER|CAP"}`), &cfg); err != nil || !cfg.Opts.Has(strtransform.LOWER|strtransform.CAP) {
+		t.Errorf("Unmarshal = (%v, %v)", cfg.Opts, err)
+	}
+}
@@ -0,0 +1,109 @@
+// Package strtransformtest 提供验证字符串转换不变式的测试工具，
+// 自定义的转换步骤可以复用与 strtransform 相同的随机化性质测试。
+package strtransformtest
+
+import (
+	"iter"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// pieces 是随机字符串的组成片段，覆盖 ASCII、带附加符号的拉丁字母、多字节文字、组合记号与表情序列
+var pieces = []string{
+	"a", "Z", "m", "Q", "0", "7", " ", "  ", "\t", "\n", "_", "-", ".", "!", "/",
+	"é", "Ö", "ß", "ı", "İ", "Ł", "世", "界", "ア", "한", "न", "्", "Ж", "ω",
+	"é", "👍", "👍🏽", "👨‍👩‍👧", "🇨🇳", "❤️",
+	"fooBar", "HTTPServer", "snake_case", "kebab-case", "v2Api",
+}
+
+// RandomString 返回由 pieces 随机拼接而成、长度不超过 16 个片段的字符串
+func RandomString(r *rand.Rand) string {
+	var sb strings.Builder
+	for range r.IntN(17) {
+		sb.WriteString(pieces[r.IntN(len(pieces))])
+	}
+	return sb.String()
+}
+
+// Config 控制性质测试的规模与输入，零值使用默认参数
+type Config struct {
+	N        int                       // 随机输入的个数，默认为 500
+	Seed     uint64                    // 随机数种子，固定种子使失败可以复现
+	Generate func(r *rand.Rand) string // 输入生成器，默认为 RandomString
+}
+
+// inputs 依次产生边界输入与 N 个随机输入
+func (c Config) inputs() iter.Seq[string] {
+	n, gen := c.N, c.Generate
+	if n <= 0 {
+		n = 500
+	}
+	if gen == nil {
+		gen = RandomString
+	}
+	r := rand.New(rand.NewPCG(c.Seed, c.Seed))
+	return func(yield func(string) bool) {
+		// 先覆盖边界输入，再使用随机输入
+		for _, s := range []string{"", " ", "a"} {
+			if !yield(s) {
+				return
+			}
+		}
+		for range n {
+			if !yield(gen(r)) {
+				return
+			}
+		}
+	}
+}
+
+// CheckIdempotentFunc 验证对随机输入 s 都有 f(f(s)) == f(s)，发现反例时报告第一个并停止
+func (c Config) CheckIdempotentFunc(t testing.TB, f strtransform.Step) {
+	t.Helper()
+	for s := range c.inputs() {
+		once := f(s)
+		if twice := f(once); twice != once {
+			t.Errorf("转换不满足幂等性\n输入: %q\n一次: %q\n两次: %q", s, once, twice)
+			return
+		}
+	}
+}
+
+// CheckReversibleFunc 验证对随机输入 s 都有 f(f(s)) == s，即 f 是自身的逆，发现反例时报告第一个并停止
+func (c Config) CheckReversibleFunc(t testing.TB, f strtransform.Step) {
+	t.Helper()
+	for s := range c.inputs() {
+		once := f(s)
+		if twice := f(once); twice != s {
+			t.Errorf("转换不可逆\n输入: %q\n一次: %q\n两次: %q", s, once, twice)
+			return
+		}
+	}
+}
+
+// CheckIdempotent 使用默认参数验证 opts 对应的转换满足幂等性
+func CheckIdempotent(t testing.TB, opts strtransform.Options) {
+	t.Helper()
+	Config{}.CheckIdempotentFunc(t, strtransform.FromOptions(opts).Apply)
+}
+
+// CheckReversible 使用默认参数验证 opts 对应的转换执行两次后还原输入
+func CheckReversible(t testing.TB, opts strtransform.Options) {
+	t.Helper()
+	Config{}.CheckReversibleFunc(t, strtransform.FromOptions(opts).Apply)
+}
+
+// CheckIdempotentFunc 使用默认参数验证自定义转换 f 满足幂等性
+func CheckIdempotentFunc(t testing.TB, f strtransform.Step) {
+	t.Helper()
+	Config{}.CheckIdempotentFunc(t, f)
+}
+
+// CheckReversibleFunc 使用默认参数验证自定义转换 f 执行两次后还原输入
+func CheckReversibleFunc(t testing.TB, f strtransform.Step) {
+	t.Helper()
+	Config{}.CheckReversibleFunc(t, f)
+}
@@ -0,0 +1,86 @@
+package strtransformtest_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+	"github.com/moweilong/efficient-go/base/strtransform/strtransformtest"
+)
+
+// TestIdempotent 测试 strtransform 中应满足幂等性的选项
+func TestIdempotent(t *testing.T) {
+	for _, o := range []strtransform.Option{
+		strtransform.UPPER, strtransform.LOWER, strtransform.TRIM,
+		strtransform.SNAKE, strtransform.KEBAB, strtransform.SLUG,
+	} {
+		opts := strtransform.NewOptions(o)
+		t.Run(opts.String(), func(t *testing.T) {
+			strtransformtest.CheckIdempotent(t, opts)
+		})
+	}
+}
+
+// TestReversible 测试按 rune 反转执行两次后还原
+func TestReversible(t *testing.T) {
+	strtransformtest.CheckReversible(t, strtransform.NewOptions(strtransform.REV))
+	strtransformtest.CheckReversibleFunc(t, strtransform.Reverse)
+}
+
+// recorder 记录失败信息而不使测试失败，用于验证检查函数能发现反例
+type recorder struct {
+	testing.TB
+	msg string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+// TestCheckDetectsViolations 测试检查函数能发现不满足性质的转换
+func TestCheckDetectsViolations(t *testing.T) {
+	testCases := []struct {
+		name  string
+		check func(testing.TB)
+	}{
+		{"追加后缀不幂等", func(tb testing.TB) {
+			strtransformtest.CheckIdempotentFunc(tb, func(s string) string { return s + "!" })
+		}},
+		{"大写不可逆", func(tb testing.TB) {
+			strtransformtest.CheckReversible(tb, strtransform.NewOptions(strtransform.UPPER))
+		}},
+		{"按字素簇反转对落单的组合记号不可逆", func(tb testing.TB) {
+			strtransformtest.CheckReversible(tb, strtransform.NewOptions(strtransform.REV, strtransform.GRAPHEME))
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			tc.check(r)
+			if r.msg == "" {
+				t.Errorf("应发现反例")
+			}
+		})
+	}
+}
+
+// TestConfig 测试自定义输入生成器与个数
+func TestConfig(t *testing.T) {
+	calls := 0
+	cfg := strtransformtest.Config{
+		N:    10,
+		Seed: 42,
+		Generate: func(r *rand.Rand) string {
+			calls++
+			return strings.Repeat("ab", r.IntN(5))
+		},
+	}
+	cfg.CheckIdempotentFunc(t, strings.ToUpper)
+	if calls != 10 {
+		t.Errorf("生成器被调用 %d 次，预期 10", calls)
+	}
+}
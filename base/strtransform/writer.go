@@ -0,0 +1,107 @@
+package strtransform
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// ErrClosed 表示向已关闭的 Writer 写入
+var ErrClosed = errors.New("strtransform: Writer 已关闭")
+
+// Writer 将写入的文本经过转换后写到底层 io.Writer，适合处理无法一次性载入内存的大文件
+//
+// 只设置了 UPPER、LOWER 时按 rune 逐段转换，仅需缓存被分块截断的不完整 rune；
+// 其他选项依赖单词或整行上下文，此时按行转换（以 '\n' 为界，换行符原样保留），
+// 即 REV、TRIM 以及各命名风格作用于每一行，而不是整个流。
+type Writer struct {
+	w       io.Writer
+	opts    Options
+	perRune bool
+	buf     []byte // 尚未转换的数据：不完整的 rune 或不完整的行
+	err     error
+}
+
+// NewWriter 返回将转换结果写入 w 的 Writer，写入完成后必须调用 Close 输出剩余数据
+func NewWriter(w io.Writer, opts Options) *Writer {
+	return &Writer{w: w, opts: opts, perRune: opts.Bits()&^(UPPER|LOWER) == 0}
+}
+
+// Write 实现 io.Writer，返回的字节数表示 p 已被全部接收，转换结果可能延迟到后续 Write 或 Close 时输出
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buf = append(w.buf, p...)
+
+	var n int // buf 中可以立即转换的前缀长度
+	if w.perRune {
+		n = completeRunes(w.buf)
+	} else {
+		n = bytes.LastIndexByte(w.buf, '\n') + 1
+	}
+	if n == 0 {
+		return len(p), nil
+	}
+	if err := w.emit(w.buf[:n]); err != nil {
+		return 0, err
+	}
+	w.buf = append(w.buf[:0], w.buf[n:]...)
+	return len(p), nil
+}
+
+// Close 转换并输出剩余数据，不关闭底层 io.Writer
+func (w *Writer) Close() error {
+	if w.err != nil {
+		if w.err == ErrClosed {
+			return nil
+		}
+		return w.err
+	}
+	if len(w.buf) > 0 {
+		if err := w.emit(w.buf); err != nil {
+			return err
+		}
+		w.buf = nil
+	}
+	w.err = ErrClosed
+	return nil
+}
+
+// emit 转换 p 并写入底层 Writer；行模式下 p 由若干完整的行组成（最后一行可能没有换行符）
+func (w *Writer) emit(p []byte) error {
+	var out []byte
+	if w.perRune {
+		out = []byte(Apply(string(p), w.opts))
+	} else {
+		out = make([]byte, 0, len(p))
+		for len(p) > 0 {
+			line, rest, found := bytes.Cut(p, []byte{'\n'})
+			out = append(out, Apply(string(line), w.opts)...)
+			if found {
+				out = append(out, '\n')
+			}
+			p = rest
+		}
+	}
+	if _, err := w.w.Write(out); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+// completeRunes 返回 p 中不包含末尾不完整 rune 的前缀长度
+func completeRunes(p []byte) int {
+	// UTF-8 编码最长 4 字节，只需检查末尾至多 3 个字节
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax+1; i-- {
+		if utf8.RuneStart(p[i]) {
+			if !utf8.FullRune(p[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(p)
+}
@@ -0,0 +1,104 @@
+package strtransform_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/strtransform"
+)
+
+// writeChunks 将 s 随机切分成小块写入 Writer，返回输出结果
+func writeChunks(t *testing.T, r *rand.Rand, s string, opts strtransform.Options) string {
+	t.Helper()
+	var out bytes.Buffer
+	w := strtransform.NewWriter(&out, opts)
+	for p := []byte(s); len(p) > 0; {
+		n := min(1+r.Intn(5), len(p))
+		if m, err := w.Write(p[:n]); err != nil || m != n {
+			t.Fatalf("Write = (%d, %v)，预期 (%d, nil)", m, err, n)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close 失败: %v", err)
+	}
+	return out.String()
+}
+
+// TestWriter 测试分块写入与逐行调用 Apply 的结果一致，包括被切断的多字节字符
+func TestWriter(t *testing.T) {
+	const input = "Hello 世界 fooBar\n  第二行 HTTPServer  \n\nlast línea sin salto"
+	r := rand.New(rand.NewSource(1))
+	testCases := []struct {
+		name string
+		opts strtransform.Options
+	}{
+		{"UPPER", strtransform.NewOptions(strtransform.UPPER)},
+		{"LOWER", strtransform.NewOptions(strtransform.LOWER)},
+		{"REV", strtransform.NewOptions(strtransform.REV)},
+		{"TRIM|SNAKE", strtransform.NewOptions(strtransform.TRIM, strtransform.SNAKE)},
+		{"CAP", strtransform.NewOptions(strtransform.CAP)},
+		{"无配置", strtransform.NewOptions()},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := strings.Split(input, "\n")
+			for i, line := range lines {
+				lines[i] = strtransform.Apply(line, tc.opts)
+			}
+			expected := strings.Join(lines, "\n")
+			for range 20 {
+				if got := writeChunks(t, r, input, tc.opts); got != expected {
+					t.Fatalf("输出 %q，预期 %q", got, expected)
+				}
+			}
+		})
+	}
+}
+
+// TestWriterStreaming 测试按 rune 转换时不等待换行即输出
+func TestWriterStreaming(t *testing.T) {
+	var out bytes.Buffer
+	w := strtransform.NewWriter(&out, strtransform.NewOptions(strtransform.UPPER))
+	w.Write([]byte("abc\xe4\xb8")) // "中" 的前两个字节
+	if out.String() != "ABC" {
+		t.Errorf("输出 %q，预期 ABC", out.String())
+	}
+	w.Write([]byte("\xad"))
+	if out.String() != "ABC中" {
+		t.Errorf("输出 %q，预期 ABC中", out.String())
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close 失败: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("重复 Close 应返回 nil，得到 %v", err)
+	}
+	if _, err := w.Write([]byte("x")); !errors.Is(err, strtransform.ErrClosed) {
+		t.Errorf("关闭后 Write 错误为 %v，预期 ErrClosed", err)
+	}
+}
+
+type failWriter struct{}
+
+var errFail = errors.New("写入失败")
+
+func (failWriter) Write([]byte) (int, error) { return 0, errFail }
+
+// TestWriterError 测试底层写入错误会被保留
+func TestWriterError(t *testing.T) {
+	w := strtransform.NewWriter(failWriter{}, strtransform.NewOptions(strtransform.REV))
+	if _, err := w.Write([]byte("no newline")); err != nil {
+		t.Fatalf("不完整的行不应触发写入，得到 %v", err)
+	}
+	if _, err := w.Write([]byte("\n")); !errors.Is(err, errFail) {
+		t.Errorf("Write 错误为 %v，预期 errFail", err)
+	}
+	if err := w.Close(); !errors.Is(err, errFail) {
+		t.Errorf("Close 错误为 %v，预期 errFail", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Package syncx 补充标准库 sync 中缺少的同步原语。
+package syncx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// closed 是已关闭的通道，计数为 0 时 Wait 系列方法直接使用它
+var closed = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// WaitGroup 与 sync.WaitGroup 相同，但等待可以被上下文取消或超时打断，零值即可使用
+//
+// 计数变为 0 时关闭一个通道通知所有等待者，因此等待可以放进 select；
+// 该通道在第一次需要等待时才创建，不等待的使用方式没有额外分配。
+// WaitGroup 不能在使用后复制。
+type WaitGroup struct {
+	mu   sync.Mutex
+	n    int
+	done chan struct{} // 本轮计数归零时关闭，为 nil 表示还没有等待者
+}
+
+// Add 将计数加上 delta，计数变为负数时 panic
+func (wg *WaitGroup) Add(delta int) {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	wg.n += delta
+	if wg.n < 0 {
+		panic("syncx: WaitGroup 计数为负数")
+	}
+	if wg.n == 0 && wg.done != nil {
+		close(wg.done)
+		wg.done = nil
+	}
+}
+
+// Done 将计数减 1
+func (wg *WaitGroup) Done() {
+	wg.Add(-1)
+}
+
+// Go 将计数加 1 并在新的 goroutine 中运行 f，f 返回后计数减 1
+func (wg *WaitGroup) Go(f func()) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		f()
+	}()
+}
+
+// Wait 阻塞直到计数为 0
+func (wg *WaitGroup) Wait() {
+	<-wg.C()
+}
+
+// WaitContext 阻塞直到计数为 0 或 ctx 结束，后者返回 ctx 的错误
+// ctx 结束只是停止等待，已启动的任务仍会继续运行
+func (wg *WaitGroup) WaitContext(ctx context.Context) error {
+	select {
+	case <-wg.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitTimeout 阻塞直到计数为 0 或经过 d，返回是否在超时前等到了计数为 0
+func (wg *WaitGroup) WaitTimeout(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-wg.C():
+		return true
+	case <-t.C:
+		return false
+	}
+}
+
+// C 返回一个在当前计数归零时关闭的通道，计数已为 0 时返回已关闭的通道
+// 之后的 Add 会开始新一轮计数，不影响已返回的通道
+func (wg *WaitGroup) C() <-chan struct{} {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if wg.n == 0 {
+		return closed
+	}
+	if wg.done == nil {
+		wg.done = make(chan struct{})
+	}
+	return wg.done
+}
@@ -0,0 +1,84 @@
+package syncx_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/syncx"
+)
+
+// TestWaitGroup 测试 Go 与 Wait
+func TestWaitGroup(t *testing.T) {
+	var wg syncx.WaitGroup
+	wg.Wait() // 计数为 0 时立即返回
+
+	var n atomic.Int32
+	for range 100 {
+		wg.Go(func() { n.Add(1) })
+	}
+	wg.Wait()
+	if got := n.Load(); got != 100 {
+		t.Errorf("执行了 %d 个任务，预期 100", got)
+	}
+
+	// 计数归零后可以开始新一轮
+	release := make(chan struct{})
+	wg.Go(func() { <-release })
+	c := wg.C()
+	select {
+	case <-c:
+		t.Fatal("任务未结束时通道已关闭")
+	default:
+	}
+	close(release)
+	<-c
+}
+
+// TestWaitContext 测试上下文结束时停止等待
+func TestWaitContext(t *testing.T) {
+	var wg syncx.WaitGroup
+	release := make(chan struct{})
+	wg.Go(func() { <-release })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := wg.WaitContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitContext() = %v，预期 Canceled", err)
+	}
+	if wg.WaitTimeout(time.Millisecond) {
+		t.Errorf("任务未结束时 WaitTimeout 应返回 false")
+	}
+
+	close(release)
+	if err := wg.WaitContext(context.Background()); err != nil {
+		t.Errorf("WaitContext() = %v", err)
+	}
+	if !wg.WaitTimeout(time.Second) {
+		t.Errorf("任务结束后 WaitTimeout 应返回 true")
+	}
+}
+
+// TestNegative 测试计数为负数时 panic
+func TestNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("计数为负数时应 panic")
+		}
+	}()
+	var wg syncx.WaitGroup
+	wg.Done()
+}
+
+// TestAllocs 测试没有等待者时 Add、Done 与 Wait 不分配内存
+func TestAllocs(t *testing.T) {
+	var wg syncx.WaitGroup
+	benchkit.AssertAllocs(t, 0, func() {
+		wg.Add(1)
+		wg.Done()
+		wg.Wait()
+	})
+}
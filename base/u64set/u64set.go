@@ -0,0 +1,171 @@
+// Package u64set 提供专用于 uint64 键的开放寻址哈希集合。
+//
+// 与 map[uint64]struct{} 相比：
+//
+//   - 键直接存放在一个 []uint64 中，每个槽位只占 8 字节，没有桶结构、tophash 与溢出指针的额外开销
+//   - 线性探测让冲突的键位于相邻的缓存行，查找通常只访问一两个缓存行
+//   - 删除采用向后移位（backward shift），把后续的键前移填补空位，而不是留下墓碑（tombstone），
+//     大量增删之后探测链也不会变长，不需要定期重建
+//
+// 0 用作空槽位标记，键 0 本身由单独的标志记录。
+package u64set
+
+import (
+	"iter"
+	"math/bits"
+
+	"github.com/moweilong/efficient-go/base/bit"
+)
+
+// 装载因子上限为 maxLoadNum/maxLoadDen，超过后容量翻倍
+const (
+	maxLoadNum = 3
+	maxLoadDen = 4
+	minSlots   = 8
+)
+
+// Set 是 uint64 的哈希集合，零值即可使用；不能被多个 goroutine 并发修改
+type Set struct {
+	slots   []uint64 // 0 表示空槽位
+	mask    uint64
+	shift   uint // 64 - log2(len(slots))
+	n       int  // 非零键的个数
+	hasZero bool
+}
+
+// New 创建可容纳 n 个键而无需扩容的集合
+func New(n int) *Set {
+	s := &Set{}
+	s.resize(slotsFor(n))
+	return s
+}
+
+// slotsFor 返回容纳 n 个键所需的槽位数，为 2 的幂
+func slotsFor(n int) int {
+	need := uint64(max(n, 1))*maxLoadDen/maxLoadNum + 1
+	return int(max(bit.NextPowerOfTwo(need), minSlots))
+}
+
+// home 返回键 k 的理想槽位
+// 使用 Fibonacci 哈希：乘以 2^64/φ 后取高位，乘积的高位由键的所有位共同决定，相邻的整数键也能均匀分布
+func (s *Set) home(k uint64) uint64 {
+	return (k * 0x9e3779b97f4a7c15) >> s.shift
+}
+
+// Len 返回键的个数
+func (s *Set) Len() int {
+	if s.hasZero {
+		return s.n + 1
+	}
+	return s.n
+}
+
+// Has 返回 k 是否在集合中
+func (s *Set) Has(k uint64) bool {
+	if k == 0 {
+		return s.hasZero
+	}
+	if s.slots == nil {
+		return false
+	}
+	for i := s.home(k); ; i = (i + 1) & s.mask {
+		switch s.slots[i] {
+		case k:
+			return true
+		case 0:
+			return false
+		}
+	}
+}
+
+// Add 将 k 加入集合，返回 k 之前是否不在集合中
+func (s *Set) Add(k uint64) bool {
+	if k == 0 {
+		added := !s.hasZero
+		s.hasZero = true
+		return added
+	}
+	if (s.n+1)*maxLoadDen > len(s.slots)*maxLoadNum {
+		s.resize(max(len(s.slots)*2, minSlots))
+	}
+	for i := s.home(k); ; i = (i + 1) & s.mask {
+		switch s.slots[i] {
+		case k:
+			return false
+		case 0:
+			s.slots[i] = k
+			s.n++
+			return true
+		}
+	}
+}
+
+// Remove 从集合中删除 k，返回 k 之前是否在集合中
+func (s *Set) Remove(k uint64) bool {
+	if k == 0 {
+		removed := s.hasZero
+		s.hasZero = false
+		return removed
+	}
+	if s.slots == nil {
+		return false
+	}
+	i := s.home(k)
+	for s.slots[i] != k {
+		if s.slots[i] == 0 {
+			return false
+		}
+		i = (i + 1) & s.mask
+	}
+	// 向后移位：空位 i 之后探测链上的键，若其理想位置不在 (i, j] 内，说明它是越过 i 探测到 j 的，
+	// 将它移到 i，再以 j 为新的空位继续，直到遇到空槽位
+	for j := (i + 1) & s.mask; s.slots[j] != 0; j = (j + 1) & s.mask {
+		home := s.home(s.slots[j])
+		if (j-home)&s.mask >= (j-i)&s.mask {
+			s.slots[i] = s.slots[j]
+			i = j
+		}
+	}
+	s.slots[i] = 0
+	s.n--
+	return true
+}
+
+// Clear 删除所有键，保留已分配的容量
+func (s *Set) Clear() {
+	clear(s.slots)
+	s.n = 0
+	s.hasZero = false
+}
+
+// All 返回遍历所有键的迭代器，顺序不确定；遍历期间不能修改集合
+func (s *Set) All() iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		if s.hasZero && !yield(0) {
+			return
+		}
+		for _, k := range s.slots {
+			if k != 0 && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// resize 将槽位数调整为 n 并重新插入所有键
+func (s *Set) resize(n int) {
+	old := s.slots
+	s.slots = make([]uint64, n)
+	s.mask = uint64(n - 1)
+	s.shift = uint(64 - bits.TrailingZeros(uint(n)))
+	for _, k := range old {
+		if k == 0 {
+			continue
+		}
+		i := s.home(k)
+		for s.slots[i] != 0 {
+			i = (i + 1) & s.mask
+		}
+		s.slots[i] = k
+	}
+}
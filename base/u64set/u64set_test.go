@@ -0,0 +1,173 @@
+package u64set_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/memwatch"
+	"github.com/moweilong/efficient-go/base/u64set"
+)
+
+// TestSet 测试基本操作，包括键 0 与零值集合
+func TestSet(t *testing.T) {
+	var s u64set.Set
+	if s.Has(1) || s.Remove(1) || s.Len() != 0 {
+		t.Fatalf("零值集合应为空")
+	}
+	for _, k := range []uint64{0, 1, 42, 1 << 63} {
+		if !s.Add(k) || s.Add(k) || !s.Has(k) {
+			t.Errorf("Add/Has(%d) 结果错误", k)
+		}
+	}
+	if s.Len() != 4 {
+		t.Errorf("Len() = %d，预期 4", s.Len())
+	}
+	got := slices.Sorted(s.All())
+	if want := []uint64{0, 1, 42, 1 << 63}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v，预期 %v", got, want)
+	}
+	if !s.Remove(0) || s.Has(0) || !s.Remove(42) || s.Remove(42) || s.Len() != 2 {
+		t.Errorf("Remove 结果错误，Len() = %d", s.Len())
+	}
+	s.Clear()
+	if s.Len() != 0 || s.Has(1) {
+		t.Errorf("Clear 后集合应为空")
+	}
+}
+
+// TestAgainstMap 在随机增删序列上与 map 对比，键集中在小范围内以产生大量冲突与向后移位
+func TestAgainstMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := u64set.New(0)
+	m := make(map[uint64]struct{})
+	for i := range 200000 {
+		k := uint64(r.Intn(2000)) << (r.Intn(2) * 40) // 一半的键只有高位不同
+		_, had := m[k]
+		switch r.Intn(3) {
+		case 0, 1:
+			if added := s.Add(k); added == had {
+				t.Fatalf("第 %d 步 Add(%d) = %v", i, k, added)
+			}
+			m[k] = struct{}{}
+		default:
+			if removed := s.Remove(k); removed != had {
+				t.Fatalf("第 %d 步 Remove(%d) = %v", i, k, removed)
+			}
+			delete(m, k)
+		}
+		if s.Len() != len(m) {
+			t.Fatalf("第 %d 步 Len() = %d，预期 %d", i, s.Len(), len(m))
+		}
+	}
+	for k := range m {
+		if !s.Has(k) {
+			t.Fatalf("缺少键 %d", k)
+		}
+	}
+	n := 0
+	for k := range s.All() {
+		if _, ok := m[k]; !ok {
+			t.Fatalf("多出键 %d", k)
+		}
+		n++
+	}
+	if n != len(m) {
+		t.Errorf("All() 遍历了 %d 个键，预期 %d", n, len(m))
+	}
+}
+
+// TestAllocs 测试预分配容量后 Add 不分配内存
+func TestAllocs(t *testing.T) {
+	s := u64set.New(1000)
+	k := uint64(1)
+	benchkit.AssertAllocs(t, 0, func() {
+		s.Add(k)
+		k++
+	})
+}
+
+// keys 返回 n 个随机键
+func keys(n int) []uint64 {
+	r := rand.New(rand.NewSource(int64(n)))
+	ks := make([]uint64, n)
+	for i := range ks {
+		ks[i] = r.Uint64()
+	}
+	return ks
+}
+
+// BenchmarkAdd 对比插入 n 个键的开销（不预分配）与每个键占用的内存
+func BenchmarkAdd(b *testing.B) {
+	for _, n := range []int{1000, 100000} {
+		ks := keys(n)
+		b.Run(fmt.Sprintf("n=%d/u64set", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var s u64set.Set
+				for _, k := range ks {
+					s.Add(k)
+				}
+			}
+			b.ReportMetric(retained(func() any {
+				var s u64set.Set
+				for _, k := range ks {
+					s.Add(k)
+				}
+				return &s
+			})/float64(n), "B/key")
+		})
+		b.Run(fmt.Sprintf("n=%d/map", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m := make(map[uint64]struct{})
+				for _, k := range ks {
+					m[k] = struct{}{}
+				}
+			}
+			b.ReportMetric(retained(func() any {
+				m := make(map[uint64]struct{})
+				for _, k := range ks {
+					m[k] = struct{}{}
+				}
+				return m
+			})/float64(n), "B/key")
+		})
+	}
+}
+
+// retained 返回 build 构建的对象在 GC 后仍占用的堆内存
+func retained(build func() any) float64 {
+	runtime.GC()
+	before := memwatch.Take()
+	v := build()
+	runtime.GC()
+	growth := memwatch.Take().Sub(before).HeapGrowth
+	runtime.KeepAlive(v)
+	return float64(growth)
+}
+
+// BenchmarkHas 对比查找的开销，命中与未命中各占一半
+func BenchmarkHas(b *testing.B) {
+	const n = 100000
+	ks := keys(n)
+	s := u64set.New(n)
+	m := make(map[uint64]struct{}, n)
+	for _, k := range ks[:n/2] {
+		s.Add(k)
+		m[k] = struct{}{}
+	}
+	b.Run("u64set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkBool = s.Has(ks[i%n])
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, benchkit.SinkBool = m[ks[i%n]]
+		}
+	})
+}
@@ -0,0 +1,151 @@
+// Package units 提供字节大小、时长与速率等数量的解析和人类可读的格式化。
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax 表示无法解析的数量字符串
+var ErrSyntax = errors.New("units: 非法的数量字符串")
+
+// ErrRange 表示数量超出可表示的范围
+var ErrRange = errors.New("units: 数量超出范围")
+
+// ByteSize 是以字节为单位的大小
+type ByteSize uint64
+
+// SI 单位，以 1000 为进制
+const (
+	Byte ByteSize = 1
+	KB            = 1000 * Byte
+	MB            = 1000 * KB
+	GB            = 1000 * MB
+	TB            = 1000 * GB
+	PB            = 1000 * TB
+	EB            = 1000 * PB
+)
+
+// IEC 单位，以 1024 为进制
+const (
+	KiB = 1024 * Byte
+	MiB = 1024 * KiB
+	GiB = 1024 * MiB
+	TiB = 1024 * GiB
+	PiB = 1024 * TiB
+	EiB = 1024 * PiB
+)
+
+// unit 是一个单位及其后缀
+type unit struct {
+	suffix string
+	size   ByteSize
+}
+
+// 从大到小排列，格式化时取第一个不大于数值的单位
+var (
+	siUnits  = []unit{{"EB", EB}, {"PB", PB}, {"TB", TB}, {"GB", GB}, {"MB", MB}, {"KB", KB}}
+	iecUnits = []unit{{"EiB", EiB}, {"PiB", PiB}, {"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB}}
+)
+
+// parseUnits 是 ParseBytes 接受的后缀（小写），单个字母按 IEC 解释，与常见命令行工具一致
+var parseUnits = map[string]ByteSize{
+	"": Byte, "b": Byte,
+	"kb": KB, "mb": MB, "gb": GB, "tb": TB, "pb": PB, "eb": EB,
+	"kib": KiB, "mib": MiB, "gib": GiB, "tib": TiB, "pib": PiB, "eib": EiB,
+	"k": KiB, "m": MiB, "g": GiB, "t": TiB, "p": PiB, "e": EiB,
+}
+
+// String 以 IEC 单位格式化，最多保留两位小数，如 "1.5GiB"、"512B"
+func (b ByteSize) String() string {
+	return format(b, iecUnits)
+}
+
+// SI 以 SI 单位格式化，最多保留两位小数，如 "1.61GB"
+func (b ByteSize) SI() string {
+	return format(b, siUnits)
+}
+
+// format 取第一个不大于 b 的单位；舍入进位到下一单位时（如 1MiB-1 舍入为 "1024KiB"）改用更大的单位输出 "1MiB"
+func format(b ByteSize, units []unit) string {
+	for i, u := range units {
+		if b < u.size {
+			continue
+		}
+		s := formatFloat(float64(b) / float64(u.size))
+		if r, _ := strconv.ParseFloat(s, 64); i > 0 && r >= float64(units[i-1].size/u.size) {
+			return "1" + units[i-1].suffix
+		}
+		return s + u.suffix
+	}
+	return strconv.FormatUint(uint64(b), 10) + "B"
+}
+
+// formatFloat 保留两位小数并去掉末尾的 0
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// ParseBytes 解析 "1.5GiB"、"64 KB"、"4k"、"1024" 等形式的字节大小，单位不区分大小写，
+// 无单位时按字节计；小数结果向下取整到字节
+func ParseBytes(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	num, suffix := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	size, ok := parseUnits[suffix]
+	if !ok || num == "" {
+		return 0, fmt.Errorf("%w %q", ErrSyntax, s)
+	}
+
+	// 整数走精确路径，避免 float64 丢失大数精度
+	if !strings.Contains(num, ".") {
+		n, err := strconv.ParseUint(num, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w %q", ErrRange, s)
+		}
+		hi, lo := bits.Mul64(n, uint64(size))
+		if hi != 0 {
+			return 0, fmt.Errorf("%w %q", ErrRange, s)
+		}
+		return ByteSize(lo), nil
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q", ErrSyntax, s)
+	}
+	f *= float64(size)
+	if f >= math.MaxUint64 {
+		return 0, fmt.Errorf("%w %q", ErrRange, s)
+	}
+	return ByteSize(f), nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler，能无损表示时输出 IEC 形式，
+// 否则（如 1500 格式化为 "1.46KiB"、MaxUint64 格式化为 "16EiB"）输出精确的字节数，保证 UnmarshalText 往返后值不变
+func (b ByteSize) MarshalText() ([]byte, error) {
+	s := b.String()
+	if v, err := ParseBytes(s); err == nil && v == b {
+		return []byte(s), nil
+	}
+	return strconv.AppendUint(nil, uint64(b), 10), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 ParseBytes 支持的所有形式
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	v, err := ParseBytes(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
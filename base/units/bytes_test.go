@@ -0,0 +1,118 @@
+package units_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/units"
+)
+
+// TestParseBytes 测试字节大小的解析
+func TestParseBytes(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected units.ByteSize
+	}{
+		{"1024", 1024},
+		{"0", 0},
+		{"512B", 512},
+		{"1.5GiB", units.GiB + units.GiB/2},
+		{"64 KB", 64 * units.KB},
+		{"64kb", 64 * units.KB},
+		{"4k", 4 * units.KiB},
+		{" 2MiB ", 2 * units.MiB},
+		{"0.5", 0},
+		{"1.5B", 1},
+		{"18446744073709551615", math.MaxUint64},
+		{"15EiB", 15 * units.EiB},
+	}
+	for _, tc := range testCases {
+		v, err := units.ParseBytes(tc.input)
+		if err != nil || v != tc.expected {
+			t.Errorf("ParseBytes(%q) = (%d, %v)，预期 %d", tc.input, v, err, tc.expected)
+		}
+	}
+
+	errCases := []struct {
+		input string
+		err   error
+	}{
+		{"", units.ErrSyntax},
+		{"GiB", units.ErrSyntax},
+		{"1.5XB", units.ErrSyntax},
+		{"-1KB", units.ErrSyntax},
+		{"1..5KB", units.ErrSyntax},
+		{"16EiB", units.ErrRange},
+		{"16.0EiB", units.ErrRange},
+		{"18446744073709551616", units.ErrRange},
+	}
+	for _, tc := range errCases {
+		if _, err := units.ParseBytes(tc.input); !errors.Is(err, tc.err) {
+			t.Errorf("ParseBytes(%q) 错误为 %v，预期 %v", tc.input, err, tc.err)
+		}
+	}
+}
+
+// TestByteSizeString 测试 IEC 与 SI 两种格式化
+func TestByteSizeString(t *testing.T) {
+	testCases := []struct {
+		size units.ByteSize
+		iec  string
+		si   string
+	}{
+		{0, "0B", "0B"},
+		{512, "512B", "512B"},
+		{1000, "1000B", "1KB"},
+		{units.KiB, "1KiB", "1.02KB"},
+		{units.GiB + units.GiB/2, "1.5GiB", "1.61GB"},
+		{100 * units.MB, "95.37MiB", "100MB"},
+		{math.MaxUint64, "16EiB", "18.45EB"},
+		{units.KiB - 1, "1023B", "1.02KB"},
+		{units.MiB - 1, "1MiB", "1.05MB"},
+		{units.MB - 1, "976.56KiB", "1MB"},
+		{units.GB - 1, "953.67MiB", "1GB"},
+		{units.EiB - 1, "1EiB", "1.15EB"},
+		{units.MiB - 10, "1023.99KiB", "1.05MB"},
+		{units.MB - 5, "976.56KiB", "1MB"},
+		{units.MB - 6, "976.56KiB", "999.99KB"},
+	}
+	for _, tc := range testCases {
+		if s := tc.size.String(); s != tc.iec {
+			t.Errorf("(%d).String() = %q，预期 %q", uint64(tc.size), s, tc.iec)
+		}
+		if s := tc.size.SI(); s != tc.si {
+			t.Errorf("(%d).SI() = %q，预期 %q", uint64(tc.size), s, tc.si)
+		}
+	}
+}
+
+// TestByteSizeText 测试文本序列化往返
+func TestByteSizeText(t *testing.T) {
+	var b units.ByteSize
+	if err := b.UnmarshalText([]byte("2.5MiB")); err != nil || b != 2*units.MiB+units.MiB/2 {
+		t.Fatalf("UnmarshalText = (%d, %v)", b, err)
+	}
+	text, _ := b.MarshalText()
+	if string(text) != "2.5MiB" {
+		t.Errorf("MarshalText = %q，预期 2.5MiB", text)
+	}
+	if err := b.UnmarshalText([]byte("lots")); err == nil {
+		t.Errorf("非法字符串应返回错误")
+	}
+
+	// String 无法无损表示的值输出精确字节数，往返后值不变
+	for _, size := range []units.ByteSize{0, 1500, units.MiB - 1, units.EiB, math.MaxUint64} {
+		text, err := size.MarshalText()
+		if err != nil {
+			t.Fatalf("(%d).MarshalText 失败: %v", uint64(size), err)
+		}
+		var got units.ByteSize
+		if err := got.UnmarshalText(text); err != nil || got != size {
+			t.Errorf("(%d) 往返 %q = (%d, %v)", uint64(size), text, uint64(got), err)
+		}
+	}
+	if text, _ := units.ByteSize(math.MaxUint64).MarshalText(); string(text) != "18446744073709551615" {
+		t.Errorf("MaxUint64.MarshalText = %q，预期精确字节数", text)
+	}
+}
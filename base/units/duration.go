@@ -0,0 +1,59 @@
+package units
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationUnits 从大到小排列，格式化时取第一个不大于数值的单位
+var durationUnits = []struct {
+	suffix string
+	size   time.Duration
+}{
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"µs", time.Microsecond},
+}
+
+// FormatDuration 以单个最合适的单位和三位有效数字格式化时长，如 "1.23ms"、"45.6µs"、"2.5h"，
+// 适合在基准测试报告中对齐比较；与 time.Duration.String 不同，不会输出 "1m30.5s" 这样的复合形式
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		// -d 对 math.MinInt64 仍为负数，按 uint64 取绝对值
+		return "-" + formatAbsDuration(uint64(-d))
+	}
+	return formatAbsDuration(uint64(d))
+}
+
+func formatAbsDuration(ns uint64) string {
+	for i, u := range durationUnits {
+		if ns >= uint64(u.size) {
+			s := formatSig(float64(ns) / float64(u.size))
+			// 舍入后可能恰好进位到上一级单位，如 999.6ms 舍入为 1000ms，此时改用 1s
+			if i > 0 && s == strconv.FormatInt(int64(durationUnits[i-1].size/u.size), 10) {
+				return "1" + durationUnits[i-1].suffix
+			}
+			return s + u.suffix
+		}
+	}
+	return strconv.FormatUint(ns, 10) + "ns"
+}
+
+// formatSig 保留三位有效数字（整数部分超过三位时保留整数）并去掉末尾的 0
+func formatSig(f float64) string {
+	prec := 0
+	switch {
+	case f < 10:
+		prec = 2
+	case f < 100:
+		prec = 1
+	}
+	s := strconv.FormatFloat(f, 'f', prec, 64)
+	if prec > 0 {
+		s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
@@ -0,0 +1,40 @@
+package units_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/units"
+)
+
+// TestFormatDuration 测试时长的单一单位格式化
+func TestFormatDuration(t *testing.T) {
+	testCases := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "0ns"},
+		{999, "999ns"},
+		{1234, "1.23µs"},
+		{45600, "45.6µs"},
+		{1200 * time.Microsecond, "1.2ms"},
+		{1234567, "1.23ms"},
+		{123456789, "123ms"},
+		{time.Second, "1s"},
+		{999600 * time.Microsecond, "1s"}, // 舍入进位到上一级单位
+		{999400 * time.Microsecond, "999ms"},
+		{59996 * time.Millisecond, "1m"},
+		{999600, "1ms"},
+		{90 * time.Second, "1.5m"},
+		{150 * time.Minute, "2.5h"},
+		{1000 * time.Hour, "1000h"},
+		{-1500 * time.Microsecond, "-1.5ms"},
+		{math.MinInt64, "-2562048h"},
+	}
+	for _, tc := range testCases {
+		if s := units.FormatDuration(tc.d); s != tc.expected {
+			t.Errorf("FormatDuration(%d) = %q，预期 %q", int64(tc.d), s, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package units
+
+import (
+	"math"
+	"time"
+)
+
+// Rate 是每秒发生的次数，如每秒操作数或每秒字节数
+type Rate float64
+
+// siPrefixes 从大到小排列，用于 Rate 的格式化
+var siPrefixes = []struct {
+	prefix string
+	size   float64
+}{
+	{"T", 1e12},
+	{"G", 1e9},
+	{"M", 1e6},
+	{"k", 1e3},
+}
+
+// RateOf 返回 d 时间内发生 n 次对应的速率，d 不为正时返回 0
+func RateOf(n float64, d time.Duration) Rate {
+	if d <= 0 {
+		return 0
+	}
+	return Rate(n / d.Seconds())
+}
+
+// String 以 SI 前缀格式化为每秒操作数，如 "1.23M ops/s"
+func (r Rate) String() string {
+	return r.format() + " ops/s"
+}
+
+// Bytes 将 r 视为每秒字节数，以 SI 前缀格式化，如 "12.3MB/s"，与 go test -bench 的 MB/s 一致
+func (r Rate) Bytes() string {
+	return r.format() + "B/s"
+}
+
+func (r Rate) format() string {
+	f := float64(r)
+	sign := ""
+	if f < 0 {
+		sign, f = "-", -f
+	}
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 0):
+		return sign + "∞"
+	}
+	for i, p := range siPrefixes {
+		if f >= p.size {
+			s := formatSig(f / p.size)
+			// 舍入后可能恰好进位到上一级前缀，如 999.7k 舍入为 1000k，此时改用 1M
+			if s == "1000" && i > 0 {
+				return sign + "1" + siPrefixes[i-1].prefix
+			}
+			return sign + s + p.prefix
+		}
+	}
+	if s := formatSig(f); s != "1000" {
+		return sign + s
+	}
+	return sign + "1k"
+}
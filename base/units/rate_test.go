@@ -0,0 +1,39 @@
+package units_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/units"
+)
+
+// TestRate 测试速率的计算与格式化
+func TestRate(t *testing.T) {
+	testCases := []struct {
+		rate  units.Rate
+		ops   string
+		bytes string
+	}{
+		{0, "0 ops/s", "0B/s"},
+		{12.5, "12.5 ops/s", "12.5B/s"},
+		{1234567, "1.23M ops/s", "1.23MB/s"},
+		{units.RateOf(3e9, 2*time.Second), "1.5G ops/s", "1.5GB/s"},
+		{units.RateOf(100, 0), "0 ops/s", "0B/s"},
+		{-2000, "-2k ops/s", "-2kB/s"},
+		{units.Rate(math.Inf(1)), "∞ ops/s", "∞B/s"},
+		{units.Rate(math.Inf(-1)), "-∞ ops/s", "-∞B/s"},
+		{units.Rate(math.NaN()), "NaN ops/s", "NaNB/s"},
+		{999.7, "1k ops/s", "1kB/s"}, // 舍入进位到上一级前缀
+		{999.7e3, "1M ops/s", "1MB/s"},
+		{-999.7e9, "-1T ops/s", "-1TB/s"},
+	}
+	for _, tc := range testCases {
+		if s := tc.rate.String(); s != tc.ops {
+			t.Errorf("String() = %q，预期 %q", s, tc.ops)
+		}
+		if s := tc.rate.Bytes(); s != tc.bytes {
+			t.Errorf("Bytes() = %q，预期 %q", s, tc.bytes)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Package unsafeconv 提供 string 与 []byte 之间不复制内存的转换。
+//
+// 常规的 string(b) 与 []byte(s) 会复制数据（编译器能证明安全时才会省略），
+// 在热路径上转换大块数据时复制与分配的开销可观。这里的函数共享同一块内存，
+// 调用方必须遵守各函数注释中的约定，违反约定会破坏 string 不可变的语义，导致难以排查的错误。
+package unsafeconv
+
+import "unsafe"
+
+// BytesToString 返回与 b 共享内存的字符串，不复制数据
+//
+// 约定：在返回的字符串仍被使用期间，调用方不得修改 b 的内容。
+// 字符串可能被用作 map 的键或被其他代码保存，修改 b 会让这些地方看到的值悄然改变。
+// b 为空时返回 ""。
+func BytesToString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// StringToBytes 返回与 s 共享内存的字节切片，不复制数据
+//
+// 约定：调用方不得修改返回切片的内容。字符串字面量位于只读内存段，写入会直接导致程序崩溃；
+// 其他字符串虽然不会崩溃，但修改会破坏 string 不可变的语义。
+// 返回切片的容量等于长度，append 总会重新分配而不会写入 s 的内存。s 为空时返回 nil。
+func StringToBytes(s string) []byte {
+	if s == "" {
+		return nil
+	}
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
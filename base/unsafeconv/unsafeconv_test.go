@@ -0,0 +1,130 @@
+package unsafeconv_test
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/unsafeconv"
+)
+
+// TestBytesToString 测试转换结果与原切片共享内存
+func TestBytesToString(t *testing.T) {
+	b := []byte("hello")
+	s := unsafeconv.BytesToString(b)
+	if s != "hello" {
+		t.Fatalf("BytesToString = %q", s)
+	}
+	if unsafe.StringData(s) != &b[0] {
+		t.Errorf("BytesToString 复制了数据")
+	}
+	// 违反约定的演示：修改 b 会改变已经返回的字符串
+	b[0] = 'j'
+	if s != "jello" {
+		t.Errorf("修改 b 后 s = %q，预期共享内存的 %q", s, "jello")
+	}
+
+	for _, empty := range [][]byte{nil, {}, make([]byte, 0, 8)} {
+		if s := unsafeconv.BytesToString(empty); s != "" {
+			t.Errorf("BytesToString(%v) = %q，预期空字符串", empty, s)
+		}
+	}
+}
+
+// TestStringToBytes 测试转换结果与原字符串共享内存，且 append 不会写入原字符串
+func TestStringToBytes(t *testing.T) {
+	s := strings.Repeat("ab", 4)
+	b := unsafeconv.StringToBytes(s)
+	if string(b) != s || len(b) != cap(b) {
+		t.Fatalf("StringToBytes = %q，长度 %d，容量 %d", b, len(b), cap(b))
+	}
+	if &b[0] != unsafe.StringData(s) {
+		t.Errorf("StringToBytes 复制了数据")
+	}
+	grown := append(b, 'c')
+	if &grown[0] == unsafe.StringData(s) || s != "abababab" {
+		t.Errorf("append 写入了原字符串的内存")
+	}
+	if b := unsafeconv.StringToBytes(""); b != nil {
+		t.Errorf("StringToBytes(\"\") = %v，预期 nil", b)
+	}
+}
+
+// TestNoAllocs 测试转换不会分配内存，而常规转换在结果逃逸时会分配
+func TestNoAllocs(t *testing.T) {
+	b := bytes.Repeat([]byte("x"), 1024)
+	s := string(b)
+	benchkit.AssertAllocs(t, 0, func() {
+		benchkit.SinkString = unsafeconv.BytesToString(b)
+		benchkit.SinkBytes = unsafeconv.StringToBytes(s)
+	})
+
+	if benchkit.RaceEnabled {
+		return
+	}
+	// 结果写入包级变量即逃逸到堆上，常规转换必须复制
+	if n := testing.AllocsPerRun(100, func() { benchkit.SinkString = string(b) }); n != 1 {
+		t.Errorf("string(b) 每次分配 %v 次，预期 1 次", n)
+	}
+}
+
+// TestEscape 检查编译器的逃逸分析结果：两个函数都应可内联，参数只流向返回值而不逃逸到堆上，
+// 因此转换本身不会让调用方的数据被迫分配在堆上
+func TestEscape(t *testing.T) {
+	if testing.Short() {
+		t.Skip("需要调用 go build")
+	}
+	out, err := exec.Command("go", "build", "-gcflags=-m", ".").CombinedOutput()
+	if err != nil {
+		t.Fatalf("go build 失败: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"can inline BytesToString",
+		"can inline StringToBytes",
+		"leaking param: b to result",
+		"leaking param: s to result",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("逃逸分析输出中缺少 %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(string(out), "escapes to heap") {
+		t.Errorf("存在逃逸到堆上的值:\n%s", out)
+	}
+}
+
+// BenchmarkConvert 对比常规转换与零复制转换在不同长度下的开销
+func BenchmarkConvert(b *testing.B) {
+	for _, n := range []int{16, 1024, 64 << 10} {
+		data := bytes.Repeat([]byte("x"), n)
+		str := string(data)
+		b.Run(fmt.Sprintf("string(b)/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkString = string(data)
+			}
+		})
+		b.Run(fmt.Sprintf("BytesToString/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkString = unsafeconv.BytesToString(data)
+			}
+		})
+		b.Run(fmt.Sprintf("[]byte(s)/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkBytes = []byte(str)
+			}
+		})
+		b.Run(fmt.Sprintf("StringToBytes/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchkit.SinkBytes = unsafeconv.StringToBytes(str)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Package varint 实现 LEB128 变长整数编码与 zigzag 有符号整数映射。
+//
+// 编码函数均以 Append 形式追加到调用方提供的缓冲区，复用缓冲区时不会产生内存分配。
+// 编码格式与 encoding/binary 的 Uvarint/Varint 兼容。
+package varint
+
+import (
+	"errors"
+	"slices"
+)
+
+// MaxLen64 是 64 位整数编码后的最大字节数
+const MaxLen64 = 10
+
+var (
+	// ErrTruncated 表示输入在一个完整的变长整数结束之前耗尽
+	ErrTruncated = errors.New("varint: 输入数据不完整")
+	// ErrOverflow 表示编码的值超出 64 位整数的范围
+	ErrOverflow = errors.New("varint: 数值溢出 64 位")
+)
+
+// ZigZag 将有符号整数映射为无符号整数，使绝对值小的负数也能编码为短字节序列
+// 映射关系：0→0，-1→1，1→2，-2→3，2→4 ...
+func ZigZag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+// UnZigZag 是 ZigZag 的逆映射
+func UnZigZag(u uint64) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+// Len 返回 v 编码后的字节数
+func Len(v uint64) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
+
+// AppendUvarint 将 v 编码后追加到 dst：每字节存 7 位，最高位为 1 表示后面还有字节
+func AppendUvarint(dst []byte, v uint64) []byte {
+	for v >= 0x80 {
+		dst = append(dst, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(dst, byte(v))
+}
+
+// AppendVarint 将 v 经 zigzag 映射编码后追加到 dst
+func AppendVarint(dst []byte, v int64) []byte {
+	return AppendUvarint(dst, ZigZag(v))
+}
+
+// Uvarint 从 src 开头解码一个无符号变长整数，返回解码值和消耗的字节数
+func Uvarint(src []byte) (uint64, int, error) {
+	var v uint64
+	var s uint
+	for i, b := range src {
+		if i == MaxLen64 {
+			return 0, 0, ErrOverflow
+		}
+		if b < 0x80 {
+			if i == MaxLen64-1 && b > 1 {
+				return 0, 0, ErrOverflow
+			}
+			return v | uint64(b)<<s, i + 1, nil
+		}
+		v |= uint64(b&0x7F) << s
+		s += 7
+	}
+	return 0, 0, ErrTruncated
+}
+
+// Varint 从 src 开头解码一个 zigzag 编码的有符号变长整数
+func Varint(src []byte) (int64, int, error) {
+	u, n, err := Uvarint(src)
+	return UnZigZag(u), n, err
+}
+
+// AppendUvarints 依次编码 vs 中的每个整数并追加到 dst
+// 预先按最坏情况扩容一次，避免逐个追加时多次扩容
+func AppendUvarints(dst []byte, vs []uint64) []byte {
+	dst = slices.Grow(dst, len(vs)*MaxLen64)
+	for _, v := range vs {
+		dst = AppendUvarint(dst, v)
+	}
+	return dst
+}
+
+// AppendVarints 依次以 zigzag 编码 vs 中的每个整数并追加到 dst
+func AppendVarints(dst []byte, vs []int64) []byte {
+	dst = slices.Grow(dst, len(vs)*MaxLen64)
+	for _, v := range vs {
+		dst = AppendVarint(dst, v)
+	}
+	return dst
+}
+
+// DecodeUvarints 解码 src 中连续存放的全部无符号变长整数并追加到 dst
+func DecodeUvarints(dst []uint64, src []byte) ([]uint64, error) {
+	for len(src) > 0 {
+		v, n, err := Uvarint(src)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, v)
+		src = src[n:]
+	}
+	return dst, nil
+}
+
+// DecodeVarints 解码 src 中连续存放的全部 zigzag 变长整数并追加到 dst
+func DecodeVarints(dst []int64, src []byte) ([]int64, error) {
+	for len(src) > 0 {
+		v, n, err := Varint(src)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, v)
+		src = src[n:]
+	}
+	return dst, nil
+}
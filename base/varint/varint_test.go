@@ -0,0 +1,153 @@
+package varint_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/base/varint"
+)
+
+// TestZigZag 测试 zigzag 映射
+func TestZigZag(t *testing.T) {
+	testCases := []struct {
+		v int64
+		u uint64
+	}{
+		{0, 0}, {-1, 1}, {1, 2}, {-2, 3}, {2, 4},
+		{math.MaxInt64, math.MaxUint64 - 1},
+		{math.MinInt64, math.MaxUint64},
+	}
+	for _, tc := range testCases {
+		if u := varint.ZigZag(tc.v); u != tc.u {
+			t.Errorf("ZigZag(%d) = %d，预期 %d", tc.v, u, tc.u)
+		}
+		if v := varint.UnZigZag(tc.u); v != tc.v {
+			t.Errorf("UnZigZag(%d) = %d，预期 %d", tc.u, v, tc.v)
+		}
+	}
+}
+
+// TestCompatibleWithBinary 测试编码结果与 encoding/binary 一致
+func TestCompatibleWithBinary(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := []uint64{0, 1, 127, 128, 300, math.MaxUint32, math.MaxUint64}
+	for range 1000 {
+		values = append(values, r.Uint64()>>uint(r.Intn(64)))
+	}
+
+	for _, v := range values {
+		got := varint.AppendUvarint(nil, v)
+		expected := binary.AppendUvarint(nil, v)
+		if !slices.Equal(got, expected) {
+			t.Fatalf("AppendUvarint(%d) = %X，预期 %X", v, got, expected)
+		}
+		if varint.Len(v) != len(expected) {
+			t.Fatalf("Len(%d) = %d，预期 %d", v, varint.Len(v), len(expected))
+		}
+		d, n, err := varint.Uvarint(got)
+		if err != nil || d != v || n != len(got) {
+			t.Fatalf("Uvarint(%X) = (%d, %d, %v)，预期 (%d, %d, nil)", got, d, n, err, v, len(got))
+		}
+
+		s := int64(v)
+		if sv, _, _ := varint.Varint(varint.AppendVarint(nil, s)); sv != s {
+			t.Fatalf("有符号往返失败: %d", s)
+		}
+		if !slices.Equal(varint.AppendVarint(nil, s), binary.AppendVarint(nil, s)) {
+			t.Fatalf("AppendVarint(%d) 与 binary.AppendVarint 不一致", s)
+		}
+	}
+}
+
+// TestDecodeErrors 测试截断与溢出输入
+func TestDecodeErrors(t *testing.T) {
+	if _, _, err := varint.Uvarint([]byte{0x80, 0x80}); !errors.Is(err, varint.ErrTruncated) {
+		t.Errorf("截断输入错误 = %v，预期 ErrTruncated", err)
+	}
+	if _, _, err := varint.Uvarint(nil); !errors.Is(err, varint.ErrTruncated) {
+		t.Errorf("空输入错误 = %v，预期 ErrTruncated", err)
+	}
+	overflow := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x02}
+	if _, _, err := varint.Uvarint(overflow); !errors.Is(err, varint.ErrOverflow) {
+		t.Errorf("溢出输入错误 = %v，预期 ErrOverflow", err)
+	}
+	tooLong := []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}
+	if _, _, err := varint.Uvarint(tooLong); !errors.Is(err, varint.ErrOverflow) {
+		t.Errorf("超长输入错误 = %v，预期 ErrOverflow", err)
+	}
+}
+
+// TestBatch 测试批量编解码
+func TestBatch(t *testing.T) {
+	us := []uint64{0, 1, 300, math.MaxUint64, 42}
+	buf := varint.AppendUvarints(nil, us)
+	got, err := varint.DecodeUvarints(nil, buf)
+	if err != nil || !slices.Equal(got, us) {
+		t.Errorf("DecodeUvarints = (%v, %v)，预期 %v", got, err, us)
+	}
+
+	ss := []int64{0, -1, 1, math.MinInt64, math.MaxInt64, -300}
+	sbuf := varint.AppendVarints([]byte{0x00}, ss)
+	sgot, err := varint.DecodeVarints(nil, sbuf[1:])
+	if err != nil || !slices.Equal(sgot, ss) {
+		t.Errorf("DecodeVarints = (%v, %v)，预期 %v", sgot, err, ss)
+	}
+}
+
+// TestAppendNoAlloc 测试复用缓冲区时编码不分配内存
+func TestAppendNoAlloc(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = varint.AppendVarint(buf[:0], -123456789)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendVarint 分配次数 = %v，预期 0", allocs)
+	}
+}
+
+// TestAppendBatchAllocs 测试批量编码至多扩容一次，缓冲区容量足够时不分配内存
+func TestAppendBatchAllocs(t *testing.T) {
+	us := make([]uint64, 100)
+	ss := make([]int64, 100)
+	for i := range us {
+		us[i] = math.MaxUint64 >> i % 64
+		ss[i] = math.MinInt64 >> i % 64
+	}
+	buf := make([]byte, 1, 8)
+	if allocs := testing.AllocsPerRun(100, func() {
+		_ = varint.AppendUvarints(buf, us)
+	}); allocs > 1 {
+		t.Errorf("AppendUvarints 分配次数 = %v，预期至多 1", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() {
+		_ = varint.AppendVarints(buf, ss)
+	}); allocs > 1 {
+		t.Errorf("AppendVarints 分配次数 = %v，预期至多 1", allocs)
+	}
+	big := make([]byte, 0, len(us)*varint.MaxLen64)
+	if allocs := testing.AllocsPerRun(100, func() {
+		big = varint.AppendUvarints(big[:0], us)
+		big = varint.AppendVarints(big[:0], ss)
+	}); allocs != 0 {
+		t.Errorf("容量足够时批量编码分配次数 = %v，预期 0", allocs)
+	}
+}
+
+// BenchmarkAppendUvarint 对比本包与 encoding/binary 的编码性能
+func BenchmarkAppendUvarint(b *testing.B) {
+	buf := make([]byte, 0, varint.MaxLen64)
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = binary.AppendUvarint(buf[:0], uint64(i)*0x9E3779B9)
+		}
+	})
+	b.Run("varint", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = varint.AppendUvarint(buf[:0], uint64(i)*0x9E3779B9)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+// Package workpool 提供固定数量 worker 与有界任务队列的工作池。
+//
+// 队列有界使提交方在下游处理不过来时感受到背压：阻塞模式下 Submit 等待队列出现空位，
+// 拒绝模式下立即返回 ErrFull，由调用方决定降级或重试，而不是无限堆积任务直到内存耗尽。
+package workpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrFull 表示拒绝模式下队列已满
+	ErrFull = errors.New("workpool: 队列已满")
+	// ErrClosed 表示工作池已关闭
+	ErrClosed = errors.New("workpool: 已关闭")
+)
+
+// Mode 是队列满时 Submit 的行为
+type Mode uint8
+
+const (
+	Block  Mode = iota // 阻塞等待队列出现空位
+	Reject             // 立即返回 ErrFull
+)
+
+// Options 是工作池的配置
+type Options struct {
+	Workers   int  // worker 数量，不为正数时为 1
+	QueueSize int  // 队列容量，为 0 时任务必须直接交给空闲的 worker
+	Mode      Mode // 队列满时的行为
+}
+
+// Task 是提交给工作池的任务，ctx 为创建工作池时传入的上下文
+type Task func(ctx context.Context)
+
+// job 是队列中的任务及其入队时间
+type job struct {
+	task     Task
+	enqueued time.Time
+}
+
+// Pool 是工作池，零值不可用，应使用 New 创建
+type Pool struct {
+	ctx   context.Context
+	mode  Mode
+	queue chan job
+	wg    sync.WaitGroup
+
+	mu         sync.RWMutex // 保护 closed，使 Close 之后的 Submit 不再登记到 submitters
+	closed     bool
+	closing    chan struct{}  // Close 开始时关闭，唤醒阻塞在队列上的 Submit
+	submitters sync.WaitGroup // 正在执行的 Submit，全部返回后才能关闭 queue
+	closeOnce  sync.Once
+
+	submitted, completed, rejected atomic.Uint64
+	waitNs, runNs                  atomic.Int64
+}
+
+// New 创建并启动工作池；ctx 结束后 worker 不再执行队列中剩余的任务并退出
+func New(ctx context.Context, opts Options) *Pool {
+	p := &Pool{
+		ctx:     ctx,
+		mode:    opts.Mode,
+		queue:   make(chan job, max(opts.QueueSize, 0)),
+		closing: make(chan struct{}),
+	}
+	for range max(opts.Workers, 1) {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+// worker 循环执行队列中的任务
+func (p *Pool) worker() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case j, ok := <-p.queue:
+			if !ok {
+				return
+			}
+			start := time.Now()
+			p.waitNs.Add(int64(start.Sub(j.enqueued)))
+			j.task(p.ctx)
+			p.runNs.Add(int64(time.Since(start)))
+			p.completed.Add(1)
+		}
+	}
+}
+
+// Submit 提交任务
+// 阻塞模式下队列已满时等待，直到出现空位、ctx 结束或工作池的上下文结束；拒绝模式下返回 ErrFull。
+// 工作池已关闭或在等待期间开始关闭时返回 ErrClosed。
+func (p *Pool) Submit(ctx context.Context, task Task) error {
+	// 不在阻塞发送期间持有锁：任务中调用 Submit 时，持有的读锁会使等待写锁的 Close 与 worker 相互等待
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrClosed
+	}
+	p.submitters.Add(1)
+	p.mu.RUnlock()
+	defer p.submitters.Done()
+
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+
+	j := job{task: task, enqueued: time.Now()}
+	if p.mode == Reject {
+		select {
+		case p.queue <- j:
+			p.submitted.Add(1)
+			return nil
+		default:
+			p.rejected.Add(1)
+			return ErrFull
+		}
+	}
+	select {
+	case p.queue <- j:
+		p.submitted.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	case <-p.closing:
+		return ErrClosed
+	}
+}
+
+// Close 停止接受新任务，等待已提交的任务执行完毕后返回；可以重复调用
+// 正在阻塞等待队列空位的 Submit 返回 ErrClosed；工作池的上下文已结束时，未执行的任务被丢弃
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		p.mu.Lock()
+		p.closed = true
+		p.mu.Unlock()
+		close(p.closing)
+		p.submitters.Wait()
+		close(p.queue)
+	})
+	p.wg.Wait()
+}
+
+// Stats 是工作池的运行统计
+type Stats struct {
+	QueueDepth int           // 当前排队的任务数
+	Submitted  uint64        // 成功提交的任务数
+	Completed  uint64        // 执行完毕的任务数
+	Rejected   uint64        // 因队列已满被拒绝的任务数
+	AvgWait    time.Duration // 已执行任务从提交到开始执行的平均等待时间
+	AvgRun     time.Duration // 已执行任务的平均执行时间
+}
+
+// Stats 返回运行统计；各项分别读取，并发运行时彼此之间可能不完全一致
+func (p *Pool) Stats() Stats {
+	s := Stats{
+		QueueDepth: len(p.queue),
+		Submitted:  p.submitted.Load(),
+		Completed:  p.completed.Load(),
+		Rejected:   p.rejected.Load(),
+	}
+	if s.Completed > 0 {
+		s.AvgWait = time.Duration(p.waitNs.Load() / int64(s.Completed))
+		s.AvgRun = time.Duration(p.runNs.Load() / int64(s.Completed))
+	}
+	return s
+}
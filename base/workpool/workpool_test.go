@@ -0,0 +1,180 @@
+package workpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/workpool"
+)
+
+// TestPool 测试任务全部执行且统计正确
+func TestPool(t *testing.T) {
+	p := workpool.New(context.Background(), workpool.Options{Workers: 4, QueueSize: 8})
+	var n atomic.Int64
+	for i := range 100 {
+		if err := p.Submit(context.Background(), func(context.Context) {
+			time.Sleep(10 * time.Microsecond)
+			n.Add(int64(i))
+		}); err != nil {
+			t.Fatalf("Submit 失败: %v", err)
+		}
+	}
+	p.Close()
+	if n.Load() != 99*100/2 {
+		t.Errorf("任务结果之和为 %d", n.Load())
+	}
+	s := p.Stats()
+	if s.Submitted != 100 || s.Completed != 100 || s.QueueDepth != 0 || s.AvgRun <= 0 {
+		t.Errorf("Stats = %+v", s)
+	}
+	if err := p.Submit(context.Background(), func(context.Context) {}); !errors.Is(err, workpool.ErrClosed) {
+		t.Errorf("关闭后 Submit 返回 %v，预期 ErrClosed", err)
+	}
+	p.Close() // 重复关闭
+}
+
+// blockWorkers 提交 workers 个阻塞任务占满所有 worker，返回释放它们的函数
+func blockWorkers(t *testing.T, p *workpool.Pool, workers int) func() {
+	release := make(chan struct{})
+	var started sync.WaitGroup
+	started.Add(workers)
+	for range workers {
+		if err := p.Submit(context.Background(), func(context.Context) {
+			started.Done()
+			<-release
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	started.Wait()
+	return func() { close(release) }
+}
+
+// TestReject 测试拒绝模式下队列满时立即返回 ErrFull
+func TestReject(t *testing.T) {
+	p := workpool.New(context.Background(), workpool.Options{Workers: 1, QueueSize: 2, Mode: workpool.Reject})
+	release := blockWorkers(t, p, 1)
+	for range 2 {
+		if err := p.Submit(context.Background(), func(context.Context) {}); err != nil {
+			t.Fatalf("队列未满时 Submit 失败: %v", err)
+		}
+	}
+	if err := p.Submit(context.Background(), func(context.Context) {}); !errors.Is(err, workpool.ErrFull) {
+		t.Errorf("队列已满时 Submit 返回 %v，预期 ErrFull", err)
+	}
+	if s := p.Stats(); s.Rejected != 1 || s.QueueDepth != 2 {
+		t.Errorf("Stats = %+v", s)
+	}
+	release()
+	p.Close()
+}
+
+// TestBlock 测试阻塞模式下 Submit 等待空位，并可被 ctx 取消
+func TestBlock(t *testing.T) {
+	p := workpool.New(context.Background(), workpool.Options{Workers: 1, QueueSize: 1})
+	release := blockWorkers(t, p, 1)
+	if err := p.Submit(context.Background(), func(context.Context) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Submit(ctx, func(context.Context) {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("超时后 Submit 返回 %v，预期 DeadlineExceeded", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- p.Submit(context.Background(), func(context.Context) {}) }()
+	select {
+	case <-done:
+		t.Fatalf("队列已满时 Submit 不应返回")
+	case <-time.After(10 * time.Millisecond):
+	}
+	release()
+	if err := <-done; err != nil {
+		t.Errorf("出现空位后 Submit 返回 %v", err)
+	}
+	p.Close()
+	if s := p.Stats(); s.Completed != 3 || s.AvgWait <= 0 {
+		t.Errorf("Stats = %+v", s)
+	}
+}
+
+// TestCancel 测试工作池的上下文结束后 worker 退出、Submit 返回上下文错误
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := workpool.New(ctx, workpool.Options{Workers: 2, QueueSize: 4})
+	var seen atomic.Bool
+	started := make(chan struct{})
+	p.Submit(context.Background(), func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		seen.Store(true)
+	})
+	<-started
+	cancel()
+	p.Close()
+	if !seen.Load() {
+		t.Errorf("任务未收到取消信号")
+	}
+	if err := p.Submit(context.Background(), func(context.Context) {}); err == nil {
+		t.Errorf("取消后 Submit 应返回错误")
+	}
+}
+
+// TestSubmitFromTaskDuringClose 测试任务中阻塞的 Submit 不会使 Close 死锁，而是返回 ErrClosed
+func TestSubmitFromTaskDuringClose(t *testing.T) {
+	p := workpool.New(context.Background(), workpool.Options{Workers: 1})
+	started := make(chan struct{})
+	inner := make(chan error, 1)
+	p.Submit(context.Background(), func(context.Context) {
+		close(started)
+		// 唯一的 worker 正在执行本任务且队列容量为 0，这次提交会一直阻塞
+		inner <- p.Submit(context.Background(), func(context.Context) {})
+	})
+	<-started
+	time.Sleep(20 * time.Millisecond) // 等待内层 Submit 进入阻塞
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close 死锁")
+	}
+	if err := <-inner; !errors.Is(err, workpool.ErrClosed) {
+		t.Errorf("内层 Submit 错误 = %v，预期 ErrClosed", err)
+	}
+}
+
+// BenchmarkSubmit 对比通过工作池与直接启动 goroutine 执行小任务的开销
+func BenchmarkSubmit(b *testing.B) {
+	b.Run("workpool", func(b *testing.B) {
+		b.ReportAllocs()
+		p := workpool.New(context.Background(), workpool.Options{Workers: 4, QueueSize: 256})
+		var wg sync.WaitGroup
+		wg.Add(b.N)
+		task := func(context.Context) { wg.Done() }
+		for i := 0; i < b.N; i++ {
+			p.Submit(context.Background(), task)
+		}
+		wg.Wait()
+		p.Close()
+	})
+	b.Run("go", func(b *testing.B) {
+		b.ReportAllocs()
+		var wg sync.WaitGroup
+		wg.Add(b.N)
+		for i := 0; i < b.N; i++ {
+			go wg.Done()
+		}
+		wg.Wait()
+	})
+}
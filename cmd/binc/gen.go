@@ -0,0 +1,633 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// kind 是字段的编码类别
+type kind uint8
+
+const (
+	kindBool   kind = iota
+	kindInt         // 有符号整数
+	kindUint        // 无符号整数
+	kindFloat       // 浮点数
+	kindString      // 字符串，长度前缀加内容
+	kindBytes       // []byte，长度前缀加内容
+	kindArray       // [N]byte，原样写入
+)
+
+// field 描述一个字段的编码方式
+type field struct {
+	Name  string
+	Type  string   // 字段类型在生成代码中的写法，解码时用于类型转换
+	pkgs  []string // Type 中引用的其他包
+	kind  kind
+	size  int  // 整数与浮点数类型的字节数，int、uint 与 uintptr 为 0（与平台相关）；[N]byte 为 N
+	width int  // 整数的编码字节数或长度前缀的字节数，0 表示 varint/uvarint
+	big   bool // 是否大端
+}
+
+// fixed 返回字段的编码是否定长
+func (f field) fixed() bool {
+	switch f.kind {
+	case kindInt, kindUint:
+		return f.width > 0
+	case kindString, kindBytes:
+		return false
+	}
+	return true
+}
+
+// encSize 返回定长字段的编码字节数
+func (f field) encSize() int {
+	switch f.kind {
+	case kindInt, kindUint:
+		return f.width
+	case kindBool:
+		return 1
+	}
+	return f.size
+}
+
+// structType 是一个需要生成编解码方法的结构体
+type structType struct {
+	Name      string
+	Fields    []field
+	Marshal   string // MarshalBinaryTo 的函数体
+	Unmarshal string // UnmarshalBinary 的函数体
+}
+
+// config 描述一次代码生成的输入
+type config struct {
+	Package string
+	Types   []structType
+	Args    string
+}
+
+// load 解析 dir 中的 Go 文件，收集 names 中各个结构体的字段；skip 为需要忽略的文件名（即输出文件）
+func load(dir string, names []string, endian, skip string) (config, error) {
+	var big bool
+	switch endian {
+	case "little":
+	case "big":
+		big = true
+	default:
+		return config{}, fmt.Errorf("非法的字节序 %q，应为 little 或 big", endian)
+	}
+	for _, name := range names {
+		if !token.IsIdentifier(name) {
+			return config{}, fmt.Errorf("非法的类型名 %q", name)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return config{}, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == skip {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return config{}, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return config{}, fmt.Errorf("目录 %s 中没有 Go 文件", dir)
+	}
+
+	// 只需要字段的类型，忽略与本次生成无关的类型检查错误
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil), Error: func(error) {}}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+
+	cfg := config{Package: pkg.Name()}
+	for _, name := range names {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			return config{}, fmt.Errorf("未找到类型 %s", name)
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return config{}, fmt.Errorf("类型 %s 不是结构体", name)
+		}
+		t := structType{Name: name}
+		for i := range st.NumFields() {
+			v := st.Field(i)
+			if v.Name() == "_" {
+				continue
+			}
+			tag, _ := reflect.StructTag(st.Tag(i)).Lookup("bin")
+			f, skip, err := parseField(pkg, v, tag, big)
+			if err != nil {
+				return config{}, fmt.Errorf("%s.%s: %w", name, v.Name(), err)
+			}
+			if !skip {
+				t.Fields = append(t.Fields, f)
+			}
+		}
+		cfg.Types = append(cfg.Types, t)
+	}
+	return cfg, nil
+}
+
+// intSize 是大小与平台无关的整数类型的字节数
+var intSize = map[types.BasicKind]int{
+	types.Int8: 1, types.Int16: 2, types.Int32: 4, types.Int64: 8,
+	types.Uint8: 1, types.Uint16: 2, types.Uint32: 4, types.Uint64: 8,
+}
+
+// parseField 根据字段类型与 bin 标签确定编码方式，skip 表示标签要求跳过该字段
+func parseField(pkg *types.Package, v *types.Var, tag string, big bool) (f field, skip bool, err error) {
+	f = field{Name: v.Name(), big: big, width: -1}
+	f.Type = types.TypeString(v.Type(), func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		f.pkgs = append(f.pkgs, p.Path())
+		return p.Name()
+	})
+	varint := false
+	for opt := range strings.SplitSeq(tag, ",") {
+		switch opt {
+		case "":
+		case "-":
+			return field{}, true, nil
+		case "8", "16", "32", "64":
+			bits, _ := strconv.Atoi(opt)
+			f.width = bits / 8
+		case "varint":
+			varint = true
+		case "be":
+			f.big = true
+		case "le":
+			f.big = false
+		default:
+			return field{}, false, fmt.Errorf("未知的标签选项 %q", opt)
+		}
+	}
+
+	switch u := v.Type().Underlying().(type) {
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsBoolean != 0:
+			f.kind = kindBool
+		case info&types.IsInteger != 0:
+			f.kind = kindInt
+			if info&types.IsUnsigned != 0 {
+				f.kind = kindUint
+			}
+			f.size = intSize[u.Kind()]
+		case info&types.IsFloat != 0:
+			f.kind, f.size = kindFloat, 8
+			if u.Kind() == types.Float32 {
+				f.size = 4
+			}
+		case info&types.IsString != 0:
+			f.kind = kindString
+		default:
+			return field{}, false, fmt.Errorf("不支持的类型 %s", f.Type)
+		}
+	case *types.Slice:
+		if !isByte(u.Elem()) {
+			return field{}, false, fmt.Errorf("不支持的类型 %s", f.Type)
+		}
+		f.kind = kindBytes
+	case *types.Array:
+		if !isByte(u.Elem()) {
+			return field{}, false, fmt.Errorf("不支持的类型 %s", f.Type)
+		}
+		f.kind, f.size = kindArray, int(u.Len())
+	default:
+		return field{}, false, fmt.Errorf("不支持的类型 %s", f.Type)
+	}
+
+	switch f.kind {
+	case kindInt, kindUint:
+		switch {
+		case varint && f.width > 0:
+			return field{}, false, errors.New("不能同时指定位数与 varint")
+		case varint:
+			f.width = 0
+		case f.width < 0 && f.size == 0:
+			return field{}, false, fmt.Errorf("%s 的大小与平台相关，必须在标签中指定位数", f.Type)
+		case f.width < 0:
+			f.width = f.size
+		}
+	case kindString, kindBytes:
+		if f.width < 0 || varint {
+			f.width = 0
+		}
+	default:
+		if f.width >= 0 || varint {
+			return field{}, false, fmt.Errorf("%s 类型的字段不能指定位数或 varint", f.Type)
+		}
+	}
+	return f, false, nil
+}
+
+// isByte 返回 t 是否为 byte
+func isByte(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == types.Uint8
+}
+
+// writer 生成一个函数体，并记录用到的标准库包
+type writer struct {
+	buf     bytes.Buffer
+	imports map[string]bool
+}
+
+// p 写入一行代码，pkgs 为这一行用到的包
+func (w *writer) p(line string, pkgs ...string) {
+	w.buf.WriteString(line)
+	w.buf.WriteByte('\n')
+	for _, pkg := range pkgs {
+		w.imports[pkg] = true
+	}
+}
+
+// typ 返回字段类型在生成代码中的写法，并记录其引用的包
+func (w *writer) typ(f field) string {
+	for _, pkg := range f.pkgs {
+		w.imports[pkg] = true
+	}
+	return f.Type
+}
+
+// order 返回字段字节序对应的 binary.ByteOrder 变量
+func (f field) order() string {
+	if f.big {
+		return "binary.BigEndian"
+	}
+	return "binary.LittleEndian"
+}
+
+// intType 返回与字段编码宽度相同、符号相同的整数类型
+func (f field) intType() string {
+	bits := strconv.Itoa(f.width * 8)
+	if f.kind == kindInt {
+		return "int" + bits
+	}
+	return "uint" + bits
+}
+
+// putUint 生成把 x（类型为 from）按 width 个字节追加到 dst 的语句
+func putUint(w *writer, f field, width int, x, from string) {
+	to := "uint" + strconv.Itoa(width*8)
+	if width == 1 {
+		to = "byte"
+	}
+	if !sameType(from, to) {
+		x = to + "(" + x + ")"
+	}
+	if width == 1 {
+		w.p("dst = append(dst, " + x + ")")
+		return
+	}
+	w.p(fmt.Sprintf("dst = %s.AppendUint%d(dst, %s)", f.order(), width*8, x), "encoding/binary")
+}
+
+// sameType 返回类型名 a 与 b 是否表示同一个类型
+func sameType(a, b string) bool {
+	alias := func(t string) string {
+		if t == "byte" {
+			return "uint8"
+		}
+		return t
+	}
+	return alias(a) == alias(b)
+}
+
+// getUint 返回从 data[off:] 读取 width 个字节的无符号整数的表达式
+func getUint(w *writer, f field, width int, off string) string {
+	if width == 1 {
+		return "data[" + off + "]"
+	}
+	w.imports["encoding/binary"] = true
+	return fmt.Sprintf("%s.Uint%d(data[%s:])", f.order(), width*8, off)
+}
+
+// convert 返回把 x（类型为 from）转换为字段类型的表达式，类型相同时省略转换
+func (w *writer) convert(f field, x, from string) string {
+	if sameType(f.Type, from) {
+		return x
+	}
+	return w.typ(f) + "(" + x + ")"
+}
+
+// marshal 生成 MarshalBinaryTo 的函数体
+func marshal(t structType, imports map[string]bool) string {
+	w := &writer{imports: imports}
+	size, dynamic := 0, ""
+	checked := false
+	for _, f := range t.Fields {
+		switch {
+		case f.fixed():
+			size += f.encSize()
+		case f.kind == kindInt || f.kind == kindUint:
+			size += 10 // binary.MaxVarintLen64
+		default:
+			size += f.width // 定长前缀
+			if f.width == 0 {
+				size += 10 // uvarint 前缀
+			}
+			dynamic += "+len(v." + f.Name + ")"
+		}
+		checked = checked || f.mayFailMarshal()
+	}
+	if checked {
+		w.p("start := len(dst)")
+	}
+	w.p(fmt.Sprintf("dst = slices.Grow(dst, %d%s)", size, dynamic), "slices")
+
+	for _, f := range t.Fields {
+		x := "v." + f.Name
+		errorf := func(msg string) {
+			w.p(fmt.Sprintf("return dst[:start], errors.New(%q)", t.Name+"."+f.Name+": "+msg), "errors")
+		}
+		switch f.kind {
+		case kindBool:
+			w.p("if " + x + " {")
+			w.p("dst = append(dst, 1)")
+			w.p("} else {")
+			w.p("dst = append(dst, 0)")
+			w.p("}")
+		case kindInt, kindUint:
+			switch {
+			case f.width == 0 && f.kind == kindInt:
+				w.p("dst = binary.AppendVarint(dst, int64("+x+"))", "encoding/binary")
+			case f.width == 0:
+				w.p("dst = binary.AppendUvarint(dst, uint64("+x+"))", "encoding/binary")
+			default:
+				if f.size == 0 || f.width < f.size {
+					w.p(fmt.Sprintf("if %s(%s(%s)) != %s {", w.typ(f), f.intType(), x, x))
+					errorf(fmt.Sprintf("超出 %d 位整数的表示范围", f.width*8))
+					w.p("}")
+				}
+				putUint(w, f, f.width, x, f.Type)
+			}
+		case kindFloat:
+			bits := strconv.Itoa(f.size * 8)
+			w.imports["math"] = true
+			putUint(w, f, f.size, "math.Float"+bits+"bits("+f.convertTo("float"+bits, x)+")", "uint"+bits)
+		case kindString, kindBytes:
+			n := "len(" + x + ")"
+			if f.width == 0 {
+				w.p("dst = binary.AppendUvarint(dst, uint64("+n+"))", "encoding/binary")
+			} else {
+				if f.width < 8 {
+					w.p(fmt.Sprintf("if uint64(%s) > %#x {", n, uint64(1)<<(f.width*8)-1))
+					errorf(fmt.Sprintf("长度超出 %d 位长度前缀的表示范围", f.width*8))
+					w.p("}")
+				}
+				putUint(w, f, f.width, n, "int")
+			}
+			w.p("dst = append(dst, " + x + "...)")
+		case kindArray:
+			w.p("dst = append(dst, " + x + "[:]...)")
+		}
+	}
+	w.p("return dst, nil")
+	return w.buf.String()
+}
+
+// mayFailMarshal 返回编码该字段时是否需要检查取值范围
+func (f field) mayFailMarshal() bool {
+	switch f.kind {
+	case kindInt, kindUint:
+		return f.width > 0 && (f.size == 0 || f.width < f.size)
+	case kindString, kindBytes:
+		return f.width > 0 && f.width < 8
+	}
+	return false
+}
+
+// convertTo 返回把字段值 x 转换为类型 to 的表达式，类型相同时省略转换
+func (f field) convertTo(to, x string) string {
+	if f.Type == to {
+		return x
+	}
+	return to + "(" + x + ")"
+}
+
+// unmarshal 生成 UnmarshalBinary 的函数体
+// 相邻的定长字段合并为一段，只检查一次长度，段内按常量偏移读取
+func unmarshal(t structType, imports map[string]bool) string {
+	w := &writer{imports: imports}
+	errorf := func(f field, msg string) {
+		w.p(fmt.Sprintf("return errors.New(%q)", t.Name+"."+f.Name+": "+msg), "errors")
+	}
+	eof := func() { w.p("return io.ErrUnexpectedEOF", "io") }
+
+	fields := t.Fields
+	for len(fields) > 0 {
+		if !fields[0].fixed() {
+			unmarshalVar(w, fields[0], errorf, eof)
+			fields = fields[1:]
+			continue
+		}
+		n := 1
+		for n < len(fields) && fields[n].fixed() {
+			n++
+		}
+		run := fields[:n]
+		fields = fields[n:]
+
+		size := 0
+		for _, f := range run {
+			size += f.encSize()
+		}
+		w.p(fmt.Sprintf("if len(data) < %d {", size))
+		eof()
+		w.p("}")
+		off := 0
+		for _, f := range run {
+			o := strconv.Itoa(off)
+			x := "v." + f.Name
+			switch f.kind {
+			case kindBool:
+				w.p("switch data[" + o + "] {")
+				w.p("case 0:")
+				w.p(x + " = false")
+				w.p("case 1:")
+				w.p(x + " = true")
+				w.p("default:")
+				errorf(f, "非法的布尔值")
+				w.p("}")
+			case kindInt, kindUint:
+				raw := getUint(w, f, f.width, o)
+				it := f.intType()
+				if f.kind == kindInt {
+					raw = it + "(" + raw + ")"
+				}
+				// int、uint 与 uintptr 至少为 32 位，只有 64 位编码可能超出范围
+				if f.size == 0 && f.width == 8 || f.size > 0 && f.width > f.size {
+					w.p(fmt.Sprintf("if x := %s; %s(%s(x)) != x {", raw, f.intType(), w.typ(f)))
+					errorf(f, "超出 "+f.Type+" 的表示范围")
+					w.p("} else {")
+					w.p(x + " = " + w.typ(f) + "(x)")
+					w.p("}")
+				} else {
+					w.p(x + " = " + w.convert(f, raw, it))
+				}
+			case kindFloat:
+				bits := strconv.Itoa(f.size * 8)
+				w.p(x+" = "+w.convert(f, "math.Float"+bits+"frombits("+getUint(w, f, f.size, o)+")", "float"+bits), "math")
+			case kindArray:
+				w.p("copy(" + x + "[:], data[" + o + ":])")
+			}
+			off += f.encSize()
+		}
+		w.p(fmt.Sprintf("data = data[%d:]", size))
+	}
+	w.p("if len(data) != 0 {")
+	w.p(fmt.Sprintf("return errors.New(%q)", t.Name+": 数据末尾有多余的字节"), "errors")
+	w.p("}")
+	w.p("return nil")
+	return w.buf.String()
+}
+
+// unmarshalVar 生成变长字段的解码语句
+func unmarshalVar(w *writer, f field, errorf func(field, string), eof func()) {
+	x := "v." + f.Name
+	assign := func(b string) {
+		if f.kind == kindBytes {
+			w.p(x + " = append(" + x + "[:0], " + b + "...)")
+		} else {
+			w.p(x + " = " + w.typ(f) + "(" + b + ")")
+		}
+	}
+	switch {
+	case f.kind == kindInt || f.kind == kindUint:
+		fn, it := "binary.Uvarint", "uint64"
+		if f.kind == kindInt {
+			fn, it = "binary.Varint", "int64"
+		}
+		w.p("if x, n := "+fn+"(data); n == 0 {", "encoding/binary")
+		eof()
+		w.p("} else if n < 0 {")
+		errorf(f, "varint 溢出")
+		if f.size < 8 {
+			w.p(fmt.Sprintf("} else if %s(%s(x)) != x {", it, w.typ(f)))
+			errorf(f, "超出 "+f.Type+" 的表示范围")
+		}
+		w.p("} else {")
+		w.p(x + " = " + w.convert(f, "x", it))
+		w.p("data = data[n:]")
+		w.p("}")
+	case f.width == 0:
+		w.p("if x, n := binary.Uvarint(data); n == 0 {", "encoding/binary")
+		eof()
+		w.p("} else if n < 0 {")
+		errorf(f, "varint 溢出")
+		w.p("} else if x > uint64(len(data)-n) {")
+		eof()
+		w.p("} else {")
+		assign("data[n : n+int(x)]")
+		w.p("data = data[n+int(x):]")
+		w.p("}")
+	default:
+		n := strconv.Itoa(f.width)
+		w.p("if len(data) < " + n + " {")
+		eof()
+		w.p("}")
+		raw := getUint(w, f, f.width, "0")
+		w.p("if x := uint64(" + raw + "); x > uint64(len(data)-" + n + ") {")
+		eof()
+		w.p("} else {")
+		assign("data[" + n + " : " + n + "+x]")
+		w.p("data = data[" + n + "+x:]")
+		w.p("}")
+	}
+}
+
+// generate 返回格式化后的生成代码
+func generate(cfg config) ([]byte, error) {
+	if len(cfg.Types) == 0 {
+		return nil, errors.New("未指定类型")
+	}
+	imports := make(map[string]bool)
+	for i := range cfg.Types {
+		t := &cfg.Types[i]
+		if len(t.Fields) == 0 {
+			return nil, fmt.Errorf("类型 %s 没有需要编码的字段", t.Name)
+		}
+		t.Marshal = marshal(*t, imports)
+		t.Unmarshal = unmarshal(*t, imports)
+	}
+	// 标准库与其他包分为两组导入
+	var std, other []string
+	for p := range imports {
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			other = append(other, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+
+	data := struct {
+		config
+		Std, Other []string
+	}{cfg, std, other}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("binc").Parse(`// Code generated by binc {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Std}}
+	"{{.}}"
+{{- end}}
+{{- if .Other}}
+{{range .Other}}
+	"{{.}}"
+{{- end}}
+{{- end}}
+)
+{{range .Types}}
+// MarshalBinaryTo 把 v 的二进制编码追加到 dst 并返回扩展后的切片
+// 整数或长度超出编码位数的表示范围时返回原来的 dst 与错误
+func (v *{{.Name}}) MarshalBinaryTo(dst []byte) ([]byte, error) {
+{{.Marshal -}}
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (v *{{.Name}}) MarshalBinary() ([]byte, error) {
+	return v.MarshalBinaryTo(nil)
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，data 必须恰好是一个完整的编码
+// string 字段复制 data 的内容，[]byte 字段复用已有的容量，都不引用 data；出错时 v 的内容不确定
+func (v *{{.Name}}) UnmarshalBinary(data []byte) error {
+{{.Unmarshal -}}
+}
+{{end}}`))
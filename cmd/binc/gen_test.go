@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateGolden 测试生成结果与 internal/example 中提交的文件一致，
+// 修改生成逻辑后需在 internal/example 下执行 go generate 更新对照文件
+func TestGenerateGolden(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	cfg, err := load(dir, []string{"Header", "Record"}, "little", "header_binc.go")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	cfg.Args = "-type=Header,Record"
+	got, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	expected, err := os.ReadFile(filepath.Join(dir, "header_binc.go"))
+	if err != nil {
+		t.Fatalf("读取对照文件失败: %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("生成结果与 internal/example/header_binc.go 不一致，请执行 go generate 更新")
+	}
+}
+
+// writePkg 把 src 写入临时目录并返回目录
+func writePkg(t *testing.T, src string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte("package p\n\n"+src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// TestEndian 测试默认字节序与字段级覆盖
+func TestEndian(t *testing.T) {
+	dir := writePkg(t, "type T struct {\n\tA uint32\n\tB uint32 `bin:\"le\"`\n}\n")
+	cfg, err := load(dir, []string{"T"}, "big", "")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	got, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	for _, want := range []string{
+		"binary.BigEndian.AppendUint32(dst, v.A)",
+		"binary.LittleEndian.AppendUint32(dst, v.B)",
+		"v.A = binary.BigEndian.Uint32(data[0:])",
+		"v.B = binary.LittleEndian.Uint32(data[4:])",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("生成结果中缺少 %q:\n%s", want, got)
+		}
+	}
+}
+
+// TestLoadErrors 测试非法的类型与标签
+func TestLoadErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		src    string
+		endian string
+	}{
+		{"非法的字节序", "type T struct{ A uint8 }", "middle"},
+		{"未找到类型", "type U struct{ A uint8 }", "little"},
+		{"不是结构体", "type T int", "little"},
+		{"未知的标签选项", "type T struct{ A uint8 `bin:\"12\"` }", "little"},
+		{"int 未指定位数", "type T struct{ A int }", "little"},
+		{"同时指定位数与 varint", "type T struct{ A int64 `bin:\"32,varint\"` }", "little"},
+		{"浮点数指定位数", "type T struct{ A float64 `bin:\"32\"` }", "little"},
+		{"布尔值指定 varint", "type T struct{ A bool `bin:\"varint\"` }", "little"},
+		{"不支持的类型", "type T struct{ A map[string]int }", "little"},
+		{"非字节切片", "type T struct{ A []uint16 }", "little"},
+		{"嵌套结构体", "type T struct{ A struct{ B uint8 } }", "little"},
+	}
+	for _, tc := range testCases {
+		dir := writePkg(t, tc.src)
+		if _, err := load(dir, []string{"T"}, tc.endian, ""); err == nil {
+			t.Errorf("%s: 应返回错误", tc.name)
+		}
+	}
+	if _, err := load(writePkg(t, "type T struct{}"), []string{"T-1"}, "little", ""); err == nil {
+		t.Errorf("非法的类型名: 应返回错误")
+	}
+}
+
+// TestGenerateErrors 测试没有可编码字段的结构体
+func TestGenerateErrors(t *testing.T) {
+	cfg, err := load(writePkg(t, "type T struct {\n\t_ uint8\n\tA int `bin:\"-\"`\n}\n"), []string{"T"}, "little", "")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	if _, err := generate(cfg); err == nil {
+		t.Errorf("没有需要编码的字段时应返回错误")
+	}
+	if _, err := generate(config{Package: "p"}); err == nil {
+		t.Errorf("未指定类型时应返回错误")
+	}
+}
@@ -0,0 +1,41 @@
+// Package example 是 binc 生成代码的示例，同时被 binc 的测试用作对照文件。
+package example
+
+import "time"
+
+//go:generate go run github.com/moweilong/efficient-go/cmd/binc -type=Header,Record
+
+// Kind 是消息类型
+type Kind uint8
+
+const (
+	KindData Kind = iota + 1
+	KindAck
+	KindClose
+)
+
+// Header 是定长的消息头，所有字段都使用默认的小端字节序与类型本身的宽度，
+// 编码结果与 encoding/binary 对同一结构体的编码相同，测试中以此对照
+type Header struct {
+	Magic    uint32
+	Version  uint16
+	Kind     Kind
+	Flags    uint8
+	Seq      int64
+	Ratio    float64
+	Scale    float32
+	Urgent   bool
+	Checksum [16]byte
+}
+
+// Record 是变长的记录，演示 bin 标签的各个选项
+type Record struct {
+	ID      uint64        `bin:"varint"`
+	Delta   int32         `bin:"varint"`
+	Port    int           `bin:"16,be"` // int 的大小与平台相关，必须指定位数
+	Code    int8          `bin:"32"`    // 宽于字段类型，解码时检查取值范围
+	TTL     time.Duration `bin:"varint"`
+	Name    string        `bin:"8"` // 1 字节长度前缀，最长 255 字节
+	Payload []byte
+	cached  int `bin:"-"`
+}
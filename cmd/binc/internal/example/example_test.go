@@ -0,0 +1,221 @@
+package example_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/cmd/binc/internal/example"
+)
+
+var header = example.Header{
+	Magic:    0xcafebabe,
+	Version:  3,
+	Kind:     example.KindAck,
+	Flags:    0x81,
+	Seq:      -42,
+	Ratio:    math.Pi,
+	Scale:    -0.5,
+	Urgent:   true,
+	Checksum: [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+}
+
+// TestHeaderMatchesEncodingBinary 测试定长结构体的编码与 encoding/binary 的结果一致
+func TestHeaderMatchesEncodingBinary(t *testing.T) {
+	got, err := header.MarshalBinaryTo([]byte("prefix"))
+	if err != nil {
+		t.Fatalf("MarshalBinaryTo 失败: %v", err)
+	}
+	want, err := binary.Append([]byte("prefix"), binary.LittleEndian, &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("编码 = %x，encoding/binary 为 %x", got, want)
+	}
+
+	var h example.Header
+	if err := h.UnmarshalBinary(want[len("prefix"):]); err != nil {
+		t.Fatalf("UnmarshalBinary 失败: %v", err)
+	}
+	if h != header {
+		t.Errorf("解码 = %+v，预期 %+v", h, header)
+	}
+}
+
+// TestRecordRoundTrip 测试变长结构体的往返编解码
+func TestRecordRoundTrip(t *testing.T) {
+	records := []example.Record{
+		{},
+		{ID: 1, Delta: -1, Port: 8080, Code: -3, TTL: time.Second, Name: "hello", Payload: []byte{0, 1, 2}},
+		{ID: math.MaxUint64, Delta: math.MinInt32, Port: math.MinInt16, Code: math.MaxInt8, TTL: math.MinInt64,
+			Name: strings.Repeat("n", 255), Payload: bytes.Repeat([]byte{0xff}, 1000)},
+	}
+	for _, r := range records {
+		data, err := r.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%+v) 失败: %v", r, err)
+		}
+		var got example.Record
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary 失败: %v", err)
+		}
+		if len(r.Payload) == 0 {
+			r.Payload = nil
+		}
+		if !reflect.DeepEqual(got, r) {
+			t.Errorf("往返结果 = %+v，预期 %+v", got, r)
+		}
+	}
+}
+
+// TestRecordLayout 测试标签指定的编码方式
+func TestRecordLayout(t *testing.T) {
+	r := example.Record{ID: 300, Delta: -2, Port: 0x1234, Code: -1, TTL: 1, Name: "ab", Payload: []byte{9}}
+	got, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0xac, 0x02, // ID：uvarint(300)
+		0x03,       // Delta：zigzag(-2) = 3
+		0x12, 0x34, // Port：16 位大端
+		0xff, 0xff, 0xff, 0xff, // Code：32 位小端
+		0x02,           // TTL：zigzag(1) = 2
+		0x02, 'a', 'b', // Name：1 字节长度前缀
+		0x01, 9, // Payload：uvarint 长度前缀
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("编码 = %x，预期 %x", got, want)
+	}
+}
+
+// TestMarshalErrors 测试超出编码位数的取值，出错时返回原来的 dst
+func TestMarshalErrors(t *testing.T) {
+	for _, r := range []example.Record{
+		{Port: math.MaxInt16 + 1},
+		{Port: math.MinInt16 - 1},
+		{Name: strings.Repeat("n", 256)},
+	} {
+		dst, err := r.MarshalBinaryTo([]byte("keep"))
+		if err == nil {
+			t.Errorf("MarshalBinaryTo(%+v) 应返回错误", r)
+		}
+		if string(dst) != "keep" {
+			t.Errorf("出错时 dst = %q，预期保持不变", dst)
+		}
+	}
+}
+
+// TestUnmarshalErrors 测试截断、多余字节与非法取值
+func TestUnmarshalErrors(t *testing.T) {
+	r := example.Record{ID: 1 << 40, Delta: 5, Port: 1, Code: 2, TTL: time.Hour, Name: "name", Payload: []byte("payload")}
+	data, _ := r.MarshalBinary()
+	var got example.Record
+	for n := range len(data) {
+		if err := got.UnmarshalBinary(data[:n]); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("截断为 %d 字节: err = %v，预期 io.ErrUnexpectedEOF", n, err)
+		}
+	}
+	if err := got.UnmarshalBinary(append(data, 0)); err == nil {
+		t.Errorf("末尾有多余字节时应返回错误")
+	}
+
+	hdr, _ := header.MarshalBinary()
+	var h example.Header
+	for n := range len(hdr) {
+		if err := h.UnmarshalBinary(hdr[:n]); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("截断为 %d 字节: err = %v，预期 io.ErrUnexpectedEOF", n, err)
+		}
+	}
+	hdr[28] = 2 // Urgent
+	if err := h.UnmarshalBinary(hdr); err == nil {
+		t.Errorf("非法的布尔值应返回错误")
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"Code 超出 int8", []byte{0, 0, 0, 0, 0x80, 0, 0, 0, 0, 0, 0}},
+		{"Delta 超出 int32", binary.AppendVarint([]byte{0}, math.MaxInt32+1)},
+		{"varint 溢出", bytes.Repeat([]byte{0xff}, 11)},
+	}
+	for _, tc := range testCases {
+		if err := got.UnmarshalBinary(tc.data); err == nil || errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%s: err = %v，预期取值错误", tc.name, err)
+		}
+	}
+}
+
+// TestAllocs 测试复用缓冲区时编解码不分配内存
+func TestAllocs(t *testing.T) {
+	buf := make([]byte, 0, 128)
+	var h example.Header
+	benchkit.AssertAllocs(t, 0, func() {
+		buf, _ = header.MarshalBinaryTo(buf[:0])
+		_ = h.UnmarshalBinary(buf)
+	})
+	r := example.Record{ID: 7, Port: 80, Payload: []byte("payload")}
+	got := example.Record{Payload: make([]byte, 0, 16)}
+	benchkit.AssertAllocs(t, 0, func() {
+		buf, _ = r.MarshalBinaryTo(buf[:0])
+		_ = got.UnmarshalBinary(buf)
+	})
+}
+
+// BenchmarkMarshal 对比生成代码与 encoding/binary 基于反射的编码
+func BenchmarkMarshal(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	b.Run("binc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf, _ = header.MarshalBinaryTo(buf[:0])
+		}
+		benchkit.SinkBytes = buf
+	})
+	b.Run("binary.Append", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf, _ = binary.Append(buf[:0], binary.LittleEndian, &header)
+		}
+		benchkit.SinkBytes = buf
+	})
+	b.Run("binary.Write", func(b *testing.B) {
+		var w bytes.Buffer
+		for i := 0; i < b.N; i++ {
+			w.Reset()
+			_ = binary.Write(&w, binary.LittleEndian, &header)
+		}
+		benchkit.SinkBytes = w.Bytes()
+	})
+}
+
+// BenchmarkUnmarshal 对比生成代码与 encoding/binary 基于反射的解码
+func BenchmarkUnmarshal(b *testing.B) {
+	data, _ := header.MarshalBinary()
+	var h example.Header
+	b.Run("binc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = h.UnmarshalBinary(data)
+		}
+	})
+	b.Run("binary.Decode", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = binary.Decode(data, binary.LittleEndian, &h)
+		}
+	})
+	b.Run("binary.Read", func(b *testing.B) {
+		r := bytes.NewReader(data)
+		for i := 0; i < b.N; i++ {
+			r.Reset(data)
+			_ = binary.Read(r, binary.LittleEndian, &h)
+		}
+	})
+	benchkit.SinkU64 = uint64(h.Seq)
+}
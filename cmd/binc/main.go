@@ -0,0 +1,61 @@
+// binc 为结构体生成不依赖反射的二进制编解码方法 MarshalBinaryTo、MarshalBinary 与 UnmarshalBinary，
+// 替代 encoding/binary 中 binary.Write/binary.Read 基于反射的实现。
+//
+// 用法：
+//
+//	//go:generate go run github.com/moweilong/efficient-go/cmd/binc -type=Header,Record -endian=big
+//
+// 字段按声明顺序紧凑编码，没有对齐填充，字段的编码方式由类型与 bin 标签决定，标签的多个选项以逗号分隔：
+//
+//	bin:"-"           跳过该字段（名为 _ 的字段也总是跳过）
+//	bin:"16"          整数字段的编码位数（8/16/32/64），可以窄于或宽于字段类型，编解码时检查取值范围；
+//	                  用于 string 与 []byte 字段时为长度前缀的位数，默认为 uvarint 前缀
+//	bin:"varint"      整数字段使用变长编码，有符号整数采用 zigzag
+//	bin:"32,be"       be/le 指定该字段使用大端/小端字节序，覆盖 -endian
+//
+// 支持的字段类型为布尔、整数、浮点数、string、[]byte 与 [N]byte，以及底层为这些类型的具名类型；
+// int、uint 与 uintptr 的大小与平台相关，必须在标签中指定位数。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "以逗号分隔的结构体类型名称（必填）")
+	endian := flag.String("endian", "little", "默认字节序，little 或 big")
+	output := flag.String("output", "", "输出文件，默认为 <第一个类型>_binc.go")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	names := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(names[0])+"_binc.go")
+	}
+
+	cfg, err := load(dir, names, *endian, filepath.Base(*output))
+	if err != nil {
+		fatal(err)
+	}
+	cfg.Args = strings.Join(os.Args[1:], " ")
+
+	src, err := generate(cfg)
+	if err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "binc:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"text/template"
+)
+
+// config 描述一次代码生成的输入
+type config struct {
+	Type    string   // 标志类型名称
+	Base    string   // 底层无符号整数类型
+	Package string   // 生成代码的包名
+	Names   []string // 按位从低到高排列的标志名称
+	NoDecl  bool     // 是否跳过类型声明
+	Args    string   // 命令行参数，写入文件头部便于追溯
+}
+
+// baseWidths 记录支持的底层类型及其位宽
+var baseWidths = map[string]int{
+	"uint8": 8, "byte": 8, "uint16": 16, "uint32": 32, "uint64": 64, "uint": 64,
+}
+
+// generate 校验配置并返回格式化后的 Go 源码
+func generate(cfg config) ([]byte, error) {
+	if !token.IsIdentifier(cfg.Type) {
+		return nil, fmt.Errorf("非法的类型名 %q", cfg.Type)
+	}
+	if cfg.Package == "" {
+		return nil, errors.New("未指定包名")
+	}
+	width, ok := baseWidths[cfg.Base]
+	if !ok {
+		return nil, fmt.Errorf("不支持的底层类型 %q", cfg.Base)
+	}
+	if len(cfg.Names) == 0 {
+		return nil, errors.New("至少需要一个标志名称")
+	}
+	if len(cfg.Names) > width {
+		return nil, fmt.Errorf("%d 个标志超出了 %s 的位宽", len(cfg.Names), cfg.Base)
+	}
+	seen := make(map[string]bool, len(cfg.Names))
+	for _, name := range cfg.Names {
+		if !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("非法的标志名 %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("标志名 %q 重复", name)
+		}
+		seen[name] = true
+	}
+
+	data := struct {
+		config
+		Width int
+	}{cfg, width}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("bitmask").Parse(`// Code generated by bitmaskgen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+{{if not .NoDecl}}
+// {{.Type}} 是位掩码标志类型，多个标志可以用 | 组合
+type {{.Type}} {{.Base}}
+{{end}}
+const (
+{{- range $i, $name := .Names}}
+	{{$name}}{{if eq $i 0}} {{$.Type}} = 1 << iota{{end}}
+{{- end}}
+)
+
+var _{{.Type}}Names = [...]string{
+{{- range .Names}}
+	"{{.}}",
+{{- end}}
+}
+
+// String 返回以 "|" 连接的标志名称，未命名的位以十六进制输出，值为 0 时返回 "0"
+func (f {{.Type}}) String() string {
+	if f == 0 {
+		return "0"
+	}
+	var sb strings.Builder
+	for f != 0 {
+		i := bits.TrailingZeros64(uint64(f))
+		f &^= 1 << i
+		if sb.Len() > 0 {
+			sb.WriteByte('|')
+		}
+		if i < len(_{{.Type}}Names) {
+			sb.WriteString(_{{.Type}}Names[i])
+		} else {
+			sb.WriteString("0x" + strconv.FormatUint(1<<i, 16))
+		}
+	}
+	return sb.String()
+}
+
+// Parse{{.Type}} 解析以 "|" 分隔的标志名称，例如 "{{index .Names 0}}"；
+// 每一项也可以是数值字面量（如 String 为未命名的位输出的 "0x10"）；空字符串与 "0" 解析为 0
+func Parse{{.Type}}(s string) ({{.Type}}, error) {
+	var f {{.Type}}
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+next:
+	for _, part := range strings.Split(s, "|") {
+		part = strings.TrimSpace(part)
+		for i, name := range _{{.Type}}Names {
+			if part == name {
+				f |= 1 << i
+				continue next
+			}
+		}
+		if n, err := strconv.ParseUint(part, 0, {{.Width}}); err == nil {
+			f |= {{.Type}}(n)
+			continue
+		}
+		return 0, errors.New("未知的 {{.Type}} 标志: " + strconv.Quote(part))
+	}
+	return f, nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出与 String 相同
+func (f {{.Type}}) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 Parse{{.Type}} 能解析的字符串
+func (f *{{.Type}}) UnmarshalText(text []byte) error {
+	v, err := Parse{{.Type}}(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+`))
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerateGolden 测试生成结果与 internal/example 中提交的文件一致，
+// 修改模板后需在 internal/example 下执行 go generate 更新对照文件
+func TestGenerateGolden(t *testing.T) {
+	got, err := generate(config{
+		Type:    "Option",
+		Base:    "uint8",
+		Package: "example",
+		Names:   []string{"UPPER", "LOWER", "CAP", "REV"},
+		Args:    "-type=Option -base=uint8 UPPER LOWER CAP REV",
+	})
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	expected, err := os.ReadFile(filepath.Join("internal", "example", "option_bitmask.go"))
+	if err != nil {
+		t.Fatalf("读取对照文件失败: %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("生成结果与 internal/example/option_bitmask.go 不一致，请执行 go generate 更新")
+	}
+}
+
+// TestGenerateNoDecl 测试 -nodecl 不生成类型声明
+func TestGenerateNoDecl(t *testing.T) {
+	got, err := generate(config{Type: "Mode", Base: "uint16", Package: "p", Names: []string{"A"}, NoDecl: true})
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	if bytes.Contains(got, []byte("type Mode uint16")) {
+		t.Errorf("NoDecl 时不应生成类型声明")
+	}
+}
+
+// TestGenerateErrors 测试非法输入
+func TestGenerateErrors(t *testing.T) {
+	names9 := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I"}
+	testCases := []struct {
+		name string
+		cfg  config
+	}{
+		{"类型名非法", config{Type: "1x", Base: "uint8", Package: "p", Names: []string{"A"}}},
+		{"缺少包名", config{Type: "T", Base: "uint8", Names: []string{"A"}}},
+		{"底层类型不支持", config{Type: "T", Base: "int", Package: "p", Names: []string{"A"}}},
+		{"没有标志", config{Type: "T", Base: "uint8", Package: "p"}},
+		{"超出位宽", config{Type: "T", Base: "uint8", Package: "p", Names: names9}},
+		{"标志名非法", config{Type: "T", Base: "uint8", Package: "p", Names: []string{"a-b"}}},
+		{"标志名重复", config{Type: "T", Base: "uint8", Package: "p", Names: []string{"A", "A"}}},
+	}
+	for _, tc := range testCases {
+		if _, err := generate(tc.cfg); err == nil {
+			t.Errorf("%s: 应返回错误", tc.name)
+		}
+	}
+}
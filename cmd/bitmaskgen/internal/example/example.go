@@ -0,0 +1,4 @@
+// Package example 是 bitmaskgen 生成代码的示例，同时被 bitmaskgen 的测试用作对照文件。
+package example
+
+//go:generate go run github.com/moweilong/efficient-go/cmd/bitmaskgen -type=Option -base=uint8 UPPER LOWER CAP REV
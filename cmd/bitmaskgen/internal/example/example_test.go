@@ -0,0 +1,67 @@
+package example_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/moweilong/efficient-go/cmd/bitmaskgen/internal/example"
+)
+
+// TestGeneratedOption 测试生成的 String/Parse/MarshalText 行为
+func TestGeneratedOption(t *testing.T) {
+	testCases := []struct {
+		opt      example.Option
+		expected string
+	}{
+		{example.UPPER | example.REV, "UPPER|REV"},
+		{example.LOWER | example.CAP | example.REV, "LOWER|CAP|REV"},
+		{0, "0"},
+		{example.CAP | 0x80, "CAP|0x80"},
+	}
+	for _, tc := range testCases {
+		if s := tc.opt.String(); s != tc.expected {
+			t.Errorf("String() = %q，预期 %q", s, tc.expected)
+		}
+	}
+
+	opt, err := example.ParseOption("REV | UPPER")
+	if err != nil || opt != example.UPPER|example.REV {
+		t.Errorf("ParseOption = (%v, %v)", opt, err)
+	}
+	if _, err := example.ParseOption("UPPER|BOLD"); err == nil {
+		t.Errorf("未知标志应返回错误")
+	}
+}
+
+// TestGeneratedTextMarshal 测试通过 encoding.TextMarshaler 在 JSON 中以字符串表示
+func TestGeneratedTextMarshal(t *testing.T) {
+	type config struct {
+		Opts example.Option `json:"opts"`
+	}
+	data, err := json.Marshal(config{Opts: example.LOWER | example.CAP})
+	if err != nil || string(data) != `{"opts":"LOWER|CAP"}` {
+		t.Fatalf("json.Marshal = (%s, %v)", data, err)
+	}
+
+	var c config
+	if err := json.Unmarshal([]byte(`{"opts":"UPPER|REV"}`), &c); err != nil || c.Opts != example.UPPER|example.REV {
+		t.Errorf("json.Unmarshal = (%v, %v)", c.Opts, err)
+	}
+}
+
+// TestGeneratedTextRoundTrip 测试含未命名位的值经 MarshalText 与 UnmarshalText 往返后不变
+func TestGeneratedTextRoundTrip(t *testing.T) {
+	for _, f := range []example.Option{0, example.UPPER, example.LOWER | 1<<5, 1 << 7, 0xff} {
+		text, err := f.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%d) 失败: %v", f, err)
+		}
+		var got example.Option
+		if err := got.UnmarshalText(text); err != nil || got != f {
+			t.Errorf("UnmarshalText(%q) = (%d, %v)，预期 %d", text, got, err, f)
+		}
+	}
+	if _, err := example.ParseOption("0x100"); err == nil {
+		t.Errorf("超出 uint8 的数值应返回错误")
+	}
+}
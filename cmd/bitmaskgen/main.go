@@ -0,0 +1,77 @@
+// bitmaskgen 根据标志名称列表生成位掩码类型的样板代码：
+// 1 << iota 常量块、String、Parse 函数以及 MarshalText/UnmarshalText 方法。
+//
+// 用法：
+//
+//	//go:generate go run github.com/moweilong/efficient-go/cmd/bitmaskgen -type=Option -base=uint8 UPPER LOWER CAP REV
+//
+// 标志名称可以作为位置参数给出，也可以通过 -input 指定文件，文件中每行一个名称，忽略空行与 # 开头的注释。
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.Type, "type", "", "生成的标志类型名称（必填）")
+	flag.StringVar(&cfg.Base, "base", "uint32", "底层无符号整数类型")
+	flag.StringVar(&cfg.Package, "package", "", "生成代码的包名，默认取环境变量 GOPACKAGE")
+	flag.BoolVar(&cfg.NoDecl, "nodecl", false, "不生成类型声明，用于类型已手写声明的场景")
+	input := flag.String("input", "", "标志名称列表文件，每行一个名称")
+	output := flag.String("output", "", "输出文件，默认为 <type>_bitmask.go")
+	flag.Parse()
+
+	if cfg.Package == "" {
+		cfg.Package = os.Getenv("GOPACKAGE")
+	}
+	cfg.Names = flag.Args()
+	if *input != "" {
+		names, err := readNames(*input)
+		if err != nil {
+			fatal(err)
+		}
+		cfg.Names = append(cfg.Names, names...)
+	}
+	cfg.Args = strings.Join(os.Args[1:], " ")
+
+	src, err := generate(cfg)
+	if err != nil {
+		fatal(err)
+	}
+	if *output == "" {
+		*output = strings.ToLower(cfg.Type) + "_bitmask.go"
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+// readNames 从文件中读取标志名称，忽略空行与注释
+func readNames(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, s.Err()
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "bitmaskgen:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/moweilong/efficient-go/base/flame"
+)
+
+// fold 读取 paths 中的剖析文件，按 sample 指定的数值类型汇总为折叠栈并合并
+func fold(sample string, paths []string) (flame.Stacks, error) {
+	stacks := make(flame.Stacks)
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		p, err := flame.Parse(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		i := p.SampleIndex(sample)
+		if i < 0 {
+			return nil, fmt.Errorf("%s: 没有类型为 %q 的样本，可选类型为 %v", path, sample, p.SampleTypes)
+		}
+		stacks.Merge(p.Fold(i))
+	}
+	return stacks, nil
+}
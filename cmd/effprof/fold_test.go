@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+var sink [][]byte
+
+//go:noinline
+func allocate() {
+	for range 100 {
+		sink = append(sink, make([]byte, 4096))
+	}
+}
+
+// writeHeapProfile 在 dir 中写入一份包含 allocate 分配样本的堆剖析
+func writeHeapProfile(t *testing.T, dir, name string) string {
+	t.Helper()
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+	allocate()
+	runtime.GC()
+
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := pprof.Lookup("heap").WriteTo(f, 0); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestFold 测试合并多个剖析文件
+func TestFold(t *testing.T) {
+	dir := t.TempDir()
+	a := writeHeapProfile(t, dir, "a.heap.pprof")
+	b := writeHeapProfile(t, dir, "b.heap.pprof")
+
+	one, err := fold("alloc_space", []string{a})
+	if err != nil {
+		t.Fatalf("fold 失败: %v", err)
+	}
+	two, err := fold("alloc_space", []string{a, b})
+	if err != nil {
+		t.Fatalf("fold 失败: %v", err)
+	}
+	if two.Total() <= one.Total() {
+		t.Errorf("合并两个文件后的总量 %d 应大于单个文件的 %d", two.Total(), one.Total())
+	}
+	found := false
+	for stack := range one {
+		if strings.HasSuffix(stack, ".allocate") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("折叠栈中没有 allocate")
+	}
+
+	if _, err := fold("cpu", []string{a}); err == nil || !strings.Contains(err.Error(), "alloc_space") {
+		t.Errorf("不存在的样本类型应返回列出可选类型的错误，得到 %v", err)
+	}
+	if _, err := fold("", []string{filepath.Join(dir, "missing")}); err == nil {
+		t.Errorf("文件不存在时应返回错误")
+	}
+	bad := filepath.Join(dir, "bad.pprof")
+	os.WriteFile(bad, []byte{0x0b}, 0o644)
+	if _, err := fold("", []string{bad}); err == nil || !strings.Contains(err.Error(), "bad.pprof") {
+		t.Errorf("非法文件应返回带文件名的错误，得到 %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+// effprof 将 pprof 剖析文件转换为折叠栈格式，用于生成火焰图。
+//
+// 用法：
+//
+//	effprof [-sample=类型] [-o 输出文件] 剖析文件...
+//
+// 多个剖析文件的样本会被合并，例如 benchkit.WithProfiles 为多个子基准测试写入的文件。
+// 输出每行为 "root;caller;leaf 数值"，可交给 flamegraph.pl 或 speedscope：
+//
+//	effprof profiles/BenchmarkFilter_test.cpu.pprof | flamegraph.pl > filter.svg
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	sample := flag.String("sample", "", `样本数值类型，如 "cpu"、"alloc_space"、"inuse_space"、"delay"，默认为最后一列`)
+	output := flag.String("o", "", "输出文件，默认为标准输出")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fatal(errors.New("至少需要一个剖析文件"))
+	}
+
+	stacks, err := fold(*sample, flag.Args())
+	if err != nil {
+		fatal(err)
+	}
+	if *output == "" {
+		if _, err := stacks.WriteTo(os.Stdout); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		fatal(err)
+	}
+	_, err = stacks.WriteTo(f)
+	// 某些文件系统直到 Close 才报告写入失败，不能忽略它的错误
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "effprof:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// value 是一个枚举常量
+type value struct {
+	Name string // 常量名
+	Str  string // String 输出的字符串
+	Val  int64  // 常量值，无符号类型按位存放，比较时需按 config.Unsigned 解释
+	pos  token.Pos
+}
+
+// config 描述一次代码生成的输入
+type config struct {
+	Type       string
+	Package    string
+	Values     []value
+	TrimPrefix string
+	Registry   bool // 是否额外生成 enum.Registry 变量
+	Unsigned   bool // 底层类型是否为无符号整数
+	Args       string
+}
+
+// load 解析 dir 中的 Go 文件，收集类型为 typeName 的全部常量；skip 为需要忽略的文件名（即输出文件）
+func load(dir, typeName, skip string) (config, error) {
+	if !token.IsIdentifier(typeName) {
+		return config{}, fmt.Errorf("非法的类型名 %q", typeName)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return config{}, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == skip {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return config{}, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return config{}, fmt.Errorf("目录 %s 中没有 Go 文件", dir)
+	}
+
+	// 只需要常量的值，忽略类型检查中与导入包相关的错误
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil), Error: func(error) {}}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, info)
+
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil {
+		return config{}, fmt.Errorf("未找到类型 %s", typeName)
+	}
+	b, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || b.Info()&types.IsInteger == 0 {
+		return config{}, fmt.Errorf("类型 %s 的底层类型必须是整数", typeName)
+	}
+
+	cfg := config{Type: typeName, Package: pkg.Name(), Unsigned: b.Info()&types.IsUnsigned != 0}
+	for id, def := range info.Defs {
+		c, ok := def.(*types.Const)
+		if !ok || id.Name == "_" || !types.Identical(c.Type(), obj.Type()) {
+			continue
+		}
+		var v int64
+		if cfg.Unsigned {
+			// uint64 常量可能超出 int64 范围，按位存放
+			u, exact := constant.Uint64Val(c.Val())
+			if !exact {
+				return config{}, fmt.Errorf("常量 %s 的值超出 uint64 范围", id.Name)
+			}
+			v = int64(u)
+		} else {
+			var exact bool
+			if v, exact = constant.Int64Val(c.Val()); !exact {
+				return config{}, fmt.Errorf("常量 %s 的值超出 int64 范围", id.Name)
+			}
+		}
+		cfg.Values = append(cfg.Values, value{Name: id.Name, Val: v, pos: id.Pos()})
+	}
+	sort.Slice(cfg.Values, func(i, j int) bool { return cfg.Values[i].pos < cfg.Values[j].pos })
+	return cfg, nil
+}
+
+// generate 返回格式化后的生成代码
+func generate(cfg config) ([]byte, error) {
+	if len(cfg.Values) == 0 {
+		return nil, fmt.Errorf("未找到类型为 %s 的常量", cfg.Type)
+	}
+
+	// 按值排序，值相同时按声明顺序，并去掉别名
+	sort.Slice(cfg.Values, func(i, j int) bool {
+		a, b := cfg.Values[i], cfg.Values[j]
+		if a.Val == b.Val {
+			return a.pos < b.pos
+		}
+		if cfg.Unsigned {
+			return uint64(a.Val) < uint64(b.Val)
+		}
+		return a.Val < b.Val
+	})
+	values := cfg.Values[:0]
+	seen := make(map[string]bool)
+	for i, v := range cfg.Values {
+		if i > 0 && v.Val == cfg.Values[i-1].Val {
+			continue
+		}
+		v.Str = strings.TrimPrefix(v.Name, cfg.TrimPrefix)
+		if v.Str == "" {
+			return nil, fmt.Errorf("常量 %s 去掉前缀后为空", v.Name)
+		}
+		if seen[v.Str] {
+			return nil, errors.New("去掉前缀后存在重复的名称 " + v.Str)
+		}
+		seen[v.Str] = true
+		values = append(values, v)
+	}
+	cfg.Values = values
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, cfg); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("enum").Parse(`// Code generated by enumgen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+{{- if .Registry}}
+
+	"github.com/moweilong/efficient-go/base/enum"
+{{- end}}
+)
+
+var _{{.Type}}Values = []{{.Type}}{
+{{- range .Values}}
+	{{.Name}},
+{{- end}}
+}
+
+{{- if .Registry}}
+
+// {{.Type}}Registry 包含 {{.Type}} 的全部取值，可与手写枚举共用 enum.Registry 的解析与格式化代码
+var {{.Type}}Registry = func() *enum.Registry[{{.Type}}] {
+	r := enum.NewRegistry[{{.Type}}]()
+{{- range .Values}}
+	r.MustRegister("{{.Str}}", {{.Name}})
+{{- end}}
+	return r
+}()
+{{- end}}
+
+// Values 按常量值从小到大返回 {{.Type}} 的所有取值
+func {{.Type}}Values() []{{.Type}} {
+	return append([]{{.Type}}(nil), _{{.Type}}Values...)
+}
+
+// String 返回常量名称，未定义的值输出为 "{{.Type}}(数值)"
+func (v {{.Type}}) String() string {
+	switch v {
+{{- range .Values}}
+	case {{.Name}}:
+		return "{{.Str}}"
+{{- end}}
+	}
+{{- if .Unsigned}}
+	return "{{.Type}}(" + strconv.FormatUint(uint64(v), 10) + ")"
+{{- else}}
+	return "{{.Type}}(" + strconv.FormatInt(int64(v), 10) + ")"
+{{- end}}
+}
+
+// Parse{{.Type}} 将名称解析为 {{.Type}}
+func Parse{{.Type}}(s string) ({{.Type}}, error) {
+	switch s {
+{{- range .Values}}
+	case "{{.Str}}":
+		return {{.Name}}, nil
+{{- end}}
+	}
+	return 0, errors.New("未知的 {{.Type}}: " + strconv.Quote(s))
+}
+
+// MarshalJSON 实现 json.Marshaler，将取值序列化为名称字符串
+func (v {{.Type}}) MarshalJSON() ([]byte, error) {
+	s := v.String()
+	if _, err := Parse{{.Type}}(s); err != nil {
+		return nil, errors.New("无法序列化未定义的 {{.Type}} 值 " + s)
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，从名称字符串反序列化
+func (v *{{.Type}}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	x, err := Parse{{.Type}}(s)
+	if err != nil {
+		return err
+	}
+	*v = x
+	return nil
+}
+`))
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerateGolden 测试生成结果与 internal/example 中提交的文件一致，
+// 修改模板后需在 internal/example 下执行 go generate 更新对照文件
+func TestGenerateGolden(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	cfg, err := load(dir, "Color", "color_enum.go")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	cfg.TrimPrefix = "Color"
+	cfg.Registry = true
+	cfg.Args = "-type=Color -trimprefix=Color -registry"
+	got, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	expected, err := os.ReadFile(filepath.Join(dir, "color_enum.go"))
+	if err != nil {
+		t.Fatalf("读取对照文件失败: %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("生成结果与 internal/example/color_enum.go 不一致，请执行 go generate 更新")
+	}
+}
+
+// TestLoad 测试常量收集：按值排序、忽略别名与空白标识符
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	src := `package p
+
+import "fmt"
+
+type Level uint8
+
+const (
+	Debug Level = iota + 1
+	_
+	Warn
+	Info Level = 2
+	Other = 7
+)
+
+var _ = fmt.Sprint
+`
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := load(dir, "Level", "")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	got, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	if !bytes.Contains(got, []byte("[]Level{\n\tDebug,\n\tInfo,\n\tWarn,\n}")) {
+		t.Errorf("取值顺序错误:\n%s", got)
+	}
+}
+
+// TestLoadUnsigned 测试 uint64 枚举：最高位为 1 的常量不超出范围，按无符号值排序，未定义的值按无符号数输出
+func TestLoadUnsigned(t *testing.T) {
+	dir := t.TempDir()
+	src := `package p
+
+type Flag uint64
+
+const (
+	High Flag = 1 << 63
+	Max  Flag = 1<<64 - 1
+	Low  Flag = 1
+)
+`
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := load(dir, "Flag", "")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	got, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	if !bytes.Contains(got, []byte("[]Flag{\n\tLow,\n\tHigh,\n\tMax,\n}")) {
+		t.Errorf("取值顺序错误:\n%s", got)
+	}
+	if !bytes.Contains(got, []byte("strconv.FormatUint(uint64(v), 10)")) {
+		t.Errorf("未定义的值应按无符号数输出:\n%s", got)
+	}
+}
+
+// TestErrors 测试非法输入
+func TestErrors(t *testing.T) {
+	dir := t.TempDir()
+	src := "package p\n\ntype S string\n\ntype N int\n\nconst (\n\tNA N = iota\n\tNB\n)\n"
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, typ := range []string{"1x", "Missing", "S"} {
+		if _, err := load(dir, typ, ""); err == nil {
+			t.Errorf("load(%q) 应返回错误", typ)
+		}
+	}
+
+	cfg, err := load(dir, "N", "")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	testCases := []struct {
+		name   string
+		prefix string
+		rename string
+	}{
+		{"去掉前缀后为空", "NA", ""},
+		{"去掉前缀后重名", "N", "A"}, // NB 改名为 A，去掉前缀后与 NA 同为 "A"
+	}
+	for _, tc := range testCases {
+		c := cfg
+		c.Values = append([]value(nil), cfg.Values...)
+		c.TrimPrefix = tc.prefix
+		if tc.rename != "" {
+			c.Values[1].Name = tc.rename
+		}
+		if _, err := generate(c); err == nil {
+			t.Errorf("%s: 应返回错误", tc.name)
+		}
+	}
+
+	if _, err := generate(config{Type: "N", Package: "p"}); err == nil {
+		t.Errorf("没有常量时应返回错误")
+	}
+}
@@ -0,0 +1,17 @@
+// Package example 是 enumgen 生成代码的示例，同时被 enumgen 的测试用作对照文件。
+package example
+
+//go:generate go run github.com/moweilong/efficient-go/cmd/enumgen -type=Color -trimprefix=Color -registry
+
+// Color 是以 iota 定义的枚举类型
+type Color int
+
+const (
+	ColorRed Color = iota
+	ColorGreen
+	ColorBlue
+	_
+	ColorBlack
+
+	ColorDefault = ColorRed // 别名，生成代码时忽略
+)
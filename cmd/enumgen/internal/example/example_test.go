@@ -0,0 +1,82 @@
+package example_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/moweilong/efficient-go/cmd/enumgen/internal/example"
+)
+
+// TestGeneratedColor 测试生成的 String/Parse/Values 行为
+func TestGeneratedColor(t *testing.T) {
+	testCases := []struct {
+		c        example.Color
+		expected string
+	}{
+		{example.ColorRed, "Red"},
+		{example.ColorBlue, "Blue"},
+		{example.ColorBlack, "Black"},
+		{example.ColorDefault, "Red"},
+		{3, "Color(3)"},
+	}
+	for _, tc := range testCases {
+		if s := tc.c.String(); s != tc.expected {
+			t.Errorf("String() = %q，预期 %q", s, tc.expected)
+		}
+	}
+
+	expected := []example.Color{example.ColorRed, example.ColorGreen, example.ColorBlue, example.ColorBlack}
+	if vs := example.ColorValues(); !slices.Equal(vs, expected) {
+		t.Errorf("ColorValues() = %v，预期 %v", vs, expected)
+	}
+
+	if c, err := example.ParseColor("Green"); err != nil || c != example.ColorGreen {
+		t.Errorf("ParseColor = (%v, %v)", c, err)
+	}
+	if _, err := example.ParseColor("green"); err == nil {
+		t.Errorf("ParseColor 应区分大小写")
+	}
+}
+
+// TestGeneratedColorJSON 测试生成的 JSON 序列化
+func TestGeneratedColorJSON(t *testing.T) {
+	type palette struct {
+		Fg example.Color `json:"fg"`
+		Bg example.Color `json:"bg"`
+	}
+	data, err := json.Marshal(palette{Fg: example.ColorBlack, Bg: example.ColorGreen})
+	if err != nil || string(data) != `{"fg":"Black","bg":"Green"}` {
+		t.Fatalf("Marshal = (%s, %v)", data, err)
+	}
+
+	var p palette
+	if err := json.Unmarshal(data, &p); err != nil || p.Fg != example.ColorBlack || p.Bg != example.ColorGreen {
+		t.Errorf("Unmarshal = (%+v, %v)", p, err)
+	}
+
+	if _, err := json.Marshal(example.Color(3)); err == nil {
+		t.Errorf("未定义的值序列化应返回错误")
+	}
+	for _, bad := range []string{`"Pink"`, `1`} {
+		var c example.Color
+		if err := json.Unmarshal([]byte(bad), &c); err == nil {
+			t.Errorf("Unmarshal(%s) 应返回错误", bad)
+		}
+	}
+}
+
+// TestGeneratedColorRegistry 测试 -registry 生成的注册表与 String/Parse 一致
+func TestGeneratedColorRegistry(t *testing.T) {
+	for _, c := range example.ColorValues() {
+		if s := example.ColorRegistry.Format(c); s != c.String() {
+			t.Errorf("Format(%d) = %q，预期 %q", int(c), s, c.String())
+		}
+		if v, err := example.ColorRegistry.Parse(c.String()); err != nil || v != c {
+			t.Errorf("Parse(%q) = (%v, %v)", c.String(), v, err)
+		}
+	}
+	if example.ColorRegistry.IsValid(3) {
+		t.Errorf("IsValid(3) 应为 false")
+	}
+}
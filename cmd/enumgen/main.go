@@ -0,0 +1,55 @@
+// enumgen 为以 iota 定义的枚举类型生成 String、Parse、Values 以及 MarshalJSON/UnmarshalJSON，
+// 免去手写 switch 语句。
+//
+// 用法：
+//
+//	//go:generate go run github.com/moweilong/efficient-go/cmd/enumgen -type=Color
+//
+// enumgen 读取当前目录下的非测试 Go 文件，找出所有类型为 -type 的常量，按常量值排序后生成代码；
+// 值相同的常量（别名）只保留第一个。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeName := flag.String("type", "", "枚举类型名称（必填）")
+	trimPrefix := flag.String("trimprefix", "", "生成字符串时去掉常量名的前缀")
+	registry := flag.Bool("registry", false, "额外生成 <type>Registry 变量（*enum.Registry）")
+	output := flag.String("output", "", "输出文件，默认为 <type>_enum.go")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(*typeName)+"_enum.go")
+	}
+
+	cfg, err := load(dir, *typeName, filepath.Base(*output))
+	if err != nil {
+		fatal(err)
+	}
+	cfg.TrimPrefix = *trimPrefix
+	cfg.Registry = *registry
+	cfg.Args = strings.Join(os.Args[1:], " ")
+
+	src, err := generate(cfg)
+	if err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "enumgen:", err)
+	os.Exit(1)
+}
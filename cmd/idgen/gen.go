@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// idSpec 描述一个 ID 类型
+type idSpec struct {
+	Name string // 类型名称
+	Base string // 底层无符号整数类型
+	Bits int    // 有效位数
+}
+
+// Len 返回二进制编码的字节数
+func (s idSpec) Len() int {
+	return (s.Bits + 7) / 8
+}
+
+// Shifts 返回大端序编码时各字节的右移位数
+func (s idSpec) Shifts() []int {
+	shifts := make([]int, s.Len())
+	for i := range shifts {
+		shifts[i] = 8 * (len(shifts) - 1 - i)
+	}
+	return shifts
+}
+
+// config 描述一次代码生成的输入
+type config struct {
+	Package string   // 生成代码的包名
+	IDs     []idSpec // 按参数顺序排列的 ID 类型
+	Args    string   // 命令行参数，写入文件头部便于追溯
+}
+
+// baseWidths 记录支持的底层类型及其位宽；uint 的位宽与平台有关，不支持
+var baseWidths = map[string]int{
+	"uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64,
+}
+
+// parseSpec 解析 名称:底层类型[:位数] 形式的参数
+func parseSpec(s string) (idSpec, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return idSpec{}, fmt.Errorf("非法的 ID 描述 %q，应为 名称:底层类型[:位数]", s)
+	}
+	spec := idSpec{Name: parts[0], Base: parts[1], Bits: baseWidths[parts[1]]}
+	if len(parts) == 3 {
+		n, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return idSpec{}, fmt.Errorf("非法的位数 %q", parts[2])
+		}
+		spec.Bits = n
+	}
+	return spec, nil
+}
+
+// generate 校验配置并返回格式化后的 Go 源码
+func generate(cfg config) ([]byte, error) {
+	if cfg.Package == "" {
+		return nil, errors.New("未指定包名")
+	}
+	if len(cfg.IDs) == 0 {
+		return nil, errors.New("至少需要一个 ID 类型")
+	}
+	seen := make(map[string]bool, len(cfg.IDs))
+	for _, id := range cfg.IDs {
+		if !token.IsIdentifier(id.Name) {
+			return nil, fmt.Errorf("非法的类型名 %q", id.Name)
+		}
+		if seen[id.Name] {
+			return nil, fmt.Errorf("类型名 %q 重复", id.Name)
+		}
+		seen[id.Name] = true
+		width, ok := baseWidths[id.Base]
+		if !ok {
+			return nil, fmt.Errorf("%s: 不支持的底层类型 %q", id.Name, id.Base)
+		}
+		if id.Bits < 1 || id.Bits > width {
+			return nil, fmt.Errorf("%s: 位数 %d 不在 [1, %d] 内", id.Name, id.Bits, width)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, cfg); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("id").Parse(`// Code generated by idgen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"errors"
+	"strconv"
+)
+{{range .IDs}}
+// {{.Name}} 是 {{.Bits}} 位的强类型 ID，底层类型为 {{.Base}}
+type {{.Name}} {{.Base}}
+
+const (
+	// Max{{.Name}} 是 {{.Name}} 可表示的最大值
+	Max{{.Name}} {{.Name}} = 1<<{{.Bits}} - 1
+	// {{.Name}}Len 是 {{.Name}} 二进制编码的字节数
+	{{.Name}}Len = {{.Len}}
+)
+
+// New{{.Name}} 返回 v 对应的 {{.Name}}，v 超过 Max{{.Name}} 时返回错误
+func New{{.Name}}(v uint64) ({{.Name}}, error) {
+{{- if lt .Bits 64}}
+	if v > uint64(Max{{.Name}}) {
+		return 0, errors.New("{{.Name}} 超出范围: " + strconv.FormatUint(v, 10))
+	}
+{{- end}}
+	return {{.Name}}(v), nil
+}
+
+// Must{{.Name}} 与 New{{.Name}} 相同，但超出范围时 panic
+func Must{{.Name}}(v uint64) {{.Name}} {
+	id, err := New{{.Name}}(v)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Uint64 返回 id 的数值
+func (id {{.Name}}) Uint64() uint64 {
+	return uint64(id)
+}
+
+// String 返回 id 的十进制形式
+func (id {{.Name}}) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// AppendBinary 实现 encoding.BinaryAppender，按大端序追加 {{.Name}}Len 个字节
+func (id {{.Name}}) AppendBinary(b []byte) ([]byte, error) {
+	return append(b{{range .Shifts}}, byte(id{{if .}}>>{{.}}{{end}}){{end}}), nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (id {{.Name}}) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, {{.Name}}Len))
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，data 的长度必须为 {{.Name}}Len
+func (id *{{.Name}}) UnmarshalBinary(data []byte) error {
+	if len(data) != {{.Name}}Len {
+		return errors.New("{{.Name}}: 二进制长度应为 {{.Len}}，实际为 " + strconv.Itoa(len(data)))
+	}
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	x, err := New{{.Name}}(v)
+	if err != nil {
+		return err
+	}
+	*id = x
+	return nil
+}
+{{end}}`))
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerateGolden 测试生成结果与 internal/example 中提交的文件一致，
+// 修改模板后需在 internal/example 下执行 go generate 更新对照文件
+func TestGenerateGolden(t *testing.T) {
+	cfg := config{Package: "example", Args: "UserID:uint32:24 OrderID:uint64 ShardID:uint8:4"}
+	for _, arg := range []string{"UserID:uint32:24", "OrderID:uint64", "ShardID:uint8:4"} {
+		id, err := parseSpec(arg)
+		if err != nil {
+			t.Fatalf("parseSpec(%q) 失败: %v", arg, err)
+		}
+		cfg.IDs = append(cfg.IDs, id)
+	}
+	got, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	expected, err := os.ReadFile(filepath.Join("internal", "example", "ids_gen.go"))
+	if err != nil {
+		t.Fatalf("读取对照文件失败: %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("生成结果与 internal/example/ids_gen.go 不一致，请执行 go generate 更新")
+	}
+}
+
+// TestParseSpec 测试参数解析
+func TestParseSpec(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected idSpec
+	}{
+		{"UserID:uint32", idSpec{"UserID", "uint32", 32}},
+		{"UserID:uint32:24", idSpec{"UserID", "uint32", 24}},
+		{"X:uint16:0", idSpec{"X", "uint16", 0}}, // 位数的范围由 generate 校验
+	}
+	for _, tc := range testCases {
+		got, err := parseSpec(tc.input)
+		if err != nil || got != tc.expected {
+			t.Errorf("parseSpec(%q) = (%+v, %v)，预期 %+v", tc.input, got, err, tc.expected)
+		}
+	}
+	for _, bad := range []string{"UserID", "UserID:uint32:24:1", "UserID:uint32:x"} {
+		if _, err := parseSpec(bad); err == nil {
+			t.Errorf("parseSpec(%q) 应返回错误", bad)
+		}
+	}
+
+	if l := (idSpec{Bits: 17}).Len(); l != 3 {
+		t.Errorf("17 位的编码长度为 %d，预期 3", l)
+	}
+}
+
+// TestGenerateErrors 测试非法输入
+func TestGenerateErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  config
+	}{
+		{"缺少包名", config{IDs: []idSpec{{"A", "uint8", 8}}}},
+		{"没有类型", config{Package: "p"}},
+		{"类型名非法", config{Package: "p", IDs: []idSpec{{"1A", "uint8", 8}}}},
+		{"类型名重复", config{Package: "p", IDs: []idSpec{{"A", "uint8", 8}, {"A", "uint16", 16}}}},
+		{"底层类型不支持", config{Package: "p", IDs: []idSpec{{"A", "uint", 64}}}},
+		{"位数为 0", config{Package: "p", IDs: []idSpec{{"A", "uint8", 0}}}},
+		{"位数超出位宽", config{Package: "p", IDs: []idSpec{{"A", "uint16", 17}}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := generate(tc.cfg); err == nil {
+				t.Errorf("应返回错误")
+			}
+		})
+	}
+}
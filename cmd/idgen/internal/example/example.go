@@ -0,0 +1,4 @@
+// Package example 是 idgen 生成代码的示例，同时被 idgen 的测试用作对照文件。
+package example
+
+//go:generate go run github.com/moweilong/efficient-go/cmd/idgen UserID:uint32:24 OrderID:uint64 ShardID:uint8:4
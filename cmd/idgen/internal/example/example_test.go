@@ -0,0 +1,72 @@
+package example_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/moweilong/efficient-go/cmd/idgen/internal/example"
+)
+
+// TestGeneratedRange 测试生成的构造函数的范围检查
+func TestGeneratedRange(t *testing.T) {
+	if id, err := example.NewUserID(1<<24 - 1); err != nil || id != example.MaxUserID {
+		t.Errorf("NewUserID(MaxUserID) = (%v, %v)", id, err)
+	}
+	if _, err := example.NewUserID(1 << 24); err == nil {
+		t.Errorf("NewUserID(1<<24) 应返回错误")
+	}
+	if _, err := example.NewShardID(16); err == nil {
+		t.Errorf("NewShardID(16) 应返回错误")
+	}
+	if id, err := example.NewOrderID(math.MaxUint64); err != nil || id != example.MaxOrderID {
+		t.Errorf("NewOrderID(MaxUint64) = (%v, %v)", id, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustShardID(100) 应 panic")
+		}
+	}()
+	example.MustShardID(100)
+}
+
+// TestGeneratedBinary 测试紧凑的定长二进制编码
+func TestGeneratedBinary(t *testing.T) {
+	u := example.MustUserID(0x123456)
+	data, err := u.MarshalBinary()
+	if err != nil || !bytes.Equal(data, []byte{0x12, 0x34, 0x56}) {
+		t.Fatalf("MarshalBinary = (%x, %v)，预期 123456", data, err)
+	}
+	var got example.UserID
+	if err := got.UnmarshalBinary(data); err != nil || got != u {
+		t.Errorf("UnmarshalBinary = (%v, %v)，预期 %v", got, err, u)
+	}
+	if u.String() != "1193046" || u.Uint64() != 0x123456 {
+		t.Errorf("String() = %q，Uint64() = %d", u.String(), u.Uint64())
+	}
+
+	// 多个 ID 追加到同一缓冲区
+	buf, _ := example.MustOrderID(1).AppendBinary(nil)
+	buf, _ = example.MustShardID(15).AppendBinary(buf)
+	if len(buf) != example.OrderIDLen+example.ShardIDLen || buf[7] != 1 || buf[8] != 15 {
+		t.Errorf("AppendBinary = %x", buf)
+	}
+
+	badCases := []struct {
+		name string
+		data []byte
+	}{
+		{"长度不足", []byte{1, 2}},
+		{"长度过长", []byte{1, 2, 3, 4}},
+	}
+	for _, tc := range badCases {
+		if err := got.UnmarshalBinary(tc.data); err == nil {
+			t.Errorf("%s: UnmarshalBinary 应返回错误", tc.name)
+		}
+	}
+	var s example.ShardID
+	if err := s.UnmarshalBinary([]byte{0x10}); err == nil {
+		t.Errorf("ShardID 解码超出 4 位的值应返回错误")
+	}
+}
@@ -0,0 +1,197 @@
+// Code generated by idgen UserID:uint32:24 OrderID:uint64 ShardID:uint8:4; DO NOT EDIT.
+
+package example
+
+import (
+	"errors"
+	"strconv"
+)
+
+// UserID 是 24 位的强类型 ID，底层类型为 uint32
+type UserID uint32
+
+const (
+	// MaxUserID 是 UserID 可表示的最大值
+	MaxUserID UserID = 1<<24 - 1
+	// UserIDLen 是 UserID 二进制编码的字节数
+	UserIDLen = 3
+)
+
+// NewUserID 返回 v 对应的 UserID，v 超过 MaxUserID 时返回错误
+func NewUserID(v uint64) (UserID, error) {
+	if v > uint64(MaxUserID) {
+		return 0, errors.New("UserID 超出范围: " + strconv.FormatUint(v, 10))
+	}
+	return UserID(v), nil
+}
+
+// MustUserID 与 NewUserID 相同，但超出范围时 panic
+func MustUserID(v uint64) UserID {
+	id, err := NewUserID(v)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Uint64 返回 id 的数值
+func (id UserID) Uint64() uint64 {
+	return uint64(id)
+}
+
+// String 返回 id 的十进制形式
+func (id UserID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// AppendBinary 实现 encoding.BinaryAppender，按大端序追加 UserIDLen 个字节
+func (id UserID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, byte(id>>16), byte(id>>8), byte(id)), nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (id UserID) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, UserIDLen))
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，data 的长度必须为 UserIDLen
+func (id *UserID) UnmarshalBinary(data []byte) error {
+	if len(data) != UserIDLen {
+		return errors.New("UserID: 二进制长度应为 3，实际为 " + strconv.Itoa(len(data)))
+	}
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	x, err := NewUserID(v)
+	if err != nil {
+		return err
+	}
+	*id = x
+	return nil
+}
+
+// OrderID 是 64 位的强类型 ID，底层类型为 uint64
+type OrderID uint64
+
+const (
+	// MaxOrderID 是 OrderID 可表示的最大值
+	MaxOrderID OrderID = 1<<64 - 1
+	// OrderIDLen 是 OrderID 二进制编码的字节数
+	OrderIDLen = 8
+)
+
+// NewOrderID 返回 v 对应的 OrderID，v 超过 MaxOrderID 时返回错误
+func NewOrderID(v uint64) (OrderID, error) {
+	return OrderID(v), nil
+}
+
+// MustOrderID 与 NewOrderID 相同，但超出范围时 panic
+func MustOrderID(v uint64) OrderID {
+	id, err := NewOrderID(v)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Uint64 返回 id 的数值
+func (id OrderID) Uint64() uint64 {
+	return uint64(id)
+}
+
+// String 返回 id 的十进制形式
+func (id OrderID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// AppendBinary 实现 encoding.BinaryAppender，按大端序追加 OrderIDLen 个字节
+func (id OrderID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, byte(id>>56), byte(id>>48), byte(id>>40), byte(id>>32), byte(id>>24), byte(id>>16), byte(id>>8), byte(id)), nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (id OrderID) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, OrderIDLen))
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，data 的长度必须为 OrderIDLen
+func (id *OrderID) UnmarshalBinary(data []byte) error {
+	if len(data) != OrderIDLen {
+		return errors.New("OrderID: 二进制长度应为 8，实际为 " + strconv.Itoa(len(data)))
+	}
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	x, err := NewOrderID(v)
+	if err != nil {
+		return err
+	}
+	*id = x
+	return nil
+}
+
+// ShardID 是 4 位的强类型 ID，底层类型为 uint8
+type ShardID uint8
+
+const (
+	// MaxShardID 是 ShardID 可表示的最大值
+	MaxShardID ShardID = 1<<4 - 1
+	// ShardIDLen 是 ShardID 二进制编码的字节数
+	ShardIDLen = 1
+)
+
+// NewShardID 返回 v 对应的 ShardID，v 超过 MaxShardID 时返回错误
+func NewShardID(v uint64) (ShardID, error) {
+	if v > uint64(MaxShardID) {
+		return 0, errors.New("ShardID 超出范围: " + strconv.FormatUint(v, 10))
+	}
+	return ShardID(v), nil
+}
+
+// MustShardID 与 NewShardID 相同，但超出范围时 panic
+func MustShardID(v uint64) ShardID {
+	id, err := NewShardID(v)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Uint64 返回 id 的数值
+func (id ShardID) Uint64() uint64 {
+	return uint64(id)
+}
+
+// String 返回 id 的十进制形式
+func (id ShardID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// AppendBinary 实现 encoding.BinaryAppender，按大端序追加 ShardIDLen 个字节
+func (id ShardID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, byte(id)), nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (id ShardID) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, ShardIDLen))
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，data 的长度必须为 ShardIDLen
+func (id *ShardID) UnmarshalBinary(data []byte) error {
+	if len(data) != ShardIDLen {
+		return errors.New("ShardID: 二进制长度应为 1，实际为 " + strconv.Itoa(len(data)))
+	}
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	x, err := NewShardID(v)
+	if err != nil {
+		return err
+	}
+	*id = x
+	return nil
+}
@@ -0,0 +1,49 @@
+// idgen 生成强类型的 ID 类型，使不同种类的 ID 在编译期无法混用，
+// 并为每个类型生成带范围检查的构造函数与紧凑的定长二进制编码。
+//
+// 用法：
+//
+//	//go:generate go run github.com/moweilong/efficient-go/cmd/idgen UserID:uint32:24 OrderID:uint64
+//
+// 每个参数的形式为 名称:底层类型[:位数]，位数省略时等于底层类型的位宽；
+// 二进制编码按大端序占用 ⌈位数/8⌉ 个字节。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.Package, "package", "", "生成代码的包名，默认取环境变量 GOPACKAGE")
+	output := flag.String("output", "ids_gen.go", "输出文件")
+	flag.Parse()
+
+	if cfg.Package == "" {
+		cfg.Package = os.Getenv("GOPACKAGE")
+	}
+	for _, arg := range flag.Args() {
+		id, err := parseSpec(arg)
+		if err != nil {
+			fatal(err)
+		}
+		cfg.IDs = append(cfg.IDs, id)
+	}
+	cfg.Args = strings.Join(os.Args[1:], " ")
+
+	src, err := generate(cfg)
+	if err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "idgen:", err)
+	os.Exit(1)
+}
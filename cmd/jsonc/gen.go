@@ -0,0 +1,538 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/moweilong/efficient-go/base/encx/jsonx"
+)
+
+// kind 是值的编码类别
+type kind uint8
+
+const (
+	kindBool   kind = iota
+	kindInt         // 有符号整数
+	kindUint        // 无符号整数
+	kindFloat       // 浮点数
+	kindString      // 字符串
+	kindBytes       // []byte，编码为 base64 字符串
+)
+
+// value 描述一个字段或切片元素的类型
+type value struct {
+	Type string // 类型在生成代码中的写法
+	kind kind
+	bits int // 浮点数的位数
+}
+
+// field 描述一个字段的编码方式
+type field struct {
+	Name      string // Go 中的字段名
+	Key       string // JSON 中的键名，已编码为 JSON 字符串，不含冒号
+	value            // 字段类型，slice 为 true 时是元素类型
+	slice     bool   // 是否为切片（[]byte 除外）
+	omitEmpty bool
+	omitZero  bool
+	isZero    bool // 类型是否有 IsZero() bool 方法
+	quoted    bool // 是否以 string 选项编码为 JSON 字符串
+}
+
+// structType 是一个需要生成编码方法的结构体
+type structType struct {
+	Name   string
+	Fields []field
+	Body   string // AppendJSON 的函数体
+}
+
+// config 描述一次代码生成的输入
+type config struct {
+	Package string
+	Types   []structType
+	Args    string
+}
+
+// load 解析 dir 中的 Go 文件，收集 names 中各个结构体的字段；skip 为需要忽略的文件名（即输出文件）
+func load(dir string, names []string, skip string) (config, error) {
+	for _, name := range names {
+		if !token.IsIdentifier(name) {
+			return config{}, fmt.Errorf("非法的类型名 %q", name)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return config{}, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == skip {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return config{}, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return config{}, fmt.Errorf("目录 %s 中没有 Go 文件", dir)
+	}
+
+	// 只需要字段的类型，忽略与本次生成无关的类型检查错误
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil), Error: func(error) {}}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+
+	cfg := config{Package: pkg.Name()}
+	for _, name := range names {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			return config{}, fmt.Errorf("未找到类型 %s", name)
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return config{}, fmt.Errorf("类型 %s 不是结构体", name)
+		}
+		t := structType{Name: name}
+		keys := make(map[string]bool)
+		for i := range st.NumFields() {
+			v := st.Field(i)
+			tag, _ := reflect.StructTag(st.Tag(i)).Lookup("json")
+			if tag == "-" || !v.Exported() && !v.Embedded() {
+				continue
+			}
+			f, err := parseField(pkg, v, tag)
+			if err != nil {
+				return config{}, fmt.Errorf("%s.%s: %w", name, v.Name(), err)
+			}
+			// encoding/json 在同名字段冲突时静默丢弃它们，这里直接报错
+			if keys[f.Key] {
+				return config{}, fmt.Errorf("%s.%s: 键名 %s 重复", name, v.Name(), f.Key)
+			}
+			keys[f.Key] = true
+			t.Fields = append(t.Fields, f)
+		}
+		cfg.Types = append(cfg.Types, t)
+	}
+	return cfg, nil
+}
+
+// parseField 根据字段类型与 json 标签确定编码方式
+func parseField(pkg *types.Package, v *types.Var, tag string) (field, error) {
+	if v.Embedded() {
+		return field{}, errors.New("不支持嵌入字段")
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if !validKey(name) {
+		name = v.Name()
+	}
+	f := field{Name: v.Name(), Key: string(jsonx.AppendString(nil, name))}
+	for opt := range strings.SplitSeq(opts, ",") {
+		switch opt {
+		case "":
+		case "omitempty":
+			f.omitEmpty = true
+		case "omitzero":
+			f.omitZero = true
+		case "string":
+			f.quoted = true
+		default:
+			return field{}, fmt.Errorf("未知的标签选项 %q", opt)
+		}
+	}
+
+	t := v.Type()
+	if err := checkMarshaler(t); err != nil {
+		return field{}, err
+	}
+	f.isZero = hasIsZero(t)
+	if s, ok := t.Underlying().(*types.Slice); ok && !isByte(s.Elem()) {
+		if err := checkMarshaler(s.Elem()); err != nil {
+			return field{}, err
+		}
+		if b, ok := s.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Uint8 {
+			return field{}, fmt.Errorf("元素类型为 %s 的切片会被 encoding/json 编码为 base64，不支持", s.Elem())
+		}
+		f.slice = true
+		t = s.Elem()
+	}
+	val, err := parseValue(pkg, t)
+	if err != nil {
+		return field{}, err
+	}
+	f.value = val
+	if f.quoted && (f.slice || f.kind == kindString || f.kind == kindBytes) {
+		return field{}, errors.New("string 选项只能用于数值与布尔字段")
+	}
+	return f, nil
+}
+
+// parseValue 确定非切片类型 t 的编码方式
+func parseValue(pkg *types.Package, t types.Type) (value, error) {
+	val := value{Type: types.TypeString(t, types.RelativeTo(pkg))}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsBoolean != 0:
+			val.kind = kindBool
+		case info&types.IsInteger != 0 && info&types.IsUnsigned != 0:
+			val.kind = kindUint
+		case info&types.IsInteger != 0:
+			val.kind = kindInt
+		case info&types.IsFloat != 0:
+			val.kind, val.bits = kindFloat, 64
+			if u.Kind() == types.Float32 {
+				val.bits = 32
+			}
+		case info&types.IsString != 0:
+			val.kind = kindString
+		default:
+			return value{}, fmt.Errorf("不支持的类型 %s", val.Type)
+		}
+		return val, nil
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			val.kind = kindBytes
+			return val, nil
+		}
+	}
+	return value{}, fmt.Errorf("不支持的类型 %s", val.Type)
+}
+
+// checkMarshaler 检查 t 是否有 encoding/json 会优先使用的编码方法，这样的类型无法静态生成一致的编码
+func checkMarshaler(t types.Type) error {
+	ms := types.NewMethodSet(types.NewPointer(t))
+	for _, m := range []string{"MarshalJSON", "MarshalText"} {
+		if ms.Lookup(nil, m) != nil {
+			return fmt.Errorf("类型 %s 实现了 %s，不支持", t, m)
+		}
+	}
+	if n, ok := t.(*types.Named); ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == "encoding/json" {
+		return fmt.Errorf("不支持的类型 %s", t)
+	}
+	return nil
+}
+
+// hasIsZero 返回 t 或 *t 是否有 IsZero() bool 方法，omitzero 以其结果为准
+func hasIsZero(t types.Type) bool {
+	sel := types.NewMethodSet(types.NewPointer(t)).Lookup(nil, "IsZero")
+	if sel == nil {
+		return false
+	}
+	sig := sel.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	b, ok := sig.Results().At(0).Type().(*types.Basic)
+	return ok && b.Kind() == types.Bool
+}
+
+// isByte 返回 t 是否为 byte
+func isByte(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == types.Uint8
+}
+
+// validKey 返回标签中的名称能否作为键名，规则与 encoding/json 相同，不能时使用字段名
+func validKey(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// writer 生成一个函数体，合并相邻的常量输出，并记录用到的包
+type writer struct {
+	buf     bytes.Buffer
+	lit     strings.Builder // 尚未写出的常量
+	imports map[string]bool
+}
+
+// p 写入一行代码，pkgs 为这一行用到的包；之前的常量先写出
+func (w *writer) p(line string, pkgs ...string) {
+	w.flush()
+	w.buf.WriteString(line)
+	w.buf.WriteByte('\n')
+	for _, pkg := range pkgs {
+		w.imports[pkg] = true
+	}
+}
+
+// flush 写出追加常量的语句
+func (w *writer) flush() {
+	s := w.lit.String()
+	w.lit.Reset()
+	switch {
+	case s == "":
+		return
+	case len(s) == 1:
+		w.buf.WriteString("dst = append(dst, " + strconv.QuoteRune(rune(s[0])) + ")\n")
+	case strconv.CanBackquote(s):
+		w.buf.WriteString("dst = append(dst, `" + s + "`...)\n")
+	default:
+		w.buf.WriteString("dst = append(dst, " + strconv.Quote(s) + "...)\n")
+	}
+}
+
+// convertTo 返回把类型为 v 的 x 转换为 to 的表达式，类型相同时省略转换
+func (v value) convertTo(to, x string) string {
+	if v.Type == to {
+		return x
+	}
+	return to + "(" + x + ")"
+}
+
+const jsonxPath = "github.com/moweilong/efficient-go/base/encx/jsonx"
+
+// body 生成 AppendJSON 的函数体
+func body(t structType, imports map[string]bool) string {
+	w := &writer{imports: imports}
+	// 第一个字段可能被省略时无法在生成时确定逗号的位置：每个字段都以逗号开头，最后把第一个逗号改为 {
+	leading := t.Fields[0].omitEmpty || t.Fields[0].omitZero
+	hasFloat := slices.ContainsFunc(t.Fields, func(f field) bool { return f.kind == kindFloat })
+	if leading || hasFloat {
+		w.p("start := len(dst)")
+	}
+	w.p("dst = slices.Grow(dst, "+t.sizeHint()+")", "slices")
+	if hasFloat {
+		w.p("var err error")
+	}
+	if !leading {
+		w.lit.WriteByte('{')
+	}
+
+	for i, f := range t.Fields {
+		x := "v." + f.Name
+		cond := f.omitCond(x)
+		if cond != "" {
+			w.p("if " + cond + " {")
+		}
+		if i > 0 || leading {
+			w.lit.WriteByte(',')
+		}
+		w.lit.WriteString(f.Key + ":")
+		errorf := func() {
+			w.p(fmt.Sprintf("return dst[:start], fmt.Errorf(%q, err)", t.Name+"."+f.Name+": %w"), "fmt")
+		}
+		if f.slice {
+			nonNil := f.omitEmpty || f.omitZero
+			if !nonNil {
+				w.p("if " + x + " == nil {")
+				w.lit.WriteString("null")
+				w.p("} else {")
+			}
+			w.lit.WriteByte('[')
+			w.p("for i, e := range " + x + " {")
+			w.p("if i > 0 {")
+			w.lit.WriteByte(',')
+			w.p("}")
+			appendValue(w, f.value, "e", false, errorf)
+			w.p("}")
+			w.lit.WriteByte(']')
+			if !nonNil {
+				w.p("}")
+			}
+		} else {
+			appendValue(w, f.value, x, f.quoted, errorf)
+		}
+		if cond != "" {
+			w.p("}")
+		}
+	}
+
+	if leading {
+		w.p("if len(dst) == start {")
+		w.lit.WriteByte('{')
+		w.p("} else {")
+		w.p("dst[start] = '{'")
+		w.p("}")
+	}
+	w.lit.WriteByte('}')
+	w.p("return dst, nil")
+	return w.buf.String()
+}
+
+// sizeHint 返回编码长度的估计值，字符串与 []byte 字段按实际长度计入，切片字段不计入元素
+func (t structType) sizeHint() string {
+	size, dynamic := 2, ""
+	for _, f := range t.Fields {
+		size += len(f.Key) + 2
+		switch {
+		case f.slice:
+			size += 2
+		case f.kind == kindBool:
+			size += 5
+		case f.kind == kindInt || f.kind == kindUint:
+			size += 20
+		case f.kind == kindFloat:
+			size += 24
+		case f.kind == kindString:
+			size += 2
+			dynamic += "+len(v." + f.Name + ")"
+		case f.kind == kindBytes:
+			size += 4
+			dynamic += "+len(v." + f.Name + ")*4/3"
+		}
+	}
+	return strconv.Itoa(size) + dynamic
+}
+
+// omitCond 返回字段需要输出的条件，没有 omitempty 与 omitzero 选项时返回空字符串
+func (f field) omitCond(x string) string {
+	var conds []string
+	if f.omitEmpty {
+		switch {
+		case f.slice || f.kind == kindBytes:
+			conds = append(conds, "len("+x+") != 0")
+		case f.kind == kindBool:
+			conds = append(conds, x)
+		case f.kind == kindString:
+			conds = append(conds, x+` != ""`)
+		default:
+			conds = append(conds, x+" != 0")
+		}
+	}
+	if f.omitZero {
+		var c string
+		switch {
+		case f.isZero:
+			c = "!" + x + ".IsZero()"
+		case f.slice || f.kind == kindBytes:
+			c = x + " != nil"
+		case f.kind == kindBool:
+			c = x
+		case f.kind == kindString:
+			c = x + ` != ""`
+		default:
+			c = x + " != 0"
+		}
+		if !slices.Contains(conds, c) {
+			conds = append(conds, c)
+		}
+	}
+	return strings.Join(conds, " && ")
+}
+
+// appendValue 生成把类型为 v 的 x 追加到 dst 的语句，quoted 表示外加一层引号
+func appendValue(w *writer, v value, x string, quoted bool, errorf func()) {
+	if v.kind == kindBool {
+		t, f := "true", "false"
+		if quoted {
+			t, f = `"true"`, `"false"`
+		}
+		w.p("if " + x + " {")
+		w.lit.WriteString(t)
+		w.p("} else {")
+		w.lit.WriteString(f)
+		w.p("}")
+		return
+	}
+	q := func() {
+		if quoted {
+			w.lit.WriteByte('"')
+		}
+	}
+	q()
+	switch v.kind {
+	case kindInt:
+		w.p("dst = strconv.AppendInt(dst, "+v.convertTo("int64", x)+", 10)", "strconv")
+	case kindUint:
+		w.p("dst = strconv.AppendUint(dst, "+v.convertTo("uint64", x)+", 10)", "strconv")
+	case kindFloat:
+		w.p(fmt.Sprintf("if dst, err = jsonx.AppendFloat(dst, %s, %d); err != nil {", v.convertTo("float64", x), v.bits), jsonxPath)
+		errorf()
+		w.p("}")
+	case kindString:
+		w.p("dst = jsonx.AppendString(dst, "+v.convertTo("string", x)+")", jsonxPath)
+	case kindBytes:
+		w.p("dst = jsonx.AppendBytes(dst, "+x+")", jsonxPath)
+	}
+	q()
+}
+
+// generate 返回格式化后的生成代码
+func generate(cfg config) ([]byte, error) {
+	if len(cfg.Types) == 0 {
+		return nil, errors.New("未指定类型")
+	}
+	imports := make(map[string]bool)
+	for i := range cfg.Types {
+		t := &cfg.Types[i]
+		if len(t.Fields) == 0 {
+			return nil, fmt.Errorf("类型 %s 没有需要编码的字段", t.Name)
+		}
+		t.Body = body(*t, imports)
+	}
+	// 标准库与其他包分为两组导入
+	var std, other []string
+	for p := range imports {
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			other = append(other, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+
+	data := struct {
+		config
+		Std, Other []string
+	}{cfg, std, other}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("jsonc").Parse(`// Code generated by jsonc {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Std}}
+	"{{.}}"
+{{- end}}
+{{- if .Other}}
+{{range .Other}}
+	"{{.}}"
+{{- end}}
+{{- end}}
+)
+{{range .Types}}
+// AppendJSON 把 v 的 JSON 编码追加到 dst 并返回扩展后的切片，结果与 json.Marshal(v) 相同
+// 浮点数字段为 NaN 或 ±Inf 时返回原来的 dst 与错误
+func (v *{{.Name}}) AppendJSON(dst []byte) ([]byte, error) {
+{{.Body -}}
+}
+
+// MarshalJSON 实现 json.Marshaler
+func (v *{{.Name}}) MarshalJSON() ([]byte, error) {
+	return v.AppendJSON(nil)
+}
+{{end}}`))
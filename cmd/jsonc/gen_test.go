@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateGolden 测试生成结果与 internal/example 中提交的文件一致，
+// 修改生成逻辑后需在 internal/example 下执行 go generate 更新对照文件
+func TestGenerateGolden(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	cfg, err := load(dir, []string{"User", "Event"}, "user_jsonc.go")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	cfg.Args = "-type=User,Event"
+	got, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	expected, err := os.ReadFile(filepath.Join(dir, "user_jsonc.go"))
+	if err != nil {
+		t.Fatalf("读取对照文件失败: %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("生成结果与 internal/example/user_jsonc.go 不一致，请执行 go generate 更新")
+	}
+}
+
+// writePkg 把 src 写入临时目录并返回目录
+func writePkg(t *testing.T, src string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte("package p\n\n"+src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// TestKeys 测试键名的确定规则与 encoding/json 相同
+func TestKeys(t *testing.T) {
+	dir := writePkg(t, "type T struct {\n"+
+		"\tA int `json:\"a\"`\n"+
+		"\tB int `json:\",omitempty\"`\n"+
+		"\tC int `json:\"bad\\\"name\"`\n"+
+		"\tD int `json:\"-,\"`\n"+
+		"\tE int `json:\"-\"`\n"+
+		"\tf int\n"+
+		"}\n")
+	cfg, err := load(dir, []string{"T"}, "")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	var keys []string
+	for _, f := range cfg.Types[0].Fields {
+		keys = append(keys, f.Key)
+	}
+	if got, want := strings.Join(keys, " "), `"a" "B" "C" "-"`; got != want {
+		t.Errorf("键名 = %s，预期 %s", got, want)
+	}
+}
+
+// TestLoadErrors 测试不支持的类型与标签
+func TestLoadErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+	}{
+		{"未找到类型", "type U struct{ A int }"},
+		{"不是结构体", "type T int"},
+		{"未知的标签选项", "type T struct{ A int `json:\",inline\"` }"},
+		{"map", "type T struct{ A map[string]int }"},
+		{"接口", "type T struct{ A any }"},
+		{"指针", "type T struct{ A *int }"},
+		{"嵌套结构体", "type T struct{ A struct{ B int } }"},
+		{"嵌入字段", "type E struct{ B int }\ntype T struct{ E }"},
+		{"二维切片", "type T struct{ A [][]int }"},
+		{"数组", "type T struct{ A [4]int }"},
+		{"复数", "type T struct{ A complex128 }"},
+		{"实现了 MarshalJSON", "type M int\nfunc (*M) MarshalJSON() ([]byte, error) { return nil, nil }\ntype T struct{ A M }"},
+		{"元素实现了 MarshalText", "type M int\nfunc (M) MarshalText() ([]byte, error) { return nil, nil }\ntype T struct{ A []M }"},
+		{"元素为具名 uint8 的切片", "type B uint8\ntype T struct{ A []B }"},
+		{"字符串使用 string 选项", "type T struct{ A string `json:\",string\"` }"},
+		{"切片使用 string 选项", "type T struct{ A []int `json:\",string\"` }"},
+		{"键名重复", "type T struct {\n\tA int `json:\"x\"`\n\tB int `json:\"x\"`\n}"},
+	}
+	for _, tc := range testCases {
+		if _, err := load(writePkg(t, tc.src), []string{"T"}, ""); err == nil {
+			t.Errorf("%s: 应返回错误", tc.name)
+		}
+	}
+	if _, err := load(writePkg(t, "type T struct{}"), []string{"T-1"}, ""); err == nil {
+		t.Errorf("非法的类型名: 应返回错误")
+	}
+}
+
+// TestGenerateErrors 测试没有可编码字段的结构体
+func TestGenerateErrors(t *testing.T) {
+	cfg, err := load(writePkg(t, "type T struct {\n\ta int\n\tB int `json:\"-\"`\n}\n"), []string{"T"}, "")
+	if err != nil {
+		t.Fatalf("load 失败: %v", err)
+	}
+	if _, err := generate(cfg); err == nil {
+		t.Errorf("没有需要编码的字段时应返回错误")
+	}
+	if _, err := generate(config{Package: "p"}); err == nil {
+		t.Errorf("未指定类型时应返回错误")
+	}
+}
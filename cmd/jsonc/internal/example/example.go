@@ -0,0 +1,49 @@
+// Package example 是 jsonc 生成代码的示例，同时被 jsonc 的测试用作对照文件。
+package example
+
+import "time"
+
+//go:generate go run github.com/moweilong/efficient-go/cmd/jsonc -type=User,Event
+
+// Role 是用户角色
+type Role uint8
+
+const (
+	RoleGuest Role = iota
+	RoleMember
+	RoleAdmin
+)
+
+// Priority 是事件优先级，非正数表示未设置
+type Priority int8
+
+// IsZero 返回优先级是否未设置，omitzero 以此判断是否省略
+func (p Priority) IsZero() bool { return p <= 0 }
+
+// User 是常见的扁平 API 对象，第一个字段总是输出
+type User struct {
+	ID       int64    `json:"id"`
+	Name     string   `json:"name"`
+	Email    string   `json:"email,omitempty"`
+	Role     Role     `json:"role"`
+	Age      uint8    `json:"age,omitempty"`
+	Balance  float64  `json:"balance"`
+	Verified bool     `json:"verified"`
+	Tags     []string `json:"tags"`
+	Avatar   []byte   `json:"avatar,omitempty"`
+	Internal int      `json:"-"`
+	password string
+}
+
+// Event 演示 json 标签的各个选项，第一个字段可能被省略
+type Event struct {
+	Kind     string        `json:"kind,omitempty"`
+	Seq      uint64        `json:"seq,string"`
+	Latency  time.Duration `json:"latency_ns"`
+	Ratio    float32       `json:"ratio,omitzero"`
+	Points   []float64     `json:"points,omitempty"`
+	Flags    []bool
+	Priority Priority `json:"priority,omitzero"`
+	Done     bool     `json:",string"`
+	Note     string   `json:"<note>"`
+}
@@ -0,0 +1,195 @@
+package example_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/moweilong/efficient-go/base/benchkit"
+	"github.com/moweilong/efficient-go/base/encx/jsonx"
+	"github.com/moweilong/efficient-go/cmd/jsonc/internal/example"
+)
+
+// plainUser 与 plainEvent 去掉了生成的方法，json.Marshal 对它们走反射路径，作为对照
+type (
+	plainUser  example.User
+	plainEvent example.Event
+)
+
+// stdJSON 返回 json.Marshal 的结果
+// 旧版本的 encoding/json 把非法的 UTF-8 字节写作 \ufffd，统一为 U+FFFD 后再比较
+func stdJSON(t testing.TB, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal 失败: %v", err)
+	}
+	return strings.ReplaceAll(string(b), `\ufffd`, string(utf8.RuneError))
+}
+
+var user = example.User{
+	ID:       42,
+	Name:     "Gopher <gopher@example.com>",
+	Email:    "gopher@example.com",
+	Role:     example.RoleAdmin,
+	Age:      13,
+	Balance:  1234.5,
+	Verified: true,
+	Tags:     []string{"go", "json", "快速"},
+	Avatar:   []byte{0x89, 'P', 'N', 'G'},
+}
+
+// TestMatchesEncodingJSON 测试典型值与边界值的编码与 json.Marshal 一致
+func TestMatchesEncodingJSON(t *testing.T) {
+	users := []example.User{
+		{},
+		user,
+		{ID: math.MinInt64, Name: "\x00\n\"\\\u2028\xff", Balance: 1e-7, Tags: []string{}, Avatar: []byte{}, Internal: 1},
+	}
+	for _, u := range users {
+		got, err := u.AppendJSON([]byte("prefix"))
+		if err != nil {
+			t.Fatalf("AppendJSON(%+v) 失败: %v", u, err)
+		}
+		if want := "prefix" + stdJSON(t, plainUser(u)); string(got) != want {
+			t.Errorf("AppendJSON = %s，预期 %s", got, want)
+		}
+	}
+
+	events := []example.Event{
+		{},
+		{Kind: "deploy", Seq: math.MaxUint64, Latency: -time.Millisecond, Ratio: 0.25, Points: []float64{1, -2.5, 1e21},
+			Flags: []bool{true, false}, Priority: 3, Done: true, Note: "a&b"},
+		{Ratio: float32(math.Copysign(0, -1)), Points: []float64{}, Flags: []bool{}, Priority: -1},
+	}
+	for _, e := range events {
+		got, err := e.AppendJSON(nil)
+		if err != nil {
+			t.Fatalf("AppendJSON(%+v) 失败: %v", e, err)
+		}
+		if want := stdJSON(t, plainEvent(e)); string(got) != want {
+			t.Errorf("AppendJSON = %s，预期 %s", got, want)
+		}
+	}
+}
+
+// randString 返回随机的字符串，包含需要转义的字符与非法的 UTF-8
+func randString(r *rand.Rand) string {
+	const chars = "ab\"\\<>&\n\x00é中\u2028\xff"
+	var b strings.Builder
+	for range r.Intn(20) {
+		c, size := utf8.DecodeRuneInString(chars[r.Intn(len(chars)):])
+		if c == utf8.RuneError && size == 1 {
+			b.WriteByte(0xff)
+		} else {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// TestRandom 测试随机值的编码与 json.Marshal 一致
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 2000 {
+		u := example.User{
+			ID:       r.Int63() - r.Int63(),
+			Name:     randString(r),
+			Email:    randString(r),
+			Role:     example.Role(r.Intn(256)),
+			Age:      uint8(r.Intn(3)),
+			Balance:  r.NormFloat64() * math.Pow10(r.Intn(40)-20),
+			Verified: r.Intn(2) == 0,
+		}
+		for range r.Intn(3) {
+			u.Tags = append(u.Tags, randString(r))
+		}
+		if r.Intn(2) == 0 {
+			u.Avatar = []byte(randString(r))
+		}
+		got, err := u.AppendJSON(nil)
+		if err != nil || string(got) != stdJSON(t, plainUser(u)) {
+			t.Fatalf("AppendJSON = %s, %v，预期 %s", got, err, stdJSON(t, plainUser(u)))
+		}
+
+		e := example.Event{Seq: r.Uint64(), Latency: time.Duration(r.Int63()), Priority: example.Priority(r.Intn(5) - 2)}
+		if r.Intn(2) == 0 {
+			e.Kind = randString(r)
+		}
+		if r.Intn(2) == 0 {
+			e.Ratio = float32(r.NormFloat64())
+		}
+		for range r.Intn(3) {
+			e.Points = append(e.Points, r.ExpFloat64())
+			e.Flags = append(e.Flags, r.Intn(2) == 0)
+		}
+		got, err = e.AppendJSON(nil)
+		if err != nil || string(got) != stdJSON(t, plainEvent(e)) {
+			t.Fatalf("AppendJSON = %s, %v，预期 %s", got, err, stdJSON(t, plainEvent(e)))
+		}
+	}
+}
+
+// TestMarshalJSON 测试 json.Marshal 通过生成的 MarshalJSON 得到相同的结果
+func TestMarshalJSON(t *testing.T) {
+	got, err := json.Marshal(&user)
+	if err != nil {
+		t.Fatalf("json.Marshal 失败: %v", err)
+	}
+	if want := stdJSON(t, plainUser(user)); string(got) != want {
+		t.Errorf("json.Marshal = %s，预期 %s", got, want)
+	}
+}
+
+// TestUnsupportedFloat 测试浮点数为 NaN 或 ±Inf 时返回错误与原来的 dst
+func TestUnsupportedFloat(t *testing.T) {
+	u := user
+	u.Balance = math.NaN()
+	dst, err := u.AppendJSON([]byte("keep"))
+	if !errors.Is(err, jsonx.ErrUnsupportedValue) || string(dst) != "keep" {
+		t.Errorf("AppendJSON = %q, %v，预期 ErrUnsupportedValue", dst, err)
+	}
+	e := example.Event{Kind: "k", Points: []float64{1, math.Inf(-1)}}
+	dst, err = e.AppendJSON([]byte("keep"))
+	if !errors.Is(err, jsonx.ErrUnsupportedValue) || string(dst) != "keep" {
+		t.Errorf("AppendJSON = %q, %v，预期 ErrUnsupportedValue", dst, err)
+	}
+}
+
+// TestAllocs 测试复用缓冲区时编码不分配内存
+func TestAllocs(t *testing.T) {
+	dst := make([]byte, 0, 1024)
+	benchkit.AssertAllocs(t, 0, func() {
+		dst, _ = user.AppendJSON(dst[:0])
+	})
+}
+
+// BenchmarkMarshal 对比生成的 AppendJSON 与 encoding/json 的编码开销
+func BenchmarkMarshal(b *testing.B) {
+	b.Run("AppendJSON", func(b *testing.B) {
+		dst := make([]byte, 0, 1024)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dst, _ = user.AppendJSON(dst[:0])
+		}
+		benchkit.SinkBytes = dst
+	})
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkBytes, _ = user.MarshalJSON()
+		}
+	})
+	b.Run("encoding_json", func(b *testing.B) {
+		p := plainUser(user)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchkit.SinkBytes, _ = json.Marshal(&p)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+// Code generated by jsonc -type=User,Event; DO NOT EDIT.
+
+package example
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/moweilong/efficient-go/base/encx/jsonx"
+)
+
+// AppendJSON 把 v 的 JSON 编码追加到 dst 并返回扩展后的切片，结果与 json.Marshal(v) 相同
+// 浮点数字段为 NaN 或 ±Inf 时返回原来的 dst 与错误
+func (v *User) AppendJSON(dst []byte) ([]byte, error) {
+	start := len(dst)
+	dst = slices.Grow(dst, 180+len(v.Name)+len(v.Email)+len(v.Avatar)*4/3)
+	var err error
+	dst = append(dst, `{"id":`...)
+	dst = strconv.AppendInt(dst, v.ID, 10)
+	dst = append(dst, `,"name":`...)
+	dst = jsonx.AppendString(dst, v.Name)
+	if v.Email != "" {
+		dst = append(dst, `,"email":`...)
+		dst = jsonx.AppendString(dst, v.Email)
+	}
+	dst = append(dst, `,"role":`...)
+	dst = strconv.AppendUint(dst, uint64(v.Role), 10)
+	if v.Age != 0 {
+		dst = append(dst, `,"age":`...)
+		dst = strconv.AppendUint(dst, uint64(v.Age), 10)
+	}
+	dst = append(dst, `,"balance":`...)
+	if dst, err = jsonx.AppendFloat(dst, v.Balance, 64); err != nil {
+		return dst[:start], fmt.Errorf("User.Balance: %w", err)
+	}
+	dst = append(dst, `,"verified":`...)
+	if v.Verified {
+		dst = append(dst, `true`...)
+	} else {
+		dst = append(dst, `false`...)
+	}
+	dst = append(dst, `,"tags":`...)
+	if v.Tags == nil {
+		dst = append(dst, `null`...)
+	} else {
+		dst = append(dst, '[')
+		for i, e := range v.Tags {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = jsonx.AppendString(dst, e)
+		}
+		dst = append(dst, ']')
+	}
+	if len(v.Avatar) != 0 {
+		dst = append(dst, `,"avatar":`...)
+		dst = jsonx.AppendBytes(dst, v.Avatar)
+	}
+	dst = append(dst, '}')
+	return dst, nil
+}
+
+// MarshalJSON 实现 json.Marshaler
+func (v *User) MarshalJSON() ([]byte, error) {
+	return v.AppendJSON(nil)
+}
+
+// AppendJSON 把 v 的 JSON 编码追加到 dst 并返回扩展后的切片，结果与 json.Marshal(v) 相同
+// 浮点数字段为 NaN 或 ±Inf 时返回原来的 dst 与错误
+func (v *Event) AppendJSON(dst []byte) ([]byte, error) {
+	start := len(dst)
+	dst = slices.Grow(dst, 196+len(v.Kind)+len(v.Note))
+	var err error
+	if v.Kind != "" {
+		dst = append(dst, `,"kind":`...)
+		dst = jsonx.AppendString(dst, v.Kind)
+	}
+	dst = append(dst, `,"seq":"`...)
+	dst = strconv.AppendUint(dst, v.Seq, 10)
+	dst = append(dst, `","latency_ns":`...)
+	dst = strconv.AppendInt(dst, int64(v.Latency), 10)
+	if v.Ratio != 0 {
+		dst = append(dst, `,"ratio":`...)
+		if dst, err = jsonx.AppendFloat(dst, float64(v.Ratio), 32); err != nil {
+			return dst[:start], fmt.Errorf("Event.Ratio: %w", err)
+		}
+	}
+	if len(v.Points) != 0 {
+		dst = append(dst, `,"points":[`...)
+		for i, e := range v.Points {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = jsonx.AppendFloat(dst, e, 64); err != nil {
+				return dst[:start], fmt.Errorf("Event.Points: %w", err)
+			}
+		}
+		dst = append(dst, ']')
+	}
+	dst = append(dst, `,"Flags":`...)
+	if v.Flags == nil {
+		dst = append(dst, `null`...)
+	} else {
+		dst = append(dst, '[')
+		for i, e := range v.Flags {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if e {
+				dst = append(dst, `true`...)
+			} else {
+				dst = append(dst, `false`...)
+			}
+		}
+		dst = append(dst, ']')
+	}
+	if !v.Priority.IsZero() {
+		dst = append(dst, `,"priority":`...)
+		dst = strconv.AppendInt(dst, int64(v.Priority), 10)
+	}
+	dst = append(dst, `,"Done":`...)
+	if v.Done {
+		dst = append(dst, `"true"`...)
+	} else {
+		dst = append(dst, `"false"`...)
+	}
+	dst = append(dst, `,"\u003cnote\u003e":`...)
+	dst = jsonx.AppendString(dst, v.Note)
+	if len(dst) == start {
+		dst = append(dst, '{')
+	} else {
+		dst[start] = '{'
+	}
+	dst = append(dst, '}')
+	return dst, nil
+}
+
+// MarshalJSON 实现 json.Marshaler
+func (v *Event) MarshalJSON() ([]byte, error) {
+	return v.AppendJSON(nil)
+}
@@ -0,0 +1,61 @@
+// jsonc 为结构体生成不依赖反射的 JSON 编码方法 AppendJSON 与 MarshalJSON，
+// 输出与 encoding/json 的 json.Marshal 逐字节相同，而不需要逐字段反射与每次调用的内存分配。
+//
+// 用法：
+//
+//	//go:generate go run github.com/moweilong/efficient-go/cmd/jsonc -type=User,Event
+//
+// 只支持扁平的结构体：字段类型为布尔、整数、浮点数、string、[]byte、以这些类型（[]byte 除外）为元素的切片，
+// 以及底层为这些类型的具名类型；不支持 map、接口、指针、嵌套结构体与嵌入字段，
+// 也不支持实现了 json.Marshaler 或 encoding.TextMarshaler 的类型，遇到时报错而不是生成与 encoding/json 不一致的代码。
+//
+// 字段名与选项取自 json 标签，含义与 encoding/json 相同：
+//
+//	json:"name"           JSON 中的键名
+//	json:"-"              跳过该字段（未导出的字段也总是跳过）
+//	json:",omitempty"     值为 false、0、空字符串或空切片时省略
+//	json:",omitzero"      值为零值时省略，类型有 IsZero() bool 方法时以其结果为准
+//	json:",string"        把数值或布尔值编码为 JSON 字符串
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "以逗号分隔的结构体类型名称（必填）")
+	output := flag.String("output", "", "输出文件，默认为 <第一个类型>_jsonc.go")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	names := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(names[0])+"_jsonc.go")
+	}
+
+	cfg, err := load(dir, names, filepath.Base(*output))
+	if err != nil {
+		fatal(err)
+	}
+	cfg.Args = strings.Join(os.Args[1:], " ")
+
+	src, err := generate(cfg)
+	if err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "jsonc:", err)
+	os.Exit(1)
+}
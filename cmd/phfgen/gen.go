@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/moweilong/efficient-go/base/phf"
+)
+
+// config 描述一次代码生成的输入
+type config struct {
+	Name    string // 查找表名称
+	Input   string // 键列表文件
+	Package string // 生成代码的包名
+	Args    string // 命令行参数，写入文件头部便于追溯
+}
+
+// Bench 返回测试与基准测试函数名中使用的导出形式名称
+func (c config) Bench() string {
+	r, n := utf8.DecodeRuneInString(c.Name)
+	return string(unicode.ToUpper(r)) + c.Name[n:] + "Lookup"
+}
+
+// parseKeys 按行解析键列表，忽略空行与以 # 开头的行，键两端的空白被去掉
+func parseKeys(data string) []string {
+	var keys []string
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, line)
+	}
+	return keys
+}
+
+// validate 校验配置
+func validate(cfg config) error {
+	if !token.IsIdentifier(cfg.Name) {
+		return fmt.Errorf("非法的名称 %q", cfg.Name)
+	}
+	if cfg.Package == "" {
+		return errors.New("未指定包名")
+	}
+	return nil
+}
+
+// slot 是槽位上的键及其在输入中的序号
+type slot struct {
+	Key string
+	Pos int
+}
+
+// generate 为 keys 构建完美哈希，返回格式化后的查找表源码与测试源码
+func generate(cfg config, keys []string) (code, test []byte, err error) {
+	if err := validate(cfg); err != nil {
+		return nil, nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil, errors.New("键列表为空")
+	}
+	t, err := phf.Build(keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := struct {
+		config
+		Table  *phf.Table
+		Pilots [][]uint32
+		Slots  []slot
+	}{config: cfg, Table: t, Slots: make([]slot, len(keys))}
+	for i := 0; i < len(t.Pilots); i += 8 {
+		data.Pilots = append(data.Pilots, t.Pilots[i:min(i+8, len(t.Pilots))])
+	}
+	for i, k := range keys {
+		data.Slots[t.Index(k)] = slot{Key: k, Pos: i}
+	}
+
+	if code, err = render(codeTmpl, data); err != nil {
+		return nil, nil, err
+	}
+	if test, err = render(testTmpl, data); err != nil {
+		return nil, nil, err
+	}
+	return code, test, nil
+}
+
+func render(t *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var codeTmpl = template.Must(template.New("code").Parse(`// Code generated by phfgen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import "github.com/moweilong/efficient-go/base/phf"
+
+// {{.Name}}Table 是 {{.Input}} 中 {{.Table.Size}} 个键的最小完美哈希
+var {{.Name}}Table = phf.Table{
+	Seed: {{printf "%#x" .Table.Seed}},
+	Size: {{.Table.Size}},
+	Pilots: []uint32{
+{{- range .Pilots}}
+		{{range .}}{{.}}, {{end}}
+{{- end}}
+	},
+}
+
+// {{.Name}}Slots 按完美哈希的序号排列键及其在 {{.Input}} 中的序号
+var {{.Name}}Slots = [{{.Table.Size}}]struct {
+	key string
+	pos int
+}{
+{{- range .Slots}}
+	{ {{- printf "%q" .Key}}, {{.Pos -}} },
+{{- end}}
+}
+
+// {{.Name}}Lookup 返回 key 在 {{.Input}} 中的序号，key 不在其中时返回 (0, false)
+func {{.Name}}Lookup(key string) (int, bool) {
+	s := &{{.Name}}Slots[{{.Name}}Table.Index(key)]
+	if s.key != key {
+		return 0, false
+	}
+	return s.pos, true
+}
+`))
+
+var testTmpl = template.Must(template.New("test").Parse(`// Code generated by phfgen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import "testing"
+
+// Test{{.Bench}} 校验每个键都能查到自己的序号，且集合外的键查不到
+func Test{{.Bench}}(t *testing.T) {
+	for _, s := range {{.Name}}Slots {
+		if pos, ok := {{.Name}}Lookup(s.key); !ok || pos != s.pos {
+			t.Errorf("{{.Name}}Lookup(%q) = (%d, %v)，预期 (%d, true)", s.key, pos, ok, s.pos)
+		}
+		if _, ok := {{.Name}}Lookup(s.key + "\x00"); ok {
+			t.Errorf("{{.Name}}Lookup(%q) 不应命中", s.key+"\x00")
+		}
+	}
+}
+
+var sink{{.Bench}} bool
+
+// Benchmark{{.Bench}} 对比完美哈希与 map 的查找开销
+func Benchmark{{.Bench}}(b *testing.B) {
+	m := make(map[string]int, len({{.Name}}Slots))
+	for _, s := range {{.Name}}Slots {
+		m[s.key] = s.pos
+	}
+	b.Run("phf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, sink{{.Bench}} = {{.Name}}Lookup({{.Name}}Slots[i%len({{.Name}}Slots)].key)
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, sink{{.Bench}} = m[{{.Name}}Slots[i%len({{.Name}}Slots)].key]
+		}
+	})
+}
+`))
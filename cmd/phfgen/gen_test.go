@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// exampleConfig 与 internal/example 中 go:generate 指令的参数一致
+var exampleConfig = config{
+	Name:    "keyword",
+	Input:   "keywords.txt",
+	Package: "example",
+	Args:    "-name=keyword -input=keywords.txt",
+}
+
+// TestGenerateGolden 测试生成结果与 internal/example 中提交的文件一致，
+// 修改模板或 base/phf 的构建算法后需在 internal/example 下执行 go generate 更新对照文件
+func TestGenerateGolden(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("internal", "example", "keywords.txt"))
+	if err != nil {
+		t.Fatalf("读取键列表失败: %v", err)
+	}
+	code, test, err := generate(exampleConfig, parseKeys(string(data)))
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	for file, got := range map[string][]byte{"keyword_phf.go": code, "keyword_phf_test.go": test} {
+		expected, err := os.ReadFile(filepath.Join("internal", "example", file))
+		if err != nil {
+			t.Fatalf("读取对照文件失败: %v", err)
+		}
+		if !bytes.Equal(got, expected) {
+			t.Errorf("生成结果与 internal/example/%s 不一致，请执行 go generate 更新", file)
+		}
+	}
+}
+
+// TestParseKeys 测试键列表的解析
+func TestParseKeys(t *testing.T) {
+	got := parseKeys("# 注释\nfoo\n\n  bar \r\n#baz\nqux")
+	if want := []string{"foo", "bar", "qux"}; !slices.Equal(got, want) {
+		t.Errorf("parseKeys = %q，预期 %q", got, want)
+	}
+}
+
+// TestGenerateErrors 测试非法输入
+func TestGenerateErrors(t *testing.T) {
+	modify := func(f func(*config)) config {
+		c := exampleConfig
+		f(&c)
+		return c
+	}
+	testCases := []struct {
+		name string
+		cfg  config
+		keys []string
+	}{
+		{"名称非法", modify(func(c *config) { c.Name = "a-b" }), []string{"a"}},
+		{"缺少包名", modify(func(c *config) { c.Package = "" }), []string{"a"}},
+		{"键列表为空", exampleConfig, nil},
+		{"键重复", exampleConfig, []string{"a", "b", "a"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := generate(tc.cfg, tc.keys); err == nil {
+				t.Errorf("应返回错误")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Package example 是 phfgen 生成代码的示例，同时被 phfgen 的测试用作对照文件。
+package example
+
+//go:generate go run github.com/moweilong/efficient-go/cmd/phfgen -name=keyword -input=keywords.txt
+
+// Token 是 Go 关键字的编号，顺序与 keywords.txt 一致
+type Token int
+
+const (
+	Break Token = iota
+	Case
+	Chan
+	Const
+	Continue
+	Default
+	Defer
+	Else
+	Fallthrough
+	For
+	Func
+	Go
+	Goto
+	If
+	Import
+	Interface
+	Map
+	Package
+	Range
+	Return
+	Select
+	Struct
+	Switch
+	Type
+	Var
+)
+
+// Keyword 返回标识符 s 对应的关键字，s 不是关键字时返回 (0, false)
+func Keyword(s string) (Token, bool) {
+	i, ok := keywordLookup(s)
+	return Token(i), ok
+}
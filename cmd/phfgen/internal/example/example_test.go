@@ -0,0 +1,33 @@
+package example_test
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/moweilong/efficient-go/cmd/phfgen/internal/example"
+)
+
+// TestKeyword 测试基于生成的完美哈希实现的 Keyword 与 go/token 的判断一致
+func TestKeyword(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want example.Token
+		ok   bool
+	}{
+		{"break", example.Break, true},
+		{"fallthrough", example.Fallthrough, true},
+		{"var", example.Var, true},
+		{"Break", 0, false},
+		{"vars", 0, false},
+		{"", 0, false},
+	}
+	for _, tc := range testCases {
+		got, ok := example.Keyword(tc.s)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("Keyword(%q) = (%d, %v)，预期 (%d, %v)", tc.s, got, ok, tc.want, tc.ok)
+		}
+		if ok != token.IsKeyword(tc.s) {
+			t.Errorf("Keyword(%q) 与 token.IsKeyword 不一致", tc.s)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Code generated by phfgen -name=keyword -input=keywords.txt; DO NOT EDIT.
+
+package example
+
+import "testing"
+
+// TestKeywordLookup 校验每个键都能查到自己的序号，且集合外的键查不到
+func TestKeywordLookup(t *testing.T) {
+	for _, s := range keywordSlots {
+		if pos, ok := keywordLookup(s.key); !ok || pos != s.pos {
+			t.Errorf("keywordLookup(%q) = (%d, %v)，预期 (%d, true)", s.key, pos, ok, s.pos)
+		}
+		if _, ok := keywordLookup(s.key + "\x00"); ok {
+			t.Errorf("keywordLookup(%q) 不应命中", s.key+"\x00")
+		}
+	}
+}
+
+var sinkKeywordLookup bool
+
+// BenchmarkKeywordLookup 对比完美哈希与 map 的查找开销
+func BenchmarkKeywordLookup(b *testing.B) {
+	m := make(map[string]int, len(keywordSlots))
+	for _, s := range keywordSlots {
+		m[s.key] = s.pos
+	}
+	b.Run("phf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, sinkKeywordLookup = keywordLookup(keywordSlots[i%len(keywordSlots)].key)
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, sinkKeywordLookup = m[keywordSlots[i%len(keywordSlots)].key]
+		}
+	})
+}
@@ -0,0 +1,51 @@
+// phfgen 为固定的字符串键列表在生成阶段构建最小完美哈希（见 base/phf），
+// 生成无冲突、O(1) 的静态查找表，以及校验查找结果并与 map 对比速度的测试。
+//
+// 用法：
+//
+//	//go:generate go run github.com/moweilong/efficient-go/cmd/phfgen -name=keyword -input=keywords.txt
+//
+// -input 每行一个键，忽略空行与以 # 开头的行，键不能重复。生成的 <name>Lookup(key) 返回
+// key 在输入文件中的序号（从 0 开始，不计忽略的行），调用方可以用它索引与键一一对应的数组或常量。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.Name, "name", "", "查找表名称，生成的函数为 <name>Lookup（必填）")
+	flag.StringVar(&cfg.Input, "input", "", "键列表文件，每行一个键（必填）")
+	flag.StringVar(&cfg.Package, "package", "", "生成代码的包名，默认取环境变量 GOPACKAGE")
+	flag.Parse()
+
+	if cfg.Package == "" {
+		cfg.Package = os.Getenv("GOPACKAGE")
+	}
+	cfg.Args = strings.Join(os.Args[1:], " ")
+
+	data, err := os.ReadFile(cfg.Input)
+	if err != nil {
+		fatal(err)
+	}
+	code, test, err := generate(cfg, parseKeys(string(data)))
+	if err != nil {
+		fatal(err)
+	}
+	base := strings.ToLower(cfg.Name) + "_phf"
+	if err := os.WriteFile(base+".go", code, 0o644); err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(base+"_test.go", test, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "phfgen:", err)
+	os.Exit(1)
+}
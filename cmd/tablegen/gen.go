@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// config 描述一次代码生成的输入
+type config struct {
+	Name    string   // 查找表名称
+	Type    string   // 表项的整数类型
+	Expr    string   // 以 byte 类型变量 x 为输入的表达式
+	Imports []string // 表达式依赖的包
+	Package string   // 生成代码的包名
+	Args    string   // 命令行参数，写入文件头部便于追溯
+}
+
+// Var 返回查找表的变量名
+func (c config) Var() string {
+	return c.Name + "Table"
+}
+
+// Bench 返回基准测试与测试函数名中使用的导出形式名称
+func (c config) Bench() string {
+	r, n := utf8.DecodeRuneInString(c.Name)
+	return string(unicode.ToUpper(r)) + c.Name[n:] + "Table"
+}
+
+// typeWidths 记录支持的表项类型及是否有符号
+var typeWidths = map[string]int{
+	"uint8": 8, "byte": 8, "uint16": 16, "uint32": 32, "uint64": 64,
+	"int8": 8, "int16": 16, "int32": 32, "int64": 64, "int": 64, "uint": 64,
+}
+
+// validate 校验配置
+func validate(cfg config) error {
+	if !token.IsIdentifier(cfg.Name) {
+		return fmt.Errorf("非法的名称 %q", cfg.Name)
+	}
+	if cfg.Package == "" {
+		return errors.New("未指定包名")
+	}
+	if _, ok := typeWidths[cfg.Type]; !ok {
+		return fmt.Errorf("不支持的表项类型 %q", cfg.Type)
+	}
+	if _, err := parser.ParseExpr(cfg.Expr); err != nil {
+		return fmt.Errorf("非法的表达式 %q: %v", cfg.Expr, err)
+	}
+	return nil
+}
+
+// evaluate 在 dir 下临时生成并运行一个程序，返回表达式在 0~255 上的 256 个取值
+func evaluate(cfg config, dir string) ([]string, error) {
+	var buf bytes.Buffer
+	if err := evalTmpl.Execute(&buf, cfg); err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp(dir, "tablegen")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	if err := os.WriteFile(filepath.Join(tmp, "main.go"), buf.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmp
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("求值失败: %v\n%s", err, stderr.String())
+	}
+	values := strings.Fields(string(out))
+	if len(values) != 256 {
+		return nil, fmt.Errorf("求值得到 %d 个结果，预期 256", len(values))
+	}
+	return values, nil
+}
+
+// generate 返回格式化后的查找表源码与测试源码
+func generate(cfg config, values []string) (table, test []byte, err error) {
+	if err := validate(cfg); err != nil {
+		return nil, nil, err
+	}
+	if len(values) != 256 {
+		return nil, nil, fmt.Errorf("需要 256 个取值，实际为 %d", len(values))
+	}
+	for _, v := range values {
+		if _, err := strconv.ParseInt(v, 10, typeWidths[cfg.Type]); err != nil {
+			if _, err := strconv.ParseUint(v, 10, typeWidths[cfg.Type]); err != nil {
+				return nil, nil, fmt.Errorf("取值 %q 不是合法的 %s", v, cfg.Type)
+			}
+		}
+	}
+
+	data := struct {
+		config
+		Rows [][]string
+	}{config: cfg}
+	for i := 0; i < 256; i += 16 {
+		data.Rows = append(data.Rows, values[i:i+16])
+	}
+
+	if table, err = render(tableTmpl, data); err != nil {
+		return nil, nil, err
+	}
+	if test, err = render(testTmpl, data); err != nil {
+		return nil, nil, err
+	}
+	return table, test, nil
+}
+
+func render(t *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var evalTmpl = template.Must(template.New("eval").Parse(`package main
+
+import (
+	"fmt"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+func main() {
+	for i := 0; i < 256; i++ {
+		x := byte(i)
+		fmt.Println({{.Type}}({{.Expr}}))
+	}
+}
+`))
+
+var tableTmpl = template.Must(template.New("table").Parse(`// Code generated by tablegen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+// {{.Var}} 是 {{.Expr}} 在 x = 0~255 上的预计算结果，用 {{.Var}}[x] 代替逐次计算
+var {{.Var}} = [256]{{.Type}}{
+{{- range .Rows}}
+	{{range .}}{{.}}, {{end}}
+{{- end}}
+}
+`))
+
+var testTmpl = template.Must(template.New("test").Parse(`// Code generated by tablegen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"testing"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// Test{{.Bench}} 校验查找表与 {{.Expr}} 的计算结果一致
+func Test{{.Bench}}(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		x := byte(i)
+		if got, want := {{.Var}}[x], {{.Type}}({{.Expr}}); got != want {
+			t.Errorf("{{.Var}}[%d] = %v，预期 %v", x, got, want)
+		}
+	}
+}
+
+var sink{{.Bench}} {{.Type}}
+
+// Benchmark{{.Bench}} 对比查表与直接计算的开销
+func Benchmark{{.Bench}}(b *testing.B) {
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink{{.Bench}} = {{.Var}}[byte(i)]
+		}
+	})
+	b.Run("computed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := byte(i)
+			sink{{.Bench}} = {{.Type}}({{.Expr}})
+		}
+	})
+}
+`))
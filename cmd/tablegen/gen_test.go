@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// exampleConfig 与 internal/example 中 go:generate 指令的参数一致
+var exampleConfig = config{
+	Name:    "popcount",
+	Type:    "uint8",
+	Expr:    "bits.OnesCount8(x)",
+	Imports: []string{"math/bits"},
+	Package: "example",
+	Args:    "-name=popcount -type=uint8 -import=math/bits -expr=bits.OnesCount8(x)",
+}
+
+// popcountValues 返回 bits.OnesCount8 在 0~255 上的取值
+func popcountValues() []string {
+	values := make([]string, 256)
+	for i := range values {
+		values[i] = strconv.Itoa(bits.OnesCount8(uint8(i)))
+	}
+	return values
+}
+
+// TestGenerateGolden 测试生成结果与 internal/example 中提交的文件一致，
+// 修改模板后需在 internal/example 下执行 go generate 更新对照文件
+func TestGenerateGolden(t *testing.T) {
+	table, test, err := generate(exampleConfig, popcountValues())
+	if err != nil {
+		t.Fatalf("generate 失败: %v", err)
+	}
+	for file, got := range map[string][]byte{"popcount_table.go": table, "popcount_table_test.go": test} {
+		expected, err := os.ReadFile(filepath.Join("internal", "example", file))
+		if err != nil {
+			t.Fatalf("读取对照文件失败: %v", err)
+		}
+		if !bytes.Equal(got, expected) {
+			t.Errorf("生成结果与 internal/example/%s 不一致，请执行 go generate 更新", file)
+		}
+	}
+}
+
+// TestEvaluate 测试通过临时程序对表达式求值
+func TestEvaluate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("需要调用 go run")
+	}
+	values, err := evaluate(exampleConfig, t.TempDir())
+	if err != nil {
+		t.Fatalf("evaluate 失败: %v", err)
+	}
+	if !slices.Equal(values, popcountValues()) {
+		t.Errorf("evaluate 的结果与 bits.OnesCount8 不一致")
+	}
+
+	bad := exampleConfig
+	bad.Expr = "undefinedFunc(x)"
+	if _, err := evaluate(bad, t.TempDir()); err == nil {
+		t.Errorf("无法编译的表达式应返回错误")
+	}
+}
+
+// TestGenerateErrors 测试非法输入
+func TestGenerateErrors(t *testing.T) {
+	modify := func(f func(*config)) config {
+		c := exampleConfig
+		f(&c)
+		return c
+	}
+	overflow := popcountValues()
+	overflow[3] = "256"
+	testCases := []struct {
+		name   string
+		cfg    config
+		values []string
+	}{
+		{"名称非法", modify(func(c *config) { c.Name = "a-b" }), popcountValues()},
+		{"缺少包名", modify(func(c *config) { c.Package = "" }), popcountValues()},
+		{"类型不支持", modify(func(c *config) { c.Type = "float64" }), popcountValues()},
+		{"表达式非法", modify(func(c *config) { c.Expr = "x +" }), popcountValues()},
+		{"取值个数错误", exampleConfig, popcountValues()[:255]},
+		{"取值溢出", exampleConfig, overflow},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := generate(tc.cfg, tc.values); err == nil {
+				t.Errorf("应返回错误")
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Package example 是 tablegen 生成代码的示例，同时被 tablegen 的测试用作对照文件。
+package example
+
+//go:generate go run github.com/moweilong/efficient-go/cmd/tablegen -name=popcount -type=uint8 -import=math/bits -expr=bits.OnesCount8(x)
+
+// Popcount 通过查找表返回 x 中 1 的个数
+func Popcount(x byte) int {
+	return int(popcountTable[x])
+}
@@ -0,0 +1,17 @@
+package example_test
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/moweilong/efficient-go/cmd/tablegen/internal/example"
+)
+
+// TestPopcount 测试基于生成的查找表实现的 Popcount
+func TestPopcount(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		if got, want := example.Popcount(byte(i)), bits.OnesCount8(uint8(i)); got != want {
+			t.Errorf("Popcount(%d) = %d，预期 %d", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// tablegen 将定义域为单个字节的纯函数预先计算成 [256]T 查找表，
+// 同时生成一个校验查找表与原始计算结果一致的测试，以及对比两者速度的基准测试。
+//
+// 用法：
+//
+//	//go:generate go run github.com/moweilong/efficient-go/cmd/tablegen -name=popcount -type=uint8 -import=math/bits -expr=bits.OnesCount8(x)
+//
+// -expr 是以 byte 类型变量 x 为输入的 Go 表达式，其结果会被转换为 -type；
+// tablegen 在当前目录下临时生成并运行一个程序来求值，因此表达式可以引用当前模块中可导入的包。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.Name, "name", "", "查找表名称，生成的变量为 <name>Table（必填）")
+	flag.StringVar(&cfg.Type, "type", "uint8", "表项的整数类型")
+	flag.StringVar(&cfg.Expr, "expr", "", "以 byte 类型变量 x 为输入的 Go 表达式（必填）")
+	flag.StringVar(&cfg.Package, "package", "", "生成代码的包名，默认取环境变量 GOPACKAGE")
+	imports := flag.String("import", "", "表达式依赖的包，多个包以逗号分隔")
+	flag.Parse()
+
+	if cfg.Package == "" {
+		cfg.Package = os.Getenv("GOPACKAGE")
+	}
+	if *imports != "" {
+		cfg.Imports = strings.Split(*imports, ",")
+	}
+	cfg.Args = strings.Join(os.Args[1:], " ")
+
+	if err := validate(cfg); err != nil {
+		fatal(err)
+	}
+	values, err := evaluate(cfg, ".")
+	if err != nil {
+		fatal(err)
+	}
+	table, test, err := generate(cfg, values)
+	if err != nil {
+		fatal(err)
+	}
+	base := strings.ToLower(cfg.Name) + "_table"
+	if err := os.WriteFile(base+".go", table, 0o644); err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(base+"_test.go", test, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "tablegen:", err)
+	os.Exit(1)
+}